@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"github.com/krobus00/product-service/internal/bootstrap"
+	"github.com/krobus00/product-service/internal/config"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/spf13/cobra"
+)
+
+// exportCmd represents the export command.
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "export a snapshot of the catalog to a csv, jsonl or parquet file",
+	Long: `export a snapshot of the catalog to a local file, or upload it to storage-service.
+The products are read in a single transaction so the snapshot is consistent, a manifest holding
+the row count and the sha256 of the file is written next to it once the snapshot is complete.`,
+	Example: `  product-service export --format parquet --updated-since 2023-01-01T00:00:00Z --upload`,
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		upload, _ := cmd.Flags().GetBool("upload")
+		outputDir, _ := cmd.Flags().GetString("output-dir")
+		ownerID, _ := cmd.Flags().GetString("owner-id")
+		updatedSince, _ := cmd.Flags().GetString("updated-since")
+		includeDeleted, _ := cmd.Flags().GetBool("include-deleted")
+
+		if format == "" {
+			format = config.CatalogExportFormat()
+		}
+		destination := model.CatalogExportDestinationLocal
+		if upload {
+			destination = model.CatalogExportDestinationStorage
+		}
+
+		bootstrap.StartExport(&model.CatalogExportPayload{
+			Format:         format,
+			Destination:    destination,
+			OutputDir:      outputDir,
+			OwnerID:        ownerID,
+			UpdatedSince:   updatedSince,
+			IncludeDeleted: includeDeleted,
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.Flags().String("format", "", "csv|jsonl|parquet, default to export.catalog.format")
+	exportCmd.Flags().Bool("upload", false, "upload the snapshot and its manifest to storage-service")
+	exportCmd.Flags().String("output-dir", "", "directory of the snapshot, default to export.catalog.output_dir")
+	exportCmd.Flags().String("owner-id", "", "only export the products of this owner")
+	exportCmd.Flags().String("updated-since", "", "only export the products updated since this RFC3339 timestamp")
+	exportCmd.Flags().Bool("include-deleted", false, "also export the deleted products")
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/krobus00/product-service/internal/bootstrap"
+	"github.com/spf13/cobra"
+)
+
+// importCmd represents the import command.
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "import products from a csv or jsonl file",
+	Long: `import products from a local csv or jsonl file, or from a storage-service object.
+Rows with an external_sku already used by the owner update that product, the other rows create a product.
+The job is recorded and its error report can be read with GetImportJob.`,
+	Example: `  product-service import --file products.csv --owner-id <user id> --mapping name=Title --mapping external_sku=SKU`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString("file")
+		objectID, _ := cmd.Flags().GetString("object-id")
+		if (file == "") == (objectID == "") {
+			return errors.New("set either --file or --object-id")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		file, _ := cmd.Flags().GetString("file")
+		objectID, _ := cmd.Flags().GetString("object-id")
+		format, _ := cmd.Flags().GetString("format")
+		ownerID, _ := cmd.Flags().GetString("owner-id")
+		mapping, _ := cmd.Flags().GetStringToString("mapping")
+
+		bootstrap.StartImport(file, objectID, format, ownerID, mapping)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.Flags().String("file", "", "local csv or jsonl file")
+	importCmd.Flags().String("object-id", "", "storage-service object holding the file")
+	importCmd.Flags().String("format", "", "csv|jsonl, guessed from the file name when empty")
+	importCmd.Flags().String("owner-id", "", "owner of the imported products")
+	importCmd.Flags().StringToString("mapping", nil, "product field to csv column or json key, e.g. external_sku=SKU")
+	_ = importCmd.MarkFlagRequired("owner-id")
+}
@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"github.com/krobus00/product-service/internal/bootstrap"
+	"github.com/spf13/cobra"
+)
+
+// rebuildListingCmd represents the rebuildListing command.
+var rebuildListingCmd = &cobra.Command{
+	Use:   "rebuild-listing",
+	Short: "rebuild redis product listing",
+	Long:  `rebuild redis product listing sorted sets from database`,
+	Run: func(cmd *cobra.Command, args []string) {
+		bootstrap.StartRebuildListing()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rebuildListingCmd)
+}
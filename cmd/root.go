@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/krobus00/product-service/internal/config"
+	"github.com/krobus00/product-service/internal/utils"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -31,5 +32,7 @@ func Init() {
 		log.Fatalln(err.Error())
 	}
 
+	log.AddHook(utils.NewRequestIDHook())
+
 	log.Info(fmt.Sprintf("starting %s:%s...", config.ServiceName(), config.ServiceVersion()))
 }
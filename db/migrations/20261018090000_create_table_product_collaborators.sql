@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS product_collaborators (
+    product_id varchar(36) NOT NULL,
+    user_id varchar(36) NOT NULL,
+    role varchar(16) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (product_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_product_collaborators_user_id ON product_collaborators (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS product_collaborators;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS product_ownership_transfers (
+    id varchar(36) PRIMARY KEY,
+    product_id varchar(36) NOT NULL,
+    from_owner_id varchar(36) NOT NULL,
+    to_owner_id varchar(36) NOT NULL,
+    transferred_by varchar(36) NOT NULL,
+    reason text NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_product_ownership_transfers_product_id ON product_ownership_transfers (product_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS product_ownership_transfers;
+-- +goose StatementEnd
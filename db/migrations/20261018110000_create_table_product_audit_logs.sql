@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS product_audit_logs (
+    id varchar(36) PRIMARY KEY,
+    product_id varchar(36) NOT NULL,
+    actor_id varchar(36) NOT NULL,
+    action varchar(32) NOT NULL,
+    origin text NOT NULL,
+    changes jsonb NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_product_audit_logs_product_id_created_at ON product_audit_logs (product_id, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION product_audit_logs_immutable() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'product_audit_logs is append only';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER product_audit_logs_immutable
+    BEFORE UPDATE OR DELETE ON product_audit_logs
+    FOR EACH ROW EXECUTE FUNCTION product_audit_logs_immutable();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS product_audit_logs;
+DROP FUNCTION IF EXISTS product_audit_logs_immutable();
+-- +goose StatementEnd
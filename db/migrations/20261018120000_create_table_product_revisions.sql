@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS product_revisions (
+    product_id varchar(36) NOT NULL,
+    revision bigint NOT NULL,
+    name text NOT NULL,
+    description text NOT NULL,
+    price float NOT NULL,
+    thumbnail_id varchar(36) NOT NULL,
+    owner_id varchar(36) NOT NULL,
+    deleted_at TIMESTAMP DEFAULT NULL,
+    created_by varchar(36) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (product_id, revision)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS product_revisions;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE products ADD COLUMN IF NOT EXISTS external_sku varchar(255) NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE UNIQUE INDEX IF NOT EXISTS idx_products_owner_id_external_sku ON products (owner_id, external_sku) WHERE external_sku <> '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_products_owner_id_external_sku;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE products DROP COLUMN IF EXISTS external_sku;
+-- +goose StatementEnd
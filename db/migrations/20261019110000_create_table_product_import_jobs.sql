@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS product_import_jobs (
+    id varchar(36) PRIMARY KEY,
+    owner_id varchar(36) NOT NULL,
+    created_by varchar(36) NOT NULL,
+    object_id varchar(36) NOT NULL DEFAULT '',
+    file_name text NOT NULL DEFAULT '',
+    format varchar(16) NOT NULL,
+    column_mapping jsonb NOT NULL DEFAULT '{}',
+    status varchar(16) NOT NULL,
+    processed_rows bigint NOT NULL DEFAULT 0,
+    created_count bigint NOT NULL DEFAULT 0,
+    updated_count bigint NOT NULL DEFAULT 0,
+    failed_count bigint NOT NULL DEFAULT 0,
+    error_message text NOT NULL DEFAULT '',
+    started_at TIMESTAMP DEFAULT NULL,
+    finished_at TIMESTAMP DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_product_import_jobs_owner_id_created_at ON product_import_jobs (owner_id, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS product_import_errors (
+    id varchar(36) PRIMARY KEY,
+    job_id varchar(36) NOT NULL,
+    row_number bigint NOT NULL,
+    external_sku varchar(255) NOT NULL DEFAULT '',
+    field varchar(64) NOT NULL DEFAULT '',
+    message text NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_product_import_errors_job_id_row_number ON product_import_errors (job_id, row_number);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS product_import_errors;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS product_import_jobs;
+-- +goose StatementEnd
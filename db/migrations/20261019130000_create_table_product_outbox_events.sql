@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS product_outbox_events (
+    id bigserial PRIMARY KEY,
+    subject varchar(255) NOT NULL,
+    payload jsonb NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS product_outbox_events;
+-- +goose StatementEnd
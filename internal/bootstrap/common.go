@@ -1,63 +1,212 @@
 package bootstrap
 
 import (
-	"context"
 	"fmt"
-	"os"
-	"os/signal"
-	"sync"
-	"syscall"
-	"time"
+	"net/http"
 
-	log "github.com/sirupsen/logrus"
+	"github.com/go-redis/redis/v8"
+	grpcPrometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	authPB "github.com/krobus00/auth-service/pb/auth"
+	"github.com/krobus00/product-service/internal/config"
+	"github.com/krobus00/product-service/internal/health"
+	"github.com/krobus00/product-service/internal/infrastructure"
+	"github.com/krobus00/product-service/internal/interceptor"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/repository"
+	storagePB "github.com/krobus00/storage-service/pb/storage"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
-type operation func(ctx context.Context) error
+var (
+	// authIdempotentMethods and storageIdempotentMethods are the reads retried while the service is unavailable
+	authIdempotentMethods = []string{
+		authPB.AuthService_HasAccess_FullMethodName,
+		authPB.AuthService_FindPermissionByName_FullMethodName,
+		authPB.AuthService_FindGroupByName_FullMethodName,
+	}
+	storageIdempotentMethods = []string{
+		storagePB.StorageService_GetObjectByID_FullMethodName,
+	}
+)
 
-// gracefulShutdown waits for termination syscalls and doing clean up operations after received it.
-func gracefulShutdown(ctx context.Context, timeout time.Duration, ops map[string]operation) <-chan struct{} {
-	wait := make(chan struct{})
-	go func() {
-		s := make(chan os.Signal, 1)
+// newProductSearcher create the search backend selected by search.engine config.
+func newProductSearcher() (model.ProductSearcher, error) {
+	switch config.SearchEngine() {
+	case model.SearchEngineOpensearch:
+		osClient, err := infrastructure.NewOpensearchClient()
+		if err != nil {
+			return nil, err
+		}
+		return repository.NewOpensearchProductSearcher(osClient)
+	case model.SearchEngineBleve:
+		index, err := infrastructure.NewBleveIndex()
+		if err != nil {
+			return nil, err
+		}
+		return repository.NewBleveProductSearcher(index)
+	default:
+		return nil, fmt.Errorf("unknown search engine: %s", config.SearchEngine())
+	}
+}
 
-		// add any other syscalls that you want to be notified with
-		signal.Notify(s, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
-		<-s
+// newRateLimitInterceptor build the rate limiter selected by rate_limit.mode, it is nil when rate limiting is disabled.
+func newRateLimitInterceptor(redisClient *redis.Client) (*interceptor.RateLimitInterceptor, error) {
+	if !config.RateLimitEnabled() {
+		return nil, nil
+	}
 
-		log.Info("shutting down")
+	var rateLimiter model.RateLimiter
+	switch config.RateLimitMode() {
+	case model.RateLimiterModeLocal:
+		rateLimiter = repository.NewLocalRateLimiter()
+	case model.RateLimiterModeRedis:
+		redisRateLimiter, err := repository.NewRedisRateLimiter(redisClient)
+		if err != nil {
+			return nil, err
+		}
+		rateLimiter = redisRateLimiter
+	default:
+		return nil, fmt.Errorf("unknown rate limit mode: %s", config.RateLimitMode())
+	}
 
-		// set timeout for the ops to be done to prevent system hang
-		timeoutFunc := time.AfterFunc(timeout, func() {
-			log.Error(fmt.Sprintf("timeout %d ms has been elapsed, force exit", timeout.Milliseconds()))
-			os.Exit(0)
-		})
+	methods, err := config.RateLimitMethods()
+	if err != nil {
+		return nil, err
+	}
+	methodLimits := make(map[string]model.RateLimit, len(methods))
+	for _, method := range methods {
+		methodLimits[method.Method] = model.RateLimit{
+			Rate:  method.Rate,
+			Burst: method.Burst,
+		}
+	}
 
-		defer timeoutFunc.Stop()
+	rateLimitInterceptor := interceptor.NewRateLimitInterceptor()
+	err = rateLimitInterceptor.InjectRateLimiter(rateLimiter)
+	if err != nil {
+		return nil, err
+	}
+	err = rateLimitInterceptor.InjectLimits(model.RateLimit{
+		Rate:  config.RateLimitRate(),
+		Burst: config.RateLimitBurst(),
+	}, methodLimits)
+	if err != nil {
+		return nil, err
+	}
 
-		var wg sync.WaitGroup
+	trustedProxies, err := config.RateLimitTrustedProxies()
+	if err != nil {
+		return nil, err
+	}
+	err = rateLimitInterceptor.InjectTrustedProxies(trustedProxies)
+	if err != nil {
+		return nil, err
+	}
+	return rateLimitInterceptor, nil
+}
 
-		// Do the operations asynchronously to save time
-		for key, op := range ops {
-			wg.Add(1)
-			innerOp := op
-			innerKey := key
-			go func() {
-				defer wg.Done()
+// newMetricsServer serve prometheus metrics and the health endpoints on the metrics port.
+func newMetricsServer(healthChecker *health.Health) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.Handle("/healthz", healthChecker.LivenessHandler())
+	mux.Handle("/readyz", healthChecker.ReadinessHandler())
+	mux.Handle("/health", healthChecker.DependenciesHandler())
 
-				log.Info(fmt.Sprintf("cleaning up: %s", innerKey))
-				if err := innerOp(ctx); err != nil {
-					log.Error(fmt.Sprintf("%s: clean up failed: %s", innerKey, err.Error()))
-					return
-				}
+	return &http.Server{
+		Addr:    fmt.Sprintf(":%s", config.PortMetrics()),
+		Handler: mux,
+	}
+}
 
-				log.Info(fmt.Sprintf("%s was shutdown gracefully", innerKey))
-			}()
-		}
+// newGRPCServerOptions chain the interceptors enabled in config, tracing and metrics wrap the whole rpc
+// and auth run last so a panic while authenticating is still recovered.
+// The rate limit interceptor is optional and run after auth to limit by user.
+func newGRPCServerOptions(creds credentials.TransportCredentials, peerIdentityInterceptor *interceptor.PeerIdentityInterceptor, authInterceptor *interceptor.AuthInterceptor, rateLimitInterceptor *interceptor.RateLimitInterceptor) []grpc.ServerOption {
+	unary := []grpc.UnaryServerInterceptor{}
+	stream := []grpc.StreamServerInterceptor{}
 
-		wg.Wait()
+	if config.GRPCTracingEnabled() {
+		unary = append(unary, otelgrpc.UnaryServerInterceptor())
+		stream = append(stream, otelgrpc.StreamServerInterceptor())
+	}
+	if config.GRPCMetricsEnabled() {
+		grpcPrometheus.EnableHandlingTimeHistogram()
+		unary = append(unary, grpcPrometheus.UnaryServerInterceptor)
+		stream = append(stream, grpcPrometheus.StreamServerInterceptor)
+	}
+	if config.GRPCRequestIDEnabled() {
+		unary = append(unary, interceptor.RequestIDUnary())
+		stream = append(stream, interceptor.RequestIDStream())
+	}
+	if config.GRPCRecoveryEnabled() {
+		unary = append(unary, interceptor.RecoveryUnary())
+		stream = append(stream, interceptor.RecoveryStream())
+	}
+	unary = append(unary, peerIdentityInterceptor.Unary(), interceptor.OriginUnary(), authInterceptor.Unary())
+	stream = append(stream, peerIdentityInterceptor.Stream(), interceptor.OriginStream(), authInterceptor.Stream())
+	if rateLimitInterceptor != nil {
+		unary = append(unary, rateLimitInterceptor.Unary())
+		stream = append(stream, rateLimitInterceptor.Stream())
+	}
 
-		close(wait)
-	}()
+	return []grpc.ServerOption{
+		grpc.Creds(creds),
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+}
+
+// newGRPCDialOptions apply the client side of the enabled interceptors to connections to other services,
+// the extra interceptors run after them.
+func newGRPCDialOptions(creds credentials.TransportCredentials, extra ...grpc.UnaryClientInterceptor) []grpc.DialOption {
+	unary := []grpc.UnaryClientInterceptor{}
+	stream := []grpc.StreamClientInterceptor{}
+
+	if config.GRPCTracingEnabled() {
+		unary = append(unary, otelgrpc.UnaryClientInterceptor())
+		stream = append(stream, otelgrpc.StreamClientInterceptor())
+	}
+	if config.GRPCMetricsEnabled() {
+		grpcPrometheus.EnableClientHandlingTimeHistogram()
+		unary = append(unary, grpcPrometheus.UnaryClientInterceptor)
+		stream = append(stream, grpcPrometheus.StreamClientInterceptor)
+	}
+	if config.GRPCRequestIDEnabled() {
+		unary = append(unary, interceptor.RequestIDUnaryClient())
+		stream = append(stream, interceptor.RequestIDStreamClient())
+	}
+	unary = append(unary, extra...)
+
+	return []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(unary...),
+		grpc.WithChainStreamInterceptor(stream...),
+	}
+}
+
+// newResilienceInterceptor guard the calls to another service with the services.resilience config.
+func newResilienceInterceptor(service string, idempotentMethods []string) grpc.UnaryClientInterceptor {
+	return interceptor.ResilienceUnaryClient(service, interceptor.ResilienceOptions{
+		Timeout:           config.ServicesTimeout(),
+		RetryAttempts:     config.ServicesRetryAttempts(),
+		RetryBackoff:      config.ServicesRetryBackoff(),
+		BreakerFailures:   config.ServicesBreakerFailures(),
+		BreakerTimeout:    config.ServicesBreakerTimeout(),
+		IdempotentMethods: idempotentMethods,
+	})
+}
 
-	return wait
+// closeCertReloaders stop watching certificate files, disabled tls leave nil reloaders.
+func closeCertReloaders(reloaders ...*infrastructure.CertReloader) error {
+	var closeErr error
+	for _, reloader := range reloaders {
+		if err := reloader.Close(); err != nil && closeErr == nil {
+			closeErr = err
+		}
+	}
+	return closeErr
 }
@@ -0,0 +1,85 @@
+package bootstrap
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/goccy/go-json"
+	"github.com/hibiken/asynq"
+	"github.com/krobus00/product-service/internal/config"
+	"github.com/krobus00/product-service/internal/infrastructure"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/repository"
+	"github.com/krobus00/product-service/internal/usecase"
+	"github.com/krobus00/product-service/internal/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// StartExport write a snapshot of the catalog in this process, the snapshot is only read from the database
+// so neither the cache nor the search index are needed.
+func StartExport(payload *model.CatalogExportPayload) {
+	infrastructure.InitializeDBConn()
+
+	productRepo := repository.NewProductRepository()
+	err := productRepo.InjectDB(infrastructure.DB)
+	utils.ContinueOrFatal(err)
+
+	objectUploader := repository.NewStorageObjectUploader()
+	err = objectUploader.InjectHTTPClient(&http.Client{})
+	utils.ContinueOrFatal(err)
+
+	productUsecase := usecase.NewProductUsecase()
+	err = productUsecase.InjectDB(infrastructure.DB)
+	utils.ContinueOrFatal(err)
+	err = productUsecase.InjectProductRepo(productRepo)
+	utils.ContinueOrFatal(err)
+	err = productUsecase.InjectObjectUploader(objectUploader)
+	utils.ContinueOrFatal(err)
+
+	manifest, err := productUsecase.ExportCatalog(context.Background(), payload)
+	if manifest != nil {
+		logrus.WithFields(logrus.Fields{
+			"fileName":    manifest.FileName,
+			"path":        manifest.Path,
+			"objectID":    manifest.ObjectID,
+			"rows":        manifest.Rows,
+			"deletedRows": manifest.DeletedRows,
+			"bytes":       manifest.Bytes,
+			"sha256":      manifest.SHA256,
+		}).Info("export finished")
+	}
+
+	utils.ContinueOrFatal(err)
+}
+
+// newCatalogExportScheduler register the snapshot task on export.catalog.schedule, the task is unique
+// for its timeout so an export still running is not started twice.
+func newCatalogExportScheduler() (*asynq.Scheduler, error) {
+	scheduler, err := infrastructure.NewAsynqScheduler()
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(&model.CatalogExportPayload{
+		Format:         config.CatalogExportFormat(),
+		Destination:    config.CatalogExportDestination(),
+		IncludeDeleted: config.CatalogExportIncludeDeleted(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = scheduler.Register(config.CatalogExportSchedule(), asynq.NewTask(
+		model.TaskProductCatalogExport,
+		payload,
+		asynq.MaxRetry(config.AsynqRetry()),
+		asynq.Timeout(config.CatalogExportTimeout()),
+		asynq.Unique(config.CatalogExportTimeout()),
+		asynq.Retention(config.AsynqRetention()),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	return scheduler, nil
+}
@@ -0,0 +1,111 @@
+package bootstrap
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/krobus00/product-service/internal/config"
+	"github.com/krobus00/product-service/internal/constant"
+	"github.com/krobus00/product-service/internal/infrastructure"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/repository"
+	"github.com/krobus00/product-service/internal/usecase"
+	"github.com/krobus00/product-service/internal/utils"
+	storagePB "github.com/krobus00/storage-service/pb/storage"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+// StartImport run an import job in this process, the job is recorded like the ones created by ImportProducts
+// so its error report can be read with GetImportJob.
+func StartImport(filePath string, objectID string, format string, ownerID string, columnMapping map[string]string) {
+	payload := &model.ImportProductsPayload{
+		OwnerID:       ownerID,
+		ObjectID:      objectID,
+		Format:        format,
+		ColumnMapping: columnMapping,
+	}
+	if filePath != "" {
+		content, err := os.ReadFile(filePath)
+		utils.ContinueOrFatal(err)
+		payload.Content = content
+		payload.FileName = filepath.Base(filePath)
+	}
+
+	infrastructure.InitializeDBConn()
+
+	redisClient, err := infrastructure.NewRedisClient()
+	utils.ContinueOrFatal(err)
+
+	productSearcher, err := newProductSearcher()
+	utils.ContinueOrFatal(err)
+
+	nc, js, err := infrastructure.NewJetstreamClient()
+	utils.ContinueOrFatal(err)
+
+	storageCreds, storageCertReloader, err := infrastructure.NewClientCredentials(config.TLSClientStorageService)
+	utils.ContinueOrFatal(err)
+
+	storageConn, err := grpc.Dial(config.StorageGRPCHost(), newGRPCDialOptions(storageCreds, newResilienceInterceptor("storage-service", storageIdempotentMethods))...)
+	utils.ContinueOrFatal(err)
+	storageClient := storagePB.NewStorageServiceClient(storageConn)
+
+	productRepo := repository.NewProductRepository()
+	err = productRepo.InjectDB(infrastructure.DB)
+	utils.ContinueOrFatal(err)
+	err = productRepo.InjectRedisClient(redisClient)
+	utils.ContinueOrFatal(err)
+	err = productRepo.InjectProductSearcher(productSearcher)
+	utils.ContinueOrFatal(err)
+	auditLogRepo := repository.NewProductAuditLogRepository()
+	err = auditLogRepo.InjectDB(infrastructure.DB)
+	utils.ContinueOrFatal(err)
+	revisionRepo := repository.NewProductRevisionRepository()
+	err = revisionRepo.InjectDB(infrastructure.DB)
+	utils.ContinueOrFatal(err)
+	outboxRepo := repository.NewProductOutboxRepository()
+	err = outboxRepo.InjectDB(infrastructure.DB)
+	utils.ContinueOrFatal(err)
+	importJobRepo := repository.NewProductImportJobRepository()
+	err = importJobRepo.InjectDB(infrastructure.DB)
+	utils.ContinueOrFatal(err)
+
+	productUsecase := usecase.NewProductUsecase()
+	err = productUsecase.InjectDB(infrastructure.DB)
+	utils.ContinueOrFatal(err)
+	err = productUsecase.InjectProductRepo(productRepo)
+	utils.ContinueOrFatal(err)
+	err = productUsecase.InjectProductAuditLogRepo(auditLogRepo)
+	utils.ContinueOrFatal(err)
+	err = productUsecase.InjectProductRevisionRepo(revisionRepo)
+	utils.ContinueOrFatal(err)
+	err = productUsecase.InjectProductOutboxRepo(outboxRepo)
+	utils.ContinueOrFatal(err)
+	err = productUsecase.InjectProductImportJobRepo(importJobRepo)
+	utils.ContinueOrFatal(err)
+	err = productUsecase.InjectStorageClient(storageClient)
+	utils.ContinueOrFatal(err)
+	err = productUsecase.InjectJetstreamClient(js)
+	utils.ContinueOrFatal(err)
+
+	job, err := productUsecase.RunImport(context.Background(), payload, constant.SystemID)
+	if job != nil {
+		logrus.WithFields(logrus.Fields{
+			"jobID":     job.ID,
+			"status":    job.Status,
+			"processed": job.ProcessedRows,
+			"created":   job.CreatedCount,
+			"updated":   job.UpdatedCount,
+			"failed":    job.FailedCount,
+		}).Info("import finished")
+	}
+
+	// wait for the change events of the imported products before leaving
+	<-js.PublishAsyncComplete()
+	_ = nc.Drain()
+	_ = storageConn.Close()
+	_ = closeCertReloaders(storageCertReloader)
+
+	utils.ContinueOrFatal(err)
+}
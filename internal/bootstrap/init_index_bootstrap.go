@@ -3,42 +3,16 @@ package bootstrap
 import (
 	"context"
 
-	"github.com/krobus00/product-service/internal/constant"
-	"github.com/krobus00/product-service/internal/infrastructure"
-	"github.com/krobus00/product-service/internal/model"
 	"github.com/krobus00/product-service/internal/utils"
 	"github.com/sirupsen/logrus"
 )
 
 func StartInitIndex() {
-	osClient, err := infrastructure.NewOpensearchClient()
+	productSearcher, err := newProductSearcher()
 	utils.ContinueOrFatal(err)
-	ctx := context.Background()
+	defer productSearcher.Close()
 
 	logrus.Info("init index")
-	resCreateIndices, err := osClient.CreateIndices(ctx, model.OSProductIndex, constant.InitIndex)
-	if err != nil {
-		logrus.Error(err.Error())
-	}
-	defer resCreateIndices.Body.Close()
-
-	if resCreateIndices.IsError() {
-		logrus.Error("error init index")
-		logrus.Info(resCreateIndices)
-	} else {
-		logrus.Info("success init index")
-	}
-
-	resUpdateMapping, err := osClient.PutIndicesMapping(ctx, []string{model.OSProductIndex}, constant.IndexMapping)
-	if err != nil {
-		logrus.Error(err.Error())
-	}
-	defer resUpdateMapping.Body.Close()
-
-	if resUpdateMapping.IsError() {
-		logrus.Error("error update mapping")
-		logrus.Info(resUpdateMapping)
-	} else {
-		logrus.Info("success update mapping")
-	}
+	err = productSearcher.InitIndex(context.Background())
+	utils.ContinueOrFatal(err)
 }
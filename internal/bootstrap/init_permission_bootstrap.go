@@ -7,16 +7,22 @@ import (
 	authPB "github.com/krobus00/auth-service/pb/auth"
 	"github.com/krobus00/product-service/internal/config"
 	"github.com/krobus00/product-service/internal/constant"
+	"github.com/krobus00/product-service/internal/infrastructure"
 	"github.com/krobus00/product-service/internal/utils"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
 )
 
 func StartInitPermission() {
-	authConn, err := grpc.Dial(config.AuthGRPCHost(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	authCreds, authCertReloader, err := infrastructure.NewClientCredentials(config.TLSClientAuthService)
+	utils.ContinueOrFatal(err)
+	defer func() {
+		_ = authCertReloader.Close()
+	}()
+
+	authConn, err := grpc.Dial(config.AuthGRPCHost(), newGRPCDialOptions(authCreds, newResilienceInterceptor("auth-service", authIdempotentMethods))...)
 	utils.ContinueOrFatal(err)
 	authClient := authPB.NewAuthServiceClient(authConn)
 
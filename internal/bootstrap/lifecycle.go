@@ -0,0 +1,249 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hibiken/asynq"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+type operation func(ctx context.Context) error
+
+// hook is a single component managed by the lifecycle.
+type hook struct {
+	name string
+	// start must not block, it run in registration order.
+	start operation
+	// serve block until the component stops, an error ends the lifecycle.
+	serve func() error
+	// stop run in reverse registration order, so a component is stopped before its dependencies.
+	stop operation
+	// timeout bound stop, the lifecycle timeout is used when it is zero.
+	timeout time.Duration
+}
+
+// lifecycle start components in the order they were appended, wait for a termination signal
+// or a serve failure, then stop them in reverse order, each within its own deadline so a stuck
+// component doesn't prevent the others from being stopped.
+type lifecycle struct {
+	hooks   []*hook
+	timeout time.Duration
+}
+
+func newLifecycle(timeout time.Duration) *lifecycle {
+	return &lifecycle{
+		timeout: timeout,
+	}
+}
+
+func (l *lifecycle) Append(h *hook) {
+	l.hooks = append(l.hooks, h)
+}
+
+// Run block until every component is stopped.
+func (l *lifecycle) Run(ctx context.Context) error {
+	ctx, stopSignal := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	defer stopSignal()
+
+	serveErr := make(chan error, len(l.hooks))
+	started := 0
+	var err error
+	for _, h := range l.hooks {
+		if h.start != nil {
+			log.Info(fmt.Sprintf("starting: %s", h.name))
+			if err = h.start(ctx); err != nil {
+				err = fmt.Errorf("%s: start failed: %w", h.name, err)
+				break
+			}
+		}
+		if h.serve != nil {
+			go func(h *hook) {
+				if err := h.serve(); err != nil {
+					serveErr <- fmt.Errorf("%s: serve failed: %w", h.name, err)
+				}
+			}(h)
+		}
+		started++
+	}
+
+	if err == nil {
+		select {
+		case <-ctx.Done():
+			log.Info("shutting down")
+		case err = <-serveErr:
+			log.Error(err.Error())
+		}
+	}
+
+	stopErr := l.stop(l.hooks[:started])
+	if err != nil {
+		return err
+	}
+	return stopErr
+}
+
+func (l *lifecycle) stop(hooks []*hook) error {
+	var stopErr stopErrors
+	for i := len(hooks) - 1; i >= 0; i-- {
+		h := hooks[i]
+		if h.stop == nil {
+			continue
+		}
+
+		log.Info(fmt.Sprintf("cleaning up: %s", h.name))
+		err := l.stopHook(h)
+		if err != nil {
+			log.Error(err.Error())
+			stopErr = append(stopErr, err)
+			continue
+		}
+		log.Info(fmt.Sprintf("%s was shutdown gracefully", h.name))
+	}
+
+	if len(stopErr) == 0 {
+		return nil
+	}
+	return stopErr
+}
+
+// stopHook give up waiting once the hook deadline is reached, the hook keep running in background.
+func (l *lifecycle) stopHook(h *hook) error {
+	timeout := h.timeout
+	if timeout <= 0 {
+		timeout = l.timeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- h.stop(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("%s: clean up failed: %w", h.name, err)
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("timeout %d ms has been elapsed while stopping %s: %w", timeout.Milliseconds(), h.name, ctx.Err())
+	}
+}
+
+// stopErrors combine the clean up failures of every hook.
+type stopErrors []error
+
+func (e stopErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Is report whether any of the failures match target.
+func (e stopErrors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// runLifecycle run the lifecycle and exit the process with a non zero code when it failed.
+func runLifecycle(l *lifecycle) {
+	if err := l.Run(context.Background()); err != nil {
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+}
+
+// stopGRPCServer wait for in-flight rpcs to finish, they are cut off once the deadline is reached.
+func stopGRPCServer(ctx context.Context, srv *grpc.Server) error {
+	done := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		srv.Stop()
+		return ctx.Err()
+	}
+}
+
+// newPeriodicHook run fn every interval until the hook is stopped, a failed run is logged and retried on the next tick.
+func newPeriodicHook(name string, interval time.Duration, fn operation) *hook {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	return &hook{
+		name: name,
+		start: func(_ context.Context) error {
+			go func() {
+				defer close(done)
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+						if err := fn(ctx); err != nil && ctx.Err() == nil {
+							log.WithField("hook", name).Warn(err.Error())
+						}
+					}
+				}
+			}()
+			return nil
+		},
+		stop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-stopCtx.Done():
+				return stopCtx.Err()
+			}
+		},
+	}
+}
+
+// serveHTTP treat the expected close after Shutdown as a clean stop.
+func serveHTTP(srv *http.Server) func() error {
+	return func() error {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}
+}
+
+// stopAsynqServer wait for active tasks, asynq use its own shutdown timeout so the deadline only stop the wait.
+func stopAsynqServer(ctx context.Context, srv *asynq.Server) error {
+	done := make(chan struct{})
+	go func() {
+		srv.Shutdown()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
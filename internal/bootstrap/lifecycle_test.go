@@ -0,0 +1,146 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+type lifecycleRecorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *lifecycleRecorder) record(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *lifecycleRecorder) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string{}, r.events...)
+}
+
+func (r *lifecycleRecorder) hook(name string, stopErr error) *hook {
+	return &hook{
+		name: name,
+		start: func(ctx context.Context) error {
+			r.record("start " + name)
+			return nil
+		},
+		stop: func(ctx context.Context) error {
+			r.record("stop " + name)
+			return stopErr
+		},
+	}
+}
+
+func Test_lifecycle_Run(t *testing.T) {
+	errServe := errors.New("serve error")
+	errStop := errors.New("stop error")
+
+	tests := []struct {
+		name       string
+		hooks      func(r *lifecycleRecorder) []*hook
+		wantEvents []string
+		wantErr    error
+	}{
+		{
+			name: "stop in reverse order after a serve failure",
+			hooks: func(r *lifecycleRecorder) []*hook {
+				server := r.hook("server", nil)
+				server.serve = func() error {
+					return errServe
+				}
+				return []*hook{r.hook("database", nil), r.hook("cache", nil), server}
+			},
+			wantEvents: []string{"start database", "start cache", "start server", "stop server", "stop cache", "stop database"},
+			wantErr:    errServe,
+		},
+		{
+			name: "only stop the started hooks when a start fail",
+			hooks: func(r *lifecycleRecorder) []*hook {
+				cache := r.hook("cache", nil)
+				cache.start = func(ctx context.Context) error {
+					return errServe
+				}
+				return []*hook{r.hook("database", nil), cache, r.hook("server", nil)}
+			},
+			wantEvents: []string{"start database", "stop database"},
+			wantErr:    errServe,
+		},
+		{
+			name: "a failed stop doesn't prevent the next ones",
+			hooks: func(r *lifecycleRecorder) []*hook {
+				server := r.hook("server", nil)
+				server.serve = func() error {
+					return errServe
+				}
+				return []*hook{r.hook("database", nil), r.hook("cache", errStop), server}
+			},
+			wantEvents: []string{"start database", "start cache", "start server", "stop server", "stop cache", "stop database"},
+			wantErr:    errServe,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := new(lifecycleRecorder)
+			lc := newLifecycle(time.Second)
+			for _, h := range tt.hooks(recorder) {
+				lc.Append(h)
+			}
+
+			err := lc.Run(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("lifecycle.Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := recorder.get(); !reflect.DeepEqual(got, tt.wantEvents) {
+				t.Errorf("lifecycle.Run() events = %v, want %v", got, tt.wantEvents)
+			}
+		})
+	}
+}
+
+func Test_lifecycle_stop(t *testing.T) {
+	errStop := errors.New("stop error")
+	recorder := new(lifecycleRecorder)
+
+	stuck := make(chan struct{})
+	defer close(stuck)
+
+	lc := newLifecycle(time.Second)
+	lc.Append(recorder.hook("database", nil))
+	lc.Append(recorder.hook("cache", errStop))
+	lc.Append(&hook{
+		name:    "server",
+		timeout: 50 * time.Millisecond,
+		stop: func(ctx context.Context) error {
+			recorder.record("stop server")
+			<-stuck
+			return nil
+		},
+	})
+	lc.Append(recorder.hook("health", nil))
+
+	started := time.Now()
+	err := lc.stop(lc.hooks)
+	if elapsed := time.Since(started); elapsed > 500*time.Millisecond {
+		t.Errorf("lifecycle.stop() took %s, want the stuck hook to time out after its own timeout", elapsed)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("lifecycle.stop() error = %v, want the stuck hook timeout", err)
+	}
+	if !errors.Is(err, errStop) {
+		t.Errorf("lifecycle.stop() error = %v, want the failed hook error", err)
+	}
+
+	wantEvents := []string{"stop health", "stop server", "stop cache", "stop database"}
+	if got := recorder.get(); !reflect.DeepEqual(got, wantEvents) {
+		t.Errorf("lifecycle.stop() events = %v, want %v", got, wantEvents)
+	}
+}
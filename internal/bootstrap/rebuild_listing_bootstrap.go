@@ -0,0 +1,27 @@
+package bootstrap
+
+import (
+	"context"
+
+	"github.com/krobus00/product-service/internal/infrastructure"
+	"github.com/krobus00/product-service/internal/repository"
+	"github.com/krobus00/product-service/internal/utils"
+	"github.com/sirupsen/logrus"
+)
+
+func StartRebuildListing() {
+	infrastructure.InitializeDBConn()
+
+	redisClient, err := infrastructure.NewRedisClient()
+	utils.ContinueOrFatal(err)
+
+	productRepo := repository.NewProductRepository()
+	err = productRepo.InjectDB(infrastructure.DB)
+	utils.ContinueOrFatal(err)
+	err = productRepo.InjectRedisClient(redisClient)
+	utils.ContinueOrFatal(err)
+
+	logrus.Info("rebuild listing")
+	err = productRepo.RebuildListing(context.Background())
+	utils.ContinueOrFatal(err)
+}
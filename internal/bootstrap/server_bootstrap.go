@@ -2,23 +2,27 @@ package bootstrap
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
 
+	grpcPrometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	authPB "github.com/krobus00/auth-service/pb/auth"
 	"github.com/krobus00/product-service/internal/config"
+	"github.com/krobus00/product-service/internal/health"
 	"github.com/krobus00/product-service/internal/infrastructure"
+	"github.com/krobus00/product-service/internal/interceptor"
 	"github.com/krobus00/product-service/internal/model"
 	"github.com/krobus00/product-service/internal/repository"
+	gatewayTransport "github.com/krobus00/product-service/internal/transport/gateway"
 	grpcServer "github.com/krobus00/product-service/internal/transport/grpc"
 	"github.com/krobus00/product-service/internal/usecase"
 	"github.com/krobus00/product-service/internal/utils"
 	pb "github.com/krobus00/product-service/pb/product"
 	storagePB "github.com/krobus00/storage-service/pb/storage"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	healthPB "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 
 	"github.com/sirupsen/logrus"
@@ -34,21 +38,40 @@ func StartServer() {
 	redisClient, err := infrastructure.NewRedisClient()
 	utils.ContinueOrFatal(err)
 
-	osClient, err := infrastructure.NewOpensearchClient()
+	productSearcher, err := newProductSearcher()
 	utils.ContinueOrFatal(err)
 
 	nc, js, err := infrastructure.NewJetstreamClient()
 	utils.ContinueOrFatal(err)
 
+	asynqClient, err := infrastructure.NewAsynqClient()
+	utils.ContinueOrFatal(err)
+
 	tp, err := infrastructure.JaegerTraceProvider()
 	utils.ContinueOrFatal(err)
 
+	tokenVerifier, err := infrastructure.NewTokenVerifier()
+	utils.ContinueOrFatal(err)
+
+	// init tls
+	serverCreds, serverCertReloader, err := infrastructure.NewServerCredentials()
+	utils.ContinueOrFatal(err)
+	authCreds, authCertReloader, err := infrastructure.NewClientCredentials(config.TLSClientAuthService)
+	utils.ContinueOrFatal(err)
+	storageCreds, storageCertReloader, err := infrastructure.NewClientCredentials(config.TLSClientStorageService)
+	utils.ContinueOrFatal(err)
+	if config.TLSServerEnabled() && !config.TLSClientEnabled(config.TLSClientGateway) {
+		utils.ContinueOrFatal(errors.New("tls.clients.gateway must be enabled when tls.server is enabled"))
+	}
+	gatewayCreds, gatewayCertReloader, err := infrastructure.NewClientCredentials(config.TLSClientGateway)
+	utils.ContinueOrFatal(err)
+
 	// init grpc client
-	authConn, err := grpc.Dial(config.AuthGRPCHost(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	authConn, err := grpc.Dial(config.AuthGRPCHost(), newGRPCDialOptions(authCreds, newResilienceInterceptor("auth-service", authIdempotentMethods))...)
 	utils.ContinueOrFatal(err)
 	authClient := authPB.NewAuthServiceClient(authConn)
 
-	storageConn, err := grpc.Dial(config.StorageGRPCHost(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	storageConn, err := grpc.Dial(config.StorageGRPCHost(), newGRPCDialOptions(storageCreds, newResilienceInterceptor("storage-service", storageIdempotentMethods))...)
 	utils.ContinueOrFatal(err)
 	storageClient := storagePB.NewStorageServiceClient(storageConn)
 
@@ -58,7 +81,31 @@ func StartServer() {
 	utils.ContinueOrFatal(err)
 	err = productRepo.InjectRedisClient(redisClient)
 	utils.ContinueOrFatal(err)
-	err = productRepo.InjectOpensearchClient(osClient)
+	err = productRepo.InjectProductSearcher(productSearcher)
+	utils.ContinueOrFatal(err)
+	collaboratorRepo := repository.NewProductCollaboratorRepository()
+	err = collaboratorRepo.InjectDB(infrastructure.DB)
+	utils.ContinueOrFatal(err)
+	ownershipTransferRepo := repository.NewProductOwnershipTransferRepository()
+	err = ownershipTransferRepo.InjectDB(infrastructure.DB)
+	utils.ContinueOrFatal(err)
+	auditLogRepo := repository.NewProductAuditLogRepository()
+	err = auditLogRepo.InjectDB(infrastructure.DB)
+	utils.ContinueOrFatal(err)
+	revisionRepo := repository.NewProductRevisionRepository()
+	err = revisionRepo.InjectDB(infrastructure.DB)
+	utils.ContinueOrFatal(err)
+	outboxRepo := repository.NewProductOutboxRepository()
+	err = outboxRepo.InjectDB(infrastructure.DB)
+	utils.ContinueOrFatal(err)
+	importJobRepo := repository.NewProductImportJobRepository()
+	err = importJobRepo.InjectDB(infrastructure.DB)
+	utils.ContinueOrFatal(err)
+	permissionCacheRepo := repository.NewPermissionCacheRepository()
+	err = permissionCacheRepo.InjectRedisClient(redisClient)
+	utils.ContinueOrFatal(err)
+	idempotencyRepo := repository.NewIdempotencyRepository()
+	err = idempotencyRepo.InjectDB(infrastructure.DB)
 	utils.ContinueOrFatal(err)
 
 	// init usecase
@@ -67,12 +114,30 @@ func StartServer() {
 	utils.ContinueOrFatal(err)
 	err = productUsecase.InjectDB(infrastructure.DB)
 	utils.ContinueOrFatal(err)
+	err = productUsecase.InjectProductCollaboratorRepo(collaboratorRepo)
+	utils.ContinueOrFatal(err)
+	err = productUsecase.InjectProductOwnershipTransferRepo(ownershipTransferRepo)
+	utils.ContinueOrFatal(err)
+	err = productUsecase.InjectProductAuditLogRepo(auditLogRepo)
+	utils.ContinueOrFatal(err)
+	err = productUsecase.InjectProductRevisionRepo(revisionRepo)
+	utils.ContinueOrFatal(err)
+	err = productUsecase.InjectProductOutboxRepo(outboxRepo)
+	utils.ContinueOrFatal(err)
+	err = productUsecase.InjectProductImportJobRepo(importJobRepo)
+	utils.ContinueOrFatal(err)
 	err = productUsecase.InjectAuthClient(authClient)
 	utils.ContinueOrFatal(err)
+	err = productUsecase.InjectPermissionCacheRepo(permissionCacheRepo)
+	utils.ContinueOrFatal(err)
+	err = productUsecase.InjectIdempotencyRepo(idempotencyRepo)
+	utils.ContinueOrFatal(err)
 	err = productUsecase.InjectStorageClient(storageClient)
 	utils.ContinueOrFatal(err)
 	err = productUsecase.InjectJetstreamClient(js)
 	utils.ContinueOrFatal(err)
+	err = productUsecase.InjectAsynqClient(asynqClient)
+	utils.ContinueOrFatal(err)
 
 	// init stream
 	publisherUsecase := []model.PublisherUsecase{
@@ -89,41 +154,172 @@ func StartServer() {
 	err = grpcDelivery.InjectProductUsecase(productUsecase)
 	utils.ContinueOrFatal(err)
 
-	productGrpcServer := grpc.NewServer()
+	// init interceptor
+	authInterceptor := interceptor.NewAuthInterceptor()
+	err = authInterceptor.InjectTokenVerifier(tokenVerifier)
+	utils.ContinueOrFatal(err)
+	err = authInterceptor.InjectTrustedServices(config.AuthTrustedServices())
+	utils.ContinueOrFatal(err)
+	peerIdentityInterceptor := interceptor.NewPeerIdentityInterceptor()
+	err = peerIdentityInterceptor.InjectAllowedIdentities(config.TLSServerAllowedIdentities())
+	utils.ContinueOrFatal(err)
+
+	rateLimitInterceptor, err := newRateLimitInterceptor(redisClient)
+	utils.ContinueOrFatal(err)
+
+	productGrpcServer := grpc.NewServer(newGRPCServerOptions(serverCreds, peerIdentityInterceptor, authInterceptor, rateLimitInterceptor)...)
+
+	// init health
+	healthChecker := health.New(config.HealthCheckTimeout(), pb.ProductService_ServiceDesc.ServiceName)
+	healthChecker.Register("database", health.DBChecker(db))
+	healthChecker.Register("redis", health.RedisChecker(redisClient))
+	healthChecker.Register("product searcher", health.SearcherChecker(productSearcher))
+	healthChecker.Register("nats", health.NATSChecker(nc))
+	healthChecker.Register("auth service", health.GRPCConnChecker(authConn))
+	healthChecker.Register("storage service", health.GRPCConnChecker(storageConn))
 
 	pb.RegisterProductServiceServer(productGrpcServer, grpcDelivery)
+	healthPB.RegisterHealthServer(productGrpcServer, healthChecker.GRPCServer())
 	if config.Env() == "development" {
 		reflection.Register(productGrpcServer)
 	}
-	lis, _ := net.Listen("tcp", ":"+config.PortGRPC())
-
-	go func() {
-		_ = productGrpcServer.Serve(lis)
-	}()
-	logrus.Info(fmt.Sprintf("grpc server started on :%s", config.PortGRPC()))
+	if config.GRPCMetricsEnabled() {
+		grpcPrometheus.Register(productGrpcServer)
+	}
+	lis, err := net.Listen("tcp", ":"+config.PortGRPC())
+	utils.ContinueOrFatal(err)
 
-	http.Handle("/metrics", promhttp.Handler())
+	metricsServer := newMetricsServer(healthChecker)
 
-	go func() {
-		_ = http.ListenAndServe(fmt.Sprintf(":%s", config.PortMetrics()), nil)
-	}()
-	logrus.Info(fmt.Sprintf("metrics server started on :%s", config.PortMetrics()))
+	// init gateway
+	gatewayConn, err := grpc.Dial(config.GatewayGRPCTarget(), newGRPCDialOptions(gatewayCreds)...)
+	utils.ContinueOrFatal(err)
+	productGateway := gatewayTransport.NewGateway()
+	err = productGateway.InjectGRPCConn(gatewayConn)
+	utils.ContinueOrFatal(err)
+	gatewayHandler, err := productGateway.Handler(context.Background())
+	utils.ContinueOrFatal(err)
+	gatewayServer := &http.Server{
+		Addr:    fmt.Sprintf(":%s", config.PortHTTP()),
+		Handler: gatewayHandler,
+	}
+	healthCtx, stopHealthWatch := context.WithCancel(context.Background())
 
-	wait := gracefulShutdown(context.Background(), config.GracefulShutdownTimeOut(), map[string]operation{
-		"database connection": func(ctx context.Context) error {
+	// components are stopped in reverse order, after everything that depend on them
+	lc := newLifecycle(config.GracefulShutdownTimeOut())
+	lc.Append(&hook{
+		name: "trace provider",
+		stop: func(ctx context.Context) error {
+			return tp.Shutdown(ctx)
+		},
+	})
+	lc.Append(&hook{
+		name: "certificate reloaders",
+		stop: func(ctx context.Context) error {
+			return closeCertReloaders(serverCertReloader, authCertReloader, storageCertReloader, gatewayCertReloader)
+		},
+	})
+	lc.Append(&hook{
+		name: "database connection",
+		stop: func(ctx context.Context) error {
 			infrastructure.StopTickerCh <- true
 			return db.Close()
 		},
-		"grpc": func(ctx context.Context) error {
-			return lis.Close()
+	})
+	lc.Append(&hook{
+		name: "redis connection",
+		stop: func(ctx context.Context) error {
+			return redisClient.Close()
 		},
-		"nats connection": func(ctx context.Context) error {
+	})
+	lc.Append(&hook{
+		name: "product searcher",
+		stop: func(ctx context.Context) error {
+			return productSearcher.Close()
+		},
+	})
+	lc.Append(&hook{
+		name: "nats connection",
+		stop: func(ctx context.Context) error {
 			return nc.Drain()
 		},
-		"trace provider": func(ctx context.Context) error {
-			return tp.Shutdown(ctx)
+	})
+	lc.Append(&hook{
+		name: "asynq client connection",
+		stop: func(ctx context.Context) error {
+			return asynqClient.Close()
+		},
+	})
+	lc.Append(&hook{
+		name: "token verifier",
+		stop: func(ctx context.Context) error {
+			tokenVerifier.Close()
+			return nil
+		},
+	})
+	lc.Append(&hook{
+		name: "auth grpc connection",
+		stop: func(ctx context.Context) error {
+			return authConn.Close()
+		},
+	})
+	lc.Append(&hook{
+		name: "storage grpc connection",
+		stop: func(ctx context.Context) error {
+			return storageConn.Close()
+		},
+	})
+	lc.Append(&hook{
+		name: "metrics server",
+		start: func(ctx context.Context) error {
+			logrus.Info(fmt.Sprintf("metrics server started on :%s", config.PortMetrics()))
+			return nil
+		},
+		serve: serveHTTP(metricsServer),
+		stop: func(ctx context.Context) error {
+			return metricsServer.Shutdown(ctx)
+		},
+	})
+	lc.Append(&hook{
+		name: "grpc server",
+		start: func(ctx context.Context) error {
+			logrus.Info(fmt.Sprintf("grpc server started on :%s", config.PortGRPC()))
+			return nil
+		},
+		serve: func() error {
+			return productGrpcServer.Serve(lis)
+		},
+		stop: func(ctx context.Context) error {
+			return stopGRPCServer(ctx, productGrpcServer)
+		},
+	})
+	lc.Append(&hook{
+		name: "http gateway",
+		start: func(ctx context.Context) error {
+			logrus.Info(fmt.Sprintf("http gateway started on :%s", config.PortHTTP()))
+			return nil
+		},
+		serve: serveHTTP(gatewayServer),
+		stop: func(ctx context.Context) error {
+			if err := gatewayServer.Shutdown(ctx); err != nil {
+				return err
+			}
+			return gatewayConn.Close()
+		},
+	})
+	lc.Append(&hook{
+		// stop advertising readiness and let load balancers notice before the grpc server stop accepting rpcs
+		name: "health",
+		start: func(ctx context.Context) error {
+			healthChecker.Serve()
+			go healthChecker.Watch(healthCtx, config.HealthCheckInterval())
+			return nil
+		},
+		stop: func(ctx context.Context) error {
+			stopHealthWatch()
+			return healthChecker.Shutdown(ctx, config.HealthDrainDelay())
 		},
 	})
 
-	<-wait
+	runLifecycle(lc)
 }
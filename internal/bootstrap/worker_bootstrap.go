@@ -8,6 +8,7 @@ import (
 	"github.com/hibiken/asynq"
 	authPB "github.com/krobus00/auth-service/pb/auth"
 	"github.com/krobus00/product-service/internal/config"
+	"github.com/krobus00/product-service/internal/health"
 	"github.com/krobus00/product-service/internal/infrastructure"
 	"github.com/krobus00/product-service/internal/model"
 	"github.com/krobus00/product-service/internal/repository"
@@ -15,10 +16,8 @@ import (
 	"github.com/krobus00/product-service/internal/usecase"
 	"github.com/krobus00/product-service/internal/utils"
 	storagePB "github.com/krobus00/storage-service/pb/storage"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 func StartWorker() {
@@ -31,7 +30,7 @@ func StartWorker() {
 	redisClient, err := infrastructure.NewRedisClient()
 	utils.ContinueOrFatal(err)
 
-	osClient, err := infrastructure.NewOpensearchClient()
+	productSearcher, err := newProductSearcher()
 	utils.ContinueOrFatal(err)
 
 	nc, js, err := infrastructure.NewJetstreamClient()
@@ -45,12 +44,18 @@ func StartWorker() {
 	tp, err := infrastructure.JaegerTraceProvider()
 	utils.ContinueOrFatal(err)
 
+	// init tls
+	authCreds, authCertReloader, err := infrastructure.NewClientCredentials(config.TLSClientAuthService)
+	utils.ContinueOrFatal(err)
+	storageCreds, storageCertReloader, err := infrastructure.NewClientCredentials(config.TLSClientStorageService)
+	utils.ContinueOrFatal(err)
+
 	// init grpc client
-	authConn, err := grpc.Dial(config.AuthGRPCHost(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	authConn, err := grpc.Dial(config.AuthGRPCHost(), newGRPCDialOptions(authCreds, newResilienceInterceptor("auth-service", authIdempotentMethods))...)
 	utils.ContinueOrFatal(err)
 	authClient := authPB.NewAuthServiceClient(authConn)
 
-	storageConn, err := grpc.Dial(config.StorageGRPCHost(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	storageConn, err := grpc.Dial(config.StorageGRPCHost(), newGRPCDialOptions(storageCreds, newResilienceInterceptor("storage-service", storageIdempotentMethods))...)
 	utils.ContinueOrFatal(err)
 	storageClient := storagePB.NewStorageServiceClient(storageConn)
 
@@ -60,7 +65,34 @@ func StartWorker() {
 	utils.ContinueOrFatal(err)
 	err = productRepo.InjectRedisClient(redisClient)
 	utils.ContinueOrFatal(err)
-	err = productRepo.InjectOpensearchClient(osClient)
+	err = productRepo.InjectProductSearcher(productSearcher)
+	utils.ContinueOrFatal(err)
+	collaboratorRepo := repository.NewProductCollaboratorRepository()
+	err = collaboratorRepo.InjectDB(infrastructure.DB)
+	utils.ContinueOrFatal(err)
+	ownershipTransferRepo := repository.NewProductOwnershipTransferRepository()
+	err = ownershipTransferRepo.InjectDB(infrastructure.DB)
+	utils.ContinueOrFatal(err)
+	auditLogRepo := repository.NewProductAuditLogRepository()
+	err = auditLogRepo.InjectDB(infrastructure.DB)
+	utils.ContinueOrFatal(err)
+	revisionRepo := repository.NewProductRevisionRepository()
+	err = revisionRepo.InjectDB(infrastructure.DB)
+	utils.ContinueOrFatal(err)
+	outboxRepo := repository.NewProductOutboxRepository()
+	err = outboxRepo.InjectDB(infrastructure.DB)
+	utils.ContinueOrFatal(err)
+	importJobRepo := repository.NewProductImportJobRepository()
+	err = importJobRepo.InjectDB(infrastructure.DB)
+	utils.ContinueOrFatal(err)
+	objectUploader := repository.NewStorageObjectUploader()
+	err = objectUploader.InjectHTTPClient(&http.Client{})
+	utils.ContinueOrFatal(err)
+	permissionCacheRepo := repository.NewPermissionCacheRepository()
+	err = permissionCacheRepo.InjectRedisClient(redisClient)
+	utils.ContinueOrFatal(err)
+	idempotencyRepo := repository.NewIdempotencyRepository()
+	err = idempotencyRepo.InjectDB(infrastructure.DB)
 	utils.ContinueOrFatal(err)
 
 	// init usecase
@@ -69,27 +101,38 @@ func StartWorker() {
 	utils.ContinueOrFatal(err)
 	err = productUsecase.InjectDB(infrastructure.DB)
 	utils.ContinueOrFatal(err)
+	err = productUsecase.InjectProductCollaboratorRepo(collaboratorRepo)
+	utils.ContinueOrFatal(err)
+	err = productUsecase.InjectProductOwnershipTransferRepo(ownershipTransferRepo)
+	utils.ContinueOrFatal(err)
+	err = productUsecase.InjectProductAuditLogRepo(auditLogRepo)
+	utils.ContinueOrFatal(err)
+	err = productUsecase.InjectProductRevisionRepo(revisionRepo)
+	utils.ContinueOrFatal(err)
+	err = productUsecase.InjectProductOutboxRepo(outboxRepo)
+	utils.ContinueOrFatal(err)
+	err = productUsecase.InjectProductImportJobRepo(importJobRepo)
+	utils.ContinueOrFatal(err)
 	err = productUsecase.InjectAuthClient(authClient)
 	utils.ContinueOrFatal(err)
+	err = productUsecase.InjectPermissionCacheRepo(permissionCacheRepo)
+	utils.ContinueOrFatal(err)
+	err = productUsecase.InjectIdempotencyRepo(idempotencyRepo)
+	utils.ContinueOrFatal(err)
 	err = productUsecase.InjectStorageClient(storageClient)
 	utils.ContinueOrFatal(err)
 	err = productUsecase.InjectJetstreamClient(js)
 	utils.ContinueOrFatal(err)
 	err = productUsecase.InjectAsynqClient(asynqClient)
 	utils.ContinueOrFatal(err)
+	err = productUsecase.InjectObjectUploader(objectUploader)
+	utils.ContinueOrFatal(err)
 
 	// init stream
 	consumerUsecase := []model.ConsumerUsecase{
 		productUsecase,
 	}
 
-	for _, uc := range consumerUsecase {
-		go func(uc model.ConsumerUsecase) {
-			err = uc.ConsumeEvent()
-			utils.ContinueOrFatal(err)
-		}(uc)
-	}
-
 	// init asnyq
 	mux := asynq.NewServeMux()
 	asynqDelivery := asynqTransport.NewDelivery()
@@ -101,36 +144,132 @@ func StartWorker() {
 	err = asynqDelivery.InitRoutes()
 	utils.ContinueOrFatal(err)
 
-	http.Handle("/metrics", promhttp.Handler())
+	// init health
+	healthChecker := health.New(config.HealthCheckTimeout())
+	healthChecker.Register("database", health.DBChecker(db))
+	healthChecker.Register("redis", health.RedisChecker(redisClient))
+	healthChecker.Register("product searcher", health.SearcherChecker(productSearcher))
+	healthChecker.Register("nats", health.NATSChecker(nc))
+	healthChecker.Register("auth service", health.GRPCConnChecker(authConn))
+	healthChecker.Register("storage service", health.GRPCConnChecker(storageConn))
 
-	go func() {
-		_ = http.ListenAndServe(fmt.Sprintf(":%s", config.PortMetrics()), nil)
-	}()
-	logrus.Info(fmt.Sprintf("metrics server started on :%s", config.PortMetrics()))
+	metricsServer := newMetricsServer(healthChecker)
 
-	if err := asynqServer.Run(mux); err != nil {
-		logrus.Fatalf("could not run asynq server: %v", err)
-	}
-
-	wait := gracefulShutdown(context.Background(), config.GracefulShutdownTimeOut(), map[string]operation{
-		"database connection": func(ctx context.Context) error {
+	// components are stopped in reverse order, after everything that depend on them
+	lc := newLifecycle(config.GracefulShutdownTimeOut())
+	lc.Append(&hook{
+		name: "trace provider",
+		stop: func(ctx context.Context) error {
+			return tp.Shutdown(ctx)
+		},
+	})
+	lc.Append(&hook{
+		name: "certificate reloaders",
+		stop: func(ctx context.Context) error {
+			return closeCertReloaders(authCertReloader, storageCertReloader)
+		},
+	})
+	lc.Append(&hook{
+		name: "database connection",
+		stop: func(ctx context.Context) error {
 			infrastructure.StopTickerCh <- true
 			return db.Close()
 		},
-		"nats connection": func(ctx context.Context) error {
+	})
+	lc.Append(&hook{
+		name: "redis connection",
+		stop: func(ctx context.Context) error {
+			return redisClient.Close()
+		},
+	})
+	lc.Append(&hook{
+		name: "product searcher",
+		stop: func(ctx context.Context) error {
+			return productSearcher.Close()
+		},
+	})
+	lc.Append(&hook{
+		name: "nats connection",
+		stop: func(ctx context.Context) error {
 			return nc.Drain()
 		},
-		"asynq client connection": func(ctx context.Context) error {
-			return asynqClient.Close()
+	})
+	lc.Append(&hook{
+		name: "auth grpc connection",
+		stop: func(ctx context.Context) error {
+			return authConn.Close()
+		},
+	})
+	lc.Append(&hook{
+		name: "storage grpc connection",
+		stop: func(ctx context.Context) error {
+			return storageConn.Close()
 		},
-		"asynq server connection": func(ctx context.Context) error {
-			asynqServer.Shutdown()
+	})
+	lc.Append(&hook{
+		name: "asynq client connection",
+		stop: func(ctx context.Context) error {
 			return asynqClient.Close()
 		},
-		"trace provider": func(ctx context.Context) error {
-			return tp.Shutdown(ctx)
+	})
+	lc.Append(&hook{
+		name: "metrics server",
+		start: func(ctx context.Context) error {
+			logrus.Info(fmt.Sprintf("metrics server started on :%s", config.PortMetrics()))
+			return nil
+		},
+		serve: serveHTTP(metricsServer),
+		stop: func(ctx context.Context) error {
+			return metricsServer.Shutdown(ctx)
+		},
+	})
+	lc.Append(&hook{
+		name: "event consumer",
+		start: func(ctx context.Context) error {
+			for _, uc := range consumerUsecase {
+				if err := uc.ConsumeEvent(); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+	lc.Append(&hook{
+		name: "asynq server",
+		start: func(ctx context.Context) error {
+			return asynqServer.Start(mux)
+		},
+		stop: func(ctx context.Context) error {
+			return stopAsynqServer(ctx, asynqServer)
+		},
+	})
+	lc.Append(newPeriodicHook("product event relay", config.OutboxRelayInterval(), productUsecase.RelayProductEvents))
+	lc.Append(newPeriodicHook("idempotency cleanup", config.IdempotencyCleanupInterval(), productUsecase.DeleteExpiredIdempotencyKeys))
+	if config.CatalogExportSchedule() != "" {
+		scheduler, err := newCatalogExportScheduler()
+		utils.ContinueOrFatal(err)
+		lc.Append(&hook{
+			name: "catalog export scheduler",
+			start: func(ctx context.Context) error {
+				return scheduler.Start()
+			},
+			stop: func(ctx context.Context) error {
+				scheduler.Shutdown()
+				return nil
+			},
+		})
+	}
+	lc.Append(&hook{
+		// stop advertising readiness before the asynq server stop pulling tasks
+		name: "health",
+		start: func(ctx context.Context) error {
+			healthChecker.Serve()
+			return nil
+		},
+		stop: func(ctx context.Context) error {
+			return healthChecker.Shutdown(ctx, 0)
 		},
 	})
 
-	<-wait
+	runLifecycle(lc)
 }
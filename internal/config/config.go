@@ -3,6 +3,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net"
 	"strings"
 	"time"
 
@@ -42,6 +43,18 @@ func PortGRPC() string {
 	return viper.GetString("ports.grpc")
 }
 
+func PortHTTP() string {
+	return viper.GetString("ports.http")
+}
+
+// GatewayGRPCTarget is the grpc server dialed by the rest gateway, the local grpc port by default.
+func GatewayGRPCTarget() string {
+	if viper.GetString("gateway.grpc_target") != "" {
+		return viper.GetString("gateway.grpc_target")
+	}
+	return "localhost:" + PortGRPC()
+}
+
 func PortMetrics() string {
 	return viper.GetString("ports.metrics")
 }
@@ -215,6 +228,332 @@ func OpensearchInsecure() bool {
 	return viper.GetBool("opensearch.insecure")
 }
 
+func SearchEngine() string {
+	if viper.IsSet("search.engine") {
+		return viper.GetString("search.engine")
+	}
+	return DefaultSearchEngine
+}
+
+func SearchBlevePath() string {
+	if viper.IsSet("search.bleve.path") {
+		return viper.GetString("search.bleve.path")
+	}
+	return DefaultSearchBlevePath
+}
+
+func SearchBleveOpenTimeout() time.Duration {
+	cfg := viper.GetString("search.bleve.open_timeout")
+	return parseDuration(cfg, DefaultSearchBleveOpenTimeout)
+}
+
+func SearchTimeout() time.Duration {
+	cfg := viper.GetString("search.timeout")
+	return parseDuration(cfg, DefaultSearchTimeout)
+}
+
+func SearchBreakerFailures() uint32 {
+	if viper.GetUint32("search.breaker.failures") == 0 {
+		return DefaultSearchBreakerFailures
+	}
+	return viper.GetUint32("search.breaker.failures")
+}
+
+func SearchBreakerTimeout() time.Duration {
+	cfg := viper.GetString("search.breaker.timeout")
+	return parseDuration(cfg, DefaultSearchBreakerTimeout)
+}
+
+// GRPCTracingEnabled and the other interceptor toggles default to true, set them to false to drop the interceptor.
+func GRPCTracingEnabled() bool {
+	return getBoolOrDefault("grpc.interceptors.tracing", true)
+}
+
+func GRPCMetricsEnabled() bool {
+	return getBoolOrDefault("grpc.interceptors.metrics", true)
+}
+
+func GRPCRecoveryEnabled() bool {
+	return getBoolOrDefault("grpc.interceptors.recovery", true)
+}
+
+func GRPCRequestIDEnabled() bool {
+	return getBoolOrDefault("grpc.interceptors.request_id", true)
+}
+
+func TLSServerEnabled() bool {
+	return viper.GetBool("tls.server.enabled")
+}
+
+func TLSServerCertFile() string {
+	return viper.GetString("tls.server.cert_file")
+}
+
+func TLSServerKeyFile() string {
+	return viper.GetString("tls.server.key_file")
+}
+
+// TLSServerCAFile is the bundle used to verify client certificates.
+func TLSServerCAFile() string {
+	return viper.GetString("tls.server.ca_file")
+}
+
+func TLSServerClientAuth() string {
+	if viper.IsSet("tls.server.client_auth") {
+		return viper.GetString("tls.server.client_auth")
+	}
+	return DefaultTLSServerClientAuth
+}
+
+// TLSServerAllowedIdentities list the SPIFFE ids or certificate SANs allowed to call the grpc server,
+// every verified client is allowed when empty.
+func TLSServerAllowedIdentities() []string {
+	return viper.GetStringSlice("tls.server.allowed_identities")
+}
+
+// TLSClientEnabled and the other client getters read tls.clients.<client>, see the TLSClient constants.
+func TLSClientEnabled(client string) bool {
+	return viper.GetBool(fmt.Sprintf("tls.clients.%s.enabled", client))
+}
+
+func TLSClientCertFile(client string) string {
+	return viper.GetString(fmt.Sprintf("tls.clients.%s.cert_file", client))
+}
+
+func TLSClientKeyFile(client string) string {
+	return viper.GetString(fmt.Sprintf("tls.clients.%s.key_file", client))
+}
+
+// TLSClientCAFile is the bundle used to verify the server certificate, the system pool is used when empty.
+func TLSClientCAFile(client string) string {
+	return viper.GetString(fmt.Sprintf("tls.clients.%s.ca_file", client))
+}
+
+// TLSClientServerName override the name checked against the server certificate, the dialed host is used when empty.
+func TLSClientServerName(client string) string {
+	return viper.GetString(fmt.Sprintf("tls.clients.%s.server_name", client))
+}
+
+func HealthCheckTimeout() time.Duration {
+	cfg := viper.GetString("health.check_timeout")
+	return parseDuration(cfg, DefaultHealthCheckTimeout)
+}
+
+func HealthCheckInterval() time.Duration {
+	cfg := viper.GetString("health.check_interval")
+	return parseDuration(cfg, DefaultHealthCheckInterval)
+}
+
+// HealthDrainDelay is how long the server keep serving after it reported not ready, before it stop accepting rpcs.
+func HealthDrainDelay() time.Duration {
+	cfg := viper.GetString("health.drain_delay")
+	return parseDuration(cfg, DefaultHealthDrainDelay)
+}
+
+// MethodRateLimit override the default rate limit of a grpc method.
+type MethodRateLimit struct {
+	Method string  `mapstructure:"method"`
+	Rate   float64 `mapstructure:"rate"`
+	Burst  int     `mapstructure:"burst"`
+}
+
+func RateLimitEnabled() bool {
+	return viper.GetBool("rate_limit.enabled")
+}
+
+// RateLimitMode is local to limit per replica or redis to share the limit across replicas.
+func RateLimitMode() string {
+	if viper.IsSet("rate_limit.mode") {
+		return viper.GetString("rate_limit.mode")
+	}
+	return DefaultRateLimitMode
+}
+
+// RateLimitRate is the requests per second allowed for every user and method.
+func RateLimitRate() float64 {
+	if viper.GetFloat64("rate_limit.rate") <= 0 {
+		return DefaultRateLimitRate
+	}
+	return viper.GetFloat64("rate_limit.rate")
+}
+
+func RateLimitBurst() int {
+	if viper.GetInt("rate_limit.burst") <= 0 {
+		return DefaultRateLimitBurst
+	}
+	return viper.GetInt("rate_limit.burst")
+}
+
+func RateLimitMethods() ([]MethodRateLimit, error) {
+	methods := make([]MethodRateLimit, 0)
+	err := viper.UnmarshalKey("rate_limit.methods", &methods)
+	return methods, err
+}
+
+// RateLimitTrustedProxies are the peers allowed to tell the guest address with x-forwarded-for, as ips or cidrs.
+// Unset it trust the loopback, where the rest gateway run by default.
+func RateLimitTrustedProxies() ([]*net.IPNet, error) {
+	proxies := DefaultRateLimitTrustedProxies
+	if viper.IsSet("rate_limit.trusted_proxies") {
+		proxies = viper.GetStringSlice("rate_limit.trusted_proxies")
+	}
+
+	networks := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid rate_limit.trusted_proxies entry: %s", proxy)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate_limit.trusted_proxies entry: %s", proxy)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// IdempotencyTTL is how long the response of a write made with an idempotency key is replayed.
+func IdempotencyTTL() time.Duration {
+	cfg := viper.GetString("idempotency.ttl")
+	return parseDuration(cfg, DefaultIdempotencyTTL)
+}
+
+// OutboxRelayInterval is how often the worker publish the pending product change events.
+func OutboxRelayInterval() time.Duration {
+	cfg := viper.GetString("outbox.relay_interval")
+	return parseDuration(cfg, DefaultOutboxRelayInterval)
+}
+
+// OutboxBatchSize is the number of change events published and deleted in one transaction.
+func OutboxBatchSize() int {
+	if viper.GetInt("outbox.batch_size") <= 0 {
+		return DefaultOutboxBatchSize
+	}
+	return viper.GetInt("outbox.batch_size")
+}
+
+// IdempotencyCleanupInterval is how often the worker delete the expired idempotency records.
+func IdempotencyCleanupInterval() time.Duration {
+	cfg := viper.GetString("idempotency.cleanup_interval")
+	return parseDuration(cfg, DefaultIdempotencyCleanupInterval)
+}
+
+// ExportBatchSize is the number of products read from the database at once by ExportProducts,
+// the next batch is only read after the client received the previous one.
+func ExportBatchSize() int {
+	if viper.GetInt("export.batch_size") <= 0 {
+		return DefaultExportBatchSize
+	}
+	return viper.GetInt("export.batch_size")
+}
+
+// CatalogExportSchedule is the cron spec of the catalog snapshot enqueued by the worker, empty disable it.
+func CatalogExportSchedule() string {
+	return viper.GetString("export.catalog.schedule")
+}
+
+func CatalogExportFormat() string {
+	if viper.GetString("export.catalog.format") == "" {
+		return DefaultCatalogExportFormat
+	}
+	return viper.GetString("export.catalog.format")
+}
+
+func CatalogExportDestination() string {
+	if viper.GetString("export.catalog.destination") == "" {
+		return DefaultCatalogExportDestination
+	}
+	return viper.GetString("export.catalog.destination")
+}
+
+func CatalogExportIncludeDeleted() bool {
+	return viper.GetBool("export.catalog.include_deleted")
+}
+
+// CatalogExportOutputDir hold the local snapshots, snapshots uploaded to storage-service are written there first.
+func CatalogExportOutputDir() string {
+	if viper.GetString("export.catalog.output_dir") == "" {
+		return DefaultCatalogExportOutputDir
+	}
+	return viper.GetString("export.catalog.output_dir")
+}
+
+// CatalogExportParquetRowGroupSize is the number of products of a parquet row group, a row group is held in memory until written.
+func CatalogExportParquetRowGroupSize() int {
+	if viper.GetInt("export.catalog.parquet_row_group_size") <= 0 {
+		return DefaultCatalogExportParquetRowGroupSize
+	}
+	return viper.GetInt("export.catalog.parquet_row_group_size")
+}
+
+// CatalogExportTimeout bound the scheduled snapshot, the schedule is not enqueued again while one is pending for this long.
+func CatalogExportTimeout() time.Duration {
+	cfg := viper.GetString("export.catalog.timeout")
+	return parseDuration(cfg, DefaultCatalogExportTimeout)
+}
+
+// CatalogExportObjectType is the storage-service object type of the uploaded snapshots and manifests,
+// it has to allow their extensions.
+func CatalogExportObjectType() string {
+	if viper.GetString("export.catalog.object_type") == "" {
+		return DefaultCatalogExportObjectType
+	}
+	return viper.GetString("export.catalog.object_type")
+}
+
+// WatchBufferSize is the number of change events buffered for a WatchProducts client, a slower client
+// make the ordered consumer restart from its last event rather than skip events.
+func WatchBufferSize() int {
+	if viper.GetInt("watch.buffer_size") <= 0 {
+		return DefaultWatchBufferSize
+	}
+	return viper.GetInt("watch.buffer_size")
+}
+
+// ImportBatchSize is the number of rows written in one transaction by an import,
+// the job progress is saved after every batch.
+func ImportBatchSize() int {
+	if viper.GetInt("import.batch_size") <= 0 {
+		return DefaultImportBatchSize
+	}
+	return viper.GetInt("import.batch_size")
+}
+
+// ImportMaxErrors is the number of row errors stored per import job, later errors are only counted.
+func ImportMaxErrors() int {
+	if viper.GetInt("import.max_errors") <= 0 {
+		return DefaultImportMaxErrors
+	}
+	return viper.GetInt("import.max_errors")
+}
+
+// ImportDownloadTimeout bound the download of an import file from its storage-service signed url.
+func ImportDownloadTimeout() time.Duration {
+	cfg := viper.GetString("import.download_timeout")
+	return parseDuration(cfg, DefaultImportDownloadTimeout)
+}
+
+// QuotaMaxProductsPerOwner is the number of not deleted products an owner can have, 0 disable the quota.
+func QuotaMaxProductsPerOwner() int64 {
+	return viper.GetInt64("quota.max_products_per_owner")
+}
+
+// QuotaRetryAfter is the retry delay sent with a quota error.
+func QuotaRetryAfter() time.Duration {
+	cfg := viper.GetString("quota.retry_after")
+	return parseDuration(cfg, DefaultQuotaRetryAfter)
+}
+
 func JaegerProtocol() string {
 	return viper.GetString("jaeger.protocol")
 }
@@ -239,6 +578,95 @@ func StorageGRPCHost() string {
 	return viper.GetString("services.storage_grpc")
 }
 
+// StorageHTTPHost is the storage-service http api, its grpc api has no upload.
+func StorageHTTPHost() string {
+	return viper.GetString("services.storage_http")
+}
+
+// StorageUploadToken authenticate the uploads, storage-service record the user of the token as the uploader.
+func StorageUploadToken() string {
+	return viper.GetString("services.storage_upload_token")
+}
+
+// ServicesTimeout bound every attempt of a call to auth-service and storage-service.
+func ServicesTimeout() time.Duration {
+	cfg := viper.GetString("services.resilience.timeout")
+	return parseDuration(cfg, DefaultServicesTimeout)
+}
+
+// ServicesRetryAttempts include the first attempt, only idempotent reads are retried.
+func ServicesRetryAttempts() int {
+	if viper.GetInt("services.resilience.retry_attempts") <= 0 {
+		return DefaultServicesRetryAttempts
+	}
+	return viper.GetInt("services.resilience.retry_attempts")
+}
+
+func ServicesRetryBackoff() time.Duration {
+	cfg := viper.GetString("services.resilience.retry_backoff")
+	return parseDuration(cfg, DefaultServicesRetryBackoff)
+}
+
+func ServicesBreakerFailures() uint32 {
+	if viper.GetUint32("services.resilience.breaker.failures") == 0 {
+		return DefaultServicesBreakerFailures
+	}
+	return viper.GetUint32("services.resilience.breaker.failures")
+}
+
+func ServicesBreakerTimeout() time.Duration {
+	cfg := viper.GetString("services.resilience.breaker.timeout")
+	return parseDuration(cfg, DefaultServicesBreakerTimeout)
+}
+
+func AuthJWKSURL() string {
+	return viper.GetString("auth.jwt.jwks_url")
+}
+
+func AuthJWTSecret() string {
+	return viper.GetString("auth.jwt.secret")
+}
+
+func AuthJWTUserIDClaim() string {
+	if viper.IsSet("auth.jwt.user_id_claim") {
+		return viper.GetString("auth.jwt.user_id_claim")
+	}
+	return DefaultAuthJWTUserIDClaim
+}
+
+func AuthJWTIssuer() string {
+	return viper.GetString("auth.jwt.issuer")
+}
+
+func AuthJWTAudience() string {
+	return viper.GetString("auth.jwt.audience")
+}
+
+func AuthJWKSRefreshInterval() time.Duration {
+	cfg := viper.GetString("auth.jwt.jwks_refresh_interval")
+	return parseDuration(cfg, DefaultAuthJWKSRefreshInterval)
+}
+
+func AuthJWKSRefreshLimit() time.Duration {
+	cfg := viper.GetString("auth.jwt.jwks_refresh_limit")
+	return parseDuration(cfg, DefaultAuthJWKSRefreshLimit)
+}
+
+func AuthPermissionCacheTTL() time.Duration {
+	cfg := viper.GetString("auth.permission_cache_ttl")
+	return parseDuration(cfg, DefaultAuthPermissionCacheTTL)
+}
+
+// AuthTrustedServices list the verified service identities allowed to act on behalf of the request user_id.
+func AuthTrustedServices() []string {
+	return viper.GetStringSlice("auth.trusted_services")
+}
+
+// AuthAnonymousRead allow guests to read not deleted products without a permission check.
+func AuthAnonymousRead() bool {
+	return viper.GetBool("auth.anonymous_read")
+}
+
 func LoadConfig() error {
 	viper.SetConfigName("config")
 	viper.SetConfigType("yml")
@@ -255,6 +683,13 @@ func LoadConfig() error {
 	return nil
 }
 
+func getBoolOrDefault(key string, defaultValue bool) bool {
+	if !viper.IsSet(key) {
+		return defaultValue
+	}
+	return viper.GetBool(key)
+}
+
 func parseDuration(in string, defaultDuration time.Duration) time.Duration {
 	dur, err := time.ParseDuration(in)
 	if err != nil {
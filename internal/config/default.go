@@ -25,4 +25,69 @@ const (
 	DefaultAsynqConcurrency = 10
 	DefaultAsynqRetry       = 3
 	DefaultAsynqRetention   = 15 * time.Minute
+
+	DefaultAuthJWTUserIDClaim      = "sub"
+	DefaultAuthJWKSRefreshInterval = 1 * time.Hour
+	DefaultAuthJWKSRefreshLimit    = 5 * time.Minute
+	DefaultAuthPermissionCacheTTL  = 1 * time.Minute
+
+	DefaultSearchEngine           = "opensearch"
+	DefaultSearchBlevePath        = "data/products.bleve"
+	DefaultSearchBleveOpenTimeout = 5 * time.Second
+	DefaultSearchTimeout          = 2 * time.Second
+	DefaultSearchBreakerFailures  = 5
+	DefaultSearchBreakerTimeout   = 30 * time.Second
+
+	DefaultHealthCheckTimeout  = 2 * time.Second
+	DefaultHealthCheckInterval = 5 * time.Second
+	DefaultHealthDrainDelay    = 5 * time.Second
+
+	DefaultTLSServerClientAuth = TLSClientAuthNone
+
+	DefaultServicesTimeout         = 3 * time.Second
+	DefaultServicesRetryAttempts   = 3
+	DefaultServicesRetryBackoff    = 100 * time.Millisecond
+	DefaultServicesBreakerFailures = 5
+	DefaultServicesBreakerTimeout  = 30 * time.Second
+
+	DefaultRateLimitMode  = "local"
+	DefaultRateLimitRate  = float64(20)
+	DefaultRateLimitBurst = 40
+
+	DefaultIdempotencyTTL             = 24 * time.Hour
+	DefaultIdempotencyCleanupInterval = 1 * time.Hour
+
+	DefaultExportBatchSize = 500
+
+	DefaultOutboxRelayInterval = 1 * time.Second
+	DefaultOutboxBatchSize     = 100
+
+	DefaultCatalogExportFormat              = "csv"
+	DefaultCatalogExportDestination         = "local"
+	DefaultCatalogExportOutputDir           = "exports"
+	DefaultCatalogExportParquetRowGroupSize = 10000
+	DefaultCatalogExportTimeout             = 1 * time.Hour
+	DefaultCatalogExportObjectType          = "CATALOG_EXPORT"
+
+	DefaultWatchBufferSize = 256
+
+	DefaultImportBatchSize       = 100
+	DefaultImportMaxErrors       = 10000
+	DefaultImportDownloadTimeout = 10 * time.Minute
+
+	DefaultQuotaRetryAfter = 1 * time.Hour
+)
+
+// DefaultRateLimitTrustedProxies is the loopback, the rest gateway dial the grpc server there by default.
+var DefaultRateLimitTrustedProxies = []string{"127.0.0.1/8", "::1"}
+
+const (
+	TLSClientAuthNone          = "none"
+	TLSClientAuthRequest       = "request"
+	TLSClientAuthVerifyIfGiven = "verify_if_given"
+	TLSClientAuthRequire       = "require"
+
+	TLSClientAuthService    = "auth"
+	TLSClientStorageService = "storage"
+	TLSClientGateway        = "gateway"
 )
@@ -6,6 +6,21 @@ const (
 	KeyDBCtx      ctxKey = "DB"
 	KeyUserIDCtx  ctxKey = "USERID"
 	KeyDataSource ctxKey = "DATA_SOURCE"
+	// KeyTrustedServiceCtx is set when the verified caller is a trusted service acting on behalf of a user
+	KeyTrustedServiceCtx ctxKey = "TRUSTED_SERVICE"
+	// KeyOriginCtx hold the rpc method or task type that started the request, recorded in the audit log
+	KeyOriginCtx ctxKey = "ORIGIN"
+	// KeyRequestIDCtx correlate every log line of a request, read from or returned in the x-request-id metadata
+	KeyRequestIDCtx ctxKey = "REQUEST_ID"
+	// KeyIdempotencyCtx hold the idempotency key of a write, from the request field or the idempotency-key metadata
+	KeyIdempotencyCtx ctxKey = "IDEMPOTENCY_KEY"
+	// KeyIdempotencyRecordCtx hold the record a write made with an idempotency key store in its transaction
+	KeyIdempotencyRecordCtx ctxKey = "IDEMPOTENCY_RECORD"
+	// KeyAfterCommitCtx hold the hooks to run once the transaction of the context is committed
+	KeyAfterCommitCtx ctxKey = "AFTER_COMMIT"
+
+	// ErrorDomain is reported in the ErrorInfo details of grpc errors
+	ErrorDomain = string("product-service")
 
 	SystemID = string("SYSTEM")
 	GuestID  = string("GUEST")
@@ -16,3 +31,31 @@ const (
 	SourceOS
 	SourceRedis
 )
+
+const (
+	HeaderAuthorization = "authorization"
+	HeaderDataSource    = "x-data-source"
+	HeaderRequestID     = "x-request-id"
+	HeaderForwardedFor  = "x-forwarded-for"
+	HeaderIdempotency   = "idempotency-key"
+
+	BearerScheme = "bearer"
+
+	DataSourceDB         = "db"
+	DataSourceOpensearch = "opensearch"
+	DataSourceRedis      = "redis"
+)
+
+var (
+	DataSources = map[string]int{
+		DataSourceDB:         SourceDB,
+		DataSourceOpensearch: SourceOS,
+		DataSourceRedis:      SourceRedis,
+	}
+
+	DataSourceNames = map[int]string{
+		SourceDB:    DataSourceDB,
+		SourceOS:    DataSourceOpensearch,
+		SourceRedis: DataSourceRedis,
+	}
+)
@@ -136,6 +136,9 @@ var IndexMapping = strings.NewReader(`{
         }
       }
     },
+    "collaborator_ids": {
+      "type": "keyword"
+    },
     "created_at": {
       "type": "date",
       "fields": {
@@ -7,9 +7,16 @@ const (
 	PermissionProductCreate      = string("PRODUCT_CREATE")       // Only access to create product
 	PermissionProductUpdate      = string("PRODUCT_UPDATE")       // Only access to update product
 	PermissionProductDelete      = string("PRODUCT_DELETE")       // Only access to delete product
+	PermissionProductRestore     = string("PRODUCT_RESTORE")      // Only access to restore deleted product
 	PermissionProductRead        = string("PRODUCT_READ")         // Only access to read product
 	PermissionProductModifyOther = string("PRODUCT_MODIFY_OTHER") // Only allow access to modify other user product
 	PermissionProductReadDeleted = string("PRODUCT_READ_DELETED") // only access to read deleted product
+	PermissionProductDataSource  = string("PRODUCT_DATA_SOURCE")  // Only allow access to choose the listing data source
+
+	PermissionProductTransferOwnership = string("PRODUCT_TRANSFER_OWNERSHIP") // Only allow access to move products to another owner
+	PermissionProductExport            = string("PRODUCT_EXPORT")             // Only allow access to stream the whole catalog
+	PermissionProductWatch             = string("PRODUCT_WATCH")              // Only allow access to follow the product changes
+	PermissionProductImport            = string("PRODUCT_IMPORT")             // Only allow access to import products from a file
 )
 
 var (
@@ -20,7 +27,13 @@ var (
 		PermissionProductCreate,
 		PermissionProductUpdate,
 		PermissionProductDelete,
+		PermissionProductRestore,
 		PermissionProductModifyOther,
+		PermissionProductDataSource,
+		PermissionProductTransferOwnership,
+		PermissionProductExport,
+		PermissionProductWatch,
+		PermissionProductImport,
 	}
 
 	SeedGroupPermissios = map[string][]string{
@@ -29,6 +42,7 @@ var (
 			PermissionProductRead,
 			PermissionProductUpdate,
 			PermissionProductDelete,
+			PermissionProductRestore,
 		},
 		"SUPER_USER": {
 			PermissionFullAccess,
@@ -37,7 +51,13 @@ var (
 			PermissionProductCreate,
 			PermissionProductUpdate,
 			PermissionProductDelete,
+			PermissionProductRestore,
 			PermissionProductModifyOther,
+			PermissionProductDataSource,
+			PermissionProductTransferOwnership,
+			PermissionProductExport,
+			PermissionProductWatch,
+			PermissionProductImport,
 		},
 	}
 )
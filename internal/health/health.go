@@ -0,0 +1,267 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/goccy/go-json"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/nats-io/nats.go"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	grpcHealth "google.golang.org/grpc/health"
+	healthPB "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	statusOK    = "ok"
+	statusError = "error"
+)
+
+var (
+	ErrStarting     = errors.New("starting")
+	ErrShuttingDown = errors.New("shutting down")
+)
+
+// Checker report whether a single dependency is reachable.
+type Checker func(ctx context.Context) error
+
+// Health keep the readiness of the process, it back the grpc.health.v1 service and the /healthz, /readyz and /health endpoints.
+// Readiness only depend on the process own state, the dependencies are reported by /health so an outage of a shared
+// dependency doesn't take every replica out of the load balancer at once.
+type Health struct {
+	timeout      time.Duration
+	mu           sync.RWMutex
+	checkers     map[string]Checker
+	serving      int32
+	shuttingDown int32
+	grpcServer   *grpcHealth.Server
+	services     []string
+}
+
+// New create the health state, services are the grpc service names reported next to the overall "" service.
+func New(timeout time.Duration, services ...string) *Health {
+	h := &Health{
+		timeout:    timeout,
+		checkers:   make(map[string]Checker),
+		grpcServer: grpcHealth.NewServer(),
+		services:   services,
+	}
+	h.setServingStatus(healthPB.HealthCheckResponse_NOT_SERVING)
+	return h
+}
+
+func (h *Health) Register(name string, checker Checker) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checkers[name] = checker
+}
+
+// GRPCServer return the grpc.health.v1 implementation to register on the grpc server.
+func (h *Health) GRPCServer() healthPB.HealthServer {
+	return h.grpcServer
+}
+
+// Check run every checker concurrently and return the error of each failing dependency.
+func (h *Health) Check(ctx context.Context) map[string]error {
+	h.mu.RLock()
+	checkers := make(map[string]Checker, len(h.checkers))
+	for name, checker := range h.checkers {
+		checkers[name] = checker
+	}
+	h.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]error, len(checkers))
+	)
+	for name, checker := range checkers {
+		wg.Add(1)
+		go func(name string, checker Checker) {
+			defer wg.Done()
+			err := checker(ctx)
+			mu.Lock()
+			results[name] = err
+			mu.Unlock()
+		}(name, checker)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// Serve mark the process as ready, call it once every listener is started.
+func (h *Health) Serve() {
+	if atomic.LoadInt32(&h.shuttingDown) == 1 {
+		return
+	}
+	atomic.StoreInt32(&h.serving, 1)
+	h.setServingStatus(healthPB.HealthCheckResponse_SERVING)
+}
+
+// Ready return nil while the process is serving, ErrStarting or ErrShuttingDown otherwise.
+func (h *Health) Ready() error {
+	switch {
+	case atomic.LoadInt32(&h.shuttingDown) == 1:
+		return ErrShuttingDown
+	case atomic.LoadInt32(&h.serving) == 0:
+		return ErrStarting
+	}
+	return nil
+}
+
+// Watch log the unreachable dependencies every interval until the context is done, it doesn't change the readiness.
+func (h *Health) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for name, err := range h.Check(ctx) {
+				if err != nil {
+					log.WithField("dependency", name).Warn(err.Error())
+				}
+			}
+		}
+	}
+}
+
+func (h *Health) setServingStatus(status healthPB.HealthCheckResponse_ServingStatus) {
+	h.grpcServer.SetServingStatus("", status)
+	for _, service := range h.services {
+		h.grpcServer.SetServingStatus(service, status)
+	}
+}
+
+// Shutdown mark the process as not ready then wait for drainDelay, call it before the listeners are closed
+// so load balancers notice and stop routing new requests first.
+func (h *Health) Shutdown(ctx context.Context, drainDelay time.Duration) error {
+	atomic.StoreInt32(&h.shuttingDown, 1)
+	h.grpcServer.Shutdown()
+
+	if drainDelay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(drainDelay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// LivenessHandler serve /healthz, the process is alive as long as it can answer.
+func (h *Health) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{"status": statusOK})
+	})
+}
+
+// ReadinessHandler serve /readyz, the process is ready from Serve until Shutdown.
+func (h *Health) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := h.Ready(); err != nil {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]any{"status": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"status": statusOK})
+	})
+}
+
+// DependenciesHandler serve /health with the status of every dependency, it is meant for dashboards and alerts,
+// not for probes.
+func (h *Health) DependenciesHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		results := h.Check(r.Context())
+
+		names := make([]string, 0, len(results))
+		for name := range results {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		code := http.StatusOK
+		body := map[string]any{"status": statusOK}
+		checks := make(map[string]string, len(results))
+		for _, name := range names {
+			checks[name] = statusOK
+			if results[name] != nil {
+				checks[name] = results[name].Error()
+				body["status"] = statusError
+				code = http.StatusServiceUnavailable
+			}
+		}
+		body["checks"] = checks
+		writeJSON(w, code, body)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func DBChecker(db *sql.DB) Checker {
+	return func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	}
+}
+
+func RedisChecker(client *goredis.Client) Checker {
+	return func(ctx context.Context) error {
+		return client.Ping(ctx).Err()
+	}
+}
+
+func NATSChecker(nc *nats.Conn) Checker {
+	return func(ctx context.Context) error {
+		if status := nc.Status(); status != nats.CONNECTED {
+			return fmt.Errorf("nats connection status: %d", status)
+		}
+		return nil
+	}
+}
+
+func SearcherChecker(searcher model.ProductSearcher) Checker {
+	return func(ctx context.Context) error {
+		return searcher.Ping(ctx)
+	}
+}
+
+// GRPCConnChecker wait for the client connection to be ready, an idle connection is asked to connect first.
+func GRPCConnChecker(conn *grpc.ClientConn) Checker {
+	return func(ctx context.Context) error {
+		for {
+			state := conn.GetState()
+			switch state {
+			case connectivity.Ready:
+				return nil
+			case connectivity.Idle:
+				conn.Connect()
+			case connectivity.TransientFailure, connectivity.Shutdown:
+				return fmt.Errorf("grpc connection state: %s", state)
+			}
+			if !conn.WaitForStateChange(ctx, state) {
+				return fmt.Errorf("grpc connection state: %s: %w", state, ctx.Err())
+			}
+		}
+	}
+}
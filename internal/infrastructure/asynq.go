@@ -7,12 +7,12 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-func NewAsynqClient() (*asynq.Client, error) {
+func newAsynqRedisClientOpt() (asynq.RedisClientOpt, error) {
 	redisURL, err := goredis.ParseURL(config.RedisAsynqHost())
 	if err != nil {
-		return nil, err
+		return asynq.RedisClientOpt{}, err
 	}
-	client := asynq.NewClient(asynq.RedisClientOpt{
+	return asynq.RedisClientOpt{
 		Network:      redisURL.Network,
 		Addr:         redisURL.Addr,
 		DB:           redisURL.DB,
@@ -21,27 +21,26 @@ func NewAsynqClient() (*asynq.Client, error) {
 		DialTimeout:  config.RedisDialTimeout(),
 		WriteTimeout: config.RedisWriteTimeout(),
 		ReadTimeout:  config.RedisReadTimeout(),
-	})
+	}, nil
+}
+
+func NewAsynqClient() (*asynq.Client, error) {
+	opt, err := newAsynqRedisClientOpt()
+	if err != nil {
+		return nil, err
+	}
+	client := asynq.NewClient(opt)
 
 	return client, nil
 }
 
 func NewAsynqServer() (*asynq.Server, error) {
-	redisURL, err := goredis.ParseURL(config.RedisAsynqHost())
+	opt, err := newAsynqRedisClientOpt()
 	if err != nil {
 		return nil, err
 	}
 	srv := asynq.NewServer(
-		asynq.RedisClientOpt{
-			Network:      redisURL.Network,
-			Addr:         redisURL.Addr,
-			DB:           redisURL.DB,
-			Username:     redisURL.Username,
-			Password:     redisURL.Password,
-			DialTimeout:  config.RedisDialTimeout(),
-			WriteTimeout: config.RedisWriteTimeout(),
-			ReadTimeout:  config.RedisReadTimeout(),
-		},
+		opt,
 		asynq.Config{
 			Concurrency: config.AsynqConcurrency(),
 			Logger:      logrus.New(),
@@ -49,3 +48,14 @@ func NewAsynqServer() (*asynq.Server, error) {
 	)
 	return srv, nil
 }
+
+func NewAsynqScheduler() (*asynq.Scheduler, error) {
+	opt, err := newAsynqRedisClientOpt()
+	if err != nil {
+		return nil, err
+	}
+	scheduler := asynq.NewScheduler(opt, &asynq.SchedulerOpts{
+		Logger: logrus.New(),
+	})
+	return scheduler, nil
+}
@@ -0,0 +1,90 @@
+package infrastructure
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/custom"
+	"github.com/blevesearch/bleve/v2/analysis/token/lowercase"
+	"github.com/blevesearch/bleve/v2/analysis/token/ngram"
+	"github.com/blevesearch/bleve/v2/analysis/tokenizer/unicode"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/krobus00/product-service/internal/config"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/sirupsen/logrus"
+	bolt "go.etcd.io/bbolt"
+)
+
+// NewBleveIndex open the embedded on-disk index, the index will be created when it does not exist yet.
+// The index is locked by the process that open it, so it can't be shared between server and worker.
+func NewBleveIndex() (bleve.Index, error) {
+	index, err := bleve.OpenUsing(config.SearchBlevePath(), map[string]interface{}{
+		"bolt_timeout": config.SearchBleveOpenTimeout().String(),
+	})
+	if err == nil {
+		return index, nil
+	}
+	if errors.Is(err, bolt.ErrTimeout) {
+		return nil, fmt.Errorf("%w: %s is opened by another process, server and worker can't share the bleve engine, use opensearch to run both", model.ErrBleveIndexLocked, config.SearchBlevePath())
+	}
+	if !errors.Is(err, bleve.ErrorIndexPathDoesNotExist) {
+		return nil, err
+	}
+
+	logrus.Info("creating bleve index at ", config.SearchBlevePath())
+	indexMapping, err := NewBleveIndexMapping()
+	if err != nil {
+		return nil, err
+	}
+	return bleve.New(config.SearchBlevePath(), indexMapping)
+}
+
+// NewBleveIndexMapping mirror the opensearch product index mapping.
+func NewBleveIndexMapping() (mapping.IndexMapping, error) {
+	indexMapping := bleve.NewIndexMapping()
+	err := indexMapping.AddCustomTokenFilter(model.BleveProductTokenFilter, map[string]interface{}{
+		"type": ngram.Name,
+		"min":  float64(model.BleveProductNgramMin),
+		"max":  float64(model.BleveProductNgramMax),
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = indexMapping.AddCustomAnalyzer(model.BleveProductAnalyzer, map[string]interface{}{
+		"type":          custom.Name,
+		"tokenizer":     unicode.Name,
+		"token_filters": []string{lowercase.Name, model.BleveProductTokenFilter},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	keywordField := bleve.NewKeywordFieldMapping()
+
+	textField := bleve.NewTextFieldMapping()
+	textField.Analyzer = model.BleveProductAnalyzer
+	textField.IncludeTermVectors = true
+
+	sortableField := func(name string) *mapping.FieldMapping {
+		field := bleve.NewKeywordFieldMapping()
+		field.Name = name
+		field.Store = false
+		return field
+	}
+
+	productMapping := bleve.NewDocumentStaticMapping()
+	productMapping.AddFieldMappingsAt("id", keywordField)
+	productMapping.AddFieldMappingsAt("name", textField, sortableField("name.keyword"))
+	productMapping.AddFieldMappingsAt("description", textField, sortableField("description.keyword"))
+	productMapping.AddFieldMappingsAt("price", bleve.NewNumericFieldMapping())
+	productMapping.AddFieldMappingsAt("owner_id", keywordField)
+	productMapping.AddFieldMappingsAt("collaborator_ids", keywordField)
+	productMapping.AddFieldMappingsAt("created_at", bleve.NewDateTimeFieldMapping())
+	productMapping.AddFieldMappingsAt("updated_at", bleve.NewDateTimeFieldMapping())
+	productMapping.AddFieldMappingsAt("deleted", bleve.NewBooleanFieldMapping())
+
+	indexMapping.DefaultMapping = productMapping
+
+	return indexMapping, nil
+}
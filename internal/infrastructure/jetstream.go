@@ -1,6 +1,8 @@
 package infrastructure
 
 import (
+	"fmt"
+
 	"github.com/krobus00/product-service/internal/config"
 	"github.com/nats-io/nats.go"
 	"github.com/sirupsen/logrus"
@@ -23,6 +25,9 @@ func NewJetstreamClient() (*nats.Conn, nats.JetStreamContext, error) {
 	// Create JetStream Context
 	js, err := nc.JetStream(
 		nats.PublishAsyncMaxPending(config.JetstreamMaxPending()),
+		nats.PublishAsyncErrHandler(func(js nats.JetStream, msg *nats.Msg, err error) {
+			logrus.WithField("subject", msg.Subject).Error(fmt.Sprintf("unable to publish: %v", err))
+		}),
 	)
 
 	if err != nil {
@@ -0,0 +1,82 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/MicahParks/keyfunc"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/krobus00/product-service/internal/config"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+type jwtVerifier struct {
+	jwks    *keyfunc.JWKS
+	keyFunc jwt.Keyfunc
+	parser  *jwt.Parser
+}
+
+// NewTokenVerifier verify JWTs locally, keys are fetched from the JWKS endpoint and refreshed in background,
+// the HMAC secret is only used when no JWKS endpoint is configured.
+func NewTokenVerifier() (model.TokenVerifier, error) {
+	if config.AuthJWKSURL() != "" {
+		jwks, err := keyfunc.Get(config.AuthJWKSURL(), keyfunc.Options{
+			RefreshInterval:   config.AuthJWKSRefreshInterval(),
+			RefreshRateLimit:  config.AuthJWKSRefreshLimit(),
+			RefreshUnknownKID: true,
+			RefreshErrorHandler: func(err error) {
+				logrus.Error(fmt.Sprintf("failed to refresh jwks: %s", err.Error()))
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &jwtVerifier{
+			jwks:    jwks,
+			keyFunc: jwks.Keyfunc,
+			parser:  jwt.NewParser(jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "PS256", "PS384", "PS512", "EdDSA"})),
+		}, nil
+	}
+
+	if config.AuthJWTSecret() != "" {
+		secret := []byte(config.AuthJWTSecret())
+		return &jwtVerifier{
+			keyFunc: func(token *jwt.Token) (interface{}, error) {
+				return secret, nil
+			},
+			parser: jwt.NewParser(jwt.WithValidMethods([]string{"HS256", "HS384", "HS512"})),
+		}, nil
+	}
+
+	return nil, errors.New("auth.jwt.jwks_url or auth.jwt.secret must be set")
+}
+
+func (v *jwtVerifier) Verify(ctx context.Context, tokenString string) (string, error) {
+	claims := jwt.MapClaims{}
+	_, err := v.parser.ParseWithClaims(tokenString, claims, v.keyFunc)
+	if err != nil {
+		return "", model.ErrInvalidToken
+	}
+
+	if issuer := config.AuthJWTIssuer(); issuer != "" && !claims.VerifyIssuer(issuer, true) {
+		return "", model.ErrInvalidToken
+	}
+	if audience := config.AuthJWTAudience(); audience != "" && !claims.VerifyAudience(audience, true) {
+		return "", model.ErrInvalidToken
+	}
+
+	userID, ok := claims[config.AuthJWTUserIDClaim()].(string)
+	if !ok || userID == "" {
+		return "", model.ErrInvalidToken
+	}
+
+	return userID, nil
+}
+
+func (v *jwtVerifier) Close() {
+	if v.jwks != nil {
+		v.jwks.EndBackground()
+	}
+}
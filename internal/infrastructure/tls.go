@@ -0,0 +1,264 @@
+package infrastructure
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/krobus00/product-service/internal/config"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// CertReloader keep a certificate and a ca bundle loaded from disk, they are reloaded whenever
+// one of the watched directories change so rotated certificates are used without a restart.
+type CertReloader struct {
+	certFile string
+	keyFile  string
+	caFile   string
+
+	mu     sync.RWMutex
+	cert   *tls.Certificate
+	caPool *x509.CertPool
+
+	watcher *fsnotify.Watcher
+	done    chan struct{}
+}
+
+// NewCertReloader load the files and start watching them, empty paths are skipped.
+func NewCertReloader(certFile string, keyFile string, caFile string) (*CertReloader, error) {
+	if (certFile == "") != (keyFile == "") {
+		return nil, errors.New("cert_file and key_file must be set together")
+	}
+
+	r := &CertReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		caFile:   caFile,
+		done:     make(chan struct{}),
+	}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	// watch the directories, files mounted from kubernetes secrets are replaced through a symlink swap
+	dirs := map[string]bool{}
+	for _, file := range []string{certFile, keyFile, caFile} {
+		if file == "" {
+			continue
+		}
+		dir := filepath.Dir(file)
+		if dirs[dir] {
+			continue
+		}
+		dirs[dir] = true
+		if err := watcher.Add(dir); err != nil {
+			_ = watcher.Close()
+			return nil, err
+		}
+	}
+	r.watcher = watcher
+
+	go r.watch()
+
+	return r, nil
+}
+
+// Reload read the files again, the previous certificate and bundle are kept when they are invalid.
+func (r *CertReloader) Reload() error {
+	var cert *tls.Certificate
+	if r.certFile != "" {
+		keyPair, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+		if err != nil {
+			return fmt.Errorf("load key pair: %w", err)
+		}
+		cert = &keyPair
+	}
+
+	var caPool *x509.CertPool
+	if r.caFile != "" {
+		pem, err := os.ReadFile(r.caFile)
+		if err != nil {
+			return fmt.Errorf("read ca bundle: %w", err)
+		}
+		caPool = x509.NewCertPool()
+		if !caPool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificate found in ca bundle %s", r.caFile)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = cert
+	r.caPool = caPool
+	return nil
+}
+
+func (r *CertReloader) watch() {
+	for {
+		select {
+		case <-r.done:
+			return
+		case event, ok := <-r.watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			if err := r.Reload(); err != nil {
+				logrus.Warn(fmt.Sprintf("failed to reload certificates, keep the previous ones: %s", err.Error()))
+				continue
+			}
+			logrus.Info(fmt.Sprintf("certificates reloaded after %s", event.Name))
+		case err, ok := <-r.watcher.Errors:
+			if !ok {
+				return
+			}
+			logrus.Error(fmt.Sprintf("certificate watcher: %s", err.Error()))
+		}
+	}
+}
+
+// Certificate return the current certificate, nil when no cert_file is configured.
+func (r *CertReloader) Certificate() *tls.Certificate {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert
+}
+
+// CAPool return the current ca bundle, nil when no ca_file is configured.
+func (r *CertReloader) CAPool() *x509.CertPool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.caPool
+}
+
+// Close stop watching the files, it is safe to call on a nil reloader.
+func (r *CertReloader) Close() error {
+	if r == nil {
+		return nil
+	}
+	close(r.done)
+	return r.watcher.Close()
+}
+
+// NewServerCredentials build the grpc server credentials from tls.server config,
+// the reloader is nil when tls is disabled.
+func NewServerCredentials() (credentials.TransportCredentials, *CertReloader, error) {
+	if !config.TLSServerEnabled() {
+		if len(config.TLSServerAllowedIdentities()) > 0 {
+			return nil, nil, errors.New("tls.server.allowed_identities require tls.server.enabled")
+		}
+		return insecure.NewCredentials(), nil, nil
+	}
+
+	clientAuth, err := parseClientAuth(config.TLSServerClientAuth())
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(config.TLSServerAllowedIdentities()) > 0 && clientAuth < tls.VerifyClientCertIfGiven {
+		return nil, nil, errors.New("tls.server.allowed_identities require tls.server.client_auth verify_if_given or require")
+	}
+	if config.TLSServerCertFile() == "" {
+		return nil, nil, errors.New("tls.server.cert_file and tls.server.key_file must be set")
+	}
+	if clientAuth >= tls.VerifyClientCertIfGiven && config.TLSServerCAFile() == "" {
+		return nil, nil, errors.New("tls.server.ca_file must be set to verify client certificates")
+	}
+
+	reloader, err := NewCertReloader(config.TLSServerCertFile(), config.TLSServerKeyFile(), config.TLSServerCAFile())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// resolved per handshake so a reloaded certificate or ca bundle apply to new connections
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*reloader.Certificate()},
+				ClientAuth:   clientAuth,
+				ClientCAs:    reloader.CAPool(),
+				NextProtos:   []string{"h2"},
+			}, nil
+		},
+	}
+
+	return credentials.NewTLS(tlsConfig), reloader, nil
+}
+
+// NewClientCredentials build the credentials to dial another service from tls.clients.<client> config,
+// the reloader is nil when tls is disabled for this client.
+func NewClientCredentials(client string) (credentials.TransportCredentials, *CertReloader, error) {
+	if !config.TLSClientEnabled(client) {
+		return insecure.NewCredentials(), nil, nil
+	}
+
+	reloader, err := NewCertReloader(config.TLSClientCertFile(client), config.TLSClientKeyFile(client), config.TLSClientCAFile(client))
+	if err != nil {
+		return nil, nil, fmt.Errorf("tls.clients.%s: %w", client, err)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: config.TLSClientServerName(client),
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			if cert := reloader.Certificate(); cert != nil {
+				return cert, nil
+			}
+			return &tls.Certificate{}, nil
+		},
+		// the default verification use a fixed RootCAs, the server certificate is verified
+		// in VerifyConnection against the reloaded ca bundle instead
+		InsecureSkipVerify: true, //nolint:gosec
+		VerifyConnection: func(state tls.ConnectionState) error {
+			return verifyServerCertificate(state, reloader.CAPool())
+		},
+	}
+
+	return credentials.NewTLS(tlsConfig), reloader, nil
+}
+
+// verifyServerCertificate do what crypto/tls does when InsecureSkipVerify is false, a nil pool use the system roots.
+func verifyServerCertificate(state tls.ConnectionState, roots *x509.CertPool) error {
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("server did not present a certificate")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		DNSName:       state.ServerName,
+		Roots:         roots,
+		Intermediates: intermediates,
+	})
+	return err
+}
+
+func parseClientAuth(clientAuth string) (tls.ClientAuthType, error) {
+	switch clientAuth {
+	case config.TLSClientAuthNone:
+		return tls.NoClientCert, nil
+	case config.TLSClientAuthRequest:
+		return tls.RequestClientCert, nil
+	case config.TLSClientAuthVerifyIfGiven:
+		return tls.VerifyClientCertIfGiven, nil
+	case config.TLSClientAuthRequire:
+		return tls.RequireAndVerifyClientCert, nil
+	default:
+		return tls.NoClientCert, fmt.Errorf("unknown tls.server.client_auth: %s", clientAuth)
+	}
+}
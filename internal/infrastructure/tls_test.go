@@ -0,0 +1,172 @@
+package infrastructure
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeTestCertificate write a self signed certificate and its key, the serial tell the generated certificates apart.
+func writeTestCertificate(t *testing.T, certFile string, keyFile string, serial int64) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: "product-service"},
+		DNSNames:              []string{"product-service"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// written next to the target then renamed, like a kubernetes secret update
+	writeFileAtomic(t, certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	writeFileAtomic(t, keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+func writeFileAtomic(t *testing.T, name string, content []byte) {
+	t.Helper()
+	tmp := name + ".tmp"
+	if err := os.WriteFile(tmp, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, name); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func certificateSerial(t *testing.T, cert *tls.Certificate) int64 {
+	t.Helper()
+	if cert == nil || len(cert.Certificate) == 0 {
+		t.Fatal("no certificate loaded")
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return leaf.SerialNumber.Int64()
+}
+
+func waitForSerial(t *testing.T, r *CertReloader, want int64) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if certificateSerial(t, r.Certificate()) == want {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("CertReloader.Certificate() serial = %d, want %d", certificateSerial(t, r.Certificate()), want)
+}
+
+func TestCertReloader_Reload(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	writeTestCertificate(t, certFile, keyFile, 1)
+
+	r, err := NewCertReloader(certFile, keyFile, certFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	if got := certificateSerial(t, r.Certificate()); got != 1 {
+		t.Fatalf("CertReloader.Certificate() serial = %d, want 1", got)
+	}
+	if r.CAPool() == nil {
+		t.Fatal("CertReloader.CAPool() = nil, want the ca bundle")
+	}
+
+	// a rotated certificate is picked up by the watcher
+	writeTestCertificate(t, certFile, keyFile, 2)
+	waitForSerial(t, r, 2)
+
+	// an invalid certificate keep the previous one
+	writeFileAtomic(t, certFile, []byte("not a certificate"))
+	if err := r.Reload(); err == nil {
+		t.Error("CertReloader.Reload() error = nil, want an invalid key pair error")
+	}
+	if got := certificateSerial(t, r.Certificate()); got != 2 {
+		t.Errorf("CertReloader.Certificate() serial = %d after an invalid rotation, want 2", got)
+	}
+
+	writeTestCertificate(t, certFile, keyFile, 3)
+	waitForSerial(t, r, 3)
+}
+
+func TestNewCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	writeTestCertificate(t, certFile, keyFile, 1)
+
+	tests := []struct {
+		name     string
+		certFile string
+		keyFile  string
+		caFile   string
+		wantErr  bool
+	}{
+		{
+			name:     "success",
+			certFile: certFile,
+			keyFile:  keyFile,
+			caFile:   certFile,
+			wantErr:  false,
+		},
+		{
+			name:    "success ca only",
+			caFile:  certFile,
+			wantErr: false,
+		},
+		{
+			name:     "key file missing",
+			certFile: certFile,
+			wantErr:  true,
+		},
+		{
+			name:     "ca bundle without certificate",
+			certFile: certFile,
+			keyFile:  keyFile,
+			caFile:   keyFile,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewCertReloader(tt.certFile, tt.keyFile, tt.caFile)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewCertReloader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := r.Close(); err != nil {
+				t.Errorf("CertReloader.Close() error = %v", err)
+			}
+		})
+	}
+}
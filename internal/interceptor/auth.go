@@ -0,0 +1,97 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/krobus00/product-service/internal/constant"
+	"github.com/krobus00/product-service/internal/model"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// AuthInterceptor authenticate the caller from the authorization metadata,
+// callers without a token are treated as guest and are left to the usecase permission checks.
+type AuthInterceptor struct {
+	verifier        model.TokenVerifier
+	trustedServices map[string]bool
+}
+
+func NewAuthInterceptor() *AuthInterceptor {
+	return &AuthInterceptor{
+		trustedServices: map[string]bool{},
+	}
+}
+
+func (i *AuthInterceptor) InjectTokenVerifier(verifier model.TokenVerifier) error {
+	if verifier == nil {
+		return errors.New("invalid token verifier")
+	}
+	i.verifier = verifier
+	return nil
+}
+
+func (i *AuthInterceptor) InjectTrustedServices(services []string) error {
+	for _, service := range services {
+		i.trustedServices[service] = true
+	}
+	return nil
+}
+
+func (i *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := i.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (i *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := i.authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func (i *AuthInterceptor) authenticate(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(constant.HeaderAuthorization)
+	if len(values) == 0 {
+		return context.WithValue(ctx, constant.KeyUserIDCtx, constant.GuestID), nil
+	}
+
+	scheme, token, found := strings.Cut(values[0], " ")
+	if !found || !strings.EqualFold(scheme, constant.BearerScheme) || token == "" {
+		return nil, status.Error(codes.Unauthenticated, model.ErrInvalidToken.Error())
+	}
+
+	userID, err := i.verifier.Verify(ctx, token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, model.ErrInvalidToken.Error())
+	}
+
+	ctx = context.WithValue(ctx, constant.KeyUserIDCtx, userID)
+	if i.trustedServices[userID] {
+		ctx = context.WithValue(ctx, constant.KeyTrustedServiceCtx, true)
+	}
+
+	return ctx, nil
+}
+
+// serverStream override the stream context with the authenticated one.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
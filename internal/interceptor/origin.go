@@ -0,0 +1,24 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/krobus00/product-service/internal/constant"
+	"google.golang.org/grpc"
+)
+
+// OriginUnary record the called rpc method so changes can be traced back to it.
+func OriginUnary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = context.WithValue(ctx, constant.KeyOriginCtx, info.FullMethod)
+		return handler(ctx, req)
+	}
+}
+
+// OriginStream record the called rpc method so changes can be traced back to it.
+func OriginStream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := context.WithValue(ss.Context(), constant.KeyOriginCtx, info.FullMethod)
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
@@ -0,0 +1,109 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/x509"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	healthPB "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// PeerIdentityInterceptor only let through callers whose verified client certificate carry an allowed
+// SPIFFE id or SAN, every caller is allowed when no identity is configured.
+type PeerIdentityInterceptor struct {
+	identities map[string]bool
+	// prefixes come from identities ending with "*", e.g. spiffe://example.org/ns/default/*
+	prefixes []string
+}
+
+func NewPeerIdentityInterceptor() *PeerIdentityInterceptor {
+	return &PeerIdentityInterceptor{
+		identities: map[string]bool{},
+	}
+}
+
+func (i *PeerIdentityInterceptor) InjectAllowedIdentities(identities []string) error {
+	for _, identity := range identities {
+		if strings.HasSuffix(identity, "*") {
+			i.prefixes = append(i.prefixes, strings.TrimSuffix(identity, "*"))
+			continue
+		}
+		i.identities[identity] = true
+	}
+	return nil
+}
+
+func (i *PeerIdentityInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := i.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (i *PeerIdentityInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := i.authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (i *PeerIdentityInterceptor) authorize(ctx context.Context, fullMethod string) error {
+	if len(i.identities) == 0 && len(i.prefixes) == 0 {
+		return nil
+	}
+	// probes do not present a client certificate
+	if strings.HasPrefix(fullMethod, "/"+healthPB.Health_ServiceDesc.ServiceName+"/") {
+		return nil
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return status.Error(codes.PermissionDenied, "peer identity not allowed")
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return status.Error(codes.PermissionDenied, "peer identity not allowed")
+	}
+
+	for _, identity := range certificateIdentities(tlsInfo.State.VerifiedChains[0][0]) {
+		if i.allowed(identity) {
+			return nil
+		}
+	}
+	return status.Error(codes.PermissionDenied, "peer identity not allowed")
+}
+
+func (i *PeerIdentityInterceptor) allowed(identity string) bool {
+	if i.identities[identity] {
+		return true
+	}
+	for _, prefix := range i.prefixes {
+		if strings.HasPrefix(identity, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// certificateIdentities list the URI SANs first since SPIFFE ids are carried there.
+func certificateIdentities(cert *x509.Certificate) []string {
+	identities := make([]string, 0)
+	for _, uri := range cert.URIs {
+		identities = append(identities, uri.String())
+	}
+	identities = append(identities, cert.DNSNames...)
+	identities = append(identities, cert.EmailAddresses...)
+	for _, ip := range cert.IPAddresses {
+		identities = append(identities, ip.String())
+	}
+	return identities
+}
@@ -0,0 +1,135 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/url"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// insecureAuthInfo is what a peer connected without tls carry.
+type insecureAuthInfo struct{}
+
+func (insecureAuthInfo) AuthType() string {
+	return "insecure"
+}
+
+func newPeerContext(authInfo credentials.AuthInfo) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		Addr:     &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 5002},
+		AuthInfo: authInfo,
+	})
+}
+
+func newVerifiedTLSInfo(cert *x509.Certificate) credentials.TLSInfo {
+	return credentials.TLSInfo{
+		State: tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{{cert}},
+		},
+	}
+}
+
+func TestPeerIdentityInterceptor_Unary(t *testing.T) {
+	spiffeID, _ := url.Parse("spiffe://example.org/ns/default/sa/auth-service")
+	otherSpiffeID, _ := url.Parse("spiffe://example.org/ns/other/sa/auth-service")
+
+	tests := []struct {
+		name       string
+		identities []string
+		fullMethod string
+		ctx        context.Context
+		wantCode   codes.Code
+	}{
+		{
+			name:       "every caller allowed without identities",
+			identities: nil,
+			fullMethod: "/product.ProductService/FindByID",
+			ctx:        context.Background(),
+			wantCode:   codes.OK,
+		},
+		{
+			name:       "allowed spiffe id",
+			identities: []string{spiffeID.String()},
+			fullMethod: "/product.ProductService/FindByID",
+			ctx:        newPeerContext(newVerifiedTLSInfo(&x509.Certificate{URIs: []*url.URL{spiffeID}})),
+			wantCode:   codes.OK,
+		},
+		{
+			name:       "allowed prefix",
+			identities: []string{"spiffe://example.org/ns/default/*"},
+			fullMethod: "/product.ProductService/FindByID",
+			ctx:        newPeerContext(newVerifiedTLSInfo(&x509.Certificate{URIs: []*url.URL{spiffeID}})),
+			wantCode:   codes.OK,
+		},
+		{
+			name:       "allowed dns san",
+			identities: []string{"storage-service"},
+			fullMethod: "/product.ProductService/FindByID",
+			ctx:        newPeerContext(newVerifiedTLSInfo(&x509.Certificate{DNSNames: []string{"storage-service"}})),
+			wantCode:   codes.OK,
+		},
+		{
+			name:       "unauthorized identity",
+			identities: []string{"spiffe://example.org/ns/default/*"},
+			fullMethod: "/product.ProductService/FindByID",
+			ctx:        newPeerContext(newVerifiedTLSInfo(&x509.Certificate{URIs: []*url.URL{otherSpiffeID}, DNSNames: []string{"auth-service"}})),
+			wantCode:   codes.PermissionDenied,
+		},
+		{
+			name:       "missing peer",
+			identities: []string{spiffeID.String()},
+			fullMethod: "/product.ProductService/FindByID",
+			ctx:        context.Background(),
+			wantCode:   codes.PermissionDenied,
+		},
+		{
+			name:       "insecure peer",
+			identities: []string{spiffeID.String()},
+			fullMethod: "/product.ProductService/FindByID",
+			ctx:        newPeerContext(insecureAuthInfo{}),
+			wantCode:   codes.PermissionDenied,
+		},
+		{
+			name:       "client certificate not verified",
+			identities: []string{spiffeID.String()},
+			fullMethod: "/product.ProductService/FindByID",
+			ctx:        newPeerContext(credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{{URIs: []*url.URL{spiffeID}}}}}),
+			wantCode:   codes.PermissionDenied,
+		},
+		{
+			name:       "health probe without certificate",
+			identities: []string{spiffeID.String()},
+			fullMethod: "/grpc.health.v1.Health/Check",
+			ctx:        context.Background(),
+			wantCode:   codes.OK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := NewPeerIdentityInterceptor()
+			if err := i.InjectAllowedIdentities(tt.identities); err != nil {
+				t.Fatal(err)
+			}
+
+			called := false
+			_, err := i.Unary()(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.fullMethod}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				return nil, nil
+			})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("PeerIdentityInterceptor.Unary() code = %v, want %v", got, tt.wantCode)
+			}
+			if called != (tt.wantCode == codes.OK) {
+				t.Errorf("PeerIdentityInterceptor.Unary() handler called = %v, want %v", called, tt.wantCode == codes.OK)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/krobus00/product-service/internal/constant"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthPB "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// RateLimitInterceptor take a token from the bucket of the caller and method, it must run after the auth interceptor
+// so authenticated users are limited by user id. A failing limiter let the request through.
+type RateLimitInterceptor struct {
+	limiter      model.RateLimiter
+	defaultLimit model.RateLimit
+	methodLimits map[string]model.RateLimit
+	// trustedProxies may set x-forwarded-for, it is ignored from any other peer
+	trustedProxies []*net.IPNet
+}
+
+func NewRateLimitInterceptor() *RateLimitInterceptor {
+	return &RateLimitInterceptor{
+		methodLimits: map[string]model.RateLimit{},
+	}
+}
+
+func (i *RateLimitInterceptor) InjectRateLimiter(limiter model.RateLimiter) error {
+	if limiter == nil {
+		return errors.New("invalid rate limiter")
+	}
+	i.limiter = limiter
+	return nil
+}
+
+// InjectLimits set the limit of every method, methodLimits override it for the given methods.
+func (i *RateLimitInterceptor) InjectLimits(defaultLimit model.RateLimit, methodLimits map[string]model.RateLimit) error {
+	i.defaultLimit = defaultLimit
+	for method, limit := range methodLimits {
+		i.methodLimits[method] = limit
+	}
+	return nil
+}
+
+// InjectTrustedProxies set the peers whose x-forwarded-for tell the address of a guest.
+func (i *RateLimitInterceptor) InjectTrustedProxies(proxies []*net.IPNet) error {
+	i.trustedProxies = proxies
+	return nil
+}
+
+func (i *RateLimitInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := i.limit(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (i *RateLimitInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := i.limit(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (i *RateLimitInterceptor) limit(ctx context.Context, fullMethod string) error {
+	if strings.HasPrefix(fullMethod, "/"+healthPB.Health_ServiceDesc.ServiceName+"/") {
+		return nil
+	}
+
+	limit, ok := i.methodLimits[fullMethod]
+	if !ok {
+		limit = i.defaultLimit
+	}
+	if limit.Disabled() {
+		return nil
+	}
+
+	key := model.NewRateLimitKey(i.rateLimitSubject(ctx), fullMethod)
+	allowed, retryAfter, err := i.limiter.Allow(ctx, key, limit)
+	if err != nil {
+		logrus.WithContext(ctx).WithField("key", key).Warn(fmt.Sprintf("rate limiter failed, request allowed: %s", err.Error()))
+		return nil
+	}
+	if allowed {
+		return nil
+	}
+
+	st := status.New(codes.ResourceExhausted, model.ErrRateLimited.Error())
+	withDetails, err := st.WithDetails(
+		&errdetails.ErrorInfo{
+			Reason: "RATE_LIMITED",
+			Domain: constant.ErrorDomain,
+		},
+		&errdetails.RetryInfo{
+			RetryDelay: durationpb.New(retryAfter),
+		},
+	)
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// rateLimitSubject is the authenticated user, guests share a bucket per address. x-forwarded-for is only read
+// when the peer is a trusted proxy, the address is then the last entry not appended by a trusted proxy.
+func (i *RateLimitInterceptor) rateLimitSubject(ctx context.Context) string {
+	userID, _ := ctx.Value(constant.KeyUserIDCtx).(string)
+	if userID != "" && userID != constant.GuestID {
+		return userID
+	}
+
+	address := "unknown"
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		address = p.Addr.String()
+		if host, _, err := net.SplitHostPort(address); err == nil {
+			address = host
+		}
+	}
+	if i.isTrustedProxy(address) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		forwarded := make([]string, 0)
+		for _, value := range md.Get(constant.HeaderForwardedFor) {
+			forwarded = append(forwarded, strings.Split(value, ",")...)
+		}
+		for j := len(forwarded) - 1; j >= 0; j-- {
+			address = strings.TrimSpace(forwarded[j])
+			if !i.isTrustedProxy(address) {
+				break
+			}
+		}
+	}
+	return fmt.Sprintf("%s:%s", constant.GuestID, address)
+}
+
+func (i *RateLimitInterceptor) isTrustedProxy(address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	for _, proxy := range i.trustedProxies {
+		if proxy.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package interceptor
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/krobus00/product-service/internal/constant"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestRateLimitInterceptor_rateLimitSubject(t *testing.T) {
+	_, proxyNetwork, _ := net.ParseCIDR("10.0.0.0/8")
+	_, loopback, _ := net.ParseCIDR("127.0.0.1/8")
+
+	newCtx := func(peerAddress string, forwardedFor ...string) context.Context {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.ParseIP(peerAddress), Port: 50051},
+		})
+		if len(forwardedFor) > 0 {
+			ctx = metadata.NewIncomingContext(ctx, metadata.MD{constant.HeaderForwardedFor: forwardedFor})
+		}
+		return ctx
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{
+			name: "authenticated user",
+			ctx:  context.WithValue(newCtx("203.0.113.7", "198.51.100.1"), constant.KeyUserIDCtx, "user-1"),
+			want: "user-1",
+		},
+		{
+			name: "guest without proxy",
+			ctx:  newCtx("203.0.113.7"),
+			want: constant.GuestID + ":203.0.113.7",
+		},
+		{
+			name: "forwarded for ignored from an untrusted peer",
+			ctx:  newCtx("203.0.113.7", "198.51.100.1"),
+			want: constant.GuestID + ":203.0.113.7",
+		},
+		{
+			name: "forwarded for read from a trusted peer",
+			ctx:  newCtx("127.0.0.1", "198.51.100.1"),
+			want: constant.GuestID + ":198.51.100.1",
+		},
+		{
+			name: "spoofed entries before the last untrusted one are ignored",
+			ctx:  newCtx("10.0.0.2", "192.0.2.99, 198.51.100.1, 10.0.0.3"),
+			want: constant.GuestID + ":198.51.100.1",
+		},
+		{
+			name: "entries of several headers",
+			ctx:  newCtx("127.0.0.1", "192.0.2.99", "198.51.100.1, 10.0.0.3"),
+			want: constant.GuestID + ":198.51.100.1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := NewRateLimitInterceptor()
+			err := i.InjectTrustedProxies([]*net.IPNet{proxyNetwork, loopback})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := i.rateLimitSubject(tt.ctx); got != tt.want {
+				t.Errorf("RateLimitInterceptor.rateLimitSubject() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	grpcRecovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RecoveryUnary turn a panic into codes.Internal, the panic and its stack are only logged.
+func RecoveryUnary() grpc.UnaryServerInterceptor {
+	return grpcRecovery.UnaryServerInterceptor(grpcRecovery.WithRecoveryHandlerContext(recoverPanic))
+}
+
+// RecoveryStream turn a panic into codes.Internal, the panic and its stack are only logged.
+func RecoveryStream() grpc.StreamServerInterceptor {
+	return grpcRecovery.StreamServerInterceptor(grpcRecovery.WithRecoveryHandlerContext(recoverPanic))
+}
+
+func recoverPanic(ctx context.Context, p interface{}) error {
+	logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"panic": fmt.Sprintf("%v", p),
+		"stack": string(debug.Stack()),
+	}).Error("recovered from panic")
+	return status.Error(codes.Internal, codes.Internal.String())
+}
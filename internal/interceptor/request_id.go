@@ -0,0 +1,63 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/krobus00/product-service/internal/constant"
+	"github.com/krobus00/product-service/internal/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDUnary reuse the caller x-request-id or generate one, and send it back in the response header.
+func RequestIDUnary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = newRequestIDContext(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(constant.HeaderRequestID, utils.GetRequestIDFromCtx(ctx)))
+		return handler(ctx, req)
+	}
+}
+
+// RequestIDStream reuse the caller x-request-id or generate one, and send it back in the response header.
+func RequestIDStream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := newRequestIDContext(ss.Context())
+		_ = ss.SetHeader(metadata.Pairs(constant.HeaderRequestID, utils.GetRequestIDFromCtx(ctx)))
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// RequestIDUnaryClient forward the request id to the called service.
+func RequestIDUnaryClient() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingRequestIDContext(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// RequestIDStreamClient forward the request id to the called service.
+func RequestIDStreamClient() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingRequestIDContext(ctx), desc, cc, method, opts...)
+	}
+}
+
+func newRequestIDContext(ctx context.Context) context.Context {
+	requestID := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(constant.HeaderRequestID); len(values) > 0 {
+			requestID = values[0]
+		}
+	}
+	if requestID == "" {
+		requestID = utils.GenerateUUID()
+	}
+	return utils.NewRequestIDContext(ctx, requestID)
+}
+
+func outgoingRequestIDContext(ctx context.Context) context.Context {
+	requestID := utils.GetRequestIDFromCtx(ctx)
+	if requestID == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, constant.HeaderRequestID, requestID)
+}
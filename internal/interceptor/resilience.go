@@ -0,0 +1,105 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/krobus00/product-service/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+	"github.com/sony/gobreaker"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	clientBreakerState = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "grpc_client_circuit_breaker_state",
+		Help: "State of the circuit breaker guarding the calls to a service, 0 closed, 1 half-open and 2 open.",
+	}, []string{"service"})
+	clientRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_client_retries_total",
+		Help: "Number of retried calls to a service.",
+	}, []string{"service", "method"})
+)
+
+// ResilienceOptions configure how the calls to a service are guarded.
+type ResilienceOptions struct {
+	// Timeout bound every attempt, a shorter deadline of the caller still apply
+	Timeout       time.Duration
+	RetryAttempts int
+	// RetryBackoff is doubled after every attempt, with jitter
+	RetryBackoff    time.Duration
+	BreakerFailures uint32
+	BreakerTimeout  time.Duration
+	// IdempotentMethods are the full method names safe to retry, other methods are sent once
+	IdempotentMethods []string
+}
+
+// ResilienceUnaryClient give every attempt a deadline, retry idempotent methods while the service is unavailable
+// and open a circuit breaker after consecutive unavailable responses. Calls rejected by the open breaker fail
+// with codes.Unavailable without reaching the service.
+func ResilienceUnaryClient(service string, opts ResilienceOptions) grpc.UnaryClientInterceptor {
+	idempotent := make(map[string]bool, len(opts.IdempotentMethods))
+	for _, method := range opts.IdempotentMethods {
+		idempotent[method] = true
+	}
+
+	breaker := gobreaker.NewCircuitBreaker(gobreaker.Settings{
+		Name:    service,
+		Timeout: opts.BreakerTimeout,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return counts.ConsecutiveFailures >= opts.BreakerFailures
+		},
+		OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
+			clientBreakerState.WithLabelValues(name).Set(float64(to))
+			logrus.WithFields(logrus.Fields{
+				"breaker": name,
+				"from":    from.String(),
+				"to":      to.String(),
+			}).Warn("circuit breaker state changed")
+		},
+	})
+	clientBreakerState.WithLabelValues(service).Set(float64(gobreaker.StateClosed))
+
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		attempts := 1
+		if idempotent[method] && opts.RetryAttempts > 1 {
+			attempts = opts.RetryAttempts
+		}
+
+		backoff := opts.RetryBackoff
+		for attempt := 1; ; attempt++ {
+			var err error
+			_, breakerErr := breaker.Execute(func() (interface{}, error) {
+				attemptCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+				defer cancel()
+				err = invoker(attemptCtx, method, req, reply, cc, callOpts...)
+				// business errors and the caller own deadline mean the service is up
+				if !utils.IsUnavailableError(ctx, err) {
+					return nil, nil
+				}
+				return nil, err
+			})
+			if errors.Is(breakerErr, gobreaker.ErrOpenState) || errors.Is(breakerErr, gobreaker.ErrTooManyRequests) {
+				return status.Error(codes.Unavailable, fmt.Sprintf("%s: %s", service, breakerErr.Error()))
+			}
+			if err == nil || attempt >= attempts || !utils.IsUnavailableError(ctx, err) {
+				return err
+			}
+
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))): //nolint:gosec
+			}
+			backoff *= 2
+			clientRetries.WithLabelValues(service, method).Inc()
+		}
+	}
+}
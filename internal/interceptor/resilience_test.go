@@ -0,0 +1,72 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestResilienceUnaryClient_DeadlineExceeded(t *testing.T) {
+	const method = "/storage.StorageService/GetObjectByID"
+
+	tests := []struct {
+		name string
+		// callerTimeout is shorter than the attempt timeout when the caller run out of time first
+		callerTimeout   time.Duration
+		wantSecondCode  codes.Code
+		wantInvocations int
+	}{
+		{
+			name:          "slow service open the breaker",
+			callerTimeout: time.Second,
+			// the first call failed on the attempt timeout, the second is rejected by the open breaker
+			wantSecondCode:  codes.Unavailable,
+			wantInvocations: 1,
+		},
+		{
+			name:          "caller deadline doesn't count as a failure",
+			callerTimeout: 10 * time.Millisecond,
+			// the breaker stay closed, the second call reach the service again
+			wantSecondCode:  codes.DeadlineExceeded,
+			wantInvocations: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := ResilienceUnaryClient("storage-service-"+tt.name, ResilienceOptions{
+				Timeout:         50 * time.Millisecond,
+				RetryAttempts:   1,
+				RetryBackoff:    time.Millisecond,
+				BreakerFailures: 1,
+				BreakerTimeout:  time.Minute,
+			})
+
+			invocations := 0
+			invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				invocations++
+				<-ctx.Done()
+				return status.Error(codes.DeadlineExceeded, ctx.Err().Error())
+			}
+
+			call := func() error {
+				ctx, cancel := context.WithTimeout(context.Background(), tt.callerTimeout)
+				defer cancel()
+				return interceptor(ctx, method, nil, nil, nil, invoker)
+			}
+
+			if got := status.Code(call()); got != codes.DeadlineExceeded {
+				t.Fatalf("ResilienceUnaryClient() first call code = %v, want %v", got, codes.DeadlineExceeded)
+			}
+			if got := status.Code(call()); got != tt.wantSecondCode {
+				t.Errorf("ResilienceUnaryClient() second call code = %v, want %v", got, tt.wantSecondCode)
+			}
+			if invocations != tt.wantInvocations {
+				t.Errorf("ResilienceUnaryClient() invocations = %d, want %d", invocations, tt.wantInvocations)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+//go:generate mockgen -destination=mock/mock_permission_cache_repository.go -package=mock github.com/krobus00/product-service/internal/model PermissionCacheRepository
+
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	AuthStreamName               = "AUTH"
+	AuthPermissionChangedSubject = "AUTH.permissionChanged"
+
+	PermissionCacheKeyPattern = "auth:permissions:*"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+)
+
+// NewPermissionCacheKey identify a permission check regardless of the permissions order.
+func NewPermissionCacheKey(userID string, permissions []string) string {
+	sorted := make([]string, len(permissions))
+	copy(sorted, permissions)
+	sort.Strings(sorted)
+	return fmt.Sprintf("auth:permissions:%s:%s", userID, strings.Join(sorted, ","))
+}
+
+func NewPermissionCacheUserPattern(userID string) string {
+	return fmt.Sprintf("auth:permissions:%s:*", userID)
+}
+
+// JSPermissionChangedPayload is published by auth-service when user or group permissions change,
+// an empty user id means the change may affect every user.
+type JSPermissionChangedPayload struct {
+	UserID string `json:"userID"`
+}
+
+// TokenVerifier verify the caller bearer token and return the user id it was issued for.
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (userID string, err error)
+	Close()
+}
+
+// PermissionCacheRepository cache the auth-service HasAccess decisions per user.
+type PermissionCacheRepository interface {
+	Get(ctx context.Context, userID string, permissions []string) (allowed bool, found bool, err error)
+	Set(ctx context.Context, userID string, permissions []string, allowed bool) error
+	DeleteByUserID(ctx context.Context, userID string) error
+	DeleteAll(ctx context.Context) error
+
+	// DI
+	InjectRedisClient(client *redis.Client) error
+}
@@ -10,10 +10,18 @@ import (
 const (
 	defaultPaginationLimit = int(10)
 	maxPaginationLimit     = int(20)
+
+	DefaultHighlightPreTag  = "<em>"
+	DefaultHighlightPostTag = "</em>"
 )
 
 var (
 	ErrUnauthorizedAccess = errors.New("unauthorized access")
+	ErrInvalidDataSource  = errors.New("invalid data source")
+	ErrInvalidCursor      = errors.New("invalid cursor")
+
+	ErrAuthServiceUnavailable    = errors.New("auth service unavailable")
+	ErrStorageServiceUnavailable = errors.New("storage service unavailable")
 )
 
 type Response struct {
@@ -47,20 +55,32 @@ func (m *Response) WithData(data any) *Response {
 }
 
 type PaginationPayload struct {
-	Search         string   `json:"search" query:"search"`
-	Sort           []string `json:"sort" query:"sort"`
-	Limit          int      `json:"limit" query:"limit"`
-	Page           int      `json:"page" query:"page"`
-	IncludeDeleted bool     `json:"include_deleted" query:"includeDeleted"`
+	Search           string   `json:"search" query:"search"`
+	Sort             []string `json:"sort" query:"sort"`
+	Limit            int      `json:"limit" query:"limit"`
+	Page             int      `json:"page" query:"page"`
+	IncludeDeleted   bool     `json:"include_deleted" query:"includeDeleted"`
+	Highlight        bool     `json:"highlight" query:"highlight"`
+	HighlightPreTag  string   `json:"highlight_pre_tag" query:"highlightPreTag"`
+	HighlightPostTag string   `json:"highlight_post_tag" query:"highlightPostTag"`
+	Cursor           string   `json:"cursor" query:"cursor"`
+	OwnedOrShared    bool     `json:"owned_or_shared" query:"ownedOrShared"`
+
+	UserID string `json:"-" query:"-"` // filled by the usecase for the owned_or_shared filter
 }
 
 func NewPaginationPayloadFromProto(message *pb.PaginationRequest) *PaginationPayload {
 	return &PaginationPayload{
-		Search:         message.GetSearch(),
-		Sort:           message.GetSort(),
-		Limit:          int(message.GetLimit()),
-		Page:           int(message.GetPage()),
-		IncludeDeleted: message.GetIncludeDeleted(),
+		Search:           message.GetSearch(),
+		Sort:             message.GetSort(),
+		Limit:            int(message.GetLimit()),
+		Page:             int(message.GetPage()),
+		IncludeDeleted:   message.GetIncludeDeleted(),
+		Highlight:        message.GetHighlight(),
+		HighlightPreTag:  message.GetHighlightPreTag(),
+		HighlightPostTag: message.GetHighlightPostTag(),
+		Cursor:           message.GetCursor(),
+		OwnedOrShared:    message.GetOwnedOrShared(),
 	}
 }
 
@@ -70,23 +90,51 @@ func NewPaginationPayload() *PaginationPayload {
 
 func (m *PaginationPayload) ToProto() *pb.PaginationRequest {
 	return &pb.PaginationRequest{
-		Search:         m.Search,
-		Sort:           m.Sort,
-		Limit:          int64(m.Limit),
-		Page:           int64(m.Page),
-		IncludeDeleted: m.IncludeDeleted,
+		Search:           m.Search,
+		Sort:             m.Sort,
+		Limit:            int64(m.Limit),
+		Page:             int64(m.Page),
+		IncludeDeleted:   m.IncludeDeleted,
+		Highlight:        m.Highlight,
+		HighlightPreTag:  m.HighlightPreTag,
+		HighlightPostTag: m.HighlightPostTag,
+		Cursor:           m.Cursor,
+		OwnedOrShared:    m.OwnedOrShared,
 	}
 }
 
-func (m *PaginationPayload) Sanitize() *PaginationPayload {
-	if m.Limit <= 0 {
-		m.Limit = defaultPaginationLimit
+func sanitizePagination(limit int, page int) (int, int) {
+	if limit <= 0 {
+		limit = defaultPaginationLimit
+	}
+	if limit > maxPaginationLimit {
+		limit = maxPaginationLimit
+	}
+	if page <= 0 {
+		page = 1
 	}
-	if m.Limit > maxPaginationLimit {
-		m.Limit = maxPaginationLimit
+	return limit, page
+}
+
+// Validate reject out of range values, the limit is clamped by Sanitize and zero page is left to its default.
+func (m *PaginationPayload) Validate() error {
+	v := new(validator)
+	if m.Page < 0 {
+		v.addViolation("page", "must not be negative")
+	}
+	v.maxLength("search", m.Search, SearchMaxLength)
+	v.maxLength("highlight_pre_tag", m.HighlightPreTag, HighlightTagMaxLength)
+	v.maxLength("highlight_post_tag", m.HighlightPostTag, HighlightTagMaxLength)
+	return v.err()
+}
+
+func (m *PaginationPayload) Sanitize() *PaginationPayload {
+	m.Limit, m.Page = sanitizePagination(m.Limit, m.Page)
+	if m.Highlight && m.HighlightPreTag == "" {
+		m.HighlightPreTag = DefaultHighlightPreTag
 	}
-	if m.Page <= 0 {
-		m.Page = 1
+	if m.Highlight && m.HighlightPostTag == "" {
+		m.HighlightPostTag = DefaultHighlightPostTag
 	}
 	return m
 }
@@ -112,10 +160,13 @@ func (m *PaginationPayload) WithLimit(limit int) *PaginationPayload {
 }
 
 type PaginationResponse struct {
-	Meta    *PaginationPayload `json:"meta"`
-	Count   int64              `json:"count"`
-	MaxPage int64              `json:"maxPage"`
-	Items   []string           `json:"items"`
+	Meta       *PaginationPayload  `json:"meta"`
+	Count      int64               `json:"count"`
+	MaxPage    int64               `json:"maxPage"`
+	Items      []string            `json:"items"`
+	Highlights []*ProductHighlight `json:"highlights,omitempty"`
+	DataSource string              `json:"dataSource"`
+	NextCursor string              `json:"nextCursor,omitempty"`
 }
 
 func NewPaginationResponse(request *PaginationPayload) *PaginationResponse {
@@ -126,11 +177,18 @@ func NewPaginationResponse(request *PaginationPayload) *PaginationResponse {
 }
 
 func (m *PaginationResponse) ToProto() *pb.PaginationResponse {
+	highlights := make([]*pb.ProductHighlight, 0)
+	for _, highlight := range m.Highlights {
+		highlights = append(highlights, highlight.ToProto())
+	}
 	return &pb.PaginationResponse{
-		Meta:    m.Meta.ToProto(),
-		Count:   m.Count,
-		MaxPage: m.MaxPage,
-		Items:   m.Items,
+		Meta:       m.Meta.ToProto(),
+		Count:      m.Count,
+		MaxPage:    m.MaxPage,
+		Items:      m.Items,
+		Highlights: highlights,
+		DataSource: m.DataSource,
+		NextCursor: m.NextCursor,
 	}
 }
 
@@ -146,6 +204,23 @@ func (m *PaginationResponse) WithItems(items []string) *PaginationResponse {
 	return m
 }
 
+func (m *PaginationResponse) WithHighlights(highlights []*ProductHighlight) *PaginationResponse {
+	if len(highlights) > 0 {
+		m.Highlights = highlights
+	}
+	return m
+}
+
+func (m *PaginationResponse) WithDataSource(dataSource string) *PaginationResponse {
+	m.DataSource = dataSource
+	return m
+}
+
+func (m *PaginationResponse) WithNextCursor(cursor string) *PaginationResponse {
+	m.NextCursor = cursor
+	return m
+}
+
 func (m *PaginationResponse) BuildResponse() *PaginationResponse {
 	m.MaxPage = int64(math.Ceil(float64(m.Count) / float64(m.Meta.Limit)))
 	return m
@@ -189,10 +189,13 @@ func TestNewPaginationPayload(t *testing.T) {
 
 func TestPaginationPayload_Sanitize(t *testing.T) {
 	type fields struct {
-		Search string
-		Sort   []string
-		Limit  int
-		Page   int
+		Search           string
+		Sort             []string
+		Limit            int
+		Page             int
+		Highlight        bool
+		HighlightPreTag  string
+		HighlightPostTag string
 	}
 	tests := []struct {
 		name   string
@@ -219,14 +222,45 @@ func TestPaginationPayload_Sanitize(t *testing.T) {
 				Page:   1,
 			},
 		},
+		{
+			name: "success with default highlight tags",
+			fields: fields{
+				Highlight: true,
+			},
+			want: &PaginationPayload{
+				Limit:            defaultPaginationLimit,
+				Page:             1,
+				Highlight:        true,
+				HighlightPreTag:  DefaultHighlightPreTag,
+				HighlightPostTag: DefaultHighlightPostTag,
+			},
+		},
+		{
+			name: "success keep custom highlight tags",
+			fields: fields{
+				Highlight:        true,
+				HighlightPreTag:  "<b>",
+				HighlightPostTag: "</b>",
+			},
+			want: &PaginationPayload{
+				Limit:            defaultPaginationLimit,
+				Page:             1,
+				Highlight:        true,
+				HighlightPreTag:  "<b>",
+				HighlightPostTag: "</b>",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			m := &PaginationPayload{
-				Search: tt.fields.Search,
-				Sort:   tt.fields.Sort,
-				Limit:  tt.fields.Limit,
-				Page:   tt.fields.Page,
+				Search:           tt.fields.Search,
+				Sort:             tt.fields.Sort,
+				Limit:            tt.fields.Limit,
+				Page:             tt.fields.Page,
+				Highlight:        tt.fields.Highlight,
+				HighlightPreTag:  tt.fields.HighlightPreTag,
+				HighlightPostTag: tt.fields.HighlightPostTag,
 			}
 			if got := m.Sanitize(); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("PaginationPayload.Sanitize() = %v, want %v", got, tt.want)
@@ -528,6 +562,48 @@ func TestPaginationResponse_WithItems(t *testing.T) {
 	}
 }
 
+func TestPaginationResponse_WithHighlights(t *testing.T) {
+	sampleHighlights := []*ProductHighlight{
+		{
+			ID:   "1",
+			Name: []string{"<em>product</em>"},
+		},
+	}
+	type args struct {
+		highlights []*ProductHighlight
+	}
+	tests := []struct {
+		name string
+		args args
+		want *PaginationResponse
+	}{
+		{
+			name: "success",
+			args: args{
+				highlights: sampleHighlights,
+			},
+			want: &PaginationResponse{
+				Highlights: sampleHighlights,
+			},
+		},
+		{
+			name: "success ignore empty highlights",
+			args: args{
+				highlights: []*ProductHighlight{},
+			},
+			want: &PaginationResponse{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &PaginationResponse{}
+			if got := m.WithHighlights(tt.args.highlights); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PaginationResponse.WithHighlights() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestPaginationResponse_BuildResponse(t *testing.T) {
 	type fields struct {
 		Meta  *PaginationPayload
@@ -0,0 +1,77 @@
+//go:generate mockgen -destination=mock/mock_idempotency_repository.go -package=mock github.com/krobus00/product-service/internal/model IdempotencyRepository
+
+package model
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/goccy/go-json"
+	"gorm.io/gorm"
+)
+
+const (
+	IdempotencyOperationCreate            = "create"
+	IdempotencyOperationTransferOwnership = "transfer_ownership"
+
+	IdempotencyKeyMaxLength = 255
+	// IdempotencyCleanupBatchSize is the number of expired records deleted at once
+	IdempotencyCleanupBatchSize = 1000
+)
+
+var (
+	ErrIdempotencyKeyReused     = errors.New("idempotency key already used with a different request")
+	ErrIdempotencyKeyInProgress = errors.New("request with this idempotency key is in progress")
+)
+
+// IdempotencyRecord remember the response of a write made with an idempotency key, it is stored in the
+// transaction of the write so a committed write always has its record.
+type IdempotencyRecord struct {
+	Key         string          `json:"key" gorm:"primaryKey"`
+	RequestHash string          `json:"requestHash"`
+	Response    json.RawMessage `json:"response,omitempty" gorm:"type:jsonb"`
+	ExpiresAt   time.Time       `json:"expiresAt"`
+	CreatedAt   time.Time       `json:"createdAt"`
+}
+
+func (IdempotencyRecord) TableName() string {
+	return "idempotency_keys"
+}
+
+// NewIdempotencyKey scope the client key to the caller and the operation so keys of different users never collide.
+func NewIdempotencyKey(userID string, operation string, key string) string {
+	return fmt.Sprintf("idempotency:%s:%s:%s", userID, operation, key)
+}
+
+// NewIdempotencyRequestHash fingerprint the request to detect a key reused with a different payload.
+func NewIdempotencyRequestHash(request any) (string, error) {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func ValidateIdempotencyKey(key string) error {
+	v := new(validator)
+	v.maxLength("idempotency_key", key, IdempotencyKeyMaxLength)
+	return v.err()
+}
+
+type IdempotencyRepository interface {
+	// FindByKey return the record of the key, nil when there is none or it expired.
+	FindByKey(ctx context.Context, key string) (*IdempotencyRecord, error)
+	// Create store the record in the transaction of ctx, replacing an expired record of the key. A concurrent
+	// write with the same key wait for the other transaction, created is false when the other one committed.
+	Create(ctx context.Context, record *IdempotencyRecord) (created bool, err error)
+	// DeleteExpired delete up to limit expired records.
+	DeleteExpired(ctx context.Context, limit int) (deleted int64, err error)
+
+	// DI
+	InjectDB(db *gorm.DB) error
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/krobus00/product-service/internal/model (interfaces: ObjectUploader)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	io "io"
+	http "net/http"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockObjectUploader is a mock of ObjectUploader interface.
+type MockObjectUploader struct {
+	ctrl     *gomock.Controller
+	recorder *MockObjectUploaderMockRecorder
+}
+
+// MockObjectUploaderMockRecorder is the mock recorder for MockObjectUploader.
+type MockObjectUploaderMockRecorder struct {
+	mock *MockObjectUploader
+}
+
+// NewMockObjectUploader creates a new mock instance.
+func NewMockObjectUploader(ctrl *gomock.Controller) *MockObjectUploader {
+	mock := &MockObjectUploader{ctrl: ctrl}
+	mock.recorder = &MockObjectUploaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockObjectUploader) EXPECT() *MockObjectUploaderMockRecorder {
+	return m.recorder
+}
+
+// InjectHTTPClient mocks base method.
+func (m *MockObjectUploader) InjectHTTPClient(arg0 *http.Client) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectHTTPClient", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectHTTPClient indicates an expected call of InjectHTTPClient.
+func (mr *MockObjectUploaderMockRecorder) InjectHTTPClient(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectHTTPClient", reflect.TypeOf((*MockObjectUploader)(nil).InjectHTTPClient), arg0)
+}
+
+// Upload mocks base method.
+func (m *MockObjectUploader) Upload(arg0 context.Context, arg1 string, arg2 io.Reader) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockObjectUploaderMockRecorder) Upload(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockObjectUploader)(nil).Upload), arg0, arg1, arg2)
+}
@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/krobus00/product-service/internal/model (interfaces: PermissionCacheRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	redis "github.com/go-redis/redis/v8"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPermissionCacheRepository is a mock of PermissionCacheRepository interface.
+type MockPermissionCacheRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPermissionCacheRepositoryMockRecorder
+}
+
+// MockPermissionCacheRepositoryMockRecorder is the mock recorder for MockPermissionCacheRepository.
+type MockPermissionCacheRepositoryMockRecorder struct {
+	mock *MockPermissionCacheRepository
+}
+
+// NewMockPermissionCacheRepository creates a new mock instance.
+func NewMockPermissionCacheRepository(ctrl *gomock.Controller) *MockPermissionCacheRepository {
+	mock := &MockPermissionCacheRepository{ctrl: ctrl}
+	mock.recorder = &MockPermissionCacheRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPermissionCacheRepository) EXPECT() *MockPermissionCacheRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteAll mocks base method.
+func (m *MockPermissionCacheRepository) DeleteAll(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAll", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAll indicates an expected call of DeleteAll.
+func (mr *MockPermissionCacheRepositoryMockRecorder) DeleteAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAll", reflect.TypeOf((*MockPermissionCacheRepository)(nil).DeleteAll), arg0)
+}
+
+// DeleteByUserID mocks base method.
+func (m *MockPermissionCacheRepository) DeleteByUserID(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUserID indicates an expected call of DeleteByUserID.
+func (mr *MockPermissionCacheRepositoryMockRecorder) DeleteByUserID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserID", reflect.TypeOf((*MockPermissionCacheRepository)(nil).DeleteByUserID), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockPermissionCacheRepository) Get(arg0 context.Context, arg1 string, arg2 []string) (bool, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPermissionCacheRepositoryMockRecorder) Get(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPermissionCacheRepository)(nil).Get), arg0, arg1, arg2)
+}
+
+// InjectRedisClient mocks base method.
+func (m *MockPermissionCacheRepository) InjectRedisClient(arg0 *redis.Client) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectRedisClient", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectRedisClient indicates an expected call of InjectRedisClient.
+func (mr *MockPermissionCacheRepositoryMockRecorder) InjectRedisClient(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectRedisClient", reflect.TypeOf((*MockPermissionCacheRepository)(nil).InjectRedisClient), arg0)
+}
+
+// Set mocks base method.
+func (m *MockPermissionCacheRepository) Set(arg0 context.Context, arg1 string, arg2 []string, arg3 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockPermissionCacheRepositoryMockRecorder) Set(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockPermissionCacheRepository)(nil).Set), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/krobus00/product-service/internal/model (interfaces: ProductAuditLogRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/krobus00/product-service/internal/model"
+	gorm "gorm.io/gorm"
+)
+
+// MockProductAuditLogRepository is a mock of ProductAuditLogRepository interface.
+type MockProductAuditLogRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductAuditLogRepositoryMockRecorder
+}
+
+// MockProductAuditLogRepositoryMockRecorder is the mock recorder for MockProductAuditLogRepository.
+type MockProductAuditLogRepositoryMockRecorder struct {
+	mock *MockProductAuditLogRepository
+}
+
+// NewMockProductAuditLogRepository creates a new mock instance.
+func NewMockProductAuditLogRepository(ctrl *gomock.Controller) *MockProductAuditLogRepository {
+	mock := &MockProductAuditLogRepository{ctrl: ctrl}
+	mock.recorder = &MockProductAuditLogRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductAuditLogRepository) EXPECT() *MockProductAuditLogRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockProductAuditLogRepository) Create(arg0 context.Context, arg1 model.ProductAuditLogs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockProductAuditLogRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProductAuditLogRepository)(nil).Create), arg0, arg1)
+}
+
+// FindPaginatedByProductID mocks base method.
+func (m *MockProductAuditLogRepository) FindPaginatedByProductID(arg0 context.Context, arg1 *model.ProductHistoryPayload) (model.ProductAuditLogs, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPaginatedByProductID", arg0, arg1)
+	ret0, _ := ret[0].(model.ProductAuditLogs)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindPaginatedByProductID indicates an expected call of FindPaginatedByProductID.
+func (mr *MockProductAuditLogRepositoryMockRecorder) FindPaginatedByProductID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPaginatedByProductID", reflect.TypeOf((*MockProductAuditLogRepository)(nil).FindPaginatedByProductID), arg0, arg1)
+}
+
+// InjectDB mocks base method.
+func (m *MockProductAuditLogRepository) InjectDB(arg0 *gorm.DB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectDB", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectDB indicates an expected call of InjectDB.
+func (mr *MockProductAuditLogRepositoryMockRecorder) InjectDB(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectDB", reflect.TypeOf((*MockProductAuditLogRepository)(nil).InjectDB), arg0)
+}
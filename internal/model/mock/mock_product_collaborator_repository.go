@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/krobus00/product-service/internal/model (interfaces: ProductCollaboratorRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/krobus00/product-service/internal/model"
+	gorm "gorm.io/gorm"
+)
+
+// MockProductCollaboratorRepository is a mock of ProductCollaboratorRepository interface.
+type MockProductCollaboratorRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductCollaboratorRepositoryMockRecorder
+}
+
+// MockProductCollaboratorRepositoryMockRecorder is the mock recorder for MockProductCollaboratorRepository.
+type MockProductCollaboratorRepositoryMockRecorder struct {
+	mock *MockProductCollaboratorRepository
+}
+
+// NewMockProductCollaboratorRepository creates a new mock instance.
+func NewMockProductCollaboratorRepository(ctrl *gomock.Controller) *MockProductCollaboratorRepository {
+	mock := &MockProductCollaboratorRepository{ctrl: ctrl}
+	mock.recorder = &MockProductCollaboratorRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductCollaboratorRepository) EXPECT() *MockProductCollaboratorRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockProductCollaboratorRepository) Delete(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockProductCollaboratorRepositoryMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProductCollaboratorRepository)(nil).Delete), arg0, arg1, arg2)
+}
+
+// FindByProductID mocks base method.
+func (m *MockProductCollaboratorRepository) FindByProductID(arg0 context.Context, arg1 string) (model.ProductCollaborators, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByProductID", arg0, arg1)
+	ret0, _ := ret[0].(model.ProductCollaborators)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByProductID indicates an expected call of FindByProductID.
+func (mr *MockProductCollaboratorRepositoryMockRecorder) FindByProductID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByProductID", reflect.TypeOf((*MockProductCollaboratorRepository)(nil).FindByProductID), arg0, arg1)
+}
+
+// FindByProductIDAndUserID mocks base method.
+func (m *MockProductCollaboratorRepository) FindByProductIDAndUserID(arg0 context.Context, arg1, arg2 string) (*model.ProductCollaborator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByProductIDAndUserID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.ProductCollaborator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByProductIDAndUserID indicates an expected call of FindByProductIDAndUserID.
+func (mr *MockProductCollaboratorRepositoryMockRecorder) FindByProductIDAndUserID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByProductIDAndUserID", reflect.TypeOf((*MockProductCollaboratorRepository)(nil).FindByProductIDAndUserID), arg0, arg1, arg2)
+}
+
+// InjectDB mocks base method.
+func (m *MockProductCollaboratorRepository) InjectDB(arg0 *gorm.DB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectDB", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectDB indicates an expected call of InjectDB.
+func (mr *MockProductCollaboratorRepositoryMockRecorder) InjectDB(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectDB", reflect.TypeOf((*MockProductCollaboratorRepository)(nil).InjectDB), arg0)
+}
+
+// Upsert mocks base method.
+func (m *MockProductCollaboratorRepository) Upsert(arg0 context.Context, arg1 *model.ProductCollaborator) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockProductCollaboratorRepositoryMockRecorder) Upsert(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockProductCollaboratorRepository)(nil).Upsert), arg0, arg1)
+}
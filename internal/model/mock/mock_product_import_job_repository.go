@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/krobus00/product-service/internal/model (interfaces: ProductImportJobRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/krobus00/product-service/internal/model"
+	gorm "gorm.io/gorm"
+)
+
+// MockProductImportJobRepository is a mock of ProductImportJobRepository interface.
+type MockProductImportJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductImportJobRepositoryMockRecorder
+}
+
+// MockProductImportJobRepositoryMockRecorder is the mock recorder for MockProductImportJobRepository.
+type MockProductImportJobRepositoryMockRecorder struct {
+	mock *MockProductImportJobRepository
+}
+
+// NewMockProductImportJobRepository creates a new mock instance.
+func NewMockProductImportJobRepository(ctrl *gomock.Controller) *MockProductImportJobRepository {
+	mock := &MockProductImportJobRepository{ctrl: ctrl}
+	mock.recorder = &MockProductImportJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductImportJobRepository) EXPECT() *MockProductImportJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockProductImportJobRepository) Create(arg0 context.Context, arg1 *model.ProductImportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockProductImportJobRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProductImportJobRepository)(nil).Create), arg0, arg1)
+}
+
+// CreateErrors mocks base method.
+func (m *MockProductImportJobRepository) CreateErrors(arg0 context.Context, arg1 model.ProductImportErrors) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateErrors", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateErrors indicates an expected call of CreateErrors.
+func (mr *MockProductImportJobRepositoryMockRecorder) CreateErrors(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateErrors", reflect.TypeOf((*MockProductImportJobRepository)(nil).CreateErrors), arg0, arg1)
+}
+
+// FindByID mocks base method.
+func (m *MockProductImportJobRepository) FindByID(arg0 context.Context, arg1 string) (*model.ProductImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0, arg1)
+	ret0, _ := ret[0].(*model.ProductImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockProductImportJobRepositoryMockRecorder) FindByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockProductImportJobRepository)(nil).FindByID), arg0, arg1)
+}
+
+// FindErrorsByJobID mocks base method.
+func (m *MockProductImportJobRepository) FindErrorsByJobID(arg0 context.Context, arg1 string, arg2 int) (model.ProductImportErrors, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindErrorsByJobID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(model.ProductImportErrors)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindErrorsByJobID indicates an expected call of FindErrorsByJobID.
+func (mr *MockProductImportJobRepositoryMockRecorder) FindErrorsByJobID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindErrorsByJobID", reflect.TypeOf((*MockProductImportJobRepository)(nil).FindErrorsByJobID), arg0, arg1, arg2)
+}
+
+// InjectDB mocks base method.
+func (m *MockProductImportJobRepository) InjectDB(arg0 *gorm.DB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectDB", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectDB indicates an expected call of InjectDB.
+func (mr *MockProductImportJobRepositoryMockRecorder) InjectDB(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectDB", reflect.TypeOf((*MockProductImportJobRepository)(nil).InjectDB), arg0)
+}
+
+// Update mocks base method.
+func (m *MockProductImportJobRepository) Update(arg0 context.Context, arg1 *model.ProductImportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockProductImportJobRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProductImportJobRepository)(nil).Update), arg0, arg1)
+}
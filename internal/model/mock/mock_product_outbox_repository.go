@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/krobus00/product-service/internal/model (interfaces: ProductOutboxRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/krobus00/product-service/internal/model"
+	gorm "gorm.io/gorm"
+)
+
+// MockProductOutboxRepository is a mock of ProductOutboxRepository interface.
+type MockProductOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductOutboxRepositoryMockRecorder
+}
+
+// MockProductOutboxRepositoryMockRecorder is the mock recorder for MockProductOutboxRepository.
+type MockProductOutboxRepositoryMockRecorder struct {
+	mock *MockProductOutboxRepository
+}
+
+// NewMockProductOutboxRepository creates a new mock instance.
+func NewMockProductOutboxRepository(ctrl *gomock.Controller) *MockProductOutboxRepository {
+	mock := &MockProductOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockProductOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductOutboxRepository) EXPECT() *MockProductOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockProductOutboxRepository) Create(arg0 context.Context, arg1 model.ProductOutboxEvents) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockProductOutboxRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProductOutboxRepository)(nil).Create), arg0, arg1)
+}
+
+// DeleteByIDs mocks base method.
+func (m *MockProductOutboxRepository) DeleteByIDs(arg0 context.Context, arg1 []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByIDs", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByIDs indicates an expected call of DeleteByIDs.
+func (mr *MockProductOutboxRepositoryMockRecorder) DeleteByIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByIDs", reflect.TypeOf((*MockProductOutboxRepository)(nil).DeleteByIDs), arg0, arg1)
+}
+
+// FindPending mocks base method.
+func (m *MockProductOutboxRepository) FindPending(arg0 context.Context, arg1 int) (model.ProductOutboxEvents, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPending", arg0, arg1)
+	ret0, _ := ret[0].(model.ProductOutboxEvents)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPending indicates an expected call of FindPending.
+func (mr *MockProductOutboxRepositoryMockRecorder) FindPending(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPending", reflect.TypeOf((*MockProductOutboxRepository)(nil).FindPending), arg0, arg1)
+}
+
+// InjectDB mocks base method.
+func (m *MockProductOutboxRepository) InjectDB(arg0 *gorm.DB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectDB", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectDB indicates an expected call of InjectDB.
+func (mr *MockProductOutboxRepositoryMockRecorder) InjectDB(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectDB", reflect.TypeOf((*MockProductOutboxRepository)(nil).InjectDB), arg0)
+}
+
+// TryLockRelay mocks base method.
+func (m *MockProductOutboxRepository) TryLockRelay(arg0 context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryLockRelay", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TryLockRelay indicates an expected call of TryLockRelay.
+func (mr *MockProductOutboxRepositoryMockRecorder) TryLockRelay(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryLockRelay", reflect.TypeOf((*MockProductOutboxRepository)(nil).TryLockRelay), arg0)
+}
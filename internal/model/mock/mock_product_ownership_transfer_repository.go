@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/krobus00/product-service/internal/model (interfaces: ProductOwnershipTransferRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/krobus00/product-service/internal/model"
+	gorm "gorm.io/gorm"
+)
+
+// MockProductOwnershipTransferRepository is a mock of ProductOwnershipTransferRepository interface.
+type MockProductOwnershipTransferRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductOwnershipTransferRepositoryMockRecorder
+}
+
+// MockProductOwnershipTransferRepositoryMockRecorder is the mock recorder for MockProductOwnershipTransferRepository.
+type MockProductOwnershipTransferRepositoryMockRecorder struct {
+	mock *MockProductOwnershipTransferRepository
+}
+
+// NewMockProductOwnershipTransferRepository creates a new mock instance.
+func NewMockProductOwnershipTransferRepository(ctrl *gomock.Controller) *MockProductOwnershipTransferRepository {
+	mock := &MockProductOwnershipTransferRepository{ctrl: ctrl}
+	mock.recorder = &MockProductOwnershipTransferRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductOwnershipTransferRepository) EXPECT() *MockProductOwnershipTransferRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockProductOwnershipTransferRepository) Create(arg0 context.Context, arg1 []*model.ProductOwnershipTransfer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockProductOwnershipTransferRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProductOwnershipTransferRepository)(nil).Create), arg0, arg1)
+}
+
+// InjectDB mocks base method.
+func (m *MockProductOwnershipTransferRepository) InjectDB(arg0 *gorm.DB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectDB", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectDB indicates an expected call of InjectDB.
+func (mr *MockProductOwnershipTransferRepositoryMockRecorder) InjectDB(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectDB", reflect.TypeOf((*MockProductOwnershipTransferRepository)(nil).InjectDB), arg0)
+}
@@ -10,7 +10,6 @@ import (
 
 	redis "github.com/go-redis/redis/v8"
 	gomock "github.com/golang/mock/gomock"
-	kit "github.com/krobus00/krokit"
 	model "github.com/krobus00/product-service/internal/model"
 	gorm "gorm.io/gorm"
 )
@@ -38,6 +37,21 @@ func (m *MockProductRepository) EXPECT() *MockProductRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountByOwnerID mocks base method.
+func (m *MockProductRepository) CountByOwnerID(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByOwnerID", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByOwnerID indicates an expected call of CountByOwnerID.
+func (mr *MockProductRepositoryMockRecorder) CountByOwnerID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByOwnerID", reflect.TypeOf((*MockProductRepository)(nil).CountByOwnerID), arg0, arg1)
+}
+
 // Create mocks base method.
 func (m *MockProductRepository) Create(arg0 context.Context, arg1 *model.Product) error {
 	m.ctrl.T.Helper()
@@ -81,20 +95,51 @@ func (mr *MockProductRepositoryMockRecorder) FindByID(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockProductRepository)(nil).FindByID), arg0, arg1)
 }
 
-// FindOSPaginatedIDs mocks base method.
-func (m *MockProductRepository) FindOSPaginatedIDs(arg0 context.Context, arg1 *model.PaginationPayload) ([]string, int64, error) {
+// FindByOwnerIDAndExternalSKUs mocks base method.
+func (m *MockProductRepository) FindByOwnerIDAndExternalSKUs(arg0 context.Context, arg1 string, arg2 []string) (model.Products, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByOwnerIDAndExternalSKUs", arg0, arg1, arg2)
+	ret0, _ := ret[0].(model.Products)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByOwnerIDAndExternalSKUs indicates an expected call of FindByOwnerIDAndExternalSKUs.
+func (mr *MockProductRepositoryMockRecorder) FindByOwnerIDAndExternalSKUs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByOwnerIDAndExternalSKUs", reflect.TypeOf((*MockProductRepository)(nil).FindByOwnerIDAndExternalSKUs), arg0, arg1, arg2)
+}
+
+// FindExportBatch mocks base method.
+func (m *MockProductRepository) FindExportBatch(arg0 context.Context, arg1 *model.ExportProductsPayload, arg2 *model.ProductExportCheckpoint, arg3 int) (model.Products, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExportBatch", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(model.Products)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExportBatch indicates an expected call of FindExportBatch.
+func (mr *MockProductRepositoryMockRecorder) FindExportBatch(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExportBatch", reflect.TypeOf((*MockProductRepository)(nil).FindExportBatch), arg0, arg1, arg2, arg3)
+}
+
+// FindListingPaginatedIDs mocks base method.
+func (m *MockProductRepository) FindListingPaginatedIDs(arg0 context.Context, arg1 *model.PaginationPayload, arg2 *model.ProductListingSort) ([]string, int64, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindOSPaginatedIDs", arg0, arg1)
+	ret := m.ctrl.Call(m, "FindListingPaginatedIDs", arg0, arg1, arg2)
 	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(int64)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret2, _ := ret[2].(string)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
 }
 
-// FindOSPaginatedIDs indicates an expected call of FindOSPaginatedIDs.
-func (mr *MockProductRepositoryMockRecorder) FindOSPaginatedIDs(arg0, arg1 interface{}) *gomock.Call {
+// FindListingPaginatedIDs indicates an expected call of FindListingPaginatedIDs.
+func (mr *MockProductRepositoryMockRecorder) FindListingPaginatedIDs(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOSPaginatedIDs", reflect.TypeOf((*MockProductRepository)(nil).FindOSPaginatedIDs), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindListingPaginatedIDs", reflect.TypeOf((*MockProductRepository)(nil).FindListingPaginatedIDs), arg0, arg1, arg2)
 }
 
 // FindPaginatedIDs mocks base method.
@@ -113,6 +158,38 @@ func (mr *MockProductRepositoryMockRecorder) FindPaginatedIDs(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPaginatedIDs", reflect.TypeOf((*MockProductRepository)(nil).FindPaginatedIDs), arg0, arg1)
 }
 
+// FindRelatedIDs mocks base method.
+func (m *MockProductRepository) FindRelatedIDs(arg0 context.Context, arg1 *model.Product, arg2 *model.RelatedPayload) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRelatedIDs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRelatedIDs indicates an expected call of FindRelatedIDs.
+func (mr *MockProductRepositoryMockRecorder) FindRelatedIDs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRelatedIDs", reflect.TypeOf((*MockProductRepository)(nil).FindRelatedIDs), arg0, arg1, arg2)
+}
+
+// FindSearchPaginatedIDs mocks base method.
+func (m *MockProductRepository) FindSearchPaginatedIDs(arg0 context.Context, arg1 *model.PaginationPayload) ([]string, []*model.ProductHighlight, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSearchPaginatedIDs", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].([]*model.ProductHighlight)
+	ret2, _ := ret[2].(int64)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// FindSearchPaginatedIDs indicates an expected call of FindSearchPaginatedIDs.
+func (mr *MockProductRepositoryMockRecorder) FindSearchPaginatedIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSearchPaginatedIDs", reflect.TypeOf((*MockProductRepository)(nil).FindSearchPaginatedIDs), arg0, arg1)
+}
+
 // InjectDB mocks base method.
 func (m *MockProductRepository) InjectDB(arg0 *gorm.DB) error {
 	m.ctrl.T.Helper()
@@ -127,18 +204,18 @@ func (mr *MockProductRepositoryMockRecorder) InjectDB(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectDB", reflect.TypeOf((*MockProductRepository)(nil).InjectDB), arg0)
 }
 
-// InjectOpensearchClient mocks base method.
-func (m *MockProductRepository) InjectOpensearchClient(arg0 kit.OpensearchClient) error {
+// InjectProductSearcher mocks base method.
+func (m *MockProductRepository) InjectProductSearcher(arg0 model.ProductSearcher) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InjectOpensearchClient", arg0)
+	ret := m.ctrl.Call(m, "InjectProductSearcher", arg0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// InjectOpensearchClient indicates an expected call of InjectOpensearchClient.
-func (mr *MockProductRepositoryMockRecorder) InjectOpensearchClient(arg0 interface{}) *gomock.Call {
+// InjectProductSearcher indicates an expected call of InjectProductSearcher.
+func (mr *MockProductRepositoryMockRecorder) InjectProductSearcher(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectOpensearchClient", reflect.TypeOf((*MockProductRepository)(nil).InjectOpensearchClient), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectProductSearcher", reflect.TypeOf((*MockProductRepository)(nil).InjectProductSearcher), arg0)
 }
 
 // InjectRedisClient mocks base method.
@@ -155,6 +232,77 @@ func (mr *MockProductRepositoryMockRecorder) InjectRedisClient(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectRedisClient", reflect.TypeOf((*MockProductRepository)(nil).InjectRedisClient), arg0)
 }
 
+// LockOwner mocks base method.
+func (m *MockProductRepository) LockOwner(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockOwner", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockOwner indicates an expected call of LockOwner.
+func (mr *MockProductRepositoryMockRecorder) LockOwner(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockOwner", reflect.TypeOf((*MockProductRepository)(nil).LockOwner), arg0, arg1)
+}
+
+// RebuildListing mocks base method.
+func (m *MockProductRepository) RebuildListing(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebuildListing", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RebuildListing indicates an expected call of RebuildListing.
+func (mr *MockProductRepositoryMockRecorder) RebuildListing(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebuildListing", reflect.TypeOf((*MockProductRepository)(nil).RebuildListing), arg0)
+}
+
+// Reindex mocks base method.
+func (m *MockProductRepository) Reindex(arg0 context.Context, arg1 *model.Product) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reindex", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reindex indicates an expected call of Reindex.
+func (mr *MockProductRepositoryMockRecorder) Reindex(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reindex", reflect.TypeOf((*MockProductRepository)(nil).Reindex), arg0, arg1)
+}
+
+// RestoreByID mocks base method.
+func (m *MockProductRepository) RestoreByID(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreByID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreByID indicates an expected call of RestoreByID.
+func (mr *MockProductRepositoryMockRecorder) RestoreByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreByID", reflect.TypeOf((*MockProductRepository)(nil).RestoreByID), arg0, arg1)
+}
+
+// TransferOwnership mocks base method.
+func (m *MockProductRepository) TransferOwnership(arg0 context.Context, arg1 *model.TransferOwnershipPayload) (model.Products, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferOwnership", arg0, arg1)
+	ret0, _ := ret[0].(model.Products)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferOwnership indicates an expected call of TransferOwnership.
+func (mr *MockProductRepositoryMockRecorder) TransferOwnership(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferOwnership", reflect.TypeOf((*MockProductRepository)(nil).TransferOwnership), arg0, arg1)
+}
+
 // Update mocks base method.
 func (m *MockProductRepository) Update(arg0 context.Context, arg1 *model.Product) error {
 	m.ctrl.T.Helper()
@@ -170,11 +318,12 @@ func (mr *MockProductRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gom
 }
 
 // UpdateAllThumbnail mocks base method.
-func (m *MockProductRepository) UpdateAllThumbnail(arg0 context.Context, arg1, arg2 string) error {
+func (m *MockProductRepository) UpdateAllThumbnail(arg0 context.Context, arg1, arg2 string) (model.Products, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdateAllThumbnail", arg0, arg1, arg2)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(model.Products)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UpdateAllThumbnail indicates an expected call of UpdateAllThumbnail.
@@ -182,3 +331,17 @@ func (mr *MockProductRepositoryMockRecorder) UpdateAllThumbnail(arg0, arg1, arg2
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAllThumbnail", reflect.TypeOf((*MockProductRepository)(nil).UpdateAllThumbnail), arg0, arg1, arg2)
 }
+
+// UpsertInBatches mocks base method.
+func (m *MockProductRepository) UpsertInBatches(arg0 context.Context, arg1 model.Products) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertInBatches", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertInBatches indicates an expected call of UpsertInBatches.
+func (mr *MockProductRepositoryMockRecorder) UpsertInBatches(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertInBatches", reflect.TypeOf((*MockProductRepository)(nil).UpsertInBatches), arg0, arg1)
+}
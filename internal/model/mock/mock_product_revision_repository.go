@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/krobus00/product-service/internal/model (interfaces: ProductRevisionRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/krobus00/product-service/internal/model"
+	gorm "gorm.io/gorm"
+)
+
+// MockProductRevisionRepository is a mock of ProductRevisionRepository interface.
+type MockProductRevisionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductRevisionRepositoryMockRecorder
+}
+
+// MockProductRevisionRepositoryMockRecorder is the mock recorder for MockProductRevisionRepository.
+type MockProductRevisionRepositoryMockRecorder struct {
+	mock *MockProductRevisionRepository
+}
+
+// NewMockProductRevisionRepository creates a new mock instance.
+func NewMockProductRevisionRepository(ctrl *gomock.Controller) *MockProductRevisionRepository {
+	mock := &MockProductRevisionRepository{ctrl: ctrl}
+	mock.recorder = &MockProductRevisionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductRevisionRepository) EXPECT() *MockProductRevisionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockProductRevisionRepository) Create(arg0 context.Context, arg1 model.ProductRevisions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockProductRevisionRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProductRevisionRepository)(nil).Create), arg0, arg1)
+}
+
+// FindByProductIDAndRevision mocks base method.
+func (m *MockProductRevisionRepository) FindByProductIDAndRevision(arg0 context.Context, arg1 string, arg2 int64) (*model.ProductRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByProductIDAndRevision", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.ProductRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByProductIDAndRevision indicates an expected call of FindByProductIDAndRevision.
+func (mr *MockProductRevisionRepositoryMockRecorder) FindByProductIDAndRevision(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByProductIDAndRevision", reflect.TypeOf((*MockProductRevisionRepository)(nil).FindByProductIDAndRevision), arg0, arg1, arg2)
+}
+
+// FindPaginatedByProductID mocks base method.
+func (m *MockProductRevisionRepository) FindPaginatedByProductID(arg0 context.Context, arg1 *model.ProductRevisionsPayload) (model.ProductRevisions, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPaginatedByProductID", arg0, arg1)
+	ret0, _ := ret[0].(model.ProductRevisions)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindPaginatedByProductID indicates an expected call of FindPaginatedByProductID.
+func (mr *MockProductRevisionRepositoryMockRecorder) FindPaginatedByProductID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPaginatedByProductID", reflect.TypeOf((*MockProductRevisionRepository)(nil).FindPaginatedByProductID), arg0, arg1)
+}
+
+// InjectDB mocks base method.
+func (m *MockProductRevisionRepository) InjectDB(arg0 *gorm.DB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectDB", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectDB indicates an expected call of InjectDB.
+func (mr *MockProductRevisionRepositoryMockRecorder) InjectDB(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectDB", reflect.TypeOf((*MockProductRevisionRepository)(nil).InjectDB), arg0)
+}
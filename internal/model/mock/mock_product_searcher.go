@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/krobus00/product-service/internal/model (interfaces: ProductSearcher)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/krobus00/product-service/internal/model"
+)
+
+// MockProductSearcher is a mock of ProductSearcher interface.
+type MockProductSearcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductSearcherMockRecorder
+}
+
+// MockProductSearcherMockRecorder is the mock recorder for MockProductSearcher.
+type MockProductSearcherMockRecorder struct {
+	mock *MockProductSearcher
+}
+
+// NewMockProductSearcher creates a new mock instance.
+func NewMockProductSearcher(ctrl *gomock.Controller) *MockProductSearcher {
+	mock := &MockProductSearcher{ctrl: ctrl}
+	mock.recorder = &MockProductSearcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductSearcher) EXPECT() *MockProductSearcherMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockProductSearcher) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockProductSearcherMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockProductSearcher)(nil).Close))
+}
+
+// FindPaginatedIDs mocks base method.
+func (m *MockProductSearcher) FindPaginatedIDs(arg0 context.Context, arg1 *model.PaginationPayload) ([]string, []*model.ProductHighlight, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPaginatedIDs", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].([]*model.ProductHighlight)
+	ret2, _ := ret[2].(int64)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// FindPaginatedIDs indicates an expected call of FindPaginatedIDs.
+func (mr *MockProductSearcherMockRecorder) FindPaginatedIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPaginatedIDs", reflect.TypeOf((*MockProductSearcher)(nil).FindPaginatedIDs), arg0, arg1)
+}
+
+// FindRelatedIDs mocks base method.
+func (m *MockProductSearcher) FindRelatedIDs(arg0 context.Context, arg1 *model.Product, arg2 *model.RelatedPayload) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRelatedIDs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRelatedIDs indicates an expected call of FindRelatedIDs.
+func (mr *MockProductSearcherMockRecorder) FindRelatedIDs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRelatedIDs", reflect.TypeOf((*MockProductSearcher)(nil).FindRelatedIDs), arg0, arg1, arg2)
+}
+
+// Index mocks base method.
+func (m *MockProductSearcher) Index(arg0 context.Context, arg1 *model.Product) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Index", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Index indicates an expected call of Index.
+func (mr *MockProductSearcherMockRecorder) Index(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Index", reflect.TypeOf((*MockProductSearcher)(nil).Index), arg0, arg1)
+}
+
+// InitIndex mocks base method.
+func (m *MockProductSearcher) InitIndex(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InitIndex", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InitIndex indicates an expected call of InitIndex.
+func (mr *MockProductSearcherMockRecorder) InitIndex(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InitIndex", reflect.TypeOf((*MockProductSearcher)(nil).InitIndex), arg0)
+}
+
+// Ping mocks base method.
+func (m *MockProductSearcher) Ping(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockProductSearcherMockRecorder) Ping(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockProductSearcher)(nil).Ping), arg0)
+}
@@ -40,6 +40,21 @@ func (m *MockProductUsecase) EXPECT() *MockProductUsecaseMockRecorder {
 	return m.recorder
 }
 
+// AddCollaborator mocks base method.
+func (m *MockProductUsecase) AddCollaborator(arg0 context.Context, arg1 *model.AddCollaboratorPayload) (*model.ProductCollaborator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCollaborator", arg0, arg1)
+	ret0, _ := ret[0].(*model.ProductCollaborator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCollaborator indicates an expected call of AddCollaborator.
+func (mr *MockProductUsecaseMockRecorder) AddCollaborator(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCollaborator", reflect.TypeOf((*MockProductUsecase)(nil).AddCollaborator), arg0, arg1)
+}
+
 // ConsumeEvent mocks base method.
 func (m *MockProductUsecase) ConsumeEvent() error {
 	m.ctrl.T.Helper()
@@ -97,6 +112,49 @@ func (mr *MockProductUsecaseMockRecorder) Delete(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProductUsecase)(nil).Delete), arg0, arg1)
 }
 
+// DeleteExpiredIdempotencyKeys mocks base method.
+func (m *MockProductUsecase) DeleteExpiredIdempotencyKeys(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredIdempotencyKeys", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpiredIdempotencyKeys indicates an expected call of DeleteExpiredIdempotencyKeys.
+func (mr *MockProductUsecaseMockRecorder) DeleteExpiredIdempotencyKeys(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredIdempotencyKeys", reflect.TypeOf((*MockProductUsecase)(nil).DeleteExpiredIdempotencyKeys), arg0)
+}
+
+// ExportCatalog mocks base method.
+func (m *MockProductUsecase) ExportCatalog(arg0 context.Context, arg1 *model.CatalogExportPayload) (*model.CatalogExportManifest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportCatalog", arg0, arg1)
+	ret0, _ := ret[0].(*model.CatalogExportManifest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportCatalog indicates an expected call of ExportCatalog.
+func (mr *MockProductUsecaseMockRecorder) ExportCatalog(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportCatalog", reflect.TypeOf((*MockProductUsecase)(nil).ExportCatalog), arg0, arg1)
+}
+
+// ExportProducts mocks base method.
+func (m *MockProductUsecase) ExportProducts(arg0 context.Context, arg1 *model.ExportProductsPayload, arg2 func(*model.ExportedProduct) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportProducts", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportProducts indicates an expected call of ExportProducts.
+func (mr *MockProductUsecaseMockRecorder) ExportProducts(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportProducts", reflect.TypeOf((*MockProductUsecase)(nil).ExportProducts), arg0, arg1, arg2)
+}
+
 // FindByID mocks base method.
 func (m *MockProductUsecase) FindByID(arg0 context.Context, arg1 string) (*model.Product, error) {
 	m.ctrl.T.Helper()
@@ -127,6 +185,36 @@ func (mr *MockProductUsecaseMockRecorder) FindByIDs(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockProductUsecase)(nil).FindByIDs), arg0, arg1)
 }
 
+// FindCollaborators mocks base method.
+func (m *MockProductUsecase) FindCollaborators(arg0 context.Context, arg1 string) (model.ProductCollaborators, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCollaborators", arg0, arg1)
+	ret0, _ := ret[0].(model.ProductCollaborators)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCollaborators indicates an expected call of FindCollaborators.
+func (mr *MockProductUsecaseMockRecorder) FindCollaborators(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCollaborators", reflect.TypeOf((*MockProductUsecase)(nil).FindCollaborators), arg0, arg1)
+}
+
+// FindHistory mocks base method.
+func (m *MockProductUsecase) FindHistory(arg0 context.Context, arg1 *model.ProductHistoryPayload) (*model.ProductHistoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindHistory", arg0, arg1)
+	ret0, _ := ret[0].(*model.ProductHistoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindHistory indicates an expected call of FindHistory.
+func (mr *MockProductUsecaseMockRecorder) FindHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindHistory", reflect.TypeOf((*MockProductUsecase)(nil).FindHistory), arg0, arg1)
+}
+
 // FindPaginatedIDs mocks base method.
 func (m *MockProductUsecase) FindPaginatedIDs(arg0 context.Context, arg1 *model.PaginationPayload) (*model.PaginationResponse, error) {
 	m.ctrl.T.Helper()
@@ -142,6 +230,94 @@ func (mr *MockProductUsecaseMockRecorder) FindPaginatedIDs(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPaginatedIDs", reflect.TypeOf((*MockProductUsecase)(nil).FindPaginatedIDs), arg0, arg1)
 }
 
+// FindRelated mocks base method.
+func (m *MockProductUsecase) FindRelated(arg0 context.Context, arg1 *model.RelatedPayload) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRelated", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRelated indicates an expected call of FindRelated.
+func (mr *MockProductUsecaseMockRecorder) FindRelated(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRelated", reflect.TypeOf((*MockProductUsecase)(nil).FindRelated), arg0, arg1)
+}
+
+// FindRevision mocks base method.
+func (m *MockProductUsecase) FindRevision(arg0 context.Context, arg1 string, arg2 int64) (*model.ProductRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRevision", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.ProductRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRevision indicates an expected call of FindRevision.
+func (mr *MockProductUsecaseMockRecorder) FindRevision(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRevision", reflect.TypeOf((*MockProductUsecase)(nil).FindRevision), arg0, arg1, arg2)
+}
+
+// FindRevisions mocks base method.
+func (m *MockProductUsecase) FindRevisions(arg0 context.Context, arg1 *model.ProductRevisionsPayload) (*model.ProductRevisionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRevisions", arg0, arg1)
+	ret0, _ := ret[0].(*model.ProductRevisionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRevisions indicates an expected call of FindRevisions.
+func (mr *MockProductUsecaseMockRecorder) FindRevisions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRevisions", reflect.TypeOf((*MockProductUsecase)(nil).FindRevisions), arg0, arg1)
+}
+
+// GetImportJob mocks base method.
+func (m *MockProductUsecase) GetImportJob(arg0 context.Context, arg1 string, arg2 bool) (*model.ProductImportJobResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImportJob", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.ProductImportJobResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImportJob indicates an expected call of GetImportJob.
+func (mr *MockProductUsecaseMockRecorder) GetImportJob(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportJob", reflect.TypeOf((*MockProductUsecase)(nil).GetImportJob), arg0, arg1, arg2)
+}
+
+// HandleExportCatalogTask mocks base method.
+func (m *MockProductUsecase) HandleExportCatalogTask(arg0 context.Context, arg1 *asynq.Task) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleExportCatalogTask", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleExportCatalogTask indicates an expected call of HandleExportCatalogTask.
+func (mr *MockProductUsecaseMockRecorder) HandleExportCatalogTask(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleExportCatalogTask", reflect.TypeOf((*MockProductUsecase)(nil).HandleExportCatalogTask), arg0, arg1)
+}
+
+// HandleImportProductsTask mocks base method.
+func (m *MockProductUsecase) HandleImportProductsTask(arg0 context.Context, arg1 *asynq.Task) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleImportProductsTask", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleImportProductsTask indicates an expected call of HandleImportProductsTask.
+func (mr *MockProductUsecaseMockRecorder) HandleImportProductsTask(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleImportProductsTask", reflect.TypeOf((*MockProductUsecase)(nil).HandleImportProductsTask), arg0, arg1)
+}
+
 // HandleUpdateThumbnailTask mocks base method.
 func (m *MockProductUsecase) HandleUpdateThumbnailTask(arg0 context.Context, arg1 *asynq.Task) error {
 	m.ctrl.T.Helper()
@@ -156,6 +332,21 @@ func (mr *MockProductUsecaseMockRecorder) HandleUpdateThumbnailTask(arg0, arg1 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleUpdateThumbnailTask", reflect.TypeOf((*MockProductUsecase)(nil).HandleUpdateThumbnailTask), arg0, arg1)
 }
 
+// ImportProducts mocks base method.
+func (m *MockProductUsecase) ImportProducts(arg0 context.Context, arg1 *model.ImportProductsPayload) (*model.ProductImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportProducts", arg0, arg1)
+	ret0, _ := ret[0].(*model.ProductImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportProducts indicates an expected call of ImportProducts.
+func (mr *MockProductUsecaseMockRecorder) ImportProducts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportProducts", reflect.TypeOf((*MockProductUsecase)(nil).ImportProducts), arg0, arg1)
+}
+
 // InjectAsynqClient mocks base method.
 func (m *MockProductUsecase) InjectAsynqClient(arg0 *asynq.Client) error {
 	m.ctrl.T.Helper()
@@ -198,6 +389,20 @@ func (mr *MockProductUsecaseMockRecorder) InjectDB(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectDB", reflect.TypeOf((*MockProductUsecase)(nil).InjectDB), arg0)
 }
 
+// InjectIdempotencyRepo mocks base method.
+func (m *MockProductUsecase) InjectIdempotencyRepo(arg0 model.IdempotencyRepository) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectIdempotencyRepo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectIdempotencyRepo indicates an expected call of InjectIdempotencyRepo.
+func (mr *MockProductUsecaseMockRecorder) InjectIdempotencyRepo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectIdempotencyRepo", reflect.TypeOf((*MockProductUsecase)(nil).InjectIdempotencyRepo), arg0)
+}
+
 // InjectJetstreamClient mocks base method.
 func (m *MockProductUsecase) InjectJetstreamClient(arg0 nats.JetStreamContext) error {
 	m.ctrl.T.Helper()
@@ -212,6 +417,104 @@ func (mr *MockProductUsecaseMockRecorder) InjectJetstreamClient(arg0 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectJetstreamClient", reflect.TypeOf((*MockProductUsecase)(nil).InjectJetstreamClient), arg0)
 }
 
+// InjectObjectUploader mocks base method.
+func (m *MockProductUsecase) InjectObjectUploader(arg0 model.ObjectUploader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectObjectUploader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectObjectUploader indicates an expected call of InjectObjectUploader.
+func (mr *MockProductUsecaseMockRecorder) InjectObjectUploader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectObjectUploader", reflect.TypeOf((*MockProductUsecase)(nil).InjectObjectUploader), arg0)
+}
+
+// InjectPermissionCacheRepo mocks base method.
+func (m *MockProductUsecase) InjectPermissionCacheRepo(arg0 model.PermissionCacheRepository) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectPermissionCacheRepo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectPermissionCacheRepo indicates an expected call of InjectPermissionCacheRepo.
+func (mr *MockProductUsecaseMockRecorder) InjectPermissionCacheRepo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectPermissionCacheRepo", reflect.TypeOf((*MockProductUsecase)(nil).InjectPermissionCacheRepo), arg0)
+}
+
+// InjectProductAuditLogRepo mocks base method.
+func (m *MockProductUsecase) InjectProductAuditLogRepo(arg0 model.ProductAuditLogRepository) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectProductAuditLogRepo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectProductAuditLogRepo indicates an expected call of InjectProductAuditLogRepo.
+func (mr *MockProductUsecaseMockRecorder) InjectProductAuditLogRepo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectProductAuditLogRepo", reflect.TypeOf((*MockProductUsecase)(nil).InjectProductAuditLogRepo), arg0)
+}
+
+// InjectProductCollaboratorRepo mocks base method.
+func (m *MockProductUsecase) InjectProductCollaboratorRepo(arg0 model.ProductCollaboratorRepository) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectProductCollaboratorRepo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectProductCollaboratorRepo indicates an expected call of InjectProductCollaboratorRepo.
+func (mr *MockProductUsecaseMockRecorder) InjectProductCollaboratorRepo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectProductCollaboratorRepo", reflect.TypeOf((*MockProductUsecase)(nil).InjectProductCollaboratorRepo), arg0)
+}
+
+// InjectProductImportJobRepo mocks base method.
+func (m *MockProductUsecase) InjectProductImportJobRepo(arg0 model.ProductImportJobRepository) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectProductImportJobRepo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectProductImportJobRepo indicates an expected call of InjectProductImportJobRepo.
+func (mr *MockProductUsecaseMockRecorder) InjectProductImportJobRepo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectProductImportJobRepo", reflect.TypeOf((*MockProductUsecase)(nil).InjectProductImportJobRepo), arg0)
+}
+
+// InjectProductOutboxRepo mocks base method.
+func (m *MockProductUsecase) InjectProductOutboxRepo(arg0 model.ProductOutboxRepository) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectProductOutboxRepo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectProductOutboxRepo indicates an expected call of InjectProductOutboxRepo.
+func (mr *MockProductUsecaseMockRecorder) InjectProductOutboxRepo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectProductOutboxRepo", reflect.TypeOf((*MockProductUsecase)(nil).InjectProductOutboxRepo), arg0)
+}
+
+// InjectProductOwnershipTransferRepo mocks base method.
+func (m *MockProductUsecase) InjectProductOwnershipTransferRepo(arg0 model.ProductOwnershipTransferRepository) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectProductOwnershipTransferRepo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectProductOwnershipTransferRepo indicates an expected call of InjectProductOwnershipTransferRepo.
+func (mr *MockProductUsecaseMockRecorder) InjectProductOwnershipTransferRepo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectProductOwnershipTransferRepo", reflect.TypeOf((*MockProductUsecase)(nil).InjectProductOwnershipTransferRepo), arg0)
+}
+
 // InjectProductRepo mocks base method.
 func (m *MockProductUsecase) InjectProductRepo(arg0 model.ProductRepository) error {
 	m.ctrl.T.Helper()
@@ -226,6 +529,20 @@ func (mr *MockProductUsecaseMockRecorder) InjectProductRepo(arg0 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectProductRepo", reflect.TypeOf((*MockProductUsecase)(nil).InjectProductRepo), arg0)
 }
 
+// InjectProductRevisionRepo mocks base method.
+func (m *MockProductUsecase) InjectProductRevisionRepo(arg0 model.ProductRevisionRepository) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectProductRevisionRepo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectProductRevisionRepo indicates an expected call of InjectProductRevisionRepo.
+func (mr *MockProductUsecaseMockRecorder) InjectProductRevisionRepo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectProductRevisionRepo", reflect.TypeOf((*MockProductUsecase)(nil).InjectProductRevisionRepo), arg0)
+}
+
 // InjectStorageClient mocks base method.
 func (m *MockProductUsecase) InjectStorageClient(arg0 storage.StorageServiceClient) error {
 	m.ctrl.T.Helper()
@@ -240,6 +557,94 @@ func (mr *MockProductUsecaseMockRecorder) InjectStorageClient(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectStorageClient", reflect.TypeOf((*MockProductUsecase)(nil).InjectStorageClient), arg0)
 }
 
+// RelayProductEvents mocks base method.
+func (m *MockProductUsecase) RelayProductEvents(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RelayProductEvents", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RelayProductEvents indicates an expected call of RelayProductEvents.
+func (mr *MockProductUsecaseMockRecorder) RelayProductEvents(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelayProductEvents", reflect.TypeOf((*MockProductUsecase)(nil).RelayProductEvents), arg0)
+}
+
+// RemoveCollaborator mocks base method.
+func (m *MockProductUsecase) RemoveCollaborator(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveCollaborator", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveCollaborator indicates an expected call of RemoveCollaborator.
+func (mr *MockProductUsecaseMockRecorder) RemoveCollaborator(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCollaborator", reflect.TypeOf((*MockProductUsecase)(nil).RemoveCollaborator), arg0, arg1, arg2)
+}
+
+// Restore mocks base method.
+func (m *MockProductUsecase) Restore(arg0 context.Context, arg1 string) (*model.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1)
+	ret0, _ := ret[0].(*model.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockProductUsecaseMockRecorder) Restore(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockProductUsecase)(nil).Restore), arg0, arg1)
+}
+
+// Revert mocks base method.
+func (m *MockProductUsecase) Revert(arg0 context.Context, arg1 string, arg2 int64) (*model.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revert", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revert indicates an expected call of Revert.
+func (mr *MockProductUsecaseMockRecorder) Revert(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revert", reflect.TypeOf((*MockProductUsecase)(nil).Revert), arg0, arg1, arg2)
+}
+
+// RunImport mocks base method.
+func (m *MockProductUsecase) RunImport(arg0 context.Context, arg1 *model.ImportProductsPayload, arg2 string) (*model.ProductImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunImport", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.ProductImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunImport indicates an expected call of RunImport.
+func (mr *MockProductUsecaseMockRecorder) RunImport(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunImport", reflect.TypeOf((*MockProductUsecase)(nil).RunImport), arg0, arg1, arg2)
+}
+
+// TransferOwnership mocks base method.
+func (m *MockProductUsecase) TransferOwnership(arg0 context.Context, arg1 *model.TransferOwnershipPayload) (model.Products, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferOwnership", arg0, arg1)
+	ret0, _ := ret[0].(model.Products)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferOwnership indicates an expected call of TransferOwnership.
+func (mr *MockProductUsecaseMockRecorder) TransferOwnership(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferOwnership", reflect.TypeOf((*MockProductUsecase)(nil).TransferOwnership), arg0, arg1)
+}
+
 // Update mocks base method.
 func (m *MockProductUsecase) Update(arg0 context.Context, arg1 *model.UpdateProductPayload) (*model.Product, error) {
 	m.ctrl.T.Helper()
@@ -254,3 +659,17 @@ func (mr *MockProductUsecaseMockRecorder) Update(arg0, arg1 interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProductUsecase)(nil).Update), arg0, arg1)
 }
+
+// WatchProducts mocks base method.
+func (m *MockProductUsecase) WatchProducts(arg0 context.Context, arg1 *model.WatchProductsPayload, arg2 func(*model.ProductEvent) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchProducts", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchProducts indicates an expected call of WatchProducts.
+func (mr *MockProductUsecaseMockRecorder) WatchProducts(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchProducts", reflect.TypeOf((*MockProductUsecase)(nil).WatchProducts), arg0, arg1, arg2)
+}
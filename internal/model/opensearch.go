@@ -12,6 +12,7 @@ type OSPaginationRequest struct {
 	TrackTotalHits bool              `json:"track_total_hits"`
 	Query          Query             `json:"query"`
 	Sort           []map[string]Sort `json:"sort"`
+	Highlight      *Highlight        `json:"highlight,omitempty"`
 }
 
 type Query struct {
@@ -25,28 +26,71 @@ type Bool struct {
 }
 
 type Filter struct {
-	Term map[string]string `json:"term"`
+	Term  map[string]string `json:"term,omitempty"`
+	Range map[string]Range  `json:"range,omitempty"`
+	Bool  *FilterBool       `json:"bool,omitempty"`
+}
+
+type FilterBool struct {
+	Should             []Filter `json:"should"`
+	MinimumShouldMatch int      `json:"minimum_should_match"`
+}
+
+type Range struct {
+	Gte float64 `json:"gte"`
+	Lte float64 `json:"lte"`
 }
 
 type Must struct {
-	MultiMatch MultiMatch `json:"multi_match"`
+	MultiMatch   *MultiMatch   `json:"multi_match,omitempty"`
+	MoreLikeThis *MoreLikeThis `json:"more_like_this,omitempty"`
 }
 
 type MultiMatch struct {
+	Type               string   `json:"type,omitempty"`
 	Query              string   `json:"query"`
 	Analyzer           string   `json:"analyzer"`
 	Fields             []string `json:"fields"`
 	MinimumShouldMatch string   `json:"minimum_should_match"`
 }
 
+type MoreLikeThis struct {
+	Fields        []string           `json:"fields"`
+	Like          []MoreLikeThisLike `json:"like"`
+	MinTermFreq   int                `json:"min_term_freq"`
+	MinDocFreq    int                `json:"min_doc_freq"`
+	MaxQueryTerms int                `json:"max_query_terms"`
+}
+
+type MoreLikeThisLike struct {
+	Index string `json:"_index"`
+	ID    string `json:"_id"`
+}
+
 type MustNot struct {
-	Exists Exists `json:"exists"`
+	Exists *Exists `json:"exists,omitempty"`
+	IDs    *IDs    `json:"ids,omitempty"`
 }
 
 type Exists struct {
 	Field string `json:"field"`
 }
 
+type IDs struct {
+	Values []string `json:"values"`
+}
+
+type Highlight struct {
+	PreTags  []string                  `json:"pre_tags"`
+	PostTags []string                  `json:"post_tags"`
+	Fields   map[string]HighlightField `json:"fields"`
+}
+
+type HighlightField struct {
+	FragmentSize      int `json:"fragment_size"`
+	NumberOfFragments int `json:"number_of_fragments"`
+}
+
 // -created_at = created_at desc.
 // +created_at = created_at asc.
 type Sort struct {
@@ -72,13 +116,37 @@ func (m *OSPaginationRequest) ParseSort(req *PaginationPayload) {
 	m.Sort = sortReq
 }
 
+func (m *OSPaginationRequest) ParseHighlight(req *PaginationPayload, fields []string) {
+	if !req.Highlight {
+		return
+	}
+	highlightFields := make(map[string]HighlightField)
+	for _, field := range fields {
+		highlightFields[field] = HighlightField{
+			FragmentSize:      OSProductHighlightFragmentSize,
+			NumberOfFragments: OSProductHighlightNumberOfFragments,
+		}
+	}
+	m.Highlight = &Highlight{
+		PreTags:  []string{req.HighlightPreTag},
+		PostTags: []string{req.HighlightPostTag},
+		Fields:   highlightFields,
+	}
+}
+
+type OSRelatedRequest struct {
+	Size  int64 `json:"size"`
+	Query Query `json:"query"`
+}
+
 type OSPaginationResponse[T any] struct {
 	Hits struct {
 		Total struct {
 			Value int64 `json:"value"`
 		} `json:"total"`
 		Hits []struct {
-			Source *T `json:"_source"`
+			Source    *T                  `json:"_source"`
+			Highlight map[string][]string `json:"highlight"`
 		} `json:"hits"`
 	} `json:"hits"`
 }
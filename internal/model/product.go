@@ -1,18 +1,23 @@
 //go:generate mockgen -destination=mock/mock_product_repository.go -package=mock github.com/krobus00/product-service/internal/model ProductRepository
 //go:generate mockgen -destination=mock/mock_product_usecase.go -package=mock github.com/krobus00/product-service/internal/model ProductUsecase
+//go:generate mockgen -destination=mock/mock_product_searcher.go -package=mock github.com/krobus00/product-service/internal/model ProductSearcher
 
 package model
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/hibiken/asynq"
 	authPB "github.com/krobus00/auth-service/pb/auth"
-	kit "github.com/krobus00/krokit"
 	"github.com/krobus00/product-service/internal/utils"
 	pb "github.com/krobus00/product-service/pb/product"
 	storagePB "github.com/krobus00/storage-service/pb/storage"
@@ -25,9 +30,47 @@ const (
 	ProductStreamSubjects          = "PRODUCTS.*"
 	ProductThumbnailDeletedSubject = "PRODUCTS.thumbnailDeleted"
 
-	OSProductIndex              = "products"
-	OSProductAnalyzer           = "my_analyzer"
-	OSProductMinimumShouldMatch = "50%"
+	// ProductChangeStreamName is kept apart from ProductStreamName so the worker consumer never receive the change feed
+	ProductChangeStreamName     = "PRODUCT_CHANGES"
+	ProductChangeStreamSubjects = "PRODUCT_CHANGES.*"
+	ProductCreatedSubject       = "PRODUCT_CHANGES.created"
+	ProductUpdatedSubject       = "PRODUCT_CHANGES.updated"
+	ProductDeletedSubject       = "PRODUCT_CHANGES.deleted"
+
+	OSProductIndex                      = "products"
+	OSProductAnalyzer                   = "my_analyzer"
+	OSProductMinimumShouldMatch         = "50%"
+	OSProductShortSearchAnalyzer        = "whitespace"
+	OSProductShortSearchType            = "bool_prefix"
+	OSProductHighlightFragmentSize      = 150
+	OSProductHighlightNumberOfFragments = 3
+	OSProductRelatedMinTermFreq         = 1
+	OSProductRelatedMinDocFreq          = 1
+	OSProductRelatedMaxQueryTerms       = 25
+
+	BleveProductAnalyzer    = "product_ngram"
+	BleveProductTokenFilter = "product_ngram_filter"
+	BleveProductNgramMin    = ProductSearchNgramMin
+	BleveProductNgramMax    = 3
+
+	// ProductSearchNgramMin is the min_gram of the search analyzers, a shorter word has no ngram to match
+	ProductSearchNgramMin = 3
+
+	SearchEngineOpensearch = "opensearch"
+	SearchEngineBleve      = "bleve"
+
+	ProductListingCreatedAtKey      = "products:z:created_at"
+	ProductListingPriceKey          = "products:z:price"
+	ProductListingRebuildBatchSize  = 500
+	ProductListingRebuildMarkerKey  = "products:z:rebuilding"
+	ProductListingRebuildTouchedKey = "products:z:rebuild:touched"
+	// ProductListingRebuildTimeout release the rebuild marker of a crashed rebuild
+	ProductListingRebuildTimeout    = time.Hour
+	ProductListingDefaultSortColumn = "created_at"
+	// ProductReindexBatchSize is the number of products indexed and uncached at once after a bulk write
+	ProductReindexBatchSize = 100
+	// ProductUpsertBatchSize is the number of products written by one statement of a bulk upsert
+	ProductUpsertBatchSize = 100
 
 	ThumbnailType    = "IMAGE"
 	DefaultThumbnail = "PRODUCT_THUMBNAIL"
@@ -36,12 +79,38 @@ const (
 var (
 	ProductSearchColumns = []string{"name", "description"}
 
+	// ProductListingKeys map the sortable column to the redis sorted set that materialize it.
+	ProductListingKeys = map[string]string{
+		"created_at": ProductListingCreatedAtKey,
+		"price":      ProductListingPriceKey,
+	}
+
 	ErrProductNotFound         = errors.New("product not found")
 	ErrThumbnailNotFound       = errors.New("thumbnail not found")
 	ErrThumbnailTypeNotAllowed = errors.New("thumbnail type not allowed")
 	ErrThumbnailNotAllowed     = errors.New("thumbnail not allowed")
+	ErrProductQuotaExceeded    = errors.New("product quota exceeded")
+	ErrBleveIndexLocked        = errors.New("bleve index locked")
+	ErrListingRebuildRunning   = errors.New("listing rebuild already running")
+	ErrExternalSKUConflict     = errors.New("external sku already used by the owner")
 )
 
+// ProductQuotaError tell which owner reached the product quota, errors.Is match it with ErrProductQuotaExceeded.
+// RetryAfter is the delay suggested to the client, it is only a hint since the quota is freed by deleting products.
+type ProductQuotaError struct {
+	OwnerID    string
+	Limit      int64
+	RetryAfter time.Duration
+}
+
+func (e *ProductQuotaError) Error() string {
+	return fmt.Sprintf("%s: owner %s reached the limit of %d products", ErrProductQuotaExceeded.Error(), e.OwnerID, e.Limit)
+}
+
+func (e *ProductQuotaError) Is(target error) bool {
+	return target == ErrProductQuotaExceeded
+}
+
 type Product struct {
 	ID          string `gorm:"primaryKey"`
 	Name        string
@@ -49,9 +118,12 @@ type Product struct {
 	Price       float64
 	ThumbnailID string         // refer to object id
 	OwnerID     string         // refer to user_id
+	ExternalSKU string         // identifier from the seller catalog, unique per owner when set
 	CreatedAt   time.Time      `gorm:"<-:create"` // read and create
 	UpdatedAt   time.Time      `gorm:"<-"`        // allow read, create, and update
 	DeletedAt   gorm.DeletedAt `gorm:"index"`
+
+	CollaboratorIDs []string `gorm:"-" json:"-"` // loaded by the repository when indexing
 }
 
 type Products []*Product
@@ -64,15 +136,42 @@ func NewProductCacheKey(id string) string {
 	return fmt.Sprintf("products:id:%s", id)
 }
 
-func GetProductCacheKeys(id string) []string {
-	return []string{
-		NewProductCacheKey(id),
+// NewProductOwnerLockKey is hashed to the advisory lock serializing the writes counted against the owner quota.
+func NewProductOwnerLockKey(ownerID string) string {
+	return fmt.Sprintf("products:owner:%s", ownerID)
+}
+
+func NewProductRelatedCacheKey(id string) string {
+	return fmt.Sprintf("products:related:%s", id)
+}
+
+// NewProductRelatedTagKey is the set of the related cache keys listing the product, they are stale once it change.
+func NewProductRelatedTagKey(id string) string {
+	return fmt.Sprintf("products:related-tag:%s", id)
+}
+
+// SearchWords split the search in the words the ngram tokenizer see, runs of letters and digits.
+func SearchWords(search string) []string {
+	return strings.FieldsFunc(search, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// IsShortSearch tell whether no word of the search is long enough to produce an ngram. The search backends match
+// such a search as the prefixes of the indexed ngrams, so "ko" find the products containing "kop".
+func IsShortSearch(search string) bool {
+	for _, word := range SearchWords(search) {
+		if utf8.RuneCountInString(word) >= ProductSearchNgramMin {
+			return false
+		}
 	}
+	return true
 }
 
-func GetUpdateAllThumbnailCacheKeys() []string {
+func GetProductCacheKeys(id string) []string {
 	return []string{
-		"products:*",
+		NewProductCacheKey(id),
+		NewProductRelatedCacheKey(id),
 	}
 }
 
@@ -87,6 +186,17 @@ func (m Products) ToProto() []*pb.Product {
 	return results
 }
 
+func (m Products) IDs() []string {
+	ids := make([]string, 0)
+	for _, product := range m {
+		if product == nil {
+			continue
+		}
+		ids = append(ids, product.ID)
+	}
+	return ids
+}
+
 func (m *Product) ToProto() *pb.Product {
 	createdAt := m.CreatedAt.UTC().Format(time.RFC3339Nano)
 	updatedAt := m.UpdatedAt.UTC().Format(time.RFC3339Nano)
@@ -104,6 +214,7 @@ func (m *Product) ToProto() *pb.Product {
 		CreatedAt:   createdAt,
 		UpdatedAt:   updatedAt,
 		DeletedAt:   deletedAt,
+		ExternalSku: m.ExternalSKU,
 	}
 }
 
@@ -116,6 +227,8 @@ type DocProduct struct {
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `json:"deleted_at"`
+
+	CollaboratorIDs []string `json:"collaborator_ids"`
 }
 
 func (m *DocProduct) GetID() string {
@@ -137,7 +250,120 @@ func (m *Product) ToDoc() *DocProduct {
 		CreatedAt:   m.CreatedAt.UTC(),
 		UpdatedAt:   m.UpdatedAt.UTC(),
 		DeletedAt:   deletedAt,
+
+		CollaboratorIDs: m.CollaboratorIDs,
+	}
+}
+
+// ProductHighlight holds the matched fragments of a search hit, keyed by product id.
+type ProductHighlight struct {
+	ID          string   `json:"id"`
+	Name        []string `json:"name,omitempty"`
+	Description []string `json:"description,omitempty"`
+}
+
+func (m *ProductHighlight) ToProto() *pb.ProductHighlight {
+	return &pb.ProductHighlight{
+		Id:          m.ID,
+		Name:        m.Name,
+		Description: m.Description,
+	}
+}
+
+type BleveDocProduct struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Price       float64   `json:"price"`
+	OwnerID     string    `json:"owner_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Deleted     bool      `json:"deleted"`
+
+	CollaboratorIDs []string `json:"collaborator_ids"`
+}
+
+func (m *Product) ToBleveDoc() *BleveDocProduct {
+	return &BleveDocProduct{
+		ID:          m.ID,
+		Name:        m.Name,
+		Description: m.Description,
+		Price:       m.Price,
+		OwnerID:     m.OwnerID,
+		CreatedAt:   m.CreatedAt.UTC(),
+		UpdatedAt:   m.UpdatedAt.UTC(),
+		Deleted:     m.DeletedAt.Valid,
+
+		CollaboratorIDs: m.CollaboratorIDs,
+	}
+}
+
+// ListingScores return the sorted set score of the product for each listing key.
+func (m *Product) ListingScores() map[string]float64 {
+	return map[string]float64{
+		ProductListingCreatedAtKey: float64(m.CreatedAt.UnixMilli()),
+		ProductListingPriceKey:     m.Price,
+	}
+}
+
+type ProductListingSort struct {
+	Key  string
+	Desc bool
+}
+
+// NewProductListingSort resolve the sorted set for the pagination request,
+// ok is false when the request can't be served from the listing.
+func NewProductListingSort(req *PaginationPayload) (sort *ProductListingSort, ok bool) {
+	if req.Search != "" || req.IncludeDeleted || req.OwnedOrShared || len(req.Sort) > 1 {
+		return nil, false
+	}
+
+	column := "-" + ProductListingDefaultSortColumn
+	if len(req.Sort) == 1 {
+		column = req.Sort[0]
 	}
+
+	key, ok := ProductListingKeys[strings.TrimLeft(column, "+-")]
+	if !ok {
+		return nil, false
+	}
+
+	return &ProductListingSort{
+		Key:  key,
+		Desc: strings.HasPrefix(column, "-"),
+	}, true
+}
+
+type ProductListingCursor struct {
+	Score float64
+	ID    string
+}
+
+func NewProductListingCursor(cursor string) (*ProductListingCursor, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	score, id, found := strings.Cut(string(decoded), "|")
+	if !found || id == "" {
+		return nil, ErrInvalidCursor
+	}
+	parsedScore, err := strconv.ParseFloat(score, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &ProductListingCursor{
+		Score: parsedScore,
+		ID:    id,
+	}, nil
+}
+
+func (m *ProductListingCursor) String() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(m.ScoreString() + "|" + m.ID))
+}
+
+func (m *ProductListingCursor) ScoreString() string {
+	return strconv.FormatFloat(m.Score, 'f', -1, 64)
 }
 
 type CreateProductPayload struct {
@@ -157,6 +383,12 @@ func NewCreateProductPayloadFromProto(message *pb.CreateProductRequest) *CreateP
 	}
 }
 
+func (m *CreateProductPayload) Validate() error {
+	v := new(validator)
+	validateProductFields(v, m.Name, m.Description, m.Price, m.ThumbnailID)
+	return v.err()
+}
+
 func (m *CreateProductPayload) ToProduct(ownerID string) *Product {
 	if m.ID == "" {
 		m.ID = utils.GenerateUUID()
@@ -189,6 +421,26 @@ func NewUpdateProductPayloadFromProto(message *pb.UpdateProductRequest) *UpdateP
 	}
 }
 
+func (m *UpdateProductPayload) Validate() error {
+	v := new(validator)
+	if v.required("id", m.ID) {
+		v.uuid("id", m.ID)
+	}
+	validateProductFields(v, m.Name, m.Description, m.Price, m.ThumbnailID)
+	return v.err()
+}
+
+func validateProductFields(v *validator, name string, description string, price float64, thumbnailID string) {
+	if v.required("name", name) {
+		v.maxLength("name", name, ProductNameMaxLength)
+	}
+	v.maxLength("description", description, ProductDescriptionMaxLength)
+	v.between("price", price, 0, ProductPriceMax)
+	if v.required("thumbnail_id", thumbnailID) {
+		v.uuid("thumbnail_id", thumbnailID)
+	}
+}
+
 func (m *UpdateProductPayload) UpdateProduct(currentProduct *Product) *Product {
 	currentProduct.Name = m.Name
 	currentProduct.Description = m.Description
@@ -197,28 +449,108 @@ func (m *UpdateProductPayload) UpdateProduct(currentProduct *Product) *Product {
 	return currentProduct
 }
 
+type RelatedPayload struct {
+	ID        string
+	Limit     int
+	SameOwner bool
+	PriceBand float64 // fraction of the source product price, 0 means no restriction
+}
+
+func NewRelatedPayloadFromProto(message *pb.FindRelatedRequest) *RelatedPayload {
+	return &RelatedPayload{
+		ID:        message.GetId(),
+		Limit:     int(message.GetLimit()),
+		SameOwner: message.GetSameOwner(),
+		PriceBand: float64(message.GetPriceBand()),
+	}
+}
+
+func (m *RelatedPayload) Sanitize() *RelatedPayload {
+	if m.Limit <= 0 {
+		m.Limit = defaultPaginationLimit
+	}
+	if m.Limit > maxPaginationLimit {
+		m.Limit = maxPaginationLimit
+	}
+	if m.PriceBand < 0 {
+		m.PriceBand = 0
+	}
+	return m
+}
+
+// CacheField identify the related result of a product for the given options.
+func (m *RelatedPayload) CacheField() string {
+	return fmt.Sprintf("limit:%d:sameOwner:%t:priceBand:%g", m.Limit, m.SameOwner, m.PriceBand)
+}
+
 type ProductRepository interface {
 	Create(ctx context.Context, product *Product) error
 	Update(ctx context.Context, product *Product) error
 	DeleteByID(ctx context.Context, id string) error
+	RestoreByID(ctx context.Context, id string) error
 	FindPaginatedIDs(ctx context.Context, req *PaginationPayload) (ids []string, count int64, err error)
-	FindOSPaginatedIDs(ctx context.Context, req *PaginationPayload) (ids []string, count int64, err error)
-	UpdateAllThumbnail(ctx context.Context, oldThumbnailID string, newThumbnailID string) error
+	FindListingPaginatedIDs(ctx context.Context, req *PaginationPayload, sort *ProductListingSort) (ids []string, count int64, nextCursor string, err error)
+	RebuildListing(ctx context.Context) error
+	FindSearchPaginatedIDs(ctx context.Context, req *PaginationPayload) (ids []string, highlights []*ProductHighlight, count int64, err error)
+	FindRelatedIDs(ctx context.Context, product *Product, req *RelatedPayload) (ids []string, err error)
+	UpdateAllThumbnail(ctx context.Context, oldThumbnailID string, newThumbnailID string) (Products, error)
+	Reindex(ctx context.Context, product *Product) error
+	TransferOwnership(ctx context.Context, payload *TransferOwnershipPayload) (Products, error)
+	// UpsertInBatches create the new products and update the existing ones matched by id
+	UpsertInBatches(ctx context.Context, products Products) error
+	// LockOwner serialize the writes counted against the quota of the owner until the transaction of ctx end
+	LockOwner(ctx context.Context, ownerID string) error
 
 	// Resolver
 	FindByID(ctx context.Context, id string) (*Product, error)
+	CountByOwnerID(ctx context.Context, ownerID string) (count int64, err error)
+	FindExportBatch(ctx context.Context, req *ExportProductsPayload, after *ProductExportCheckpoint, limit int) (Products, error)
+	// FindByOwnerIDAndExternalSKUs include the deleted products since their sku is still reserved
+	FindByOwnerIDAndExternalSKUs(ctx context.Context, ownerID string, externalSKUs []string) (Products, error)
 
 	// DI
 	InjectDB(db *gorm.DB) error
 	InjectRedisClient(client *redis.Client) error
-	InjectOpensearchClient(client kit.OpensearchClient) error
+	InjectProductSearcher(searcher ProductSearcher) error
+}
+
+// ProductSearcher is the full-text search backend used for listing and related products.
+type ProductSearcher interface {
+	InitIndex(ctx context.Context) error
+	Index(ctx context.Context, product *Product) error
+	FindPaginatedIDs(ctx context.Context, req *PaginationPayload) (ids []string, highlights []*ProductHighlight, count int64, err error)
+	FindRelatedIDs(ctx context.Context, product *Product, req *RelatedPayload) (ids []string, err error)
+	Ping(ctx context.Context) error
+	Close() error
 }
 
 type ProductUsecase interface {
 	Create(ctx context.Context, payload *CreateProductPayload) (*Product, error)
 	Update(ctx context.Context, payload *UpdateProductPayload) (*Product, error)
 	Delete(ctx context.Context, id string) error
+	Restore(ctx context.Context, id string) (*Product, error)
 	FindPaginatedIDs(ctx context.Context, req *PaginationPayload) (*PaginationResponse, error)
+	FindRelated(ctx context.Context, req *RelatedPayload) ([]string, error)
+	AddCollaborator(ctx context.Context, payload *AddCollaboratorPayload) (*ProductCollaborator, error)
+	RemoveCollaborator(ctx context.Context, productID string, userID string) error
+	FindCollaborators(ctx context.Context, productID string) (ProductCollaborators, error)
+	TransferOwnership(ctx context.Context, payload *TransferOwnershipPayload) (Products, error)
+	FindHistory(ctx context.Context, req *ProductHistoryPayload) (*ProductHistoryResponse, error)
+	FindRevision(ctx context.Context, productID string, revision int64) (*ProductRevision, error)
+	FindRevisions(ctx context.Context, req *ProductRevisionsPayload) (*ProductRevisionsResponse, error)
+	Revert(ctx context.Context, productID string, revision int64) (*Product, error)
+	ExportProducts(ctx context.Context, req *ExportProductsPayload, send func(*ExportedProduct) error) error
+	WatchProducts(ctx context.Context, req *WatchProductsPayload, send func(*ProductEvent) error) error
+	ImportProducts(ctx context.Context, payload *ImportProductsPayload) (*ProductImportJob, error)
+	GetImportJob(ctx context.Context, id string, includeErrorReport bool) (*ProductImportJobResponse, error)
+	// RunImport create the job and import the rows before returning, it is meant for trusted callers such as the import command
+	RunImport(ctx context.Context, payload *ImportProductsPayload, createdBy string) (*ProductImportJob, error)
+	// ExportCatalog write a snapshot of the catalog without permission check, it is meant for the export command and task
+	ExportCatalog(ctx context.Context, payload *CatalogExportPayload) (*CatalogExportManifest, error)
+	// DeleteExpiredIdempotencyKeys is run periodically by the worker
+	DeleteExpiredIdempotencyKeys(ctx context.Context) error
+	// RelayProductEvents publish the pending change events to the change stream, it is run periodically by the worker
+	RelayProductEvents(ctx context.Context) error
 
 	// Resolver
 	FindByID(ctx context.Context, id string) (*Product, error)
@@ -227,10 +559,19 @@ type ProductUsecase interface {
 	// DI
 	InjectDB(db *gorm.DB) error
 	InjectProductRepo(repo ProductRepository) error
+	InjectProductCollaboratorRepo(repo ProductCollaboratorRepository) error
+	InjectProductOwnershipTransferRepo(repo ProductOwnershipTransferRepository) error
+	InjectProductAuditLogRepo(repo ProductAuditLogRepository) error
+	InjectProductRevisionRepo(repo ProductRevisionRepository) error
+	InjectProductImportJobRepo(repo ProductImportJobRepository) error
+	InjectProductOutboxRepo(repo ProductOutboxRepository) error
 	InjectAuthClient(client authPB.AuthServiceClient) error
+	InjectPermissionCacheRepo(repo PermissionCacheRepository) error
+	InjectIdempotencyRepo(repo IdempotencyRepository) error
 	InjectStorageClient(client storagePB.StorageServiceClient) error
 	InjectJetstreamClient(client nats.JetStreamContext) error
 	InjectAsynqClient(client *asynq.Client) error
+	InjectObjectUploader(uploader ObjectUploader) error
 
 	// Jetstream
 	CreateStream() error
@@ -238,4 +579,6 @@ type ProductUsecase interface {
 
 	// Asynq handler
 	HandleUpdateThumbnailTask(ctx context.Context, t *asynq.Task) error
+	HandleImportProductsTask(ctx context.Context, t *asynq.Task) error
+	HandleExportCatalogTask(ctx context.Context, t *asynq.Task) error
 }
@@ -0,0 +1,263 @@
+//go:generate mockgen -destination=mock/mock_product_audit_log_repository.go -package=mock github.com/krobus00/product-service/internal/model ProductAuditLogRepository
+
+package model
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/krobus00/product-service/internal/utils"
+	pb "github.com/krobus00/product-service/pb/product"
+	"gorm.io/gorm"
+)
+
+const (
+	ProductAuditActionCreate            = "CREATE"
+	ProductAuditActionUpdate            = "UPDATE"
+	ProductAuditActionDelete            = "DELETE"
+	ProductAuditActionRestore           = "RESTORE"
+	ProductAuditActionUpdateThumbnail   = "UPDATE_THUMBNAIL"
+	ProductAuditActionTransferOwnership = "TRANSFER_OWNERSHIP"
+
+	UnknownOrigin = "UNKNOWN"
+)
+
+type ProductAuditChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// ProductAuditChanges is stored as a json column.
+type ProductAuditChanges []*ProductAuditChange
+
+func (m ProductAuditChanges) Value() (driver.Value, error) {
+	if m == nil {
+		m = ProductAuditChanges{}
+	}
+	return json.Marshal(m)
+}
+
+func (m *ProductAuditChanges) Scan(value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	case nil:
+		*m = ProductAuditChanges{}
+		return nil
+	default:
+		return errors.New("invalid product audit changes")
+	}
+	return json.Unmarshal(data, m)
+}
+
+// NewProductAuditChanges list the fields that differ between both versions of the product,
+// a nil before is treated as an empty product so every field set on create is recorded.
+func NewProductAuditChanges(before *Product, after *Product) ProductAuditChanges {
+	if before == nil {
+		before = new(Product)
+	}
+	if after == nil {
+		after = new(Product)
+	}
+
+	fields := []struct {
+		name   string
+		before string
+		after  string
+	}{
+		{"name", before.Name, after.Name},
+		{"description", before.Description, after.Description},
+		{"price", formatAuditPrice(before.Price), formatAuditPrice(after.Price)},
+		{"thumbnail_id", before.ThumbnailID, after.ThumbnailID},
+		{"owner_id", before.OwnerID, after.OwnerID},
+		{"deleted_at", formatAuditDeletedAt(before.DeletedAt), formatAuditDeletedAt(after.DeletedAt)},
+	}
+
+	changes := make(ProductAuditChanges, 0)
+	for _, field := range fields {
+		if field.before == field.after {
+			continue
+		}
+		changes = append(changes, &ProductAuditChange{
+			Field:  field.name,
+			Before: field.before,
+			After:  field.after,
+		})
+	}
+	return changes
+}
+
+func formatAuditPrice(price float64) string {
+	if price == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(price, 'f', -1, 64)
+}
+
+func formatAuditDeletedAt(deletedAt gorm.DeletedAt) string {
+	if !deletedAt.Valid {
+		return ""
+	}
+	return deletedAt.Time.UTC().Format(time.RFC3339Nano)
+}
+
+type ProductAuditLog struct {
+	ID        string `gorm:"primaryKey"`
+	ProductID string // refer to product id
+	ActorID   string // refer to user_id
+	Action    string
+	Origin    string
+	Changes   ProductAuditChanges `gorm:"type:jsonb"`
+	CreatedAt time.Time           `gorm:"<-:create"` // read and create
+}
+
+type ProductAuditLogs []*ProductAuditLog
+
+func (ProductAuditLog) TableName() string {
+	return "product_audit_logs"
+}
+
+func NewProductAuditLog(action string, actorID string, origin string, before *Product, after *Product) *ProductAuditLog {
+	productID := ""
+	switch {
+	case after != nil:
+		productID = after.ID
+	case before != nil:
+		productID = before.ID
+	}
+	if origin == "" {
+		origin = UnknownOrigin
+	}
+	return &ProductAuditLog{
+		ID:        utils.GenerateUUID(),
+		ProductID: productID,
+		ActorID:   actorID,
+		Action:    action,
+		Origin:    origin,
+		Changes:   NewProductAuditChanges(before, after),
+	}
+}
+
+// ProductChange describe a single product write, before is nil on create.
+type ProductChange struct {
+	Action string
+	Before *Product
+	After  *Product
+}
+
+type ProductChanges []*ProductChange
+
+func NewProductChange(action string, before *Product, after *Product) *ProductChange {
+	return &ProductChange{
+		Action: action,
+		Before: before,
+		After:  after,
+	}
+}
+
+func (m ProductChanges) ToAuditLogs(actorID string, origin string) ProductAuditLogs {
+	logs := make(ProductAuditLogs, 0)
+	for _, change := range m {
+		logs = append(logs, NewProductAuditLog(change.Action, actorID, origin, change.Before, change.After))
+	}
+	return logs
+}
+
+func (m ProductChanges) ToRevisions(actorID string) ProductRevisions {
+	revisions := make(ProductRevisions, 0)
+	for _, change := range m {
+		if change.After == nil {
+			continue
+		}
+		revisions = append(revisions, NewProductRevision(change.After, actorID))
+	}
+	return revisions
+}
+
+func (m *ProductAuditLog) ToProto() *pb.ProductAuditLog {
+	changes := make([]*pb.ProductAuditChange, 0)
+	for _, change := range m.Changes {
+		changes = append(changes, &pb.ProductAuditChange{
+			Field:  change.Field,
+			Before: change.Before,
+			After:  change.After,
+		})
+	}
+	return &pb.ProductAuditLog{
+		Id:        m.ID,
+		ProductId: m.ProductID,
+		ActorId:   m.ActorID,
+		Action:    m.Action,
+		Origin:    m.Origin,
+		Changes:   changes,
+		CreatedAt: m.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+func (m ProductAuditLogs) ToProto() []*pb.ProductAuditLog {
+	results := make([]*pb.ProductAuditLog, 0)
+	for _, log := range m {
+		results = append(results, log.ToProto())
+	}
+	return results
+}
+
+type ProductHistoryPayload struct {
+	ProductID string
+	Limit     int
+	Page      int
+}
+
+func NewProductHistoryPayloadFromProto(message *pb.ListProductHistoryRequest) *ProductHistoryPayload {
+	return &ProductHistoryPayload{
+		ProductID: message.GetId(),
+		Limit:     int(message.GetLimit()),
+		Page:      int(message.GetPage()),
+	}
+}
+
+func (m *ProductHistoryPayload) Sanitize() *ProductHistoryPayload {
+	m.Limit, m.Page = sanitizePagination(m.Limit, m.Page)
+	return m
+}
+
+type ProductHistoryResponse struct {
+	Count   int64
+	MaxPage int64
+	Items   ProductAuditLogs
+}
+
+func NewProductHistoryResponse(req *ProductHistoryPayload, items ProductAuditLogs, count int64) *ProductHistoryResponse {
+	return &ProductHistoryResponse{
+		Count:   count,
+		MaxPage: int64(math.Ceil(float64(count) / float64(req.Limit))),
+		Items:   items,
+	}
+}
+
+func (m *ProductHistoryResponse) ToProto() *pb.ListProductHistoryResponse {
+	return &pb.ListProductHistoryResponse{
+		Count:   m.Count,
+		MaxPage: m.MaxPage,
+		Items:   m.Items.ToProto(),
+	}
+}
+
+type ProductAuditLogRepository interface {
+	Create(ctx context.Context, logs ProductAuditLogs) error
+
+	// Resolver
+	FindPaginatedByProductID(ctx context.Context, req *ProductHistoryPayload) (logs ProductAuditLogs, count int64, err error)
+
+	// DI
+	InjectDB(db *gorm.DB) error
+}
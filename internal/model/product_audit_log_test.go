@@ -0,0 +1,95 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestNewProductAuditChanges(t *testing.T) {
+	deletedAt := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	product := &Product{
+		ID:          "1fc34a8d-3d77-4f40-acbc-789c06b4fa5d",
+		Name:        "sample product",
+		Description: "red cotton shirt",
+		Price:       100,
+		ThumbnailID: "7b2b4fd5-4c4b-4bd4-9b0d-8e5a0f1e9e1a",
+		OwnerID:     "cd9614c8-112a-4374-9737-eb62cc5d6aef",
+	}
+	type args struct {
+		before *Product
+		after  *Product
+	}
+	tests := []struct {
+		name string
+		args args
+		want ProductAuditChanges
+	}{
+		{
+			name: "create",
+			args: args{
+				before: nil,
+				after:  product,
+			},
+			want: ProductAuditChanges{
+				{Field: "name", Before: "", After: "sample product"},
+				{Field: "description", Before: "", After: "red cotton shirt"},
+				{Field: "price", Before: "", After: "100"},
+				{Field: "thumbnail_id", Before: "", After: "7b2b4fd5-4c4b-4bd4-9b0d-8e5a0f1e9e1a"},
+				{Field: "owner_id", Before: "", After: "cd9614c8-112a-4374-9737-eb62cc5d6aef"},
+			},
+		},
+		{
+			name: "update price",
+			args: args{
+				before: product,
+				after: &Product{
+					ID:          product.ID,
+					Name:        product.Name,
+					Description: product.Description,
+					Price:       99.5,
+					ThumbnailID: product.ThumbnailID,
+					OwnerID:     product.OwnerID,
+				},
+			},
+			want: ProductAuditChanges{
+				{Field: "price", Before: "100", After: "99.5"},
+			},
+		},
+		{
+			name: "delete",
+			args: args{
+				before: product,
+				after: &Product{
+					ID:          product.ID,
+					Name:        product.Name,
+					Description: product.Description,
+					Price:       product.Price,
+					ThumbnailID: product.ThumbnailID,
+					OwnerID:     product.OwnerID,
+					DeletedAt:   gorm.DeletedAt{Time: deletedAt, Valid: true},
+				},
+			},
+			want: ProductAuditChanges{
+				{Field: "deleted_at", Before: "", After: "2026-10-18T09:00:00Z"},
+			},
+		},
+		{
+			name: "nothing changed",
+			args: args{
+				before: product,
+				after:  product,
+			},
+			want: ProductAuditChanges{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewProductAuditChanges(tt.args.before, tt.args.after); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewProductAuditChanges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
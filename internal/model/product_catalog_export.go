@@ -0,0 +1,358 @@
+//go:generate mockgen -destination=mock/mock_object_uploader.go -package=mock github.com/krobus00/product-service/internal/model ObjectUploader
+
+package model
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/v11/arrow"
+	"github.com/apache/arrow/go/v11/arrow/array"
+	"github.com/apache/arrow/go/v11/arrow/memory"
+	"github.com/apache/arrow/go/v11/parquet"
+	"github.com/apache/arrow/go/v11/parquet/compress"
+	"github.com/apache/arrow/go/v11/parquet/file"
+	"github.com/apache/arrow/go/v11/parquet/pqarrow"
+	"github.com/goccy/go-json"
+)
+
+const (
+	CatalogExportFormatCSV     = "csv"
+	CatalogExportFormatJSONL   = "jsonl"
+	CatalogExportFormatParquet = "parquet"
+
+	CatalogExportDestinationLocal   = "local"
+	CatalogExportDestinationStorage = "storage"
+
+	catalogExportTimeLayout = "20060102T150405Z"
+)
+
+var (
+	// CatalogExportColumns are the columns of a catalog snapshot, in every format.
+	CatalogExportColumns = []string{
+		"id",
+		"owner_id",
+		"external_sku",
+		"name",
+		"description",
+		"price",
+		"thumbnail_id",
+		"created_at",
+		"updated_at",
+		"deleted_at",
+	}
+
+	ErrInvalidCatalogExportFormat = errors.New("invalid catalog export format")
+)
+
+// CatalogExportPayload describe a snapshot of the catalog written by the export command or the scheduled task.
+type CatalogExportPayload struct {
+	Format         string `json:"format"`
+	Destination    string `json:"destination"`
+	OutputDir      string `json:"outputDir,omitempty"` // default to export.catalog.output_dir
+	OwnerID        string `json:"ownerID,omitempty"`
+	UpdatedSince   string `json:"updatedSince,omitempty"` // RFC3339, empty export every product
+	IncludeDeleted bool   `json:"includeDeleted,omitempty"`
+}
+
+func (m *CatalogExportPayload) Validate() error {
+	v := new(validator)
+	switch m.Format {
+	case CatalogExportFormatCSV, CatalogExportFormatJSONL, CatalogExportFormatParquet:
+	default:
+		v.addViolation("format", fmt.Sprintf("must be %s, %s or %s", CatalogExportFormatCSV, CatalogExportFormatJSONL, CatalogExportFormatParquet))
+	}
+	if m.Destination != CatalogExportDestinationLocal && m.Destination != CatalogExportDestinationStorage {
+		v.addViolation("destination", fmt.Sprintf("must be %s or %s", CatalogExportDestinationLocal, CatalogExportDestinationStorage))
+	}
+	if m.OwnerID != "" {
+		v.uuid("owner_id", m.OwnerID)
+	}
+	if m.UpdatedSince != "" {
+		if _, err := time.Parse(time.RFC3339Nano, m.UpdatedSince); err != nil {
+			v.addViolation("updated_since", "must be a RFC3339 timestamp")
+		}
+	}
+	return v.err()
+}
+
+// ExportProductsPayload return the filters of the snapshot, they are the ones of ExportProducts.
+func (m *CatalogExportPayload) ExportProductsPayload() *ExportProductsPayload {
+	return &ExportProductsPayload{
+		OwnerID:        m.OwnerID,
+		UpdatedSince:   m.UpdatedSince,
+		IncludeDeleted: m.IncludeDeleted,
+	}
+}
+
+// FileName name the snapshot after its start time so a nightly snapshot never overwrite the previous one.
+func (m *CatalogExportPayload) FileName(startedAt time.Time) string {
+	return fmt.Sprintf("catalog-%s.%s", startedAt.UTC().Format(catalogExportTimeLayout), m.Format)
+}
+
+// CatalogExportManifest is written next to the snapshot once it is complete, a snapshot without manifest is partial.
+type CatalogExportManifest struct {
+	FileName       string    `json:"file_name"`
+	Path           string    `json:"path,omitempty"`      // local snapshot
+	ObjectID       string    `json:"object_id,omitempty"` // snapshot uploaded to storage-service
+	Format         string    `json:"format"`
+	OwnerID        string    `json:"owner_id,omitempty"`
+	UpdatedSince   string    `json:"updated_since,omitempty"`
+	IncludeDeleted bool      `json:"include_deleted"`
+	Rows           int64     `json:"rows"`
+	DeletedRows    int64     `json:"deleted_rows"`
+	Bytes          int64     `json:"bytes"`
+	SHA256         string    `json:"sha256"`
+	StartedAt      time.Time `json:"started_at"`
+	FinishedAt     time.Time `json:"finished_at"`
+}
+
+func NewCatalogExportManifest(payload *CatalogExportPayload, startedAt time.Time) *CatalogExportManifest {
+	return &CatalogExportManifest{
+		FileName:       payload.FileName(startedAt),
+		Format:         payload.Format,
+		OwnerID:        payload.OwnerID,
+		UpdatedSince:   payload.UpdatedSince,
+		IncludeDeleted: payload.IncludeDeleted,
+		StartedAt:      startedAt.UTC(),
+	}
+}
+
+// ManifestFileName is the snapshot file name with a .manifest.json extension.
+func (m *CatalogExportManifest) ManifestFileName() string {
+	return strings.TrimSuffix(m.FileName, "."+m.Format) + ".manifest.json"
+}
+
+// Count add a written product to the row counts.
+func (m *CatalogExportManifest) Count(product *Product) {
+	m.Rows++
+	if product.DeletedAt.Valid {
+		m.DeletedRows++
+	}
+}
+
+func (m *CatalogExportManifest) JSON() ([]byte, error) {
+	return json.MarshalIndent(m, "", "  ")
+}
+
+// CatalogExportWriter encode the products of a snapshot.
+type CatalogExportWriter interface {
+	Write(product *Product) error
+	// Close flush the buffered products, the underlying writer is not closed
+	Close() error
+}
+
+// NewCatalogExportWriter return the writer of the format, parquetRowGroupSize products are held in memory by a parquet writer.
+func NewCatalogExportWriter(format string, w io.Writer, parquetRowGroupSize int) (CatalogExportWriter, error) {
+	switch format {
+	case CatalogExportFormatCSV:
+		writer := csv.NewWriter(w)
+		err := writer.Write(CatalogExportColumns)
+		if err != nil {
+			return nil, err
+		}
+		return &csvCatalogExportWriter{writer: writer}, nil
+	case CatalogExportFormatJSONL:
+		return &jsonlCatalogExportWriter{encoder: json.NewEncoder(w)}, nil
+	case CatalogExportFormatParquet:
+		return newParquetCatalogExportWriter(w, parquetRowGroupSize)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCatalogExportFormat, format)
+	}
+}
+
+func catalogExportTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+type csvCatalogExportWriter struct {
+	writer *csv.Writer
+}
+
+func (w *csvCatalogExportWriter) Write(product *Product) error {
+	deletedAt := ""
+	if product.DeletedAt.Valid {
+		deletedAt = catalogExportTime(product.DeletedAt.Time)
+	}
+	return w.writer.Write([]string{
+		product.ID,
+		product.OwnerID,
+		product.ExternalSKU,
+		product.Name,
+		product.Description,
+		strconv.FormatFloat(product.Price, 'f', -1, 64),
+		product.ThumbnailID,
+		catalogExportTime(product.CreatedAt),
+		catalogExportTime(product.UpdatedAt),
+		deletedAt,
+	})
+}
+
+func (w *csvCatalogExportWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+type catalogExportRecord struct {
+	ID          string  `json:"id"`
+	OwnerID     string  `json:"owner_id"`
+	ExternalSKU string  `json:"external_sku"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Price       float64 `json:"price"`
+	ThumbnailID string  `json:"thumbnail_id"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+	DeletedAt   *string `json:"deleted_at"`
+}
+
+type jsonlCatalogExportWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *jsonlCatalogExportWriter) Write(product *Product) error {
+	record := &catalogExportRecord{
+		ID:          product.ID,
+		OwnerID:     product.OwnerID,
+		ExternalSKU: product.ExternalSKU,
+		Name:        product.Name,
+		Description: product.Description,
+		Price:       product.Price,
+		ThumbnailID: product.ThumbnailID,
+		CreatedAt:   catalogExportTime(product.CreatedAt),
+		UpdatedAt:   catalogExportTime(product.UpdatedAt),
+	}
+	if product.DeletedAt.Valid {
+		deletedAt := catalogExportTime(product.DeletedAt.Time)
+		record.DeletedAt = &deletedAt
+	}
+	return w.encoder.Encode(record)
+}
+
+func (w *jsonlCatalogExportWriter) Close() error {
+	return nil
+}
+
+// parquetCatalogExportSchema hold the CatalogExportColumns, only deleted_at is nullable.
+func parquetCatalogExportSchema() *arrow.Schema {
+	fields := make([]arrow.Field, 0, len(CatalogExportColumns))
+	for _, name := range CatalogExportColumns {
+		field := arrow.Field{Name: name, Type: arrow.BinaryTypes.String}
+		switch name {
+		case "price":
+			field.Type = arrow.PrimitiveTypes.Float64
+		case "created_at", "updated_at":
+			field.Type = arrow.FixedWidthTypes.Timestamp_ms
+		case "deleted_at":
+			field.Type = arrow.FixedWidthTypes.Timestamp_ms
+			field.Nullable = true
+		}
+		fields = append(fields, field)
+	}
+	return arrow.NewSchema(fields, nil)
+}
+
+// parquetCatalogExportWriter buffer rowGroupSize products in a record, every record is written as a row group.
+type parquetCatalogExportWriter struct {
+	sink         io.Writer
+	schema       *arrow.Schema
+	props        *parquet.WriterProperties
+	writer       *pqarrow.FileWriter
+	builder      *array.RecordBuilder
+	rowGroupSize int
+	rows         int
+}
+
+func newParquetCatalogExportWriter(w io.Writer, rowGroupSize int) (*parquetCatalogExportWriter, error) {
+	if rowGroupSize <= 0 {
+		return nil, errors.New("invalid parquet row group size")
+	}
+
+	schema := parquetCatalogExportSchema()
+	return &parquetCatalogExportWriter{
+		// the file writer close its sink, it is hidden so the caller keep its writer open
+		sink:   struct{ io.Writer }{w},
+		schema: schema,
+		// dictionary pages are off, a dictionary holding only an empty string is read back as "\x00" by arrow v11
+		props: parquet.NewWriterProperties(
+			parquet.WithCompression(compress.Codecs.Snappy),
+			parquet.WithDictionaryDefault(false),
+			parquet.WithMaxRowGroupLength(int64(rowGroupSize)),
+			parquet.WithCreatedBy("product-service"),
+		),
+		builder:      array.NewRecordBuilder(memory.DefaultAllocator, schema),
+		rowGroupSize: rowGroupSize,
+	}, nil
+}
+
+func (w *parquetCatalogExportWriter) Write(product *Product) error {
+	for i, value := range []string{product.ID, product.OwnerID, product.ExternalSKU, product.Name, product.Description} {
+		w.builder.Field(i).(*array.StringBuilder).Append(value)
+	}
+	w.builder.Field(5).(*array.Float64Builder).Append(product.Price)
+	w.builder.Field(6).(*array.StringBuilder).Append(product.ThumbnailID)
+	w.builder.Field(7).(*array.TimestampBuilder).Append(arrow.Timestamp(product.CreatedAt.UnixMilli()))
+	w.builder.Field(8).(*array.TimestampBuilder).Append(arrow.Timestamp(product.UpdatedAt.UnixMilli()))
+	if product.DeletedAt.Valid {
+		w.builder.Field(9).(*array.TimestampBuilder).Append(arrow.Timestamp(product.DeletedAt.Time.UnixMilli()))
+	} else {
+		w.builder.Field(9).(*array.TimestampBuilder).AppendNull()
+	}
+
+	w.rows++
+	if w.rows < w.rowGroupSize {
+		return nil
+	}
+	return w.flush()
+}
+
+func (w *parquetCatalogExportWriter) flush() error {
+	// the file writer is opened on the first row group, it write the header as soon as it is created
+	if w.writer == nil {
+		writer, err := pqarrow.NewFileWriter(w.schema, w.sink, w.props, pqarrow.DefaultWriterProps())
+		if err != nil {
+			return err
+		}
+		w.writer = writer
+	}
+
+	record := w.builder.NewRecord()
+	defer record.Release()
+	w.rows = 0
+	return w.writer.Write(record)
+}
+
+func (w *parquetCatalogExportWriter) Close() error {
+	defer w.builder.Release()
+	if w.rows > 0 {
+		err := w.flush()
+		if err != nil {
+			return err
+		}
+	}
+	if w.writer != nil {
+		return w.writer.Close()
+	}
+
+	// the arrow file writer can't be closed before a record is written, an empty snapshot is written without row groups
+	schema, err := pqarrow.ToParquet(w.schema, w.props, pqarrow.DefaultWriterProps())
+	if err != nil {
+		return err
+	}
+	return file.NewParquetWriter(w.sink, schema.Root(), file.WithWriterProps(w.props)).Close()
+}
+
+// ObjectUploader upload a file to storage-service and return the id of the created object.
+type ObjectUploader interface {
+	Upload(ctx context.Context, fileName string, content io.Reader) (string, error)
+
+	// DI
+	InjectHTTPClient(client *http.Client) error
+}
@@ -0,0 +1,306 @@
+package model
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v11/arrow"
+	"github.com/apache/arrow/go/v11/arrow/array"
+	"github.com/apache/arrow/go/v11/arrow/memory"
+	"github.com/apache/arrow/go/v11/parquet/file"
+	"github.com/apache/arrow/go/v11/parquet/pqarrow"
+	"gorm.io/gorm"
+)
+
+func TestCatalogExportPayload_Validate(t *testing.T) {
+	tests := []struct {
+		name           string
+		payload        *CatalogExportPayload
+		wantViolations []string
+	}{
+		{
+			name: "every product",
+			payload: &CatalogExportPayload{
+				Format:      CatalogExportFormatParquet,
+				Destination: CatalogExportDestinationStorage,
+			},
+			wantViolations: nil,
+		},
+		{
+			name: "filters",
+			payload: &CatalogExportPayload{
+				Format:       CatalogExportFormatCSV,
+				Destination:  CatalogExportDestinationLocal,
+				OwnerID:      "3c1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e",
+				UpdatedSince: "2026-10-19T09:00:00Z",
+			},
+			wantViolations: nil,
+		},
+		{
+			name: "invalid fields",
+			payload: &CatalogExportPayload{
+				Format:       "xlsx",
+				Destination:  "s3",
+				OwnerID:      "not-a-uuid",
+				UpdatedSince: "yesterday",
+			},
+			wantViolations: []string{"format", "destination", "owner_id", "updated_since"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotViolations := violatedFields(t, tt.payload.Validate())
+			if !reflect.DeepEqual(gotViolations, tt.wantViolations) {
+				t.Errorf("CatalogExportPayload.Validate() violations = %v, want %v", gotViolations, tt.wantViolations)
+			}
+		})
+	}
+}
+
+func TestNewCatalogExportManifest(t *testing.T) {
+	startedAt := time.Date(2026, 10, 19, 2, 0, 0, 0, time.FixedZone("WIB", 7*3600))
+	payload := &CatalogExportPayload{
+		Format:         CatalogExportFormatJSONL,
+		Destination:    CatalogExportDestinationLocal,
+		IncludeDeleted: true,
+	}
+
+	manifest := NewCatalogExportManifest(payload, startedAt)
+	manifest.Count(&Product{})
+	manifest.Count(&Product{DeletedAt: gorm.DeletedAt{Time: startedAt, Valid: true}})
+
+	if manifest.FileName != "catalog-20261018T190000Z.jsonl" {
+		t.Errorf("CatalogExportManifest.FileName = %v", manifest.FileName)
+	}
+	if got := manifest.ManifestFileName(); got != "catalog-20261018T190000Z.manifest.json" {
+		t.Errorf("CatalogExportManifest.ManifestFileName() = %v", got)
+	}
+	if manifest.Rows != 2 || manifest.DeletedRows != 1 {
+		t.Errorf("CatalogExportManifest rows = %d, deleted rows = %d, want 2 and 1", manifest.Rows, manifest.DeletedRows)
+	}
+	if !manifest.IncludeDeleted || manifest.StartedAt.Location() != time.UTC {
+		t.Errorf("CatalogExportManifest = %+v", manifest)
+	}
+}
+
+func TestNewCatalogExportWriter(t *testing.T) {
+	createdAt := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	products := Products{
+		{
+			ID:          "3c1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e",
+			OwnerID:     "5b1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e",
+			ExternalSKU: "SKU-1",
+			Name:        "Kopi, \"Toraja\"",
+			Description: "arabica",
+			Price:       12.5,
+			CreatedAt:   createdAt,
+			UpdatedAt:   createdAt.Add(time.Second),
+		},
+		{
+			ID:        "7d1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e",
+			OwnerID:   "5b1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e",
+			Name:      "Teh",
+			Price:     3,
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+			DeletedAt: gorm.DeletedAt{Time: createdAt.Add(time.Minute), Valid: true},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		format  string
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "csv",
+			format: CatalogExportFormatCSV,
+			want: "id,owner_id,external_sku,name,description,price,thumbnail_id,created_at,updated_at,deleted_at\n" +
+				"3c1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e,5b1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e,SKU-1,\"Kopi, \"\"Toraja\"\"\",arabica,12.5,,2026-10-19T09:00:00Z,2026-10-19T09:00:01Z,\n" +
+				"7d1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e,5b1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e,,Teh,,3,,2026-10-19T09:00:00Z,2026-10-19T09:00:00Z,2026-10-19T09:01:00Z\n",
+		},
+		{
+			name:   "jsonl",
+			format: CatalogExportFormatJSONL,
+			want: `{"id":"3c1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e","owner_id":"5b1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e","external_sku":"SKU-1","name":"Kopi, \"Toraja\"","description":"arabica","price":12.5,"thumbnail_id":"","created_at":"2026-10-19T09:00:00Z","updated_at":"2026-10-19T09:00:01Z","deleted_at":null}` + "\n" +
+				`{"id":"7d1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e","owner_id":"5b1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e","external_sku":"","name":"Teh","description":"","price":3,"thumbnail_id":"","created_at":"2026-10-19T09:00:00Z","updated_at":"2026-10-19T09:00:00Z","deleted_at":"2026-10-19T09:01:00Z"}` + "\n",
+		},
+		{
+			name:    "invalid format",
+			format:  "xlsx",
+			wantErr: ErrInvalidCatalogExportFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			writer, err := NewCatalogExportWriter(tt.format, buf, 10)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewCatalogExportWriter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			for _, product := range products {
+				if err := writer.Write(product); err != nil {
+					t.Fatalf("CatalogExportWriter.Write() error = %v", err)
+				}
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatalf("CatalogExportWriter.Close() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("CatalogExportWriter output = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewCatalogExportWriter_Parquet(t *testing.T) {
+	createdAt := time.Date(2023, 4, 1, 8, 30, 15, 123000000, time.UTC)
+	deletedAt := createdAt.Add(48 * time.Hour)
+	products := Products{
+		{ID: "3c1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e", OwnerID: "5b1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e", ExternalSKU: "A-1", Name: "Kopi", Description: "Arabica", Price: 12.5, ThumbnailID: "6c1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e", CreatedAt: createdAt, UpdatedAt: createdAt.Add(time.Hour)},
+		{ID: "4d1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e", OwnerID: "5b1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e", Name: "Teh", Price: 0, CreatedAt: createdAt, UpdatedAt: deletedAt, DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}},
+		{ID: "7e1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e", OwnerID: "8f1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e", ExternalSKU: "B-1", Name: "Kopi susu ☕", Price: 18000.75, CreatedAt: createdAt, UpdatedAt: createdAt},
+	}
+
+	buf := new(bytes.Buffer)
+	// two row groups, the last one is flushed by Close
+	writer, err := NewCatalogExportWriter(CatalogExportFormatParquet, buf, 2)
+	if err != nil {
+		t.Fatalf("NewCatalogExportWriter() error = %v", err)
+	}
+	for _, product := range products {
+		err = writer.Write(product)
+		if err != nil {
+			t.Fatalf("CatalogExportWriter.Write() error = %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("CatalogExportWriter.Close() error = %v", err)
+	}
+
+	// the file is read back by the apache arrow implementation
+	reader, err := file.NewParquetReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("parquet reader error = %v", err)
+	}
+	defer reader.Close()
+	if reader.NumRowGroups() != 2 || reader.NumRows() != int64(len(products)) {
+		t.Errorf("parquet file has %d row groups and %d rows, want 2 and %d", reader.NumRowGroups(), reader.NumRows(), len(products))
+	}
+
+	fileReader, err := pqarrow.NewFileReader(reader, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	if err != nil {
+		t.Fatalf("parquet reader error = %v", err)
+	}
+	table, err := fileReader.ReadTable(context.Background())
+	if err != nil {
+		t.Fatalf("parquet read error = %v", err)
+	}
+	defer table.Release()
+
+	gotColumns := make([]string, 0, table.NumCols())
+	for i := 0; i < int(table.NumCols()); i++ {
+		gotColumns = append(gotColumns, table.Column(i).Name())
+	}
+	if !reflect.DeepEqual(gotColumns, CatalogExportColumns) {
+		t.Fatalf("parquet columns = %v, want %v", gotColumns, CatalogExportColumns)
+	}
+
+	wantTypes := map[string]arrow.DataType{
+		"price":      arrow.PrimitiveTypes.Float64,
+		"created_at": &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"},
+		"updated_at": &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"},
+		"deleted_at": &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"},
+	}
+	for i, name := range CatalogExportColumns {
+		wantType, ok := wantTypes[name]
+		if !ok {
+			wantType = arrow.BinaryTypes.String
+		}
+		if got := table.Column(i).DataType(); !arrow.TypeEqual(got, wantType) {
+			t.Errorf("parquet column %s type = %v, want %v", name, got, wantType)
+		}
+		if got, want := table.Column(i).NullN(), 0; name != "deleted_at" && got != want {
+			t.Errorf("parquet column %s has %d nulls, want %d", name, got, want)
+		}
+	}
+
+	for row, product := range products {
+		var wantDeletedAt any
+		if product.DeletedAt.Valid {
+			wantDeletedAt = product.DeletedAt.Time
+		}
+		want := []any{
+			product.ID,
+			product.OwnerID,
+			product.ExternalSKU,
+			product.Name,
+			product.Description,
+			product.Price,
+			product.ThumbnailID,
+			product.CreatedAt,
+			product.UpdatedAt,
+			wantDeletedAt,
+		}
+		got := make([]any, 0, len(want))
+		for i := range CatalogExportColumns {
+			got = append(got, parquetTableValue(t, table.Column(i), row))
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("parquet row %d = %v, want %v", row, got, want)
+		}
+	}
+}
+
+// parquetTableValue return the value of the row as string, float64, time.Time or nil when it is null.
+func parquetTableValue(t *testing.T, column *arrow.Column, row int) any {
+	for _, chunk := range column.Data().Chunks() {
+		if row >= chunk.Len() {
+			row -= chunk.Len()
+			continue
+		}
+		if chunk.IsNull(row) {
+			return nil
+		}
+		switch values := chunk.(type) {
+		case *array.String:
+			return values.Value(row)
+		case *array.Float64:
+			return values.Value(row)
+		case *array.Timestamp:
+			return values.Value(row).ToTime(arrow.Millisecond)
+		default:
+			t.Fatalf("parquet column %s has unexpected %T", column.Name(), chunk)
+		}
+	}
+	t.Fatalf("parquet column %s has no row %d", column.Name(), row)
+	return nil
+}
+
+func TestNewCatalogExportWriter_ParquetEmpty(t *testing.T) {
+	buf := new(bytes.Buffer)
+	writer, err := NewCatalogExportWriter(CatalogExportFormatParquet, buf, 2)
+	if err != nil {
+		t.Fatalf("NewCatalogExportWriter() error = %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("CatalogExportWriter.Close() error = %v", err)
+	}
+
+	reader, err := file.NewParquetReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("parquet reader error = %v", err)
+	}
+	defer reader.Close()
+	if reader.NumRows() != 0 || reader.MetaData().Schema.NumColumns() != len(CatalogExportColumns) {
+		t.Errorf("parquet file has %d rows and %d columns, want 0 and %d", reader.NumRows(), reader.MetaData().Schema.NumColumns(), len(CatalogExportColumns))
+	}
+}
@@ -0,0 +1,108 @@
+//go:generate mockgen -destination=mock/mock_product_collaborator_repository.go -package=mock github.com/krobus00/product-service/internal/model ProductCollaboratorRepository
+
+package model
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "github.com/krobus00/product-service/pb/product"
+	"gorm.io/gorm"
+)
+
+const (
+	CollaboratorRoleEditor = "EDITOR"
+	CollaboratorRoleViewer = "VIEWER"
+)
+
+var (
+	// CollaboratorRoles list the roles a product can be shared with.
+	CollaboratorRoles = map[string]bool{
+		CollaboratorRoleEditor: true,
+		CollaboratorRoleViewer: true,
+	}
+
+	ErrCollaboratorNotFound    = errors.New("collaborator not found")
+	ErrInvalidCollaborator     = errors.New("invalid collaborator")
+	ErrInvalidCollaboratorRole = errors.New("invalid collaborator role")
+)
+
+type ProductCollaborator struct {
+	ProductID string `gorm:"primaryKey"` // refer to product id
+	UserID    string `gorm:"primaryKey"` // refer to user_id
+	Role      string
+	CreatedAt time.Time `gorm:"<-:create"` // read and create
+	UpdatedAt time.Time `gorm:"<-"`        // allow read, create, and update
+}
+
+type ProductCollaborators []*ProductCollaborator
+
+func (ProductCollaborator) TableName() string {
+	return "product_collaborators"
+}
+
+func (m *ProductCollaborator) ToProto() *pb.ProductCollaborator {
+	return &pb.ProductCollaborator{
+		ProductId: m.ProductID,
+		UserId:    m.UserID,
+		Role:      m.Role,
+		CreatedAt: m.CreatedAt.UTC().Format(time.RFC3339Nano),
+		UpdatedAt: m.UpdatedAt.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+func (m ProductCollaborators) ToProto() []*pb.ProductCollaborator {
+	results := make([]*pb.ProductCollaborator, 0)
+	for _, collaborator := range m {
+		results = append(results, collaborator.ToProto())
+	}
+	return results
+}
+
+// HasRole check whether the collaborator has any of the roles.
+func (m *ProductCollaborator) HasRole(roles []string) bool {
+	if m == nil {
+		return false
+	}
+	for _, role := range roles {
+		if m.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+type AddCollaboratorPayload struct {
+	ProductID string
+	UserID    string
+	Role      string
+}
+
+func NewAddCollaboratorPayloadFromProto(message *pb.AddCollaboratorRequest) *AddCollaboratorPayload {
+	return &AddCollaboratorPayload{
+		ProductID: message.GetId(),
+		UserID:    message.GetCollaboratorId(),
+		Role:      message.GetRole(),
+	}
+}
+
+func (m *AddCollaboratorPayload) ToProductCollaborator() *ProductCollaborator {
+	return &ProductCollaborator{
+		ProductID: m.ProductID,
+		UserID:    m.UserID,
+		Role:      m.Role,
+	}
+}
+
+type ProductCollaboratorRepository interface {
+	Upsert(ctx context.Context, collaborator *ProductCollaborator) error
+	Delete(ctx context.Context, productID string, userID string) error
+
+	// Resolver
+	FindByProductID(ctx context.Context, productID string) (ProductCollaborators, error)
+	FindByProductIDAndUserID(ctx context.Context, productID string, userID string) (*ProductCollaborator, error)
+
+	// DI
+	InjectDB(db *gorm.DB) error
+}
@@ -0,0 +1,99 @@
+package model
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	pb "github.com/krobus00/product-service/pb/product"
+)
+
+type ExportProductsPayload struct {
+	OwnerID        string
+	UpdatedSince   string // RFC3339, empty export every product
+	IncludeDeleted bool
+	Checkpoint     string
+}
+
+func NewExportProductsPayloadFromProto(message *pb.ExportProductsRequest) *ExportProductsPayload {
+	return &ExportProductsPayload{
+		OwnerID:        message.GetOwnerId(),
+		UpdatedSince:   message.GetUpdatedSince(),
+		IncludeDeleted: message.GetIncludeDeleted(),
+		Checkpoint:     message.GetCheckpoint(),
+	}
+}
+
+func (m *ExportProductsPayload) Validate() error {
+	v := new(validator)
+	if m.OwnerID != "" {
+		v.uuid("owner_id", m.OwnerID)
+	}
+	if m.UpdatedSince != "" {
+		if _, err := time.Parse(time.RFC3339Nano, m.UpdatedSince); err != nil {
+			v.addViolation("updated_since", "must be a RFC3339 timestamp")
+		}
+	}
+	if m.Checkpoint != "" {
+		if _, err := NewProductExportCheckpoint(m.Checkpoint); err != nil {
+			v.addViolation("checkpoint", "must be a checkpoint returned by a previous export")
+		}
+	}
+	return v.err()
+}
+
+// UpdatedSinceTime return the zero time when updated_since is empty or invalid, call Validate first.
+func (m *ExportProductsPayload) UpdatedSinceTime() time.Time {
+	updatedSince, _ := time.Parse(time.RFC3339Nano, m.UpdatedSince)
+	return updatedSince
+}
+
+// ProductExportCheckpoint is the keyset position of an export, products are walked by updated_at then id
+// so a product updated while the export run is sent again near the end instead of being skipped.
+type ProductExportCheckpoint struct {
+	UpdatedAt time.Time
+	ID        string
+}
+
+func NewProductExportCheckpoint(checkpoint string) (*ProductExportCheckpoint, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(checkpoint)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	updatedAt, id, found := strings.Cut(string(decoded), "|")
+	if !found || id == "" {
+		return nil, ErrInvalidCursor
+	}
+	parsedUpdatedAt, err := time.Parse(time.RFC3339Nano, updatedAt)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &ProductExportCheckpoint{
+		UpdatedAt: parsedUpdatedAt,
+		ID:        id,
+	}, nil
+}
+
+func (m *ProductExportCheckpoint) String() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(m.UpdatedAt.UTC().Format(time.RFC3339Nano) + "|" + m.ID))
+}
+
+func (m *Product) ExportCheckpoint() *ProductExportCheckpoint {
+	return &ProductExportCheckpoint{
+		UpdatedAt: m.UpdatedAt,
+		ID:        m.ID,
+	}
+}
+
+// ExportedProduct is a product sent by an export with the checkpoint to resume after it.
+type ExportedProduct struct {
+	Product    *Product
+	Checkpoint string
+}
+
+func (m *ExportedProduct) ToProto() *pb.ExportProductsResponse {
+	return &pb.ExportProductsResponse{
+		Product:    m.Product.ToProto(),
+		Checkpoint: m.Checkpoint,
+	}
+}
@@ -0,0 +1,101 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExportProductsPayload_Validate(t *testing.T) {
+	checkpoint := (&ProductExportCheckpoint{
+		UpdatedAt: time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC),
+		ID:        "3c1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e",
+	}).String()
+
+	tests := []struct {
+		name           string
+		payload        *ExportProductsPayload
+		wantViolations []string
+	}{
+		{
+			name:           "empty filters",
+			payload:        &ExportProductsPayload{},
+			wantViolations: nil,
+		},
+		{
+			name: "every filter",
+			payload: &ExportProductsPayload{
+				OwnerID:        "3c1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e",
+				UpdatedSince:   "2026-10-19T09:00:00+07:00",
+				IncludeDeleted: true,
+				Checkpoint:     checkpoint,
+			},
+			wantViolations: nil,
+		},
+		{
+			name: "invalid filters",
+			payload: &ExportProductsPayload{
+				OwnerID:      "not-a-uuid",
+				UpdatedSince: "yesterday",
+				Checkpoint:   "not-a-checkpoint",
+			},
+			wantViolations: []string{"owner_id", "updated_since", "checkpoint"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotViolations := violatedFields(t, tt.payload.Validate())
+			if !reflect.DeepEqual(gotViolations, tt.wantViolations) {
+				t.Errorf("ExportProductsPayload.Validate() violations = %v, want %v", gotViolations, tt.wantViolations)
+			}
+		})
+	}
+}
+
+func TestNewProductExportCheckpoint(t *testing.T) {
+	product := &Product{
+		ID:        "3c1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e",
+		UpdatedAt: time.Date(2026, 10, 19, 9, 0, 0, 123456000, time.UTC),
+	}
+
+	tests := []struct {
+		name       string
+		checkpoint string
+		want       *ProductExportCheckpoint
+		wantErr    bool
+	}{
+		{
+			name:       "round trip",
+			checkpoint: product.ExportCheckpoint().String(),
+			want: &ProductExportCheckpoint{
+				UpdatedAt: product.UpdatedAt,
+				ID:        product.ID,
+			},
+			wantErr: false,
+		},
+		{
+			name:       "not base64",
+			checkpoint: "%%%",
+			want:       nil,
+			wantErr:    true,
+		},
+		{
+			name:       "missing id",
+			checkpoint: (&ProductExportCheckpoint{UpdatedAt: product.UpdatedAt}).String(),
+			want:       nil,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewProductExportCheckpoint(tt.checkpoint)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewProductExportCheckpoint() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewProductExportCheckpoint() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,384 @@
+//go:generate mockgen -destination=mock/mock_product_import_job_repository.go -package=mock github.com/krobus00/product-service/internal/model ProductImportJobRepository
+
+package model
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/krobus00/product-service/internal/utils"
+	pb "github.com/krobus00/product-service/pb/product"
+	"gorm.io/gorm"
+)
+
+const (
+	ImportFormatCSV   = "csv"
+	ImportFormatJSONL = "jsonl"
+
+	ImportJobStatusPending   = "PENDING"
+	ImportJobStatusRunning   = "RUNNING"
+	ImportJobStatusCompleted = "COMPLETED"
+	ImportJobStatusFailed    = "FAILED"
+
+	ImportFieldExternalSKU = "external_sku"
+	ImportFieldName        = "name"
+	ImportFieldDescription = "description"
+	ImportFieldPrice       = "price"
+	ImportFieldThumbnailID = "thumbnail_id"
+
+	ExternalSKUMaxLength = 255
+
+	// ImportContentMaxSize is the largest file sent inline, bigger files are uploaded to the storage service first
+	ImportContentMaxSize = 1 << 20
+
+	// ImportJobErrorsLimit is the number of row errors returned with a job, the error report has every stored error
+	ImportJobErrorsLimit = 100
+)
+
+var (
+	// ImportFields are the product fields read from an import file, in the error report order.
+	ImportFields = []string{
+		ImportFieldExternalSKU,
+		ImportFieldName,
+		ImportFieldDescription,
+		ImportFieldPrice,
+		ImportFieldThumbnailID,
+	}
+
+	// ImportFormatExtensions guess the format of a file without an explicit one.
+	ImportFormatExtensions = map[string]string{
+		".csv":    ImportFormatCSV,
+		".jsonl":  ImportFormatJSONL,
+		".ndjson": ImportFormatJSONL,
+	}
+
+	ErrImportJobNotFound    = errors.New("import job not found")
+	ErrImportObjectNotFound = errors.New("import object not found")
+	ErrInvalidImportFile    = errors.New("invalid import file")
+	ErrImportJobInterrupted = errors.New("import job interrupted, rows written before the interruption are kept")
+)
+
+// ImportColumnMapping map a product field to the csv column or json key holding it,
+// fields without a mapping are read from the column named after the field. It is stored as a json column.
+type ImportColumnMapping map[string]string
+
+// Column return the csv column or json key of the field.
+func (m ImportColumnMapping) Column(field string) string {
+	if column, ok := m[field]; ok && column != "" {
+		return column
+	}
+	return field
+}
+
+func (m ImportColumnMapping) Value() (driver.Value, error) {
+	if m == nil {
+		m = ImportColumnMapping{}
+	}
+	return json.Marshal(m)
+}
+
+func (m *ImportColumnMapping) Scan(value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	case nil:
+		*m = ImportColumnMapping{}
+		return nil
+	default:
+		return errors.New("invalid import column mapping")
+	}
+	return json.Unmarshal(data, m)
+}
+
+type ImportProductsPayload struct {
+	OwnerID       string
+	ObjectID      string // storage-service object holding the file
+	FileName      string
+	Content       []byte // the file itself when there is no object
+	Format        string
+	ColumnMapping ImportColumnMapping
+}
+
+func NewImportProductsPayloadFromProto(message *pb.ImportProductsRequest) *ImportProductsPayload {
+	return &ImportProductsPayload{
+		OwnerID:       message.GetOwnerId(),
+		ObjectID:      message.GetObjectId(),
+		FileName:      message.GetFileName(),
+		Content:       message.GetContent(),
+		Format:        message.GetFormat(),
+		ColumnMapping: message.GetColumnMapping(),
+	}
+}
+
+func (m *ImportProductsPayload) Validate() error {
+	v := new(validator)
+	if m.OwnerID != "" {
+		v.uuid("owner_id", m.OwnerID)
+	}
+	switch {
+	case m.ObjectID == "" && len(m.Content) == 0:
+		v.addViolation("object_id", "or content is required")
+	case m.ObjectID != "" && len(m.Content) > 0:
+		v.addViolation("object_id", "can't be set with content")
+	case len(m.Content) > ImportContentMaxSize:
+		v.addViolation("content", fmt.Sprintf("must be at most %d bytes, upload larger files and set object_id", ImportContentMaxSize))
+	case m.ObjectID != "":
+		v.uuid("object_id", m.ObjectID)
+	}
+	if m.Format != "" {
+		validateImportFormat(v, m.Format)
+	}
+	fields := make([]string, 0, len(m.ColumnMapping))
+	for field := range m.ColumnMapping {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		if !isImportField(field) {
+			v.addViolation(fmt.Sprintf("column_mapping[%s]", field), fmt.Sprintf("must be one of %s", strings.Join(ImportFields, ", ")))
+			continue
+		}
+		v.required(fmt.Sprintf("column_mapping[%s]", field), m.ColumnMapping[field])
+	}
+	return v.err()
+}
+
+// ResolveFormat guess the format from the file name when it was not given,
+// fileName is the name of the storage object when the payload has none.
+func (m *ImportProductsPayload) ResolveFormat(fileName string) error {
+	if m.FileName == "" {
+		m.FileName = fileName
+	}
+	if m.Format == "" {
+		m.Format = ImportFormatExtensions[strings.ToLower(filepath.Ext(m.FileName))]
+	}
+	v := new(validator)
+	validateImportFormat(v, m.Format)
+	return v.err()
+}
+
+func validateImportFormat(v *validator, format string) {
+	if format != ImportFormatCSV && format != ImportFormatJSONL {
+		v.addViolation("format", fmt.Sprintf("must be %s or %s", ImportFormatCSV, ImportFormatJSONL))
+	}
+}
+
+func isImportField(field string) bool {
+	for _, importField := range ImportFields {
+		if field == importField {
+			return true
+		}
+	}
+	return false
+}
+
+// ProductImportJob track an import from the request until every row is written,
+// counters are updated after every batch so the progress can be followed.
+type ProductImportJob struct {
+	ID            string
+	OwnerID       string // refer to user_id
+	CreatedBy     string // refer to user_id
+	ObjectID      string // refer to object id, empty when the file was sent as content
+	FileName      string
+	Format        string
+	ColumnMapping ImportColumnMapping `gorm:"type:jsonb"`
+	Status        string
+	ProcessedRows int64
+	CreatedCount  int64
+	UpdatedCount  int64
+	FailedCount   int64
+	ErrorMessage  string
+	StartedAt     *time.Time
+	FinishedAt    *time.Time
+	CreatedAt     time.Time `gorm:"<-:create"` // read and create
+	UpdatedAt     time.Time `gorm:"<-"`        // allow read, create, and update
+}
+
+func (ProductImportJob) TableName() string {
+	return "product_import_jobs"
+}
+
+func NewProductImportJob(payload *ImportProductsPayload, ownerID string, createdBy string) *ProductImportJob {
+	return &ProductImportJob{
+		ID:            utils.GenerateUUID(),
+		OwnerID:       ownerID,
+		CreatedBy:     createdBy,
+		ObjectID:      payload.ObjectID,
+		FileName:      payload.FileName,
+		Format:        payload.Format,
+		ColumnMapping: payload.ColumnMapping,
+		Status:        ImportJobStatusPending,
+	}
+}
+
+// Origin is recorded in the audit logs of the products written by the job.
+func (m *ProductImportJob) Origin() string {
+	return fmt.Sprintf("import:%s", m.ID)
+}
+
+func (m *ProductImportJob) Start() {
+	now := time.Now()
+	m.Status = ImportJobStatusRunning
+	m.StartedAt = &now
+}
+
+// Finish complete the job, or fail it with the error that stopped it.
+func (m *ProductImportJob) Finish(err error) {
+	now := time.Now()
+	m.FinishedAt = &now
+	if err != nil {
+		m.Status = ImportJobStatusFailed
+		m.ErrorMessage = err.Error()
+		return
+	}
+	m.Status = ImportJobStatusCompleted
+}
+
+func (m *ProductImportJob) ToProto() *pb.ImportJob {
+	startedAt := ""
+	if m.StartedAt != nil {
+		startedAt = m.StartedAt.UTC().Format(time.RFC3339Nano)
+	}
+	finishedAt := ""
+	if m.FinishedAt != nil {
+		finishedAt = m.FinishedAt.UTC().Format(time.RFC3339Nano)
+	}
+	return &pb.ImportJob{
+		Id:            m.ID,
+		OwnerId:       m.OwnerID,
+		CreatedBy:     m.CreatedBy,
+		ObjectId:      m.ObjectID,
+		FileName:      m.FileName,
+		Format:        m.Format,
+		ColumnMapping: m.ColumnMapping,
+		Status:        m.Status,
+		ProcessedRows: m.ProcessedRows,
+		CreatedCount:  m.CreatedCount,
+		UpdatedCount:  m.UpdatedCount,
+		FailedCount:   m.FailedCount,
+		ErrorMessage:  m.ErrorMessage,
+		CreatedAt:     m.CreatedAt.UTC().Format(time.RFC3339Nano),
+		StartedAt:     startedAt,
+		FinishedAt:    finishedAt,
+	}
+}
+
+// ProductImportError is an invalid row of an import, a row get one error per invalid field.
+type ProductImportError struct {
+	ID          string
+	JobID       string // refer to product import job id
+	RowNumber   int64
+	ExternalSKU string
+	Field       string // empty when the whole row is invalid
+	Message     string
+}
+
+type ProductImportErrors []*ProductImportError
+
+func (ProductImportError) TableName() string {
+	return "product_import_errors"
+}
+
+// NewProductImportErrors list the field violations of a validation error, other errors are reported for the whole row.
+func NewProductImportErrors(jobID string, row *ImportRow, err error) ProductImportErrors {
+	newError := func(field string, message string) *ProductImportError {
+		return &ProductImportError{
+			ID:          utils.GenerateUUID(),
+			JobID:       jobID,
+			RowNumber:   row.Number,
+			ExternalSKU: row.ExternalSKU(),
+			Field:       field,
+			Message:     message,
+		}
+	}
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		return ProductImportErrors{newError("", err.Error())}
+	}
+
+	results := make(ProductImportErrors, 0, len(validationErr.Violations))
+	for _, violation := range validationErr.Violations {
+		results = append(results, newError(violation.Field, violation.Description))
+	}
+	return results
+}
+
+func (m ProductImportErrors) ToProto() []*pb.ImportRowError {
+	results := make([]*pb.ImportRowError, 0)
+	for _, importError := range m {
+		results = append(results, &pb.ImportRowError{
+			Row:         importError.RowNumber,
+			ExternalSku: importError.ExternalSKU,
+			Field:       importError.Field,
+			Message:     importError.Message,
+		})
+	}
+	return results
+}
+
+// CSV write the error report, one line per row error.
+func (m ProductImportErrors) CSV() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	writer := csv.NewWriter(buf)
+	err := writer.Write([]string{"row", "external_sku", "field", "message"})
+	if err != nil {
+		return nil, err
+	}
+	for _, importError := range m {
+		err := writer.Write([]string{
+			strconv.FormatInt(importError.RowNumber, 10),
+			importError.ExternalSKU,
+			importError.Field,
+			importError.Message,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+type ProductImportJobResponse struct {
+	Job         *ProductImportJob
+	Errors      ProductImportErrors
+	ErrorReport []byte
+}
+
+func (m *ProductImportJobResponse) ToProto() *pb.ImportJob {
+	job := m.Job.ToProto()
+	job.Errors = m.Errors.ToProto()
+	job.ErrorReport = m.ErrorReport
+	return job
+}
+
+type ProductImportJobRepository interface {
+	Create(ctx context.Context, job *ProductImportJob) error
+	Update(ctx context.Context, job *ProductImportJob) error
+	CreateErrors(ctx context.Context, importErrors ProductImportErrors) error
+
+	// Resolver
+	FindByID(ctx context.Context, id string) (*ProductImportJob, error)
+	// FindErrorsByJobID return the errors in row order, every error when limit is not positive
+	FindErrorsByJobID(ctx context.Context, jobID string, limit int) (ProductImportErrors, error)
+
+	// DI
+	InjectDB(db *gorm.DB) error
+}
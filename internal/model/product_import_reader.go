@@ -0,0 +1,234 @@
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-json"
+)
+
+const (
+	ImportJSONLMaxLineSize = 1 << 20
+
+	utf8BOM = "\ufeff"
+)
+
+// ImportRow is a record of an import file with the raw values of the product fields.
+type ImportRow struct {
+	Number int64
+	Values map[string]string
+	// Err is set when the record itself can't be parsed
+	Err error
+}
+
+func (m *ImportRow) ExternalSKU() string {
+	return m.Values[ImportFieldExternalSKU]
+}
+
+// ToPayload validate the row and convert it to the product it describe.
+func (m *ImportRow) ToPayload() (*CreateProductPayload, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+
+	v := new(validator)
+	v.maxLength(ImportFieldExternalSKU, m.ExternalSKU(), ExternalSKUMaxLength)
+
+	price := float64(0)
+	if raw := m.Values[ImportFieldPrice]; raw != "" {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			v.addViolation(ImportFieldPrice, "must be a number")
+		}
+		price = parsed
+	}
+
+	payload := &CreateProductPayload{
+		Name:        m.Values[ImportFieldName],
+		Description: m.Values[ImportFieldDescription],
+		Price:       price,
+		ThumbnailID: m.Values[ImportFieldThumbnailID],
+	}
+	validateProductFields(v, payload.Name, payload.Description, payload.Price, payload.ThumbnailID)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// NewImportFieldError report a field of a row that passed validation but can't be imported.
+func NewImportFieldError(field string, description string) error {
+	return &ValidationError{
+		Violations: []*FieldViolation{
+			{
+				Field:       field,
+				Description: description,
+			},
+		},
+	}
+}
+
+// ImportReader stream the rows of an import file.
+type ImportReader interface {
+	// Read return the next row, or io.EOF once the file is consumed. Records that can't be parsed
+	// are returned as a row with Err set, the error is only returned when the file can't be read further.
+	Read() (*ImportRow, error)
+}
+
+func NewImportReader(format string, r io.Reader, mapping ImportColumnMapping) (ImportReader, error) {
+	switch format {
+	case ImportFormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.ReuseRecord = true
+		return &csvImportReader{
+			reader:  reader,
+			mapping: mapping,
+		}, nil
+	case ImportFormatJSONL:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), ImportJSONLMaxLineSize)
+		return &jsonlImportReader{
+			scanner: scanner,
+			mapping: mapping,
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown format %s", ErrInvalidImportFile, format)
+	}
+}
+
+type csvImportReader struct {
+	reader  *csv.Reader
+	mapping ImportColumnMapping
+	// columns hold the index of every field found in the header
+	columns map[string]int
+	row     int64
+}
+
+func (r *csvImportReader) Read() (*ImportRow, error) {
+	if r.columns == nil {
+		err := r.readHeader()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	record, err := r.reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, io.EOF
+	}
+	r.row++
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return &ImportRow{Number: r.row, Err: fmt.Errorf("invalid csv record: %w", parseErr.Err)}, nil
+		}
+		return nil, err
+	}
+
+	values := make(map[string]string, len(r.columns))
+	for field, index := range r.columns {
+		if index < len(record) {
+			values[field] = strings.TrimSpace(record[index])
+		}
+	}
+	return &ImportRow{Number: r.row, Values: values}, nil
+}
+
+// readHeader fail when a mapped column is missing, unmapped fields are optional.
+func (r *csvImportReader) readHeader() error {
+	header, err := r.reader.Read()
+	if errors.Is(err, io.EOF) {
+		return io.EOF
+	}
+	if err != nil {
+		return fmt.Errorf("%w: header: %v", ErrInvalidImportFile, err)
+	}
+
+	indexes := make(map[string]int, len(header))
+	for index, column := range header {
+		if index == 0 {
+			column = strings.TrimPrefix(column, utf8BOM)
+		}
+		column = strings.TrimSpace(column)
+		if _, ok := indexes[column]; !ok {
+			indexes[column] = index
+		}
+	}
+
+	r.columns = make(map[string]int, len(ImportFields))
+	for _, field := range ImportFields {
+		column := r.mapping.Column(field)
+		index, ok := indexes[column]
+		if ok {
+			r.columns[field] = index
+			continue
+		}
+		if _, mapped := r.mapping[field]; mapped {
+			return fmt.Errorf("%w: column %s mapped to %s not found in the header", ErrInvalidImportFile, column, field)
+		}
+	}
+	return nil
+}
+
+type jsonlImportReader struct {
+	scanner *bufio.Scanner
+	mapping ImportColumnMapping
+	row     int64
+}
+
+func (r *jsonlImportReader) Read() (*ImportRow, error) {
+	for r.scanner.Scan() {
+		r.row++
+		line := bytes.TrimSpace(r.scanner.Bytes())
+		if r.row == 1 {
+			line = bytes.TrimPrefix(line, []byte(utf8BOM))
+		}
+		if len(line) == 0 {
+			continue
+		}
+
+		record := make(map[string]any)
+		err := json.Unmarshal(line, &record)
+		if err != nil {
+			return &ImportRow{Number: r.row, Err: errors.New("invalid json object")}, nil
+		}
+
+		values := make(map[string]string, len(ImportFields))
+		for _, field := range ImportFields {
+			value, ok := record[r.mapping.Column(field)]
+			if ok {
+				values[field] = strings.TrimSpace(jsonImportValue(value))
+			}
+		}
+		return &ImportRow{Number: r.row, Values: values}, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidImportFile, r.row+1, err)
+	}
+	return nil, io.EOF
+}
+
+// jsonImportValue format a json value the way it would be written in a csv column.
+func jsonImportValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
@@ -0,0 +1,315 @@
+package model
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestImportProductsPayload_Validate(t *testing.T) {
+	tests := []struct {
+		name           string
+		payload        *ImportProductsPayload
+		wantViolations []string
+	}{
+		{
+			name: "content",
+			payload: &ImportProductsPayload{
+				Content: []byte("name,price\n"),
+				Format:  ImportFormatCSV,
+				ColumnMapping: ImportColumnMapping{
+					ImportFieldExternalSKU: "SKU",
+				},
+			},
+			wantViolations: nil,
+		},
+		{
+			name: "object",
+			payload: &ImportProductsPayload{
+				OwnerID:  "3c1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e",
+				ObjectID: "5b1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e",
+			},
+			wantViolations: nil,
+		},
+		{
+			name:           "no source",
+			payload:        &ImportProductsPayload{},
+			wantViolations: []string{"object_id"},
+		},
+		{
+			name: "both sources",
+			payload: &ImportProductsPayload{
+				ObjectID: "5b1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e",
+				Content:  []byte("name,price\n"),
+			},
+			wantViolations: []string{"object_id"},
+		},
+		{
+			name: "content too large",
+			payload: &ImportProductsPayload{
+				Content: make([]byte, ImportContentMaxSize+1),
+			},
+			wantViolations: []string{"content"},
+		},
+		{
+			name: "invalid fields",
+			payload: &ImportProductsPayload{
+				OwnerID:  "not-a-uuid",
+				ObjectID: "not-a-uuid",
+				Format:   "xlsx",
+				ColumnMapping: ImportColumnMapping{
+					"stock":         "Stock",
+					ImportFieldName: "",
+				},
+			},
+			wantViolations: []string{"owner_id", "object_id", "format", "column_mapping[name]", "column_mapping[stock]"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotViolations := violatedFields(t, tt.payload.Validate())
+			if !reflect.DeepEqual(gotViolations, tt.wantViolations) {
+				t.Errorf("ImportProductsPayload.Validate() violations = %v, want %v", gotViolations, tt.wantViolations)
+			}
+		})
+	}
+}
+
+func TestImportProductsPayload_ResolveFormat(t *testing.T) {
+	tests := []struct {
+		name           string
+		payload        *ImportProductsPayload
+		objectFileName string
+		wantFormat     string
+		wantErr        bool
+	}{
+		{
+			name:       "explicit format",
+			payload:    &ImportProductsPayload{FileName: "products.txt", Format: ImportFormatJSONL},
+			wantFormat: ImportFormatJSONL,
+			wantErr:    false,
+		},
+		{
+			name:       "from file name",
+			payload:    &ImportProductsPayload{FileName: "Products.CSV"},
+			wantFormat: ImportFormatCSV,
+			wantErr:    false,
+		},
+		{
+			name:           "from object file name",
+			payload:        &ImportProductsPayload{},
+			objectFileName: "products.ndjson",
+			wantFormat:     ImportFormatJSONL,
+			wantErr:        false,
+		},
+		{
+			name:       "unknown extension",
+			payload:    &ImportProductsPayload{FileName: "products.xlsx"},
+			wantFormat: "",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.payload.ResolveFormat(tt.objectFileName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ImportProductsPayload.ResolveFormat() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.payload.Format != tt.wantFormat {
+				t.Errorf("ImportProductsPayload.ResolveFormat() format = %v, want %v", tt.payload.Format, tt.wantFormat)
+			}
+		})
+	}
+}
+
+func readImportRows(t *testing.T, reader ImportReader) ([]*ImportRow, error) {
+	t.Helper()
+	rows := make([]*ImportRow, 0)
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return rows, err
+		}
+		rows = append(rows, row)
+	}
+}
+
+func TestNewImportReader(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		content  string
+		mapping  ImportColumnMapping
+		want     []*ImportRow
+		wantErrs []bool // whether each row has Err set
+		wantErr  error
+	}{
+		{
+			name:   "csv with mapping",
+			format: ImportFormatCSV,
+			content: "\ufeffSKU,Title,price,Notes\n" +
+				"A-1, Coffee ,12.5,ignored\n" +
+				"A-2,\"Tea, green\",3\n",
+			mapping: ImportColumnMapping{
+				ImportFieldExternalSKU: "SKU",
+				ImportFieldName:        "Title",
+			},
+			want: []*ImportRow{
+				{Number: 1, Values: map[string]string{"external_sku": "A-1", "name": "Coffee", "price": "12.5"}},
+				{Number: 2, Values: map[string]string{"external_sku": "A-2", "name": "Tea, green", "price": "3"}},
+			},
+			wantErrs: []bool{false, false},
+		},
+		{
+			name:     "csv invalid record",
+			format:   ImportFormatCSV,
+			content:  "name,price\n\"Coffee,1\nTea,2\n",
+			want:     []*ImportRow{{Number: 1}},
+			wantErrs: []bool{true},
+		},
+		{
+			name:    "csv missing mapped column",
+			format:  ImportFormatCSV,
+			content: "name,price\nCoffee,1\n",
+			mapping: ImportColumnMapping{
+				ImportFieldExternalSKU: "SKU",
+			},
+			want:    []*ImportRow{},
+			wantErr: ErrInvalidImportFile,
+		},
+		{
+			name:     "empty csv",
+			format:   ImportFormatCSV,
+			content:  "",
+			want:     []*ImportRow{},
+			wantErrs: []bool{},
+		},
+		{
+			name:   "jsonl with mapping",
+			format: ImportFormatJSONL,
+			content: "{\"sku\":\"A-1\",\"name\":\"Coffee\",\"price\":12.5,\"thumbnail_id\":null}\n" +
+				"\n" +
+				"not json\n" +
+				"{\"sku\":42,\"name\":\"Tea\"}\n",
+			mapping: ImportColumnMapping{
+				ImportFieldExternalSKU: "sku",
+			},
+			want: []*ImportRow{
+				{Number: 1, Values: map[string]string{"external_sku": "A-1", "name": "Coffee", "price": "12.5", "thumbnail_id": ""}},
+				{Number: 3},
+				{Number: 4, Values: map[string]string{"external_sku": "42", "name": "Tea"}},
+			},
+			wantErrs: []bool{false, true, false},
+		},
+		{
+			name:    "unknown format",
+			format:  "xlsx",
+			want:    nil,
+			wantErr: ErrInvalidImportFile,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := NewImportReader(tt.format, strings.NewReader(tt.content), tt.mapping)
+			var got []*ImportRow
+			if err == nil {
+				got, err = readImportRows(t, reader)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewImportReader() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("NewImportReader() rows = %d, want %d", len(got), len(tt.want))
+				return
+			}
+			for i, row := range got {
+				if (row.Err != nil) != tt.wantErrs[i] {
+					t.Errorf("NewImportReader() row %d error = %v, wantErr %v", i, row.Err, tt.wantErrs[i])
+				}
+				row.Err = nil
+				if !reflect.DeepEqual(row, tt.want[i]) {
+					t.Errorf("NewImportReader() row %d = %v, want %v", i, row, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestImportRow_ToPayload(t *testing.T) {
+	thumbnailID := "5b1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e"
+
+	tests := []struct {
+		name           string
+		row            *ImportRow
+		want           *CreateProductPayload
+		wantViolations []string
+	}{
+		{
+			name: "valid",
+			row: &ImportRow{Number: 1, Values: map[string]string{
+				"external_sku": "A-1",
+				"name":         "Coffee",
+				"price":        "12.5",
+				"thumbnail_id": thumbnailID,
+			}},
+			want: &CreateProductPayload{
+				Name:        "Coffee",
+				Price:       12.5,
+				ThumbnailID: thumbnailID,
+			},
+		},
+		{
+			name: "invalid fields",
+			row: &ImportRow{Number: 1, Values: map[string]string{
+				"external_sku": strings.Repeat("a", ExternalSKUMaxLength+1),
+				"price":        "twelve",
+			}},
+			want:           nil,
+			wantViolations: []string{"external_sku", "price", "name", "thumbnail_id"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.row.ToPayload()
+			gotViolations := violatedFields(t, err)
+			if !reflect.DeepEqual(gotViolations, tt.wantViolations) {
+				t.Errorf("ImportRow.ToPayload() violations = %v, want %v", gotViolations, tt.wantViolations)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ImportRow.ToPayload() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProductImportErrors_CSV(t *testing.T) {
+	row := &ImportRow{Number: 3, Values: map[string]string{"external_sku": "A-3"}}
+	importErrors := NewProductImportErrors("job-1", row, &ValidationError{
+		Violations: []*FieldViolation{
+			{Field: "name", Description: "is required"},
+			{Field: "price", Description: "must be a number, e.g. 12.5"},
+		},
+	})
+	importErrors = append(importErrors, NewProductImportErrors("job-1", &ImportRow{Number: 4}, errors.New("invalid json object"))...)
+
+	got, err := importErrors.CSV()
+	if err != nil {
+		t.Errorf("ProductImportErrors.CSV() error = %v", err)
+		return
+	}
+
+	want := "row,external_sku,field,message\n" +
+		"3,A-3,name,is required\n" +
+		"3,A-3,price,\"must be a number, e.g. 12.5\"\n" +
+		"4,,,invalid json object\n"
+	if string(got) != want {
+		t.Errorf("ProductImportErrors.CSV() = %q, want %q", got, want)
+	}
+}
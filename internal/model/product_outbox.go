@@ -0,0 +1,77 @@
+//go:generate mockgen -destination=mock/mock_product_outbox_repository.go -package=mock github.com/krobus00/product-service/internal/model ProductOutboxRepository
+
+package model
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/goccy/go-json"
+	"gorm.io/gorm"
+)
+
+// ProductOutboxRelayLockKey is hashed to the advisory lock letting a single relay publish at a time,
+// so the change stream keep the order of the outbox.
+const ProductOutboxRelayLockKey = "products:outbox:relay"
+
+// ProductOutboxEvent is a change event written in the transaction of the product write, the worker relay
+// publish it to the change stream then delete it.
+type ProductOutboxEvent struct {
+	ID        int64 `gorm:"primaryKey;autoIncrement"`
+	Subject   string
+	Payload   json.RawMessage `gorm:"type:jsonb"`
+	CreatedAt time.Time
+}
+
+func (ProductOutboxEvent) TableName() string {
+	return "product_outbox_events"
+}
+
+// MsgID let the change stream drop an event published twice, e.g. when the relay stop before deleting it.
+func (m *ProductOutboxEvent) MsgID() string {
+	return fmt.Sprintf("product-outbox-%d", m.ID)
+}
+
+type ProductOutboxEvents []*ProductOutboxEvent
+
+func (m ProductOutboxEvents) IDs() []int64 {
+	ids := make([]int64, 0, len(m))
+	for _, event := range m {
+		ids = append(ids, event.ID)
+	}
+	return ids
+}
+
+// ToOutboxEvents build the change events of the write, a change without product after it is not published.
+func (m ProductChanges) ToOutboxEvents() (ProductOutboxEvents, error) {
+	events := make(ProductOutboxEvents, 0)
+	for _, change := range m {
+		if change.After == nil {
+			continue
+		}
+		payload := NewJSProductChangedPayload(change)
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, &ProductOutboxEvent{
+			Subject: payload.Subject(),
+			Payload: data,
+		})
+	}
+	return events, nil
+}
+
+type ProductOutboxRepository interface {
+	Create(ctx context.Context, events ProductOutboxEvents) error
+	// TryLockRelay take the relay lock until the transaction of ctx end, false when another relay hold it
+	TryLockRelay(ctx context.Context) (bool, error)
+	// FindPending return up to limit events in the order they were written, ctx must carry the transaction
+	// holding the relay lock and deleting them.
+	FindPending(ctx context.Context, limit int) (ProductOutboxEvents, error)
+	DeleteByIDs(ctx context.Context, ids []int64) error
+
+	// DI
+	InjectDB(db *gorm.DB) error
+}
@@ -0,0 +1,88 @@
+//go:generate mockgen -destination=mock/mock_product_ownership_transfer_repository.go -package=mock github.com/krobus00/product-service/internal/model ProductOwnershipTransferRepository
+
+package model
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/krobus00/product-service/internal/utils"
+	pb "github.com/krobus00/product-service/pb/product"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidOwnershipTransfer = errors.New("invalid ownership transfer")
+)
+
+// ProductOwnershipTransfer record who moved a product to another owner and why.
+type ProductOwnershipTransfer struct {
+	ID            string
+	ProductID     string // refer to product id
+	FromOwnerID   string // refer to user_id
+	ToOwnerID     string // refer to user_id
+	TransferredBy string // refer to user_id
+	Reason        string
+	CreatedAt     time.Time `gorm:"<-:create"` // read and create
+}
+
+func (ProductOwnershipTransfer) TableName() string {
+	return "product_ownership_transfers"
+}
+
+type TransferOwnershipPayload struct {
+	ID          string
+	FromOwnerID string
+	ToOwnerID   string
+	Reason      string
+}
+
+func NewTransferOwnershipPayloadFromProto(message *pb.TransferOwnershipRequest) *TransferOwnershipPayload {
+	return &TransferOwnershipPayload{
+		ID:          message.GetId(),
+		FromOwnerID: message.GetFromOwnerId(),
+		ToOwnerID:   message.GetToOwnerId(),
+		Reason:      message.GetReason(),
+	}
+}
+
+// IsBulk check whether all products of the current owner should be transferred.
+func (m *TransferOwnershipPayload) IsBulk() bool {
+	return m.ID == ""
+}
+
+func (m *TransferOwnershipPayload) Validate() error {
+	if m.ToOwnerID == "" {
+		return ErrInvalidOwnershipTransfer
+	}
+	if m.IsBulk() && m.FromOwnerID == "" {
+		return ErrInvalidOwnershipTransfer
+	}
+	if m.FromOwnerID == m.ToOwnerID {
+		return ErrInvalidOwnershipTransfer
+	}
+	return nil
+}
+
+func (m *TransferOwnershipPayload) ToProductOwnershipTransfers(products Products, transferredBy string) []*ProductOwnershipTransfer {
+	transfers := make([]*ProductOwnershipTransfer, 0)
+	for _, product := range products {
+		transfers = append(transfers, &ProductOwnershipTransfer{
+			ID:            utils.GenerateUUID(),
+			ProductID:     product.ID,
+			FromOwnerID:   m.FromOwnerID,
+			ToOwnerID:     m.ToOwnerID,
+			TransferredBy: transferredBy,
+			Reason:        m.Reason,
+		})
+	}
+	return transfers
+}
+
+type ProductOwnershipTransferRepository interface {
+	Create(ctx context.Context, transfers []*ProductOwnershipTransfer) error
+
+	// DI
+	InjectDB(db *gorm.DB) error
+}
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"testing"
+)
+
+func TestTransferOwnershipPayload_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload *TransferOwnershipPayload
+		wantErr error
+	}{
+		{
+			name: "success single product",
+			payload: &TransferOwnershipPayload{
+				ID:        "1fc34a8d-3d77-4f40-acbc-789c06b4fa5d",
+				ToOwnerID: "cd9614c8-112a-4374-9737-eb62cc5d6aef",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "success all products of owner",
+			payload: &TransferOwnershipPayload{
+				FromOwnerID: "5b8f6f0e-0a0e-4c49-9a0c-7c6a3a2b1d00",
+				ToOwnerID:   "cd9614c8-112a-4374-9737-eb62cc5d6aef",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "missing new owner",
+			payload: &TransferOwnershipPayload{
+				ID: "1fc34a8d-3d77-4f40-acbc-789c06b4fa5d",
+			},
+			wantErr: ErrInvalidOwnershipTransfer,
+		},
+		{
+			name: "missing current owner on bulk transfer",
+			payload: &TransferOwnershipPayload{
+				ToOwnerID: "cd9614c8-112a-4374-9737-eb62cc5d6aef",
+			},
+			wantErr: ErrInvalidOwnershipTransfer,
+		},
+		{
+			name: "same owner",
+			payload: &TransferOwnershipPayload{
+				FromOwnerID: "cd9614c8-112a-4374-9737-eb62cc5d6aef",
+				ToOwnerID:   "cd9614c8-112a-4374-9737-eb62cc5d6aef",
+			},
+			wantErr: ErrInvalidOwnershipTransfer,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.payload.Validate(); err != tt.wantErr {
+				t.Errorf("TransferOwnershipPayload.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
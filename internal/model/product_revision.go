@@ -0,0 +1,152 @@
+//go:generate mockgen -destination=mock/mock_product_revision_repository.go -package=mock github.com/krobus00/product-service/internal/model ProductRevisionRepository
+
+package model
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	pb "github.com/krobus00/product-service/pb/product"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrProductRevisionNotFound = errors.New("product revision not found")
+)
+
+// ProductRevision snapshot the full product after a write, revisions are numbered per product starting from 1.
+type ProductRevision struct {
+	ProductID   string `gorm:"primaryKey"` // refer to product id
+	Revision    int64  `gorm:"primaryKey"`
+	Name        string
+	Description string
+	Price       float64
+	ThumbnailID string     // refer to object id
+	OwnerID     string     // refer to user_id
+	DeletedAt   *time.Time // deleted_at of the product, not a soft delete of the revision
+	CreatedBy   string     // refer to user_id
+	CreatedAt   time.Time  `gorm:"<-:create"` // read and create
+}
+
+type ProductRevisions []*ProductRevision
+
+func (ProductRevision) TableName() string {
+	return "product_revisions"
+}
+
+func NewProductRevision(product *Product, actorID string) *ProductRevision {
+	revision := &ProductRevision{
+		ProductID:   product.ID,
+		Name:        product.Name,
+		Description: product.Description,
+		Price:       product.Price,
+		ThumbnailID: product.ThumbnailID,
+		OwnerID:     product.OwnerID,
+		CreatedBy:   actorID,
+	}
+	if product.DeletedAt.Valid {
+		deletedAt := product.DeletedAt.Time
+		revision.DeletedAt = &deletedAt
+	}
+	return revision
+}
+
+// ToProduct rebuild the product as it was at this revision.
+func (m *ProductRevision) ToProduct() *Product {
+	product := &Product{
+		ID:          m.ProductID,
+		Name:        m.Name,
+		Description: m.Description,
+		Price:       m.Price,
+		ThumbnailID: m.ThumbnailID,
+		OwnerID:     m.OwnerID,
+		UpdatedAt:   m.CreatedAt,
+	}
+	if m.DeletedAt != nil {
+		product.DeletedAt = gorm.DeletedAt{Time: *m.DeletedAt, Valid: true}
+	}
+	return product
+}
+
+// ToUpdateProductPayload build the update that bring the product back to this revision.
+func (m *ProductRevision) ToUpdateProductPayload() *UpdateProductPayload {
+	return &UpdateProductPayload{
+		ID:          m.ProductID,
+		Name:        m.Name,
+		Description: m.Description,
+		Price:       m.Price,
+		ThumbnailID: m.ThumbnailID,
+	}
+}
+
+func (m *ProductRevision) ToProto() *pb.ProductRevision {
+	return &pb.ProductRevision{
+		ProductId: m.ProductID,
+		Revision:  m.Revision,
+		Product:   m.ToProduct().ToProto(),
+		CreatedBy: m.CreatedBy,
+		CreatedAt: m.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+func (m ProductRevisions) ToProto() []*pb.ProductRevision {
+	results := make([]*pb.ProductRevision, 0)
+	for _, revision := range m {
+		results = append(results, revision.ToProto())
+	}
+	return results
+}
+
+type ProductRevisionsPayload struct {
+	ProductID string
+	Limit     int
+	Page      int
+}
+
+func NewProductRevisionsPayloadFromProto(message *pb.ListProductRevisionsRequest) *ProductRevisionsPayload {
+	return &ProductRevisionsPayload{
+		ProductID: message.GetId(),
+		Limit:     int(message.GetLimit()),
+		Page:      int(message.GetPage()),
+	}
+}
+
+func (m *ProductRevisionsPayload) Sanitize() *ProductRevisionsPayload {
+	m.Limit, m.Page = sanitizePagination(m.Limit, m.Page)
+	return m
+}
+
+type ProductRevisionsResponse struct {
+	Count   int64
+	MaxPage int64
+	Items   ProductRevisions
+}
+
+func NewProductRevisionsResponse(req *ProductRevisionsPayload, items ProductRevisions, count int64) *ProductRevisionsResponse {
+	return &ProductRevisionsResponse{
+		Count:   count,
+		MaxPage: int64(math.Ceil(float64(count) / float64(req.Limit))),
+		Items:   items,
+	}
+}
+
+func (m *ProductRevisionsResponse) ToProto() *pb.ListProductRevisionsResponse {
+	return &pb.ListProductRevisionsResponse{
+		Count:   m.Count,
+		MaxPage: m.MaxPage,
+		Items:   m.Items.ToProto(),
+	}
+}
+
+type ProductRevisionRepository interface {
+	Create(ctx context.Context, revisions ProductRevisions) error
+
+	// Resolver
+	FindByProductIDAndRevision(ctx context.Context, productID string, revision int64) (*ProductRevision, error)
+	FindPaginatedByProductID(ctx context.Context, req *ProductRevisionsPayload) (revisions ProductRevisions, count int64, err error)
+
+	// DI
+	InjectDB(db *gorm.DB) error
+}
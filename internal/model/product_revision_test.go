@@ -0,0 +1,48 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestProductRevision_ToProduct(t *testing.T) {
+	deletedAt := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		product *Product
+	}{
+		{
+			name: "success",
+			product: &Product{
+				ID:          "1fc34a8d-3d77-4f40-acbc-789c06b4fa5d",
+				Name:        "sample product",
+				Description: "red cotton shirt",
+				Price:       100,
+				ThumbnailID: "7b2b4fd5-4c4b-4bd4-9b0d-8e5a0f1e9e1a",
+				OwnerID:     "cd9614c8-112a-4374-9737-eb62cc5d6aef",
+			},
+		},
+		{
+			name: "success deleted product",
+			product: &Product{
+				ID:          "1fc34a8d-3d77-4f40-acbc-789c06b4fa5d",
+				Name:        "sample product",
+				Description: "red cotton shirt",
+				Price:       100,
+				ThumbnailID: "7b2b4fd5-4c4b-4bd4-9b0d-8e5a0f1e9e1a",
+				OwnerID:     "cd9614c8-112a-4374-9737-eb62cc5d6aef",
+				DeletedAt:   gorm.DeletedAt{Time: deletedAt, Valid: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewProductRevision(tt.product, "SYSTEM").ToProduct(); !reflect.DeepEqual(got, tt.product) {
+				t.Errorf("ProductRevision.ToProduct() = %v, want %v", got, tt.product)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewProductListingSort(t *testing.T) {
+	type args struct {
+		req *PaginationPayload
+	}
+	tests := []struct {
+		name     string
+		args     args
+		wantSort *ProductListingSort
+		wantOk   bool
+	}{
+		{
+			name: "success default newest",
+			args: args{
+				req: &PaginationPayload{},
+			},
+			wantSort: &ProductListingSort{
+				Key:  ProductListingCreatedAtKey,
+				Desc: true,
+			},
+			wantOk: true,
+		},
+		{
+			name: "success cheapest",
+			args: args{
+				req: &PaginationPayload{
+					Sort: []string{"+price"},
+				},
+			},
+			wantSort: &ProductListingSort{
+				Key:  ProductListingPriceKey,
+				Desc: false,
+			},
+			wantOk: true,
+		},
+		{
+			name: "not supported with search",
+			args: args{
+				req: &PaginationPayload{
+					Search: "product",
+				},
+			},
+			wantSort: nil,
+			wantOk:   false,
+		},
+		{
+			name: "not supported with include deleted",
+			args: args{
+				req: &PaginationPayload{
+					IncludeDeleted: true,
+				},
+			},
+			wantSort: nil,
+			wantOk:   false,
+		},
+		{
+			name: "not supported sort column",
+			args: args{
+				req: &PaginationPayload{
+					Sort: []string{"-name"},
+				},
+			},
+			wantSort: nil,
+			wantOk:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSort, gotOk := NewProductListingSort(tt.args.req)
+			if !reflect.DeepEqual(gotSort, tt.wantSort) {
+				t.Errorf("NewProductListingSort() gotSort = %v, want %v", gotSort, tt.wantSort)
+			}
+			if gotOk != tt.wantOk {
+				t.Errorf("NewProductListingSort() gotOk = %v, want %v", gotOk, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestNewProductListingCursor(t *testing.T) {
+	type args struct {
+		cursor string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *ProductListingCursor
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: args{
+				cursor: (&ProductListingCursor{Score: 17.17, ID: "product-a"}).String(),
+			},
+			want: &ProductListingCursor{
+				Score: 17.17,
+				ID:    "product-a",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid encoding",
+			args: args{
+				cursor: "!",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "invalid score",
+			args: args{
+				cursor: "YWJjfHByb2R1Y3QtYQ",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewProductListingCursor(tt.args.cursor)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewProductListingCursor() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewProductListingCursor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsShortSearch(t *testing.T) {
+	tests := []struct {
+		search string
+		want   bool
+	}{
+		{search: "k", want: true},
+		{search: "ko", want: true},
+		{search: "ko-pi a", want: true},
+		{search: "kop", want: false},
+		{search: "a kopi", want: false},
+		{search: "", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.search, func(t *testing.T) {
+			if got := IsShortSearch(tt.search); got != tt.want {
+				t.Errorf("IsShortSearch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
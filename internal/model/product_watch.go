@@ -0,0 +1,146 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/goccy/go-json"
+	pb "github.com/krobus00/product-service/pb/product"
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	ProductEventCreated = "CREATED"
+	ProductEventUpdated = "UPDATED"
+	ProductEventDeleted = "DELETED"
+
+	WatchProductsMaxIDs = 100
+)
+
+var (
+	ErrWatchSequenceExpired = errors.New("watch sequence is no longer retained")
+)
+
+// JSProductChangedPayload is published after every committed product write.
+type JSProductChangedPayload struct {
+	Type    string   `json:"type"`
+	Action  string   `json:"action"`
+	Product *Product `json:"product"`
+	// PreviousOwnerID is set when the write moved the product to another owner
+	PreviousOwnerID string `json:"previousOwnerID,omitempty"`
+}
+
+func NewJSProductChangedPayload(change *ProductChange) *JSProductChangedPayload {
+	payload := &JSProductChangedPayload{
+		Type:    ProductEventUpdated,
+		Action:  change.Action,
+		Product: change.After,
+	}
+	switch change.Action {
+	case ProductAuditActionCreate:
+		payload.Type = ProductEventCreated
+	case ProductAuditActionDelete:
+		payload.Type = ProductEventDeleted
+	}
+	if change.Before != nil && change.After != nil && change.Before.OwnerID != change.After.OwnerID {
+		payload.PreviousOwnerID = change.Before.OwnerID
+	}
+	return payload
+}
+
+func (m *JSProductChangedPayload) Subject() string {
+	switch m.Type {
+	case ProductEventCreated:
+		return ProductCreatedSubject
+	case ProductEventDeleted:
+		return ProductDeletedSubject
+	default:
+		return ProductUpdatedSubject
+	}
+}
+
+// ProductEvent is a change read back from the change stream, Sequence is its stream sequence.
+type ProductEvent struct {
+	Sequence    uint64
+	PublishedAt time.Time
+	JSProductChangedPayload
+}
+
+func NewProductEventFromMsg(msg *nats.Msg) (*ProductEvent, error) {
+	metadata, err := msg.Metadata()
+	if err != nil {
+		return nil, err
+	}
+	event := &ProductEvent{
+		Sequence:    metadata.Sequence.Stream,
+		PublishedAt: metadata.Timestamp,
+	}
+	err = json.Unmarshal(msg.Data, &event.JSProductChangedPayload)
+	if err != nil {
+		return nil, err
+	}
+	if event.Product == nil {
+		return nil, errors.New("product changed event without product")
+	}
+	return event, nil
+}
+
+func (m *ProductEvent) ToProto() *pb.ProductEvent {
+	return &pb.ProductEvent{
+		Sequence:    m.Sequence,
+		Type:        m.Type,
+		Action:      m.Action,
+		Product:     m.Product.ToProto(),
+		PublishedAt: m.PublishedAt.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+type WatchProductsPayload struct {
+	IDs           []string
+	OwnerID       string
+	AfterSequence uint64
+}
+
+func NewWatchProductsPayloadFromProto(message *pb.WatchProductsRequest) *WatchProductsPayload {
+	return &WatchProductsPayload{
+		IDs:           message.GetIds(),
+		OwnerID:       message.GetOwnerId(),
+		AfterSequence: message.GetAfterSequence(),
+	}
+}
+
+func (m *WatchProductsPayload) Validate() error {
+	v := new(validator)
+	if len(m.IDs) > WatchProductsMaxIDs {
+		v.addViolation("ids", fmt.Sprintf("must have at most %d ids", WatchProductsMaxIDs))
+	}
+	for i, id := range m.IDs {
+		v.uuid(fmt.Sprintf("ids[%d]", i), id)
+	}
+	if m.OwnerID != "" {
+		v.uuid("owner_id", m.OwnerID)
+	}
+	return v.err()
+}
+
+// Match check whether the event pass the ids and owner filters, a product transferred away
+// from the owner still match so watchers learn it left.
+func (m *WatchProductsPayload) Match(event *ProductEvent) bool {
+	if len(m.IDs) > 0 && !m.hasID(event.Product.ID) {
+		return false
+	}
+	if m.OwnerID != "" && event.Product.OwnerID != m.OwnerID && event.PreviousOwnerID != m.OwnerID {
+		return false
+	}
+	return true
+}
+
+func (m *WatchProductsPayload) hasID(id string) bool {
+	for _, watchedID := range m.IDs {
+		if watchedID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewJSProductChangedPayload(t *testing.T) {
+	product := &Product{ID: "3c1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e", OwnerID: "owner-a"}
+	transferred := &Product{ID: product.ID, OwnerID: "owner-b"}
+
+	tests := []struct {
+		name        string
+		change      *ProductChange
+		want        *JSProductChangedPayload
+		wantSubject string
+	}{
+		{
+			name:   "create",
+			change: NewProductChange(ProductAuditActionCreate, nil, product),
+			want: &JSProductChangedPayload{
+				Type:    ProductEventCreated,
+				Action:  ProductAuditActionCreate,
+				Product: product,
+			},
+			wantSubject: ProductCreatedSubject,
+		},
+		{
+			name:   "restore",
+			change: NewProductChange(ProductAuditActionRestore, product, product),
+			want: &JSProductChangedPayload{
+				Type:    ProductEventUpdated,
+				Action:  ProductAuditActionRestore,
+				Product: product,
+			},
+			wantSubject: ProductUpdatedSubject,
+		},
+		{
+			name:   "transfer ownership",
+			change: NewProductChange(ProductAuditActionTransferOwnership, product, transferred),
+			want: &JSProductChangedPayload{
+				Type:            ProductEventUpdated,
+				Action:          ProductAuditActionTransferOwnership,
+				Product:         transferred,
+				PreviousOwnerID: "owner-a",
+			},
+			wantSubject: ProductUpdatedSubject,
+		},
+		{
+			name:   "delete",
+			change: NewProductChange(ProductAuditActionDelete, product, product),
+			want: &JSProductChangedPayload{
+				Type:    ProductEventDeleted,
+				Action:  ProductAuditActionDelete,
+				Product: product,
+			},
+			wantSubject: ProductDeletedSubject,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewJSProductChangedPayload(tt.change)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewJSProductChangedPayload() = %v, want %v", got, tt.want)
+			}
+			if got.Subject() != tt.wantSubject {
+				t.Errorf("JSProductChangedPayload.Subject() = %v, want %v", got.Subject(), tt.wantSubject)
+			}
+		})
+	}
+}
+
+func TestWatchProductsPayload_Match(t *testing.T) {
+	event := &ProductEvent{
+		JSProductChangedPayload: JSProductChangedPayload{
+			Type:            ProductEventUpdated,
+			Product:         &Product{ID: "3c1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e", OwnerID: "owner-b"},
+			PreviousOwnerID: "owner-a",
+		},
+	}
+
+	tests := []struct {
+		name    string
+		payload *WatchProductsPayload
+		want    bool
+	}{
+		{
+			name:    "no filter",
+			payload: &WatchProductsPayload{},
+			want:    true,
+		},
+		{
+			name:    "watched id",
+			payload: &WatchProductsPayload{IDs: []string{"3c1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e"}},
+			want:    true,
+		},
+		{
+			name:    "other id",
+			payload: &WatchProductsPayload{IDs: []string{"5d2c7a0e-6a36-4c59-9d0b-1a7bfa3d2f10"}},
+			want:    false,
+		},
+		{
+			name:    "current owner",
+			payload: &WatchProductsPayload{OwnerID: "owner-b"},
+			want:    true,
+		},
+		{
+			name:    "previous owner",
+			payload: &WatchProductsPayload{OwnerID: "owner-a"},
+			want:    true,
+		},
+		{
+			name:    "other owner",
+			payload: &WatchProductsPayload{OwnerID: "owner-c"},
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.payload.Match(event); got != tt.want {
+				t.Errorf("WatchProductsPayload.Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+//go:generate mockgen -destination=mock/mock_rate_limiter.go -package=mock github.com/krobus00/product-service/internal/model RateLimiter
+
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	RateLimiterModeLocal = "local"
+	RateLimiterModeRedis = "redis"
+)
+
+var (
+	ErrRateLimited = errors.New("rate limit exceeded")
+)
+
+// RateLimit allow Rate requests per second with bursts up to Burst, a Rate of 0 disable the limit.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+func (m RateLimit) Disabled() bool {
+	return m.Rate <= 0 || m.Burst <= 0
+}
+
+// RefillDuration is the time an empty bucket take to be full again.
+func (m RateLimit) RefillDuration() time.Duration {
+	return time.Duration(float64(m.Burst) / m.Rate * float64(time.Second))
+}
+
+// NewRateLimitKey identify the bucket of a caller for a method.
+func NewRateLimitKey(subject string, method string) string {
+	return fmt.Sprintf("rate_limit:%s:%s", subject, method)
+}
+
+// RateLimiter is a token bucket per key, retryAfter tell when the next token is available once the bucket is empty.
+type RateLimiter interface {
+	Allow(ctx context.Context, key string, limit RateLimit) (allowed bool, retryAfter time.Duration, err error)
+}
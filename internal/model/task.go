@@ -2,9 +2,16 @@ package model
 
 const (
 	TaskProductUpdateThumbnail = "product:updateThumbnail"
+	TaskProductImport          = "product:import"
+	TaskProductCatalogExport   = "product:catalogExport" // the payload is a CatalogExportPayload
 )
 
 type TaskUpdateThumbnailPayload struct {
 	OldObjectID string `json:"oldObjectID"`
 	NewObjectID string `json:"newObjectID"`
 }
+
+type TaskImportProductsPayload struct {
+	JobID   string `json:"jobID"`
+	Content []byte `json:"content,omitempty"` // the file sent with the request, empty when the job read a storage object
+}
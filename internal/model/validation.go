@@ -0,0 +1,92 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ProductNameMaxLength        = 255
+	ProductDescriptionMaxLength = 5000
+	ProductPriceMax             = float64(1_000_000_000)
+	SearchMaxLength             = 255
+	HighlightTagMaxLength       = 32
+)
+
+var (
+	ErrInvalidArgument = errors.New("invalid argument")
+)
+
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// ValidationError list every invalid field of a payload, errors.Is match it with ErrInvalidArgument.
+type ValidationError struct {
+	Violations []*FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	descriptions := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		descriptions = append(descriptions, fmt.Sprintf("%s %s", violation.Field, violation.Description))
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidArgument.Error(), strings.Join(descriptions, ", "))
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidArgument
+}
+
+// validator collect the violations of a payload so the caller can fix all fields at once.
+type validator struct {
+	violations []*FieldViolation
+}
+
+func (v *validator) addViolation(field string, description string) {
+	v.violations = append(v.violations, &FieldViolation{
+		Field:       field,
+		Description: description,
+	})
+}
+
+func (v *validator) required(field string, value string) bool {
+	if strings.TrimSpace(value) == "" {
+		v.addViolation(field, "is required")
+		return false
+	}
+	return true
+}
+
+func (v *validator) maxLength(field string, value string, max int) {
+	if utf8.RuneCountInString(value) > max {
+		v.addViolation(field, fmt.Sprintf("must be at most %d characters", max))
+	}
+}
+
+func (v *validator) uuid(field string, value string) {
+	if _, err := uuid.Parse(value); err != nil || len(value) != 36 {
+		v.addViolation(field, "must be a valid uuid")
+	}
+}
+
+func (v *validator) between(field string, value float64, min float64, max float64) {
+	if math.IsNaN(value) || value < min || value > max {
+		v.addViolation(field, fmt.Sprintf("must be between %v and %v", min, max))
+	}
+}
+
+func (v *validator) err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+	return &ValidationError{
+		Violations: v.violations,
+	}
+}
@@ -0,0 +1,173 @@
+package model
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCreateProductPayload_Validate(t *testing.T) {
+	tests := []struct {
+		name           string
+		payload        *CreateProductPayload
+		wantViolations []string
+	}{
+		{
+			name: "success",
+			payload: &CreateProductPayload{
+				Name:        "new product",
+				Description: "product description",
+				Price:       17.17,
+				ThumbnailID: "3c1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e",
+			},
+			wantViolations: nil,
+		},
+		{
+			name: "empty payload",
+			payload: &CreateProductPayload{
+				Name: "   ",
+			},
+			wantViolations: []string{"name", "thumbnail_id"},
+		},
+		{
+			name: "out of bounds",
+			payload: &CreateProductPayload{
+				Name:        strings.Repeat("a", ProductNameMaxLength+1),
+				Description: strings.Repeat("a", ProductDescriptionMaxLength+1),
+				Price:       -1,
+				ThumbnailID: "not-a-uuid",
+			},
+			wantViolations: []string{"name", "description", "price", "thumbnail_id"},
+		},
+		{
+			name: "price is not a number",
+			payload: &CreateProductPayload{
+				Name:        "new product",
+				Price:       math.NaN(),
+				ThumbnailID: "3c1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e",
+			},
+			wantViolations: []string{"price"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotViolations := violatedFields(t, tt.payload.Validate())
+			if !reflect.DeepEqual(gotViolations, tt.wantViolations) {
+				t.Errorf("CreateProductPayload.Validate() violations = %v, want %v", gotViolations, tt.wantViolations)
+			}
+		})
+	}
+}
+
+func TestUpdateProductPayload_Validate(t *testing.T) {
+	tests := []struct {
+		name           string
+		payload        *UpdateProductPayload
+		wantViolations []string
+	}{
+		{
+			name: "success",
+			payload: &UpdateProductPayload{
+				ID:          "1fc34a8d-3d77-4f40-acbc-789c06b4fa5d",
+				Name:        "updated product",
+				Price:       0,
+				ThumbnailID: "3c1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e",
+			},
+			wantViolations: nil,
+		},
+		{
+			name: "missing id",
+			payload: &UpdateProductPayload{
+				Name:        "updated product",
+				ThumbnailID: "3c1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e",
+			},
+			wantViolations: []string{"id"},
+		},
+		{
+			name: "invalid id and price",
+			payload: &UpdateProductPayload{
+				ID:          "{1fc34a8d-3d77-4f40-acbc-789c06b4fa5d}",
+				Name:        "updated product",
+				Price:       ProductPriceMax + 1,
+				ThumbnailID: "3c1f1f5a-3f1f-4b55-9f0e-2f0d4fbd4c1e",
+			},
+			wantViolations: []string{"id", "price"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotViolations := violatedFields(t, tt.payload.Validate())
+			if !reflect.DeepEqual(gotViolations, tt.wantViolations) {
+				t.Errorf("UpdateProductPayload.Validate() violations = %v, want %v", gotViolations, tt.wantViolations)
+			}
+		})
+	}
+}
+
+func TestPaginationPayload_Validate(t *testing.T) {
+	tests := []struct {
+		name           string
+		payload        *PaginationPayload
+		wantViolations []string
+	}{
+		{
+			name:           "success defaults",
+			payload:        &PaginationPayload{},
+			wantViolations: nil,
+		},
+		{
+			name: "success max limit",
+			payload: &PaginationPayload{
+				Limit: maxPaginationLimit,
+				Page:  3,
+			},
+			wantViolations: nil,
+		},
+		{
+			name: "out of bounds",
+			payload: &PaginationPayload{
+				Limit:           maxPaginationLimit + 1,
+				Page:            -1,
+				Search:          strings.Repeat("a", SearchMaxLength+1),
+				HighlightPreTag: strings.Repeat("a", HighlightTagMaxLength+1),
+			},
+			wantViolations: []string{"page", "search", "highlight_pre_tag"},
+		},
+		{
+			name: "negative limit is left to sanitize",
+			payload: &PaginationPayload{
+				Limit: -1,
+			},
+			wantViolations: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotViolations := violatedFields(t, tt.payload.Validate())
+			if !reflect.DeepEqual(gotViolations, tt.wantViolations) {
+				t.Errorf("PaginationPayload.Validate() violations = %v, want %v", gotViolations, tt.wantViolations)
+			}
+		})
+	}
+}
+
+func violatedFields(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Fatalf("error %v is not an invalid argument", err)
+	}
+	validationErr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("error %v is not a validation error", err)
+	}
+	fields := make([]string, 0)
+	for _, violation := range validationErr.Violations {
+		fields = append(fields, violation.Field)
+	}
+	return fields
+}
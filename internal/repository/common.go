@@ -3,7 +3,6 @@ package repository
 import (
 	"context"
 	"errors"
-	"fmt"
 	"regexp"
 	"strings"
 
@@ -22,19 +21,22 @@ func WithPagination(page int, limit int) func(db *gorm.DB) *gorm.DB {
 	}
 }
 
+var searchPatternEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// WithSearch keep the rows where any column contain value, the LIKE wildcards of value are matched literally.
 func WithSearch(value string, columns []string) func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
-		if value == "" {
+		if value == "" || len(columns) == 0 {
 			return db
 		}
-		query := ""
-		for i, column := range columns {
-			query += fmt.Sprintf("%s LIKE '%%%s%%'", column, value)
-			if i < len(columns)-1 {
-				query += " OR "
-			}
+		pattern := "%" + searchPatternEscaper.Replace(value) + "%"
+		conditions := make([]string, 0, len(columns))
+		args := make([]any, 0, len(columns))
+		for _, column := range columns {
+			conditions = append(conditions, column+" ILIKE ?")
+			args = append(args, pattern)
 		}
-		db.Where(query)
+		db.Where("("+strings.Join(conditions, " OR ")+")", args...)
 		return db
 	}
 }
@@ -61,6 +63,16 @@ func WithDeleted(includeDeleted bool) func(db *gorm.DB) *gorm.DB {
 	}
 }
 
+// WithOwnedOrShared keep the products owned by the user or shared with it through a collaborator role.
+func WithOwnedOrShared(ownedOrShared bool, userID string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if ownedOrShared {
+			db.Where("(owner_id = ? OR id IN (SELECT product_id FROM product_collaborators WHERE user_id = ?))", userID, userID)
+		}
+		return db
+	}
+}
+
 func HSetWithExpiry(ctx context.Context, redisClient *redis.Client, bucketCacheKey string, field string, data any) error {
 	if config.DisableCaching() {
 		return nil
@@ -102,22 +114,20 @@ func Get(ctx context.Context, redisClient *redis.Client, cacheKey string) ([]byt
 	}
 	cachedData, err := redisClient.Get(ctx, cacheKey).Bytes()
 	if err != nil && !errors.Is(err, redis.Nil) {
-		logrus.WithField("cacheKey", cacheKey).Error(err.Error())
+		logrus.WithContext(ctx).WithField("cacheKey", cacheKey).Error(err.Error())
 		return nil, err
 	}
 	return cachedData, nil
 }
 
 func DeleteByKeys(ctx context.Context, redisClient *redis.Client, cacheKeys []string) error {
-	if config.DisableCaching() {
+	if config.DisableCaching() || len(cacheKeys) == 0 {
 		return nil
 	}
-	for _, cacheKey := range cacheKeys {
-		err := redisClient.Del(ctx, cacheKey).Err()
-		if err != nil && !errors.Is(err, redis.Nil) {
-			logrus.WithField("cacheKey", cacheKey).Error(err.Error())
-			return err
-		}
+	err := redisClient.Del(ctx, cacheKeys...).Err()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		logrus.WithContext(ctx).WithField("cacheKeys", cacheKeys).Error(err.Error())
+		return err
 	}
 	return nil
 }
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type idempotencyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyRepository() model.IdempotencyRepository {
+	return new(idempotencyRepository)
+}
+
+func (r *idempotencyRepository) FindByKey(ctx context.Context, key string) (*model.IdempotencyRecord, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	record := new(model.IdempotencyRecord)
+	err := db.WithContext(ctx).
+		Where("key = ? AND expires_at > ?", key, time.Now()).
+		First(record).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		log.WithContext(ctx).WithField("key", key).Error(err.Error())
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func (r *idempotencyRepository) Create(ctx context.Context, record *model.IdempotencyRecord) (created bool, err error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	now := time.Now()
+	record.CreatedAt = now
+	res := db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"request_hash", "response", "expires_at", "created_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "idempotency_keys.expires_at <= ?", Vars: []any{now}},
+		}},
+	}).Create(record)
+	if res.Error != nil {
+		log.WithContext(ctx).WithField("key", record.Key).Error(res.Error.Error())
+		return false, res.Error
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+func (r *idempotencyRepository) DeleteExpired(ctx context.Context, limit int) (deleted int64, err error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	res := db.WithContext(ctx).
+		Where("key IN (?)", db.Model(&model.IdempotencyRecord{}).
+			Select("key").
+			Where("expires_at <= ?", time.Now()).
+			Limit(limit)).
+		Delete(&model.IdempotencyRecord{})
+	if res.Error != nil {
+		log.WithContext(ctx).Error(res.Error.Error())
+		return 0, res.Error
+	}
+
+	return res.RowsAffected, nil
+}
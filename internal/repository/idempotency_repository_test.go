@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+)
+
+func newIdempotencyRepoMock() (model.IdempotencyRepository, sqlmock.Sqlmock) {
+	db, sqlMock := utils.NewDBMock()
+	idempotencyRepo := NewIdempotencyRepository()
+	err := idempotencyRepo.InjectDB(db)
+	utils.ContinueOrFatal(err)
+
+	return idempotencyRepo, sqlMock
+}
+
+func Test_idempotencyRepository_FindByKey(t *testing.T) {
+	key := model.NewIdempotencyKey(utils.GenerateUUID(), model.IdempotencyOperationCreate, "create-1")
+	expiresAt := time.Now().Add(time.Hour)
+	type mockSelect struct {
+		record *model.IdempotencyRecord
+		err    error
+	}
+	tests := []struct {
+		name       string
+		mockSelect *mockSelect
+		want       *model.IdempotencyRecord
+		wantErr    bool
+	}{
+		{
+			name: "found",
+			mockSelect: &mockSelect{
+				record: &model.IdempotencyRecord{
+					Key:         key,
+					RequestHash: "hash",
+					Response:    []byte(`{"id":"1"}`),
+					ExpiresAt:   expiresAt,
+				},
+			},
+			want: &model.IdempotencyRecord{
+				Key:         key,
+				RequestHash: "hash",
+				Response:    []byte(`{"id":"1"}`),
+				ExpiresAt:   expiresAt,
+			},
+			wantErr: false,
+		},
+		{
+			name:       "not found or expired",
+			mockSelect: &mockSelect{},
+			want:       nil,
+			wantErr:    false,
+		},
+		{
+			name: "db error",
+			mockSelect: &mockSelect{
+				err: errors.New("db error"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, dbMock := newIdempotencyRepoMock()
+			rows := sqlmock.NewRows([]string{"key", "request_hash", "response", "expires_at", "created_at"})
+			if tt.mockSelect.record != nil {
+				record := tt.mockSelect.record
+				rows.AddRow(record.Key, record.RequestHash, []byte(record.Response), record.ExpiresAt, record.CreatedAt)
+			}
+			dbMock.ExpectQuery("^SELECT \\* FROM \"idempotency_keys\" WHERE key = .+ AND expires_at > .+ ORDER BY").
+				WithArgs(key, sqlmock.AnyArg()).
+				WillReturnRows(rows).
+				WillReturnError(tt.mockSelect.err)
+
+			got, err := r.FindByKey(context.TODO(), key)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("idempotencyRepository.FindByKey() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("idempotencyRepository.FindByKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_idempotencyRepository_Create(t *testing.T) {
+	key := model.NewIdempotencyKey(utils.GenerateUUID(), model.IdempotencyOperationCreate, "create-1")
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		insertErr    error
+		want         bool
+		wantErr      bool
+	}{
+		{
+			name:         "created",
+			rowsAffected: 1,
+			want:         true,
+			wantErr:      false,
+		},
+		{
+			name:         "key taken by a record not expired",
+			rowsAffected: 0,
+			want:         false,
+			wantErr:      false,
+		},
+		{
+			name:      "db error",
+			insertErr: errors.New("db error"),
+			want:      false,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, dbMock := newIdempotencyRepoMock()
+			dbMock.ExpectBegin()
+			expectInsert := dbMock.ExpectExec("^INSERT INTO \"idempotency_keys\" .+ ON CONFLICT \\(\"key\"\\) DO UPDATE SET .+ WHERE idempotency_keys.expires_at <= ").
+				WithArgs(key, "hash", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg())
+			if tt.insertErr != nil {
+				expectInsert.WillReturnError(tt.insertErr)
+				dbMock.ExpectRollback()
+			} else {
+				expectInsert.WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+				dbMock.ExpectCommit()
+			}
+
+			var got bool
+			err := utils.RunInTx(context.TODO(), r.(*idempotencyRepository).db, func(txCtx context.Context) error {
+				var err error
+				got, err = r.Create(txCtx, &model.IdempotencyRecord{
+					Key:         key,
+					RequestHash: "hash",
+					Response:    []byte(`{"id":"1"}`),
+					ExpiresAt:   time.Now().Add(time.Hour),
+				})
+				return err
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("idempotencyRepository.Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("idempotencyRepository.Create() = %v, want %v", got, tt.want)
+			}
+			if err := dbMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("idempotencyRepository.Create() %v", err)
+			}
+		})
+	}
+}
+
+func Test_idempotencyRepository_DeleteExpired(t *testing.T) {
+	r, dbMock := newIdempotencyRepoMock()
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec("^DELETE FROM \"idempotency_keys\" WHERE key IN \\(SELECT \"key\" FROM \"idempotency_keys\" WHERE expires_at <= .+ LIMIT 10\\)").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	dbMock.ExpectCommit()
+
+	got, err := r.DeleteExpired(context.TODO(), 10)
+	if err != nil {
+		t.Errorf("idempotencyRepository.DeleteExpired() error = %v", err)
+		return
+	}
+	if got != 3 {
+		t.Errorf("idempotencyRepository.DeleteExpired() = %v, want %v", got, 3)
+	}
+	if err := dbMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("idempotencyRepository.DeleteExpired() %v", err)
+	}
+}
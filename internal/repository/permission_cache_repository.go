@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/krobus00/product-service/internal/config"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+type permissionCacheRepository struct {
+	redisClient *redis.Client
+}
+
+func NewPermissionCacheRepository() model.PermissionCacheRepository {
+	return new(permissionCacheRepository)
+}
+
+func (r *permissionCacheRepository) Get(ctx context.Context, userID string, permissions []string) (allowed bool, found bool, err error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	cacheKey := model.NewPermissionCacheKey(userID, permissions)
+	allowed, err = r.redisClient.Get(ctx, cacheKey).Bool()
+	if errors.Is(err, redis.Nil) {
+		return false, false, nil
+	}
+	if err != nil {
+		log.WithContext(ctx).WithField("cacheKey", cacheKey).Error(err.Error())
+		return false, false, err
+	}
+
+	return allowed, true, nil
+}
+
+func (r *permissionCacheRepository) Set(ctx context.Context, userID string, permissions []string, allowed bool) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	cacheKey := model.NewPermissionCacheKey(userID, permissions)
+	err := r.redisClient.Set(ctx, cacheKey, allowed, config.AuthPermissionCacheTTL()).Err()
+	if err != nil {
+		log.WithContext(ctx).WithField("cacheKey", cacheKey).Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (r *permissionCacheRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	return r.deleteByPattern(ctx, model.NewPermissionCacheUserPattern(userID))
+}
+
+func (r *permissionCacheRepository) DeleteAll(ctx context.Context) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	return r.deleteByPattern(ctx, model.PermissionCacheKeyPattern)
+}
+
+func (r *permissionCacheRepository) deleteByPattern(ctx context.Context, pattern string) error {
+	iter := r.redisClient.Scan(ctx, 0, pattern, 0).Iterator()
+	for iter.Next(ctx) {
+		err := r.redisClient.Del(ctx, iter.Val()).Err()
+		if err != nil {
+			log.WithContext(ctx).WithField("cacheKey", iter.Val()).Error(err.Error())
+			return err
+		}
+	}
+	if err := iter.Err(); err != nil {
+		log.WithContext(ctx).WithField("pattern", pattern).Error(err.Error())
+		return err
+	}
+
+	return nil
+}
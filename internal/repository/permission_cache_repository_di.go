@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/go-redis/redis/v8"
+)
+
+func (r *permissionCacheRepository) InjectRedisClient(client *redis.Client) error {
+	if client == nil {
+		return errors.New("invalid redis client")
+	}
+	r.redisClient = client
+	return nil
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/krobus00/product-service/internal/config"
+	"github.com/krobus00/product-service/internal/infrastructure"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+	"github.com/spf13/viper"
+)
+
+func newPermissionCacheRepoMock(t *testing.T) (model.PermissionCacheRepository, *miniredis.Miniredis) {
+	miniRedis := miniredis.RunT(t)
+	viper.Set("redis.cache_host", fmt.Sprintf("redis://%s", miniRedis.Addr()))
+	redisClient, err := infrastructure.NewRedisClient()
+	utils.ContinueOrFatal(err)
+	permissionCacheRepo := NewPermissionCacheRepository()
+	err = permissionCacheRepo.InjectRedisClient(redisClient)
+	utils.ContinueOrFatal(err)
+
+	return permissionCacheRepo, miniRedis
+}
+
+func Test_permissionCacheRepository_Get(t *testing.T) {
+	userID := utils.GenerateUUID()
+	permissions := []string{"PRODUCT_ALL", "PRODUCT_READ", "FULL_ACCESS"}
+
+	type args struct {
+		userID      string
+		permissions []string
+	}
+	type mockCache struct {
+		allowed bool
+	}
+	tests := []struct {
+		name        string
+		args        args
+		mockCache   *mockCache
+		wantAllowed bool
+		wantFound   bool
+		wantErr     bool
+	}{
+		{
+			name: "allowed from cache",
+			args: args{
+				userID:      userID,
+				permissions: []string{"FULL_ACCESS", "PRODUCT_READ", "PRODUCT_ALL"},
+			},
+			mockCache:   &mockCache{allowed: true},
+			wantAllowed: true,
+			wantFound:   true,
+			wantErr:     false,
+		},
+		{
+			name: "denied from cache",
+			args: args{
+				userID:      userID,
+				permissions: permissions,
+			},
+			mockCache:   &mockCache{allowed: false},
+			wantAllowed: false,
+			wantFound:   true,
+			wantErr:     false,
+		},
+		{
+			name: "not found",
+			args: args{
+				userID:      userID,
+				permissions: permissions,
+			},
+			wantAllowed: false,
+			wantFound:   false,
+			wantErr:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := newPermissionCacheRepoMock(t)
+
+			if tt.mockCache != nil {
+				err := r.Set(context.TODO(), userID, permissions, tt.mockCache.allowed)
+				utils.ContinueOrFatal(err)
+			}
+
+			gotAllowed, gotFound, err := r.Get(context.TODO(), tt.args.userID, tt.args.permissions)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("permissionCacheRepository.Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotAllowed != tt.wantAllowed {
+				t.Errorf("permissionCacheRepository.Get() gotAllowed = %v, want %v", gotAllowed, tt.wantAllowed)
+			}
+			if gotFound != tt.wantFound {
+				t.Errorf("permissionCacheRepository.Get() gotFound = %v, want %v", gotFound, tt.wantFound)
+			}
+		})
+	}
+}
+
+func Test_permissionCacheRepository_Set(t *testing.T) {
+	userID := utils.GenerateUUID()
+	permissions := []string{"PRODUCT_ALL", "PRODUCT_READ", "FULL_ACCESS"}
+
+	r, miniRedis := newPermissionCacheRepoMock(t)
+
+	err := r.Set(context.TODO(), userID, permissions, true)
+	if err != nil {
+		t.Errorf("permissionCacheRepository.Set() error = %v", err)
+		return
+	}
+
+	cacheKey := model.NewPermissionCacheKey(userID, permissions)
+	if ttl := miniRedis.TTL(cacheKey); ttl != config.AuthPermissionCacheTTL() {
+		t.Errorf("permissionCacheRepository.Set() ttl = %v, want %v", ttl, config.AuthPermissionCacheTTL())
+	}
+
+	miniRedis.FastForward(config.AuthPermissionCacheTTL())
+	if miniRedis.Exists(cacheKey) {
+		t.Errorf("permissionCacheRepository.Set() cache should expire")
+	}
+}
+
+func Test_permissionCacheRepository_DeleteByUserID(t *testing.T) {
+	userID := utils.GenerateUUID()
+	otherUserID := utils.GenerateUUID()
+	permissions := []string{"PRODUCT_READ", "FULL_ACCESS"}
+
+	r, miniRedis := newPermissionCacheRepoMock(t)
+
+	utils.ContinueOrFatal(r.Set(context.TODO(), userID, permissions, true))
+	utils.ContinueOrFatal(r.Set(context.TODO(), userID, []string{"PRODUCT_UPDATE", "FULL_ACCESS"}, false))
+	utils.ContinueOrFatal(r.Set(context.TODO(), otherUserID, permissions, true))
+
+	err := r.DeleteByUserID(context.TODO(), userID)
+	if err != nil {
+		t.Errorf("permissionCacheRepository.DeleteByUserID() error = %v", err)
+		return
+	}
+
+	keys := miniRedis.Keys()
+	if len(keys) != 1 || keys[0] != model.NewPermissionCacheKey(otherUserID, permissions) {
+		t.Errorf("permissionCacheRepository.DeleteByUserID() keys = %v", keys)
+	}
+}
+
+func Test_permissionCacheRepository_DeleteAll(t *testing.T) {
+	permissions := []string{"PRODUCT_READ", "FULL_ACCESS"}
+
+	r, miniRedis := newPermissionCacheRepoMock(t)
+
+	utils.ContinueOrFatal(r.Set(context.TODO(), utils.GenerateUUID(), permissions, true))
+	utils.ContinueOrFatal(r.Set(context.TODO(), utils.GenerateUUID(), permissions, false))
+	utils.ContinueOrFatal(miniRedis.Set("product:other", "value"))
+
+	err := r.DeleteAll(context.TODO())
+	if err != nil {
+		t.Errorf("permissionCacheRepository.DeleteAll() error = %v", err)
+		return
+	}
+
+	keys := miniRedis.Keys()
+	if len(keys) != 1 || keys[0] != "product:other" {
+		t.Errorf("permissionCacheRepository.DeleteAll() keys = %v", keys)
+	}
+}
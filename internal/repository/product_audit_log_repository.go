@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type productAuditLogRepository struct {
+	db *gorm.DB
+}
+
+func NewProductAuditLogRepository() model.ProductAuditLogRepository {
+	return new(productAuditLogRepository)
+}
+
+func (r *productAuditLogRepository) Create(ctx context.Context, logs model.ProductAuditLogs) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	if len(logs) == 0 {
+		return nil
+	}
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"total": len(logs),
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	err := db.WithContext(ctx).Create(&logs).Error
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (r *productAuditLogRepository) FindPaginatedByProductID(ctx context.Context, req *model.ProductHistoryPayload) (logs model.ProductAuditLogs, count int64, err error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"productID": req.ProductID,
+		"page":      req.Page,
+		"limit":     req.Limit,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	err = db.WithContext(ctx).
+		Model(&model.ProductAuditLog{}).
+		Where("product_id = ?", req.ProductID).
+		Count(&count).Error
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, 0, err
+	}
+
+	logs = make(model.ProductAuditLogs, 0)
+	err = db.WithContext(ctx).
+		Scopes(WithPagination(req.Page, req.Limit)).
+		Where("product_id = ?", req.ProductID).
+		Order("created_at DESC").
+		Find(&logs).Error
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, 0, err
+	}
+
+	return logs, count, nil
+}
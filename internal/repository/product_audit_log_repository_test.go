@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+)
+
+func newProductAuditLogRepoMock() (model.ProductAuditLogRepository, sqlmock.Sqlmock) {
+	db, sqlMock := utils.NewDBMock()
+	auditLogRepo := NewProductAuditLogRepository()
+	err := auditLogRepo.InjectDB(db)
+	utils.ContinueOrFatal(err)
+
+	return auditLogRepo, sqlMock
+}
+
+func Test_productAuditLogRepository_Create(t *testing.T) {
+	log := model.NewProductAuditLog(model.ProductAuditActionUpdate, utils.GenerateUUID(), "/pb.product.ProductService/Update",
+		&model.Product{ID: utils.GenerateUUID(), Price: 100},
+		&model.Product{ID: utils.GenerateUUID(), Price: 110},
+	)
+	tests := []struct {
+		name    string
+		logs    model.ProductAuditLogs
+		mockErr error
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			logs:    model.ProductAuditLogs{log},
+			mockErr: nil,
+			wantErr: false,
+		},
+		{
+			name:    "success without log",
+			logs:    model.ProductAuditLogs{},
+			wantErr: false,
+		},
+		{
+			name:    "db error",
+			logs:    model.ProductAuditLogs{log},
+			mockErr: errors.New("db error"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, dbMock := newProductAuditLogRepoMock()
+
+			if len(tt.logs) > 0 {
+				dbMock.ExpectBegin()
+				dbMock.ExpectExec("INSERT INTO \"product_audit_logs\"").
+					WithArgs(log.ID, log.ProductID, log.ActorID, log.Action, log.Origin, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(tt.mockErr)
+				if tt.wantErr {
+					dbMock.ExpectRollback()
+				} else {
+					dbMock.ExpectCommit()
+				}
+			}
+
+			if err := r.Create(context.TODO(), tt.logs); (err != nil) != tt.wantErr {
+				t.Errorf("productAuditLogRepository.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := dbMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("productAuditLogRepository.Create() %v", err)
+			}
+		})
+	}
+}
+
+func Test_productAuditLogRepository_FindPaginatedByProductID(t *testing.T) {
+	productID := utils.GenerateUUID()
+	actorID := utils.GenerateUUID()
+	logID := utils.GenerateUUID()
+	now := time.Now()
+	tests := []struct {
+		name         string
+		req          *model.ProductHistoryPayload
+		mockCountErr error
+		mockFindErr  error
+		wantLogs     model.ProductAuditLogs
+		wantCount    int64
+		wantErr      bool
+	}{
+		{
+			name: "success",
+			req: &model.ProductHistoryPayload{
+				ProductID: productID,
+				Limit:     10,
+				Page:      1,
+			},
+			wantLogs: model.ProductAuditLogs{
+				{
+					ID:        logID,
+					ProductID: productID,
+					ActorID:   actorID,
+					Action:    model.ProductAuditActionUpdate,
+					Origin:    "/pb.product.ProductService/Update",
+					Changes: model.ProductAuditChanges{
+						{Field: "price", Before: "100", After: "110"},
+					},
+					CreatedAt: now,
+				},
+			},
+			wantCount: 1,
+			wantErr:   false,
+		},
+		{
+			name: "error when count",
+			req: &model.ProductHistoryPayload{
+				ProductID: productID,
+				Limit:     10,
+				Page:      1,
+			},
+			mockCountErr: errors.New("db error"),
+			wantErr:      true,
+		},
+		{
+			name: "error when find",
+			req: &model.ProductHistoryPayload{
+				ProductID: productID,
+				Limit:     10,
+				Page:      1,
+			},
+			mockFindErr: errors.New("db error"),
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, dbMock := newProductAuditLogRepoMock()
+
+			dbMock.ExpectQuery("SELECT count\\(\\*\\) FROM \"product_audit_logs\" WHERE product_id = \\$1").
+				WithArgs(productID).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1)).
+				WillReturnError(tt.mockCountErr)
+
+			if tt.mockCountErr == nil {
+				dbMock.ExpectQuery("SELECT \\* FROM \"product_audit_logs\" WHERE product_id = \\$1 ORDER BY created_at DESC LIMIT 10").
+					WithArgs(productID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "actor_id", "action", "origin", "changes", "created_at"}).
+						AddRow(logID, productID, actorID, model.ProductAuditActionUpdate, "/pb.product.ProductService/Update", `[{"field":"price","before":"100","after":"110"}]`, now)).
+					WillReturnError(tt.mockFindErr)
+			}
+
+			gotLogs, gotCount, err := r.FindPaginatedByProductID(context.TODO(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productAuditLogRepository.FindPaginatedByProductID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotLogs, tt.wantLogs) {
+				t.Errorf("productAuditLogRepository.FindPaginatedByProductID() gotLogs = %v, want %v", gotLogs, tt.wantLogs)
+			}
+			if gotCount != tt.wantCount {
+				t.Errorf("productAuditLogRepository.FindPaginatedByProductID() gotCount = %v, want %v", gotCount, tt.wantCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type productCollaboratorRepository struct {
+	db *gorm.DB
+}
+
+func NewProductCollaboratorRepository() model.ProductCollaboratorRepository {
+	return new(productCollaboratorRepository)
+}
+
+func (r *productCollaboratorRepository) Upsert(ctx context.Context, collaborator *model.ProductCollaborator) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"productID": collaborator.ProductID,
+		"userID":    collaborator.UserID,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	collaborator.UpdatedAt = time.Now()
+	err := db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "product_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role", "updated_at"}),
+	}).Create(collaborator).Error
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (r *productCollaboratorRepository) Delete(ctx context.Context, productID string, userID string) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"productID": productID,
+		"userID":    userID,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	res := db.WithContext(ctx).
+		Where("product_id = ? AND user_id = ?", productID, userID).
+		Delete(&model.ProductCollaborator{})
+	if res.Error != nil {
+		logger.Error(res.Error.Error())
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return model.ErrCollaboratorNotFound
+	}
+
+	return nil
+}
+
+func (r *productCollaboratorRepository) FindByProductID(ctx context.Context, productID string) (model.ProductCollaborators, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"productID": productID,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+	collaborators := model.ProductCollaborators{}
+
+	err := db.WithContext(ctx).
+		Where("product_id = ?", productID).
+		Order("created_at").
+		Find(&collaborators).Error
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return collaborators, nil
+}
+
+func (r *productCollaboratorRepository) FindByProductIDAndUserID(ctx context.Context, productID string, userID string) (*model.ProductCollaborator, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"productID": productID,
+		"userID":    userID,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+	collaborator := new(model.ProductCollaborator)
+
+	err := db.WithContext(ctx).
+		Where("product_id = ? AND user_id = ?", productID, userID).
+		First(collaborator).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return collaborator, nil
+}
@@ -0,0 +1,244 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+	"gorm.io/gorm"
+)
+
+func newProductCollaboratorRepoMock() (model.ProductCollaboratorRepository, sqlmock.Sqlmock) {
+	db, sqlMock := utils.NewDBMock()
+	collaboratorRepo := NewProductCollaboratorRepository()
+	err := collaboratorRepo.InjectDB(db)
+	utils.ContinueOrFatal(err)
+
+	return collaboratorRepo, sqlMock
+}
+
+func Test_productCollaboratorRepository_Upsert(t *testing.T) {
+	productID := utils.GenerateUUID()
+	userID := utils.GenerateUUID()
+	type args struct {
+		collaborator *model.ProductCollaborator
+	}
+	tests := []struct {
+		name    string
+		args    args
+		mockErr error
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: args{
+				collaborator: &model.ProductCollaborator{
+					ProductID: productID,
+					UserID:    userID,
+					Role:      model.CollaboratorRoleEditor,
+				},
+			},
+			mockErr: nil,
+			wantErr: false,
+		},
+		{
+			name: "db error",
+			args: args{
+				collaborator: &model.ProductCollaborator{
+					ProductID: productID,
+					UserID:    userID,
+					Role:      model.CollaboratorRoleViewer,
+				},
+			},
+			mockErr: errors.New("db error"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, dbMock := newProductCollaboratorRepoMock()
+
+			dbMock.ExpectBegin()
+			dbMock.ExpectExec("INSERT INTO \"product_collaborators\" .+ ON CONFLICT \\(\"product_id\",\"user_id\"\\) DO UPDATE SET \"role\"=\"excluded\".\"role\",\"updated_at\"=\"excluded\".\"updated_at\"").
+				WithArgs(productID, userID, tt.args.collaborator.Role, sqlmock.AnyArg(), sqlmock.AnyArg()).
+				WillReturnResult(sqlmock.NewResult(1, 1)).
+				WillReturnError(tt.mockErr)
+			if tt.wantErr {
+				dbMock.ExpectRollback()
+			} else {
+				dbMock.ExpectCommit()
+			}
+
+			if err := r.Upsert(context.TODO(), tt.args.collaborator); (err != nil) != tt.wantErr {
+				t.Errorf("productCollaboratorRepository.Upsert() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_productCollaboratorRepository_Delete(t *testing.T) {
+	productID := utils.GenerateUUID()
+	userID := utils.GenerateUUID()
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		mockErr      error
+		wantErr      error
+	}{
+		{
+			name:         "success",
+			rowsAffected: 1,
+			mockErr:      nil,
+			wantErr:      nil,
+		},
+		{
+			name:         "collaborator not found",
+			rowsAffected: 0,
+			mockErr:      nil,
+			wantErr:      model.ErrCollaboratorNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, dbMock := newProductCollaboratorRepoMock()
+
+			dbMock.ExpectBegin()
+			dbMock.ExpectExec("DELETE FROM \"product_collaborators\"").
+				WithArgs(productID, userID).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected)).
+				WillReturnError(tt.mockErr)
+			dbMock.ExpectCommit()
+
+			if err := r.Delete(context.TODO(), productID, userID); !errors.Is(err, tt.wantErr) {
+				t.Errorf("productCollaboratorRepository.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_productCollaboratorRepository_FindByProductID(t *testing.T) {
+	productID := utils.GenerateUUID()
+	now := time.Now()
+	collaborators := model.ProductCollaborators{
+		{
+			ProductID: productID,
+			UserID:    utils.GenerateUUID(),
+			Role:      model.CollaboratorRoleEditor,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		{
+			ProductID: productID,
+			UserID:    utils.GenerateUUID(),
+			Role:      model.CollaboratorRoleViewer,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+	}
+	tests := []struct {
+		name    string
+		mockErr error
+		want    model.ProductCollaborators
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			mockErr: nil,
+			want:    collaborators,
+			wantErr: false,
+		},
+		{
+			name:    "db error",
+			mockErr: errors.New("db error"),
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, dbMock := newProductCollaboratorRepoMock()
+
+			rows := sqlmock.NewRows([]string{"product_id", "user_id", "role", "created_at", "updated_at"})
+			for _, collaborator := range collaborators {
+				rows.AddRow(collaborator.ProductID, collaborator.UserID, collaborator.Role, collaborator.CreatedAt, collaborator.UpdatedAt)
+			}
+			dbMock.ExpectQuery("^SELECT .+ FROM \"product_collaborators\" WHERE product_id = .+ ORDER BY created_at").
+				WithArgs(productID).
+				WillReturnRows(rows).
+				WillReturnError(tt.mockErr)
+
+			got, err := r.FindByProductID(context.TODO(), productID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productCollaboratorRepository.FindByProductID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("productCollaboratorRepository.FindByProductID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_productCollaboratorRepository_FindByProductIDAndUserID(t *testing.T) {
+	productID := utils.GenerateUUID()
+	userID := utils.GenerateUUID()
+	now := time.Now()
+	collaborator := &model.ProductCollaborator{
+		ProductID: productID,
+		UserID:    userID,
+		Role:      model.CollaboratorRoleEditor,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	tests := []struct {
+		name    string
+		mockErr error
+		want    *model.ProductCollaborator
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			mockErr: nil,
+			want:    collaborator,
+			wantErr: false,
+		},
+		{
+			name:    "not found",
+			mockErr: gorm.ErrRecordNotFound,
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name:    "db error",
+			mockErr: errors.New("db error"),
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, dbMock := newProductCollaboratorRepoMock()
+
+			rows := sqlmock.NewRows([]string{"product_id", "user_id", "role", "created_at", "updated_at"}).
+				AddRow(collaborator.ProductID, collaborator.UserID, collaborator.Role, collaborator.CreatedAt, collaborator.UpdatedAt)
+			dbMock.ExpectQuery("^SELECT .+ FROM \"product_collaborators\" WHERE product_id = .+ AND user_id = ").
+				WithArgs(productID, userID).
+				WillReturnRows(rows).
+				WillReturnError(tt.mockErr)
+
+			got, err := r.FindByProductIDAndUserID(context.TODO(), productID, userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productCollaboratorRepository.FindByProductIDAndUserID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("productCollaboratorRepository.FindByProductIDAndUserID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// productImportJobProgressColumns are the columns changed while a job run, the request itself is never updated.
+var productImportJobProgressColumns = []string{
+	"status",
+	"processed_rows",
+	"created_count",
+	"updated_count",
+	"failed_count",
+	"error_message",
+	"started_at",
+	"finished_at",
+	"updated_at",
+}
+
+type productImportJobRepository struct {
+	db *gorm.DB
+}
+
+func NewProductImportJobRepository() model.ProductImportJobRepository {
+	return new(productImportJobRepository)
+}
+
+func (r *productImportJobRepository) Create(ctx context.Context, job *model.ProductImportJob) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"jobID":   job.ID,
+		"ownerID": job.OwnerID,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	err := db.WithContext(ctx).Create(job).Error
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (r *productImportJobRepository) Update(ctx context.Context, job *model.ProductImportJob) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"jobID":  job.ID,
+		"status": job.Status,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	job.UpdatedAt = time.Now()
+	err := db.WithContext(ctx).
+		Model(job).
+		Select(productImportJobProgressColumns).
+		Updates(job).Error
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (r *productImportJobRepository) CreateErrors(ctx context.Context, importErrors model.ProductImportErrors) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	if len(importErrors) == 0 {
+		return nil
+	}
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"jobID": importErrors[0].JobID,
+		"total": len(importErrors),
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	err := db.WithContext(ctx).Create(&importErrors).Error
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (r *productImportJobRepository) FindByID(ctx context.Context, id string) (*model.ProductImportJob, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"jobID": id,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	job := new(model.ProductImportJob)
+	err := db.WithContext(ctx).Where("id = ?", id).First(job).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (r *productImportJobRepository) FindErrorsByJobID(ctx context.Context, jobID string, limit int) (model.ProductImportErrors, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"jobID": jobID,
+		"limit": limit,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	query := db.WithContext(ctx).
+		Where("job_id = ?", jobID).
+		Order("row_number").
+		Order("field")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	importErrors := make(model.ProductImportErrors, 0)
+	err := query.Find(&importErrors).Error
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return importErrors, nil
+}
@@ -0,0 +1,312 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+)
+
+func newProductImportJobRepoMock() (model.ProductImportJobRepository, sqlmock.Sqlmock) {
+	db, sqlMock := utils.NewDBMock()
+	importJobRepo := NewProductImportJobRepository()
+	err := importJobRepo.InjectDB(db)
+	utils.ContinueOrFatal(err)
+
+	return importJobRepo, sqlMock
+}
+
+func Test_productImportJobRepository_Create(t *testing.T) {
+	job := model.NewProductImportJob(&model.ImportProductsPayload{
+		FileName:      "products.csv",
+		Format:        model.ImportFormatCSV,
+		ColumnMapping: model.ImportColumnMapping{model.ImportFieldExternalSKU: "SKU"},
+	}, utils.GenerateUUID(), utils.GenerateUUID())
+	tests := []struct {
+		name    string
+		mockErr error
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			mockErr: nil,
+			wantErr: false,
+		},
+		{
+			name:    "db error",
+			mockErr: errors.New("db error"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, dbMock := newProductImportJobRepoMock()
+
+			dbMock.ExpectBegin()
+			dbMock.ExpectExec("INSERT INTO \"product_import_jobs\"").
+				WithArgs(job.ID, job.OwnerID, job.CreatedBy, job.ObjectID, job.FileName, job.Format, []byte(`{"external_sku":"SKU"}`),
+					model.ImportJobStatusPending, 0, 0, 0, 0, "", nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+				WillReturnResult(sqlmock.NewResult(1, 1)).
+				WillReturnError(tt.mockErr)
+			if tt.wantErr {
+				dbMock.ExpectRollback()
+			} else {
+				dbMock.ExpectCommit()
+			}
+
+			if err := r.Create(context.TODO(), job); (err != nil) != tt.wantErr {
+				t.Errorf("productImportJobRepository.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := dbMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("productImportJobRepository.Create() %v", err)
+			}
+		})
+	}
+}
+
+func Test_productImportJobRepository_Update(t *testing.T) {
+	tests := []struct {
+		name    string
+		mockErr error
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			mockErr: nil,
+			wantErr: false,
+		},
+		{
+			name:    "db error",
+			mockErr: errors.New("db error"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, dbMock := newProductImportJobRepoMock()
+
+			job := &model.ProductImportJob{
+				ID:            utils.GenerateUUID(),
+				OwnerID:       utils.GenerateUUID(),
+				Status:        model.ImportJobStatusRunning,
+				ProcessedRows: 10,
+				CreatedCount:  7,
+				UpdatedCount:  2,
+				FailedCount:   1,
+			}
+			job.Start()
+
+			dbMock.ExpectBegin()
+			dbMock.ExpectExec("^UPDATE \"product_import_jobs\" SET \"status\"=\\$1,\"processed_rows\"=\\$2,\"created_count\"=\\$3,\"updated_count\"=\\$4,\"failed_count\"=\\$5,\"error_message\"=\\$6,\"started_at\"=\\$7,\"finished_at\"=\\$8,\"updated_at\"=\\$9 WHERE \"id\" = \\$10$").
+				WithArgs(model.ImportJobStatusRunning, 10, 7, 2, 1, "", sqlmock.AnyArg(), nil, sqlmock.AnyArg(), job.ID).
+				WillReturnResult(sqlmock.NewResult(1, 1)).
+				WillReturnError(tt.mockErr)
+			if tt.wantErr {
+				dbMock.ExpectRollback()
+			} else {
+				dbMock.ExpectCommit()
+			}
+
+			if err := r.Update(context.TODO(), job); (err != nil) != tt.wantErr {
+				t.Errorf("productImportJobRepository.Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if job.UpdatedAt.IsZero() {
+				t.Errorf("productImportJobRepository.Update() updated_at is not set")
+			}
+			if err := dbMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("productImportJobRepository.Update() %v", err)
+			}
+		})
+	}
+}
+
+func Test_productImportJobRepository_CreateErrors(t *testing.T) {
+	jobID := utils.GenerateUUID()
+	importErrors := model.NewProductImportErrors(jobID, &model.ImportRow{Number: 2, Values: map[string]string{"external_sku": "A-2"}},
+		model.NewImportFieldError(model.ImportFieldName, "is required"))
+	tests := []struct {
+		name         string
+		importErrors model.ProductImportErrors
+		mockErr      error
+		wantErr      bool
+	}{
+		{
+			name:         "success",
+			importErrors: importErrors,
+			mockErr:      nil,
+			wantErr:      false,
+		},
+		{
+			name:         "success without error",
+			importErrors: model.ProductImportErrors{},
+			wantErr:      false,
+		},
+		{
+			name:         "db error",
+			importErrors: importErrors,
+			mockErr:      errors.New("db error"),
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, dbMock := newProductImportJobRepoMock()
+
+			if len(tt.importErrors) > 0 {
+				dbMock.ExpectBegin()
+				dbMock.ExpectExec("INSERT INTO \"product_import_errors\"").
+					WithArgs(importErrors[0].ID, jobID, 2, "A-2", model.ImportFieldName, "is required").
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(tt.mockErr)
+				if tt.wantErr {
+					dbMock.ExpectRollback()
+				} else {
+					dbMock.ExpectCommit()
+				}
+			}
+
+			if err := r.CreateErrors(context.TODO(), tt.importErrors); (err != nil) != tt.wantErr {
+				t.Errorf("productImportJobRepository.CreateErrors() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := dbMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("productImportJobRepository.CreateErrors() %v", err)
+			}
+		})
+	}
+}
+
+func Test_productImportJobRepository_FindByID(t *testing.T) {
+	jobID := utils.GenerateUUID()
+	ownerID := utils.GenerateUUID()
+	now := time.Now()
+	tests := []struct {
+		name       string
+		mockRow    bool
+		mockErr    error
+		wantResult *model.ProductImportJob
+		wantErr    bool
+	}{
+		{
+			name:    "success",
+			mockRow: true,
+			wantResult: &model.ProductImportJob{
+				ID:            jobID,
+				OwnerID:       ownerID,
+				CreatedBy:     ownerID,
+				FileName:      "products.csv",
+				Format:        model.ImportFormatCSV,
+				ColumnMapping: model.ImportColumnMapping{model.ImportFieldExternalSKU: "SKU"},
+				Status:        model.ImportJobStatusCompleted,
+				ProcessedRows: 3,
+				CreatedCount:  3,
+				CreatedAt:     now,
+				UpdatedAt:     now,
+			},
+			wantErr: false,
+		},
+		{
+			name:       "not found",
+			mockRow:    false,
+			wantResult: nil,
+			wantErr:    false,
+		},
+		{
+			name:       "db error",
+			mockRow:    true,
+			mockErr:    errors.New("db error"),
+			wantResult: nil,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, dbMock := newProductImportJobRepoMock()
+
+			rows := sqlmock.NewRows([]string{"id", "owner_id", "created_by", "file_name", "format", "column_mapping", "status", "processed_rows", "created_count", "created_at", "updated_at"})
+			if tt.mockRow {
+				rows.AddRow(jobID, ownerID, ownerID, "products.csv", model.ImportFormatCSV, `{"external_sku":"SKU"}`, model.ImportJobStatusCompleted, 3, 3, now, now)
+			}
+			dbMock.ExpectQuery("^SELECT \\* FROM \"product_import_jobs\" WHERE id = \\$1 ORDER BY \"product_import_jobs\".\"id\" LIMIT 1$").
+				WithArgs(jobID).
+				WillReturnRows(rows).
+				WillReturnError(tt.mockErr)
+
+			got, err := r.FindByID(context.TODO(), jobID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productImportJobRepository.FindByID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.wantResult) {
+				t.Errorf("productImportJobRepository.FindByID() = %v, want %v", got, tt.wantResult)
+			}
+		})
+	}
+}
+
+func Test_productImportJobRepository_FindErrorsByJobID(t *testing.T) {
+	jobID := utils.GenerateUUID()
+	errorID := utils.GenerateUUID()
+	tests := []struct {
+		name       string
+		limit      int
+		mockQuery  string
+		mockErr    error
+		wantResult model.ProductImportErrors
+		wantErr    bool
+	}{
+		{
+			name:      "success with limit",
+			limit:     100,
+			mockQuery: "^SELECT \\* FROM \"product_import_errors\" WHERE job_id = \\$1 ORDER BY row_number,field LIMIT 100$",
+			wantResult: model.ProductImportErrors{
+				{ID: errorID, JobID: jobID, RowNumber: 2, ExternalSKU: "A-2", Field: "name", Message: "is required"},
+			},
+			wantErr: false,
+		},
+		{
+			name:      "success without limit",
+			limit:     0,
+			mockQuery: "^SELECT \\* FROM \"product_import_errors\" WHERE job_id = \\$1 ORDER BY row_number,field$",
+			wantResult: model.ProductImportErrors{
+				{ID: errorID, JobID: jobID, RowNumber: 2, ExternalSKU: "A-2", Field: "name", Message: "is required"},
+			},
+			wantErr: false,
+		},
+		{
+			name:       "db error",
+			limit:      100,
+			mockQuery:  "^SELECT \\* FROM \"product_import_errors\" WHERE job_id = \\$1 ORDER BY row_number,field LIMIT 100$",
+			mockErr:    errors.New("db error"),
+			wantResult: nil,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, dbMock := newProductImportJobRepoMock()
+
+			dbMock.ExpectQuery(tt.mockQuery).
+				WithArgs(jobID).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "job_id", "row_number", "external_sku", "field", "message"}).
+					AddRow(errorID, jobID, 2, "A-2", "name", "is required")).
+				WillReturnError(tt.mockErr)
+
+			got, err := r.FindErrorsByJobID(context.TODO(), jobID, tt.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productImportJobRepository.FindErrorsByJobID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.wantResult) {
+				t.Errorf("productImportJobRepository.FindErrorsByJobID() = %v, want %v", got, tt.wantResult)
+			}
+			if err := dbMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("productImportJobRepository.FindErrorsByJobID() %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,295 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+var (
+	// syncListingScript write the member to the listing sorted sets, and to the sets being rebuilt while a rebuild run.
+	// The member is marked as touched so the rebuild doesn't overwrite it with the row it read before the write.
+	// KEYS: rebuild marker, touched set, then every listing key followed by its rebuild key.
+	// ARGV: member, then the score of every listing key, no score remove the member.
+	syncListingScript = redis.NewScript(`
+local rebuilding = redis.call('EXISTS', KEYS[1]) == 1
+if rebuilding then
+	redis.call('SADD', KEYS[2], ARGV[1])
+end
+for i = 3, #KEYS, 2 do
+	local score = ARGV[(i - 1) / 2 + 1]
+	local keys = {KEYS[i]}
+	if rebuilding then
+		table.insert(keys, KEYS[i + 1])
+	end
+	for _, key in ipairs(keys) do
+		if score == nil then
+			redis.call('ZREM', key, ARGV[1])
+		else
+			redis.call('ZADD', key, score, ARGV[1])
+		end
+	end
+end
+return 0
+`)
+
+	// rebuildListingBatchScript add a batch read from the database to the rebuild keys, skipping the touched members.
+	// KEYS: touched set, then every rebuild key. ARGV: member followed by its score on every rebuild key, repeated.
+	rebuildListingBatchScript = redis.NewScript(`
+local size = #KEYS
+for i = 1, #ARGV, size do
+	local member = ARGV[i]
+	if redis.call('SISMEMBER', KEYS[1], member) == 0 then
+		for j = 2, size do
+			redis.call('ZADD', KEYS[j], ARGV[i + j - 1], member)
+		end
+	end
+end
+return 0
+`)
+
+	// swapListingScript replace the listing keys by the rebuild keys and end the rebuild.
+	// KEYS: rebuild marker, touched set, then every listing key followed by its rebuild key.
+	swapListingScript = redis.NewScript(`
+for i = 3, #KEYS, 2 do
+	if redis.call('EXISTS', KEYS[i + 1]) == 1 then
+		redis.call('RENAME', KEYS[i + 1], KEYS[i])
+	else
+		redis.call('DEL', KEYS[i])
+	end
+end
+redis.call('DEL', KEYS[1], KEYS[2])
+return 0
+`)
+)
+
+// listingKeys return the listing keys in a stable order.
+func listingKeys() []string {
+	keys := make([]string, 0, len(model.ProductListingKeys))
+	for _, key := range model.ProductListingKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func listingRebuildKey(key string) string {
+	return key + ":rebuild"
+}
+
+// listingSwapKeys are the keys of syncListingScript and swapListingScript.
+func listingSwapKeys() []string {
+	keys := []string{model.ProductListingRebuildMarkerKey, model.ProductListingRebuildTouchedKey}
+	for _, key := range listingKeys() {
+		keys = append(keys, key, listingRebuildKey(key))
+	}
+	return keys
+}
+
+// syncListing keep the listing sorted sets in sync with the product, deleted products are never listed.
+func (r *productRepository) syncListing(ctx context.Context, product *model.Product) error {
+	if product.DeletedAt.Valid {
+		return r.removeFromListing(ctx, product.ID)
+	}
+	return r.addToListing(ctx, product)
+}
+
+// addToListing upsert the product score on every listing sorted set.
+func (r *productRepository) addToListing(ctx context.Context, product *model.Product) error {
+	scores := product.ListingScores()
+	args := []interface{}{product.ID}
+	for _, key := range listingKeys() {
+		args = append(args, scores[key])
+	}
+	return syncListingScript.Run(ctx, r.redisClient, listingSwapKeys(), args...).Err()
+}
+
+func (r *productRepository) removeFromListing(ctx context.Context, id string) error {
+	return syncListingScript.Run(ctx, r.redisClient, listingSwapKeys(), id).Err()
+}
+
+func (r *productRepository) FindListingPaginatedIDs(ctx context.Context, req *model.PaginationPayload, sort *model.ProductListingSort) (ids []string, count int64, nextCursor string, err error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"key":    sort.Key,
+		"desc":   sort.Desc,
+		"cursor": req.Cursor,
+		"page":   req.Page,
+		"limit":  req.Limit,
+	})
+	productIds := make([]string, 0)
+
+	count, err = r.redisClient.ZCard(ctx, sort.Key).Result()
+	if err != nil {
+		logger.Error(err.Error())
+		return productIds, 0, "", err
+	}
+
+	var members []redis.Z
+	if req.Cursor != "" {
+		members, err = r.findListingAfterCursor(ctx, req, sort)
+	} else {
+		members, err = r.findListingPage(ctx, req, sort)
+	}
+	if err != nil {
+		logger.Error(err.Error())
+		return productIds, 0, "", err
+	}
+
+	// one extra member is fetched to know whether there is a next page
+	if len(members) > req.Limit {
+		members = members[:req.Limit]
+		last := members[len(members)-1]
+		nextCursor = (&model.ProductListingCursor{
+			Score: last.Score,
+			ID:    listingMemberID(last),
+		}).String()
+	}
+
+	for _, member := range members {
+		productIds = append(productIds, listingMemberID(member))
+	}
+
+	return productIds, count, nextCursor, nil
+}
+
+func listingMemberID(member redis.Z) string {
+	id, _ := member.Member.(string)
+	return id
+}
+
+func (r *productRepository) findListingPage(ctx context.Context, req *model.PaginationPayload, sort *model.ProductListingSort) ([]redis.Z, error) {
+	start := int64((req.Page - 1) * req.Limit)
+	stop := start + int64(req.Limit)
+	if sort.Desc {
+		return r.redisClient.ZRevRangeWithScores(ctx, sort.Key, start, stop).Result()
+	}
+	return r.redisClient.ZRangeWithScores(ctx, sort.Key, start, stop).Result()
+}
+
+// findListingAfterCursor read the members that come after the cursor, members with the same score are ordered by id.
+func (r *productRepository) findListingAfterCursor(ctx context.Context, req *model.PaginationPayload, sort *model.ProductListingSort) ([]redis.Z, error) {
+	cursor, err := model.NewProductListingCursor(req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]redis.Z, 0)
+	batchSize := int64(req.Limit + 1)
+	offset := int64(0)
+	for {
+		var batch []redis.Z
+		if sort.Desc {
+			batch, err = r.redisClient.ZRevRangeByScoreWithScores(ctx, sort.Key, &redis.ZRangeBy{
+				Min:    "-inf",
+				Max:    cursor.ScoreString(),
+				Offset: offset,
+				Count:  batchSize,
+			}).Result()
+		} else {
+			batch, err = r.redisClient.ZRangeByScoreWithScores(ctx, sort.Key, &redis.ZRangeBy{
+				Min:    cursor.ScoreString(),
+				Max:    "+inf",
+				Offset: offset,
+				Count:  batchSize,
+			}).Result()
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, member := range batch {
+			id := listingMemberID(member)
+			if member.Score == cursor.Score && ((!sort.Desc && id <= cursor.ID) || (sort.Desc && id >= cursor.ID)) {
+				continue
+			}
+			results = append(results, member)
+		}
+
+		if len(results) > req.Limit || int64(len(batch)) < batchSize {
+			return results, nil
+		}
+		offset += batchSize
+	}
+}
+
+// RebuildListing materialize the listing sorted sets from the database,
+// the new sets are built on temporary keys and renamed so readers never see a partial listing.
+// The products written during the rebuild are synced to the temporary keys too and never overwritten by the rebuild.
+func (r *productRepository) RebuildListing(ctx context.Context) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := log.WithContext(ctx).WithField("batchSize", model.ProductListingRebuildBatchSize)
+
+	keys := listingKeys()
+	batchKeys := []string{model.ProductListingRebuildTouchedKey}
+	cleanupKeys := []string{model.ProductListingRebuildTouchedKey}
+	for _, key := range keys {
+		batchKeys = append(batchKeys, listingRebuildKey(key))
+		cleanupKeys = append(cleanupKeys, listingRebuildKey(key))
+	}
+
+	started, err := r.redisClient.SetNX(ctx, model.ProductListingRebuildMarkerKey, time.Now().UTC().Format(time.RFC3339), model.ProductListingRebuildTimeout).Result()
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+	if !started {
+		return model.ErrListingRebuildRunning
+	}
+	// the marker must be set before the first row is read so no write is missed by both the rebuild and the sync
+	err = r.redisClient.Del(ctx, cleanupKeys...).Err()
+	if err != nil {
+		logger.Error(err.Error())
+		_ = r.redisClient.Del(ctx, model.ProductListingRebuildMarkerKey).Err()
+		return err
+	}
+
+	total := 0
+	products := make([]*model.Product, 0)
+	err = r.db.WithContext(ctx).
+		Select("id", "price", "created_at").
+		Where("deleted_at IS NULL").
+		FindInBatches(&products, model.ProductListingRebuildBatchSize, func(tx *gorm.DB, batch int) error {
+			args := make([]interface{}, 0, len(products)*(len(keys)+1))
+			for _, product := range products {
+				scores := product.ListingScores()
+				args = append(args, product.ID)
+				for _, key := range keys {
+					args = append(args, scores[key])
+				}
+			}
+			total += len(products)
+			if len(args) == 0 {
+				return nil
+			}
+			return rebuildListingBatchScript.Run(ctx, r.redisClient, batchKeys, args...).Err()
+		}).Error
+	if err != nil {
+		logger.Error(err.Error())
+		cleanupKeys = append(cleanupKeys, model.ProductListingRebuildMarkerKey)
+		_ = r.redisClient.Del(ctx, cleanupKeys...).Err()
+		return err
+	}
+
+	err = swapListingScript.Run(ctx, r.redisClient, listingSwapKeys()).Err()
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	logger.WithField("total", total).Info("listing rebuilt")
+
+	return nil
+}
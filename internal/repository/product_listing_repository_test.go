@@ -0,0 +1,254 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+)
+
+func Test_productRepository_FindListingPaginatedIDs(t *testing.T) {
+	// product-c and product-b have the same price, ties are ordered by id
+	members := map[string]float64{
+		"product-a": 10,
+		"product-b": 20,
+		"product-c": 20,
+		"product-d": 30,
+	}
+	type args struct {
+		req  *model.PaginationPayload
+		sort *model.ProductListingSort
+	}
+	tests := []struct {
+		name           string
+		args           args
+		wantIds        []string
+		wantCount      int64
+		wantNextCursor string
+		wantErr        bool
+	}{
+		{
+			name: "success page ascending",
+			args: args{
+				req: &model.PaginationPayload{
+					Limit: 2,
+					Page:  1,
+				},
+				sort: &model.ProductListingSort{
+					Key: model.ProductListingPriceKey,
+				},
+			},
+			wantIds:        []string{"product-a", "product-b"},
+			wantCount:      4,
+			wantNextCursor: (&model.ProductListingCursor{Score: 20, ID: "product-b"}).String(),
+			wantErr:        false,
+		},
+		{
+			name: "success last page descending",
+			args: args{
+				req: &model.PaginationPayload{
+					Limit: 3,
+					Page:  2,
+				},
+				sort: &model.ProductListingSort{
+					Key:  model.ProductListingPriceKey,
+					Desc: true,
+				},
+			},
+			wantIds:        []string{"product-a"},
+			wantCount:      4,
+			wantNextCursor: "",
+			wantErr:        false,
+		},
+		{
+			name: "success cursor ascending between ties",
+			args: args{
+				req: &model.PaginationPayload{
+					Limit:  2,
+					Page:   1,
+					Cursor: (&model.ProductListingCursor{Score: 20, ID: "product-b"}).String(),
+				},
+				sort: &model.ProductListingSort{
+					Key: model.ProductListingPriceKey,
+				},
+			},
+			wantIds:        []string{"product-c", "product-d"},
+			wantCount:      4,
+			wantNextCursor: "",
+			wantErr:        false,
+		},
+		{
+			name: "success cursor descending between ties",
+			args: args{
+				req: &model.PaginationPayload{
+					Limit:  1,
+					Page:   1,
+					Cursor: (&model.ProductListingCursor{Score: 20, ID: "product-c"}).String(),
+				},
+				sort: &model.ProductListingSort{
+					Key:  model.ProductListingPriceKey,
+					Desc: true,
+				},
+			},
+			wantIds:        []string{"product-b"},
+			wantCount:      4,
+			wantNextCursor: (&model.ProductListingCursor{Score: 20, ID: "product-b"}).String(),
+			wantErr:        false,
+		},
+		{
+			name: "invalid cursor",
+			args: args{
+				req: &model.PaginationPayload{
+					Limit:  2,
+					Page:   1,
+					Cursor: "invalid",
+				},
+				sort: &model.ProductListingSort{
+					Key: model.ProductListingPriceKey,
+				},
+			},
+			wantIds:   []string{},
+			wantCount: 0,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _, miniRedis := newProductRepoMock(t)
+			for member, score := range members {
+				_, _ = miniRedis.ZAdd(model.ProductListingPriceKey, score, member)
+			}
+
+			gotIds, gotCount, gotNextCursor, err := r.FindListingPaginatedIDs(context.TODO(), tt.args.req, tt.args.sort)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productRepository.FindListingPaginatedIDs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotIds, tt.wantIds) {
+				t.Errorf("productRepository.FindListingPaginatedIDs() gotIds = %v, want %v", gotIds, tt.wantIds)
+			}
+			if gotCount != tt.wantCount {
+				t.Errorf("productRepository.FindListingPaginatedIDs() gotCount = %v, want %v", gotCount, tt.wantCount)
+			}
+			if gotNextCursor != tt.wantNextCursor {
+				t.Errorf("productRepository.FindListingPaginatedIDs() gotNextCursor = %v, want %v", gotNextCursor, tt.wantNextCursor)
+			}
+		})
+	}
+}
+
+func Test_productRepository_RebuildListing(t *testing.T) {
+	createdAt := time.Now()
+	type product struct {
+		id    string
+		price float64
+	}
+	tests := []struct {
+		name     string
+		products []product
+		mockErr  error
+		wantIds  []string
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			products: []product{
+				{id: "product-a", price: 10},
+				{id: "product-b", price: 20},
+			},
+			wantIds: []string{"product-a", "product-b"},
+			wantErr: false,
+		},
+		{
+			name:     "success without product",
+			products: []product{},
+			wantIds:  []string{},
+			wantErr:  false,
+		},
+		{
+			name:    "db error",
+			mockErr: errors.New("db error"),
+			wantIds: []string{"stale-product"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, dbMock, miniRedis := newProductRepoMock(t)
+			_, _ = miniRedis.ZAdd(model.ProductListingPriceKey, 1, "stale-product")
+
+			rows := sqlmock.NewRows([]string{"id", "price", "created_at"})
+			for _, product := range tt.products {
+				rows.AddRow(product.id, product.price, createdAt)
+			}
+			dbMock.ExpectQuery("SELECT \"id\",\"price\",\"created_at\" FROM \"products\"").
+				WillReturnRows(rows).
+				WillReturnError(tt.mockErr)
+
+			if err := r.RebuildListing(context.TODO()); (err != nil) != tt.wantErr {
+				t.Errorf("productRepository.RebuildListing() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			gotIds, _ := miniRedis.ZMembers(model.ProductListingPriceKey)
+			if gotIds == nil {
+				gotIds = []string{}
+			}
+			if !reflect.DeepEqual(gotIds, tt.wantIds) {
+				t.Errorf("productRepository.RebuildListing() gotIds = %v, want %v", gotIds, tt.wantIds)
+			}
+		})
+	}
+}
+
+func Test_productRepository_RebuildListing_ConcurrentWrites(t *testing.T) {
+	createdAt := time.Now()
+	r, dbMock, miniRedis := newProductRepoMock(t)
+	repo, ok := r.(*productRepository)
+	if !ok {
+		t.Fatal("invalid product repository")
+	}
+
+	// the rows are read before the products are written, the rebuild must not bring the old rows back
+	rows := sqlmock.NewRows([]string{"id", "price", "created_at"}).
+		AddRow("product-a", 10, createdAt).
+		AddRow("product-b", 20, createdAt)
+	dbMock.ExpectQuery("SELECT \"id\",\"price\",\"created_at\" FROM \"products\"").
+		WillDelayFor(200 * time.Millisecond).
+		WillReturnRows(rows)
+
+	done := make(chan error)
+	go func() {
+		done <- r.RebuildListing(context.TODO())
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	if err := r.RebuildListing(context.TODO()); !errors.Is(err, model.ErrListingRebuildRunning) {
+		t.Errorf("productRepository.RebuildListing() error = %v, want %v", err, model.ErrListingRebuildRunning)
+	}
+	utils.ContinueOrFatal(repo.removeFromListing(context.TODO(), "product-a"))
+	utils.ContinueOrFatal(repo.addToListing(context.TODO(), &model.Product{ID: "product-b", Price: 99, CreatedAt: createdAt}))
+	utils.ContinueOrFatal(repo.addToListing(context.TODO(), &model.Product{ID: "product-c", Price: 30, CreatedAt: createdAt}))
+
+	if err := <-done; err != nil {
+		t.Fatalf("productRepository.RebuildListing() error = %v", err)
+	}
+
+	gotIds, _ := miniRedis.ZMembers(model.ProductListingPriceKey)
+	if !reflect.DeepEqual(gotIds, []string{"product-c", "product-b"}) {
+		t.Errorf("productRepository.RebuildListing() gotIds = %v", gotIds)
+	}
+	if score, _ := miniRedis.ZScore(model.ProductListingPriceKey, "product-b"); score != 99 {
+		t.Errorf("productRepository.RebuildListing() product-b price = %v, want 99", score)
+	}
+	for _, key := range []string{model.ProductListingRebuildMarkerKey, model.ProductListingRebuildTouchedKey} {
+		if miniRedis.Exists(key) {
+			t.Errorf("productRepository.RebuildListing() kept %s", key)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type productOutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewProductOutboxRepository() model.ProductOutboxRepository {
+	return new(productOutboxRepository)
+}
+
+func (r *productOutboxRepository) Create(ctx context.Context, events model.ProductOutboxEvents) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	err := db.WithContext(ctx).Create(&events).Error
+	if err != nil {
+		log.WithContext(ctx).WithField("total", len(events)).Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// TryLockRelay take a transaction scoped advisory lock without waiting, it is released on commit or rollback.
+func (r *productOutboxRepository) TryLockRelay(ctx context.Context) (bool, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	var locked bool
+	err := db.WithContext(ctx).
+		Raw("SELECT pg_try_advisory_xact_lock(hashtext(?))", model.ProductOutboxRelayLockKey).
+		Scan(&locked).Error
+	if err != nil {
+		log.WithContext(ctx).Error(err.Error())
+		return false, err
+	}
+
+	return locked, nil
+}
+
+func (r *productOutboxRepository) FindPending(ctx context.Context, limit int) (model.ProductOutboxEvents, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	events := make(model.ProductOutboxEvents, 0)
+	err := db.WithContext(ctx).
+		Order("id").
+		Limit(limit).
+		Find(&events).Error
+	if err != nil {
+		log.WithContext(ctx).Error(err.Error())
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (r *productOutboxRepository) DeleteByIDs(ctx context.Context, ids []int64) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	err := db.WithContext(ctx).
+		Where("id IN ?", ids).
+		Delete(&model.ProductOutboxEvent{}).Error
+	if err != nil {
+		log.WithContext(ctx).WithField("total", len(ids)).Error(err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+)
+
+func newProductOutboxRepoMock() (model.ProductOutboxRepository, sqlmock.Sqlmock) {
+	db, sqlMock := utils.NewDBMock()
+	outboxRepo := NewProductOutboxRepository()
+	err := outboxRepo.InjectDB(db)
+	utils.ContinueOrFatal(err)
+
+	return outboxRepo, sqlMock
+}
+
+func Test_productOutboxRepository_Create(t *testing.T) {
+	tests := []struct {
+		name      string
+		events    model.ProductOutboxEvents
+		insertErr error
+		wantIDs   []int64
+		wantErr   bool
+	}{
+		{
+			name: "success",
+			events: model.ProductOutboxEvents{
+				{Subject: model.ProductCreatedSubject, Payload: []byte(`{"type":"PRODUCT_CREATED"}`)},
+				{Subject: model.ProductUpdatedSubject, Payload: []byte(`{"type":"PRODUCT_UPDATED"}`)},
+			},
+			wantIDs: []int64{1, 2},
+			wantErr: false,
+		},
+		{
+			name:    "nothing to write",
+			events:  model.ProductOutboxEvents{},
+			wantIDs: []int64{},
+			wantErr: false,
+		},
+		{
+			name: "db error",
+			events: model.ProductOutboxEvents{
+				{Subject: model.ProductCreatedSubject, Payload: []byte(`{"type":"PRODUCT_CREATED"}`)},
+			},
+			insertErr: errors.New("db error"),
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, dbMock := newProductOutboxRepoMock()
+			if len(tt.events) > 0 {
+				dbMock.ExpectBegin()
+				rows := sqlmock.NewRows([]string{"id"})
+				for _, id := range tt.wantIDs {
+					rows.AddRow(id)
+				}
+				dbMock.ExpectQuery("^INSERT INTO \"product_outbox_events\" \\(\"subject\",\"payload\",\"created_at\"\\) VALUES .+ RETURNING \"id\"").
+					WillReturnRows(rows).
+					WillReturnError(tt.insertErr)
+				if tt.wantErr {
+					dbMock.ExpectRollback()
+				} else {
+					dbMock.ExpectCommit()
+				}
+			}
+
+			err := r.Create(context.TODO(), tt.events)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productOutboxRepository.Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(tt.events.IDs(), tt.wantIDs) {
+				t.Errorf("productOutboxRepository.Create() ids = %v, want %v", tt.events.IDs(), tt.wantIDs)
+			}
+			if err := dbMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("productOutboxRepository.Create() %v", err)
+			}
+		})
+	}
+}
+
+func Test_productOutboxRepository_TryLockRelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		mockLock bool
+		lockErr  error
+		want     bool
+		wantErr  bool
+	}{
+		{
+			name:     "lock taken",
+			mockLock: true,
+			want:     true,
+			wantErr:  false,
+		},
+		{
+			name:     "lock held by another relay",
+			mockLock: false,
+			want:     false,
+			wantErr:  false,
+		},
+		{
+			name:    "db error",
+			lockErr: errors.New("db error"),
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, dbMock := newProductOutboxRepoMock()
+			dbMock.ExpectQuery("^SELECT pg_try_advisory_xact_lock\\(hashtext\\(\\$1\\)\\)").
+				WithArgs(model.ProductOutboxRelayLockKey).
+				WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(tt.mockLock)).
+				WillReturnError(tt.lockErr)
+
+			got, err := r.TryLockRelay(context.TODO())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productOutboxRepository.TryLockRelay() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("productOutboxRepository.TryLockRelay() = %v, want %v", got, tt.want)
+			}
+			if err := dbMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("productOutboxRepository.TryLockRelay() %v", err)
+			}
+		})
+	}
+}
+
+func Test_productOutboxRepository_FindPending(t *testing.T) {
+	createdAt := time.Now()
+	r, dbMock := newProductOutboxRepoMock()
+	rows := sqlmock.NewRows([]string{"id", "subject", "payload", "created_at"}).
+		AddRow(1, model.ProductCreatedSubject, []byte(`{"type":"PRODUCT_CREATED"}`), createdAt)
+	dbMock.ExpectQuery("^SELECT \\* FROM \"product_outbox_events\" ORDER BY id LIMIT 10$").
+		WillReturnRows(rows)
+
+	got, err := r.FindPending(context.TODO(), 10)
+	if err != nil {
+		t.Errorf("productOutboxRepository.FindPending() error = %v", err)
+		return
+	}
+	want := model.ProductOutboxEvents{
+		{ID: 1, Subject: model.ProductCreatedSubject, Payload: []byte(`{"type":"PRODUCT_CREATED"}`), CreatedAt: createdAt},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("productOutboxRepository.FindPending() = %v, want %v", got, want)
+	}
+}
+
+func Test_productOutboxRepository_DeleteByIDs(t *testing.T) {
+	r, dbMock := newProductOutboxRepoMock()
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec("^DELETE FROM \"product_outbox_events\" WHERE id IN \\(\\$1,\\$2\\)").
+		WithArgs(1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	dbMock.ExpectCommit()
+
+	err := r.DeleteByIDs(context.TODO(), []int64{1, 2})
+	if err != nil {
+		t.Errorf("productOutboxRepository.DeleteByIDs() error = %v", err)
+	}
+	if err := dbMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("productOutboxRepository.DeleteByIDs() %v", err)
+	}
+}
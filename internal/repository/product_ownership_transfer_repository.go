@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type productOwnershipTransferRepository struct {
+	db *gorm.DB
+}
+
+func NewProductOwnershipTransferRepository() model.ProductOwnershipTransferRepository {
+	return new(productOwnershipTransferRepository)
+}
+
+func (r *productOwnershipTransferRepository) Create(ctx context.Context, transfers []*model.ProductOwnershipTransfer) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	if len(transfers) == 0 {
+		return nil
+	}
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"total": len(transfers),
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	err := db.WithContext(ctx).Create(&transfers).Error
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+)
+
+func newProductOwnershipTransferRepoMock() (model.ProductOwnershipTransferRepository, sqlmock.Sqlmock) {
+	db, sqlMock := utils.NewDBMock()
+	transferRepo := NewProductOwnershipTransferRepository()
+	err := transferRepo.InjectDB(db)
+	utils.ContinueOrFatal(err)
+
+	return transferRepo, sqlMock
+}
+
+func Test_productOwnershipTransferRepository_Create(t *testing.T) {
+	transfer := &model.ProductOwnershipTransfer{
+		ID:            utils.GenerateUUID(),
+		ProductID:     utils.GenerateUUID(),
+		FromOwnerID:   utils.GenerateUUID(),
+		ToOwnerID:     utils.GenerateUUID(),
+		TransferredBy: utils.GenerateUUID(),
+		Reason:        "storefront merged",
+	}
+	tests := []struct {
+		name      string
+		transfers []*model.ProductOwnershipTransfer
+		mockErr   error
+		wantErr   bool
+	}{
+		{
+			name:      "success",
+			transfers: []*model.ProductOwnershipTransfer{transfer},
+			mockErr:   nil,
+			wantErr:   false,
+		},
+		{
+			name:      "success without transfer",
+			transfers: []*model.ProductOwnershipTransfer{},
+			wantErr:   false,
+		},
+		{
+			name:      "db error",
+			transfers: []*model.ProductOwnershipTransfer{transfer},
+			mockErr:   errors.New("db error"),
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, dbMock := newProductOwnershipTransferRepoMock()
+
+			if len(tt.transfers) > 0 {
+				dbMock.ExpectBegin()
+				dbMock.ExpectExec("INSERT INTO \"product_ownership_transfers\"").
+					WithArgs(transfer.ID, transfer.ProductID, transfer.FromOwnerID, transfer.ToOwnerID, transfer.TransferredBy, transfer.Reason, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(tt.mockErr)
+				if tt.wantErr {
+					dbMock.ExpectRollback()
+				} else {
+					dbMock.ExpectCommit()
+				}
+			}
+
+			if err := r.Create(context.TODO(), tt.transfers); (err != nil) != tt.wantErr {
+				t.Errorf("productOwnershipTransferRepository.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := dbMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("productOwnershipTransferRepository.Create() %v", err)
+			}
+		})
+	}
+}
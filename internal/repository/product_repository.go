@@ -3,13 +3,11 @@ package repository
 import (
 	"context"
 	"errors"
-	"io"
-	"strings"
 	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/goccy/go-json"
-	kit "github.com/krobus00/krokit"
+	"github.com/krobus00/product-service/internal/config"
 	"github.com/krobus00/product-service/internal/model"
 	"github.com/krobus00/product-service/internal/utils"
 	log "github.com/sirupsen/logrus"
@@ -20,7 +18,7 @@ import (
 type productRepository struct {
 	db          *gorm.DB
 	redisClient *redis.Client
-	osClient    kit.OpensearchClient
+	searcher    model.ProductSearcher
 }
 
 func NewProductRepository() model.ProductRepository {
@@ -32,7 +30,7 @@ func (r *productRepository) Create(ctx context.Context, product *model.Product)
 	ctx, span := utils.NewSpan(ctx, fn)
 	defer span.End()
 
-	logger := log.WithFields(log.Fields{
+	logger := log.WithContext(ctx).WithFields(log.Fields{
 		"productID":   product.ID,
 		"thumbnailID": product.ThumbnailID,
 	})
@@ -45,14 +43,7 @@ func (r *productRepository) Create(ctx context.Context, product *model.Product)
 		return err
 	}
 
-	res, err := r.osClient.Index(ctx, model.OSProductIndex, product.ToDoc())
-	if err != nil {
-		logger.Error(err.Error())
-		return err
-	}
-	defer res.Body.Close()
-
-	_ = DeleteByKeys(ctx, r.redisClient, model.GetProductCacheKeys(product.ID))
+	r.afterWrite(ctx, product)
 
 	return nil
 }
@@ -62,27 +53,23 @@ func (r *productRepository) Update(ctx context.Context, product *model.Product)
 	ctx, span := utils.NewSpan(ctx, fn)
 	defer span.End()
 
-	logger := log.WithFields(log.Fields{
+	logger := log.WithContext(ctx).WithFields(log.Fields{
 		"productID": product.ID,
 	})
 
 	db := utils.GetTxFromContext(ctx, r.db)
 
+	// the columns are named so a field set back to its zero value, like a price of 0, is written too
 	product.UpdatedAt = time.Now()
-	err := db.WithContext(ctx).Updates(product).Error
-	if err != nil {
-		logger.Error(err.Error())
-		return err
-	}
-
-	res, err := r.osClient.Index(ctx, model.OSProductIndex, product.ToDoc())
+	err := db.WithContext(ctx).
+		Select("name", "description", "price", "thumbnail_id", "updated_at").
+		Updates(product).Error
 	if err != nil {
 		logger.Error(err.Error())
 		return err
 	}
-	defer res.Body.Close()
 
-	_ = DeleteByKeys(ctx, r.redisClient, model.GetProductCacheKeys(product.ID))
+	r.afterWrite(ctx, product)
 
 	return nil
 }
@@ -92,7 +79,7 @@ func (r *productRepository) DeleteByID(ctx context.Context, id string) error {
 	ctx, span := utils.NewSpan(ctx, fn)
 	defer span.End()
 
-	logger := log.WithFields(log.Fields{
+	logger := log.WithContext(ctx).WithFields(log.Fields{
 		"productID": id,
 	})
 
@@ -100,21 +87,59 @@ func (r *productRepository) DeleteByID(ctx context.Context, id string) error {
 
 	product := new(model.Product)
 
-	err := db.WithContext(ctx).Clauses(clause.Returning{}).
-		Where("id = ?", id).Delete(product).Error
+	// updated_at is bumped with deleted_at so incremental exports pick the deletion up
+	now := time.Now()
+	err := db.WithContext(ctx).Model(product).Clauses(clause.Returning{}).
+		Where("id = ?", id).Updates(map[string]any{
+		"deleted_at": now,
+		"updated_at": now,
+	}).Error
 	if err != nil {
 		logger.Error(err.Error())
 		return err
 	}
 
-	res, err := r.osClient.Index(ctx, model.OSProductIndex, product.ToDoc())
+	utils.AfterCommit(ctx, func(ctx context.Context) {
+		err := r.index(ctx, product)
+		if err != nil {
+			logger.Error(err.Error())
+		}
+
+		err = r.removeFromListing(ctx, id)
+		if err != nil {
+			logger.Error(err.Error())
+		}
+
+		r.deleteCache(ctx, id)
+	})
+
+	return nil
+}
+
+func (r *productRepository) RestoreByID(ctx context.Context, id string) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"productID": id,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	product := new(model.Product)
+
+	err := db.WithContext(ctx).Unscoped().Model(product).Clauses(clause.Returning{}).
+		Where("id = ?", id).Updates(map[string]any{
+		"deleted_at": nil,
+		"updated_at": time.Now(),
+	}).Error
 	if err != nil {
 		logger.Error(err.Error())
 		return err
 	}
-	defer res.Body.Close()
 
-	_ = DeleteByKeys(ctx, r.redisClient, model.GetProductCacheKeys(id))
+	r.afterWrite(ctx, product)
 
 	return nil
 }
@@ -124,7 +149,7 @@ func (r *productRepository) FindPaginatedIDs(ctx context.Context, req *model.Pag
 	ctx, span := utils.NewSpan(ctx, fn)
 	defer span.End()
 
-	logger := log.WithFields(log.Fields{
+	logger := log.WithContext(ctx).WithFields(log.Fields{
 		"search": req.Search,
 		"sort":   req.Sort,
 		"page":   req.Page,
@@ -144,6 +169,7 @@ func (r *productRepository) FindPaginatedIDs(ctx context.Context, req *model.Pag
 		WithSearch(req.Search, model.ProductSearchColumns),
 		WithSortBy(req.Sort),
 		WithDeleted(req.IncludeDeleted),
+		WithOwnedOrShared(req.OwnedOrShared, req.UserID),
 	).
 		Select("id").
 		Model(&model.Product{}).
@@ -161,7 +187,7 @@ func (r *productRepository) FindByID(ctx context.Context, id string) (*model.Pro
 	ctx, span := utils.NewSpan(ctx, fn)
 	defer span.End()
 
-	logger := log.WithFields(log.Fields{
+	logger := log.WithContext(ctx).WithFields(log.Fields{
 		"productID": id,
 	})
 
@@ -202,12 +228,121 @@ func (r *productRepository) FindByID(ctx context.Context, id string) (*model.Pro
 	return product, nil
 }
 
+// CountByOwnerID count the not deleted products of the owner.
+func (r *productRepository) CountByOwnerID(ctx context.Context, ownerID string) (count int64, err error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"ownerID": ownerID,
+	})
+	db := utils.GetTxFromContext(ctx, r.db)
+	err = db.WithContext(ctx).
+		Model(&model.Product{}).
+		Where("owner_id = ?", ownerID).
+		Count(&count).Error
+	if err != nil {
+		logger.Error(err.Error())
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// LockOwner take a transaction scoped advisory lock of the owner, it is released on commit or rollback
+// so it only hold when ctx carry a transaction.
+func (r *productRepository) LockOwner(ctx context.Context, ownerID string) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	db := utils.GetTxFromContext(ctx, r.db)
+	err := db.WithContext(ctx).
+		Exec("SELECT pg_advisory_xact_lock(hashtext(?))", model.NewProductOwnerLockKey(ownerID)).Error
+	if err != nil {
+		log.WithContext(ctx).WithField("ownerID", ownerID).Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// FindExportBatch return the next products of an export after the checkpoint, ordered by updated_at then id.
+func (r *productRepository) FindExportBatch(ctx context.Context, req *model.ExportProductsPayload, after *model.ProductExportCheckpoint, limit int) (model.Products, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"ownerID":        req.OwnerID,
+		"updatedSince":   req.UpdatedSince,
+		"includeDeleted": req.IncludeDeleted,
+		"limit":          limit,
+	})
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	query := db.WithContext(ctx).Unscoped().Scopes(
+		WithDeleted(req.IncludeDeleted),
+	)
+	if req.OwnerID != "" {
+		query = query.Where("owner_id = ?", req.OwnerID)
+	}
+	if req.UpdatedSince != "" {
+		query = query.Where("updated_at >= ?", req.UpdatedSinceTime().UTC())
+	}
+	if after != nil {
+		query = query.Where("(updated_at, id) > (?, ?)", after.UpdatedAt.UTC(), after.ID)
+	}
+
+	products := make(model.Products, 0)
+	err := query.
+		Order("updated_at").
+		Order("id").
+		Limit(limit).
+		Find(&products).Error
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return products, nil
+}
+
+func (r *productRepository) FindByOwnerIDAndExternalSKUs(ctx context.Context, ownerID string, externalSKUs []string) (model.Products, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	products := make(model.Products, 0)
+	if len(externalSKUs) == 0 {
+		return products, nil
+	}
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"ownerID": ownerID,
+		"total":   len(externalSKUs),
+	})
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	err := db.WithContext(ctx).
+		Unscoped().
+		Where("owner_id = ? AND external_sku IN ?", ownerID, externalSKUs).
+		Find(&products).Error
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return products, nil
+}
+
 func (r *productRepository) countPaginated(ctx context.Context, req *model.PaginationPayload) (count int64, err error) {
 	_, _, fn := utils.Trace()
 	ctx, span := utils.NewSpan(ctx, fn)
 	defer span.End()
 
-	logger := log.WithFields(log.Fields{
+	logger := log.WithContext(ctx).WithFields(log.Fields{
 		"search": req.Search,
 		"sort":   req.Sort,
 		"page":   req.Page,
@@ -217,6 +352,7 @@ func (r *productRepository) countPaginated(ctx context.Context, req *model.Pagin
 	err = db.WithContext(ctx).Unscoped().Scopes(
 		WithSearch(req.Search, model.ProductSearchColumns),
 		WithDeleted(req.IncludeDeleted),
+		WithOwnedOrShared(req.OwnedOrShared, req.UserID),
 	).
 		Model(&model.Product{}).
 		Select("id").
@@ -230,105 +366,330 @@ func (r *productRepository) countPaginated(ctx context.Context, req *model.Pagin
 	return count, nil
 }
 
-func (r *productRepository) FindOSPaginatedIDs(ctx context.Context, req *model.PaginationPayload) (ids []string, count int64, err error) {
+func (r *productRepository) FindSearchPaginatedIDs(ctx context.Context, req *model.PaginationPayload) (ids []string, highlights []*model.ProductHighlight, count int64, err error) {
 	_, _, fn := utils.Trace()
 	ctx, span := utils.NewSpan(ctx, fn)
 	defer span.End()
 
-	logger := log.WithFields(log.Fields{
-		"search": req.Search,
-		"sort":   req.Sort,
-		"page":   req.Page,
-		"limit":  req.Limit,
+	return r.searcher.FindPaginatedIDs(ctx, req)
+}
+
+func (r *productRepository) FindRelatedIDs(ctx context.Context, product *model.Product, req *model.RelatedPayload) (ids []string, err error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"productID": product.ID,
+		"limit":     req.Limit,
+		"sameOwner": req.SameOwner,
+		"priceBand": req.PriceBand,
 	})
 	productIds := make([]string, 0)
+	cacheKey := model.NewProductRelatedCacheKey(product.ID)
 
-	paginationRequest := &model.OSPaginationRequest{
-		From:           int64((req.Page - 1) * req.Limit),
-		Size:           int64(req.Limit),
-		TrackTotalHits: true,
-		Query: model.Query{
-			Bool: model.Bool{
-				Must: model.Must{
-					MultiMatch: model.MultiMatch{
-						Query:              req.Search,
-						Analyzer:           model.OSProductAnalyzer,
-						Fields:             model.ProductSearchColumns,
-						MinimumShouldMatch: model.OSProductMinimumShouldMatch,
-					},
-				},
-			},
-		},
-	}
-
-	if !req.IncludeDeleted {
-		paginationRequest.Query.Bool.Filter = append(paginationRequest.Query.Bool.Filter, model.Filter{
-			Term: map[string]string{
-				"deleted_at.keyword": "null",
-			},
-		})
+	cachedData, err := HGet(ctx, r.redisClient, cacheKey, req.CacheField())
+	if err != nil && !errors.Is(err, redis.Nil) {
+		logger.Error(err.Error())
+	}
+	if err == nil && json.Unmarshal(cachedData, &productIds) == nil {
+		return productIds, nil
 	}
 
-	paginationRequest.ParseSort(req)
-
-	docData, err := json.Marshal(paginationRequest)
+	productIds, err = r.searcher.FindRelatedIDs(ctx, product, req)
 	if err != nil {
 		logger.Error(err.Error())
-		return productIds, count, err
+		return productIds, err
 	}
 
-	body := strings.NewReader(string(docData))
-
-	res, err := r.osClient.Search(ctx, []string{model.OSProductIndex}, body)
+	err = HSetWithExpiry(ctx, r.redisClient, cacheKey, req.CacheField(), productIds)
 	if err != nil {
 		logger.Error(err.Error())
-		return productIds, count, err
+		return productIds, nil
 	}
-	defer res.Body.Close()
 
-	osProducts := new(model.OSPaginationResponse[model.Product])
-
-	bytes, err := io.ReadAll(res.Body)
+	err = r.tagRelated(ctx, cacheKey, productIds)
 	if err != nil {
 		logger.Error(err.Error())
-		return productIds, count, err
 	}
 
-	err = json.Unmarshal(bytes, &osProducts)
-	if err != nil {
-		logger.Error(err.Error())
-		return productIds, count, err
+	return productIds, nil
+}
+
+// tagRelated record the related cache key under every product it list so a change of any of them invalidate it.
+func (r *productRepository) tagRelated(ctx context.Context, cacheKey string, productIds []string) error {
+	if config.DisableCaching() || len(productIds) == 0 {
+		return nil
 	}
+	_, err := r.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, id := range productIds {
+			tagKey := model.NewProductRelatedTagKey(id)
+			pipe.SAdd(ctx, tagKey, cacheKey)
+			pipe.Expire(ctx, tagKey, config.RedisCacheTTL())
+		}
+		return nil
+	})
+	return err
+}
 
-	for _, hit := range osProducts.Hits.Hits {
-		productIds = append(productIds, hit.Source.ID)
+// deleteCache remove the cached products and the cached related products listing them.
+func (r *productRepository) deleteCache(ctx context.Context, ids ...string) {
+	if config.DisableCaching() || len(ids) == 0 {
+		return
 	}
+	logger := log.WithContext(ctx).WithField("productIDs", ids)
 
-	return productIds, osProducts.GetCount(), nil
+	tagCmds := make([]*redis.StringSliceCmd, 0, len(ids))
+	_, err := r.redisClient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, id := range ids {
+			tagCmds = append(tagCmds, pipe.SMembers(ctx, model.NewProductRelatedTagKey(id)))
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		logger.Error(err.Error())
+	}
+
+	cacheKeys := make([]string, 0)
+	for i, id := range ids {
+		cacheKeys = append(cacheKeys, model.GetProductCacheKeys(id)...)
+		cacheKeys = append(cacheKeys, tagCmds[i].Val()...)
+		cacheKeys = append(cacheKeys, model.NewProductRelatedTagKey(id))
+	}
+	err = DeleteByKeys(ctx, r.redisClient, cacheKeys)
+	if err != nil {
+		logger.Error(err.Error())
+	}
 }
 
-func (r *productRepository) UpdateAllThumbnail(ctx context.Context, oldThumbnailID string, newThumbnailID string) error {
+func (r *productRepository) UpdateAllThumbnail(ctx context.Context, oldThumbnailID string, newThumbnailID string) (model.Products, error) {
 	_, _, fn := utils.Trace()
 	ctx, span := utils.NewSpan(ctx, fn)
 	defer span.End()
 
-	logger := log.WithFields(log.Fields{
+	logger := log.WithContext(ctx).WithFields(log.Fields{
 		"oldThumbnailID": oldThumbnailID,
 		"newThumbnailID": newThumbnailID,
 	})
 
 	db := utils.GetTxFromContext(ctx, r.db)
 
+	products := make(model.Products, 0)
 	err := db.WithContext(ctx).
-		Model(&model.Product{}).
+		Model(&products).
+		Clauses(clause.Returning{}).
 		Where("thumbnail_id = ?", oldThumbnailID).
 		Updates(model.Product{ThumbnailID: newThumbnailID, UpdatedAt: time.Now()}).Error
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	utils.AfterCommit(ctx, func(ctx context.Context) {
+		r.reindexInBatches(ctx, products)
+	})
+
+	return products, nil
+}
+
+func (r *productRepository) Reindex(ctx context.Context, product *model.Product) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	if utils.InTx(ctx) {
+		utils.AfterCommit(ctx, func(ctx context.Context) {
+			err := r.index(ctx, product)
+			if err != nil {
+				log.WithContext(ctx).WithField("productID", product.ID).Error(err.Error())
+			}
+		})
+		return nil
+	}
+
+	err := r.index(ctx, product)
+	if err != nil {
+		log.WithContext(ctx).WithField("productID", product.ID).Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// TransferOwnership move the product or all products of the current owner, including the deleted ones, to the new owner.
+func (r *productRepository) TransferOwnership(ctx context.Context, payload *model.TransferOwnershipPayload) (model.Products, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"productID":   payload.ID,
+		"fromOwnerID": payload.FromOwnerID,
+		"toOwnerID":   payload.ToOwnerID,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	products := make(model.Products, 0)
+	query := db.WithContext(ctx).Unscoped().Model(&products).Clauses(clause.Returning{}).
+		Where("owner_id = ?", payload.FromOwnerID)
+	if !payload.IsBulk() {
+		query = query.Where("id = ?", payload.ID)
+	}
+	err := query.Updates(map[string]any{
+		"owner_id":   payload.ToOwnerID,
+		"updated_at": time.Now(),
+	}).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		// the external sku is the only unique key changed by the transfer
+		return nil, model.ErrExternalSKUConflict
+	}
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	utils.AfterCommit(ctx, func(ctx context.Context) {
+		r.reindexInBatches(ctx, products)
+	})
+
+	return products, nil
+}
+
+// UpsertInBatches write the products with one statement per batch, the existing products only get
+// their editable fields updated. They are indexed, listed and uncached once the transaction is committed.
+func (r *productRepository) UpsertInBatches(ctx context.Context, products model.Products) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := log.WithContext(ctx).WithField("total", len(products))
+
+	if len(products) == 0 {
+		return nil
+	}
+
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	now := time.Now()
+	for _, product := range products {
+		product.UpdatedAt = now
+	}
+	err := db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"name", "description", "price", "thumbnail_id", "updated_at"}),
+		}).
+		CreateInBatches(products, model.ProductUpsertBatchSize).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		// a conflicting id is updated, only the external sku can be duplicated
+		return model.ErrExternalSKUConflict
+	}
 	if err != nil {
 		logger.Error(err.Error())
 		return err
 	}
 
-	_ = DeleteByKeys(ctx, r.redisClient, model.GetUpdateAllThumbnailCacheKeys())
+	utils.AfterCommit(ctx, func(ctx context.Context) {
+		r.reindexInBatches(ctx, products)
+		for _, product := range products {
+			err := r.syncListing(ctx, product)
+			if err != nil {
+				log.WithContext(ctx).WithField("productID", product.ID).Error(err.Error())
+			}
+		}
+	})
 
 	return nil
 }
+
+// reindexInBatches index and uncache products written in bulk, every batch load its collaborators
+// and delete its cache keys in one round trip.
+func (r *productRepository) reindexInBatches(ctx context.Context, products model.Products) {
+	logger := log.WithContext(ctx).WithField("total", len(products))
+
+	for start := 0; start < len(products); start += model.ProductReindexBatchSize {
+		end := start + model.ProductReindexBatchSize
+		if end > len(products) {
+			end = len(products)
+		}
+		batch := products[start:end]
+
+		err := r.indexBatch(ctx, batch)
+		if err != nil {
+			logger.Error(err.Error())
+		}
+
+		r.deleteCache(ctx, batch.IDs()...)
+	}
+}
+
+// indexBatch index the products with the collaborators of the whole batch loaded at once,
+// a product failing to index doesn't stop the rest of the batch.
+func (r *productRepository) indexBatch(ctx context.Context, products model.Products) error {
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	collaborators := make(model.ProductCollaborators, 0)
+	err := db.WithContext(ctx).
+		Select("product_id", "user_id").
+		Where("product_id IN ?", products.IDs()).
+		Find(&collaborators).Error
+	if err != nil {
+		return err
+	}
+
+	collaboratorIDs := make(map[string][]string)
+	for _, collaborator := range collaborators {
+		collaboratorIDs[collaborator.ProductID] = append(collaboratorIDs[collaborator.ProductID], collaborator.UserID)
+	}
+
+	var indexErr error
+	for _, product := range products {
+		product.CollaboratorIDs = make([]string, 0, len(collaboratorIDs[product.ID]))
+		product.CollaboratorIDs = append(product.CollaboratorIDs, collaboratorIDs[product.ID]...)
+
+		err = r.searcher.Index(ctx, product)
+		if err != nil {
+			log.WithContext(ctx).WithField("productID", product.ID).Error(err.Error())
+			indexErr = err
+		}
+	}
+	return indexErr
+}
+
+// afterWrite index, list and uncache the written product once the transaction is committed,
+// a rolled back write never reach the search backend or the cache.
+func (r *productRepository) afterWrite(ctx context.Context, product *model.Product) {
+	utils.AfterCommit(ctx, func(ctx context.Context) {
+		logger := log.WithContext(ctx).WithField("productID", product.ID)
+
+		err := r.index(ctx, product)
+		if err != nil {
+			logger.Error(err.Error())
+		}
+
+		err = r.syncListing(ctx, product)
+		if err != nil {
+			logger.Error(err.Error())
+		}
+
+		r.deleteCache(ctx, product.ID)
+	})
+}
+
+// index load the product collaborators so the search backend can filter products shared with a user.
+func (r *productRepository) index(ctx context.Context, product *model.Product) error {
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	collaboratorIDs := make([]string, 0)
+	err := db.WithContext(ctx).
+		Model(&model.ProductCollaborator{}).
+		Where("product_id = ?", product.ID).
+		Pluck("user_id", &collaboratorIDs).Error
+	if err != nil {
+		return err
+	}
+	product.CollaboratorIDs = collaboratorIDs
+
+	return r.searcher.Index(ctx, product)
+}
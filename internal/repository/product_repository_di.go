@@ -4,7 +4,7 @@ import (
 	"errors"
 
 	"github.com/go-redis/redis/v8"
-	kit "github.com/krobus00/krokit"
+	"github.com/krobus00/product-service/internal/model"
 	"gorm.io/gorm"
 )
 
@@ -24,10 +24,10 @@ func (r *productRepository) InjectRedisClient(client *redis.Client) error {
 	return nil
 }
 
-func (r *productRepository) InjectOpensearchClient(client kit.OpensearchClient) error {
-	if client == nil {
-		return errors.New("invalid opensearch client")
+func (r *productRepository) InjectProductSearcher(searcher model.ProductSearcher) error {
+	if searcher == nil {
+		return errors.New("invalid product searcher")
 	}
-	r.osClient = client
+	r.searcher = searcher
 	return nil
 }
@@ -2,22 +2,22 @@ package repository
 
 import (
 	"context"
+	"database/sql/driver"
 	"errors"
 	"fmt"
-	"io"
-	"io/ioutil"
 	"reflect"
-	"strings"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/alicebob/miniredis/v2"
+	"github.com/goccy/go-json"
 	"github.com/golang/mock/gomock"
-	kitMock "github.com/krobus00/krokit/mock"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/krobus00/product-service/internal/infrastructure"
 	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/model/mock"
 	"github.com/krobus00/product-service/internal/utils"
-	"github.com/opensearch-project/opensearch-go/opensearchapi"
 	"github.com/spf13/viper"
 	"gorm.io/gorm"
 )
@@ -92,23 +92,19 @@ func Test_productRepository_Create(t *testing.T) {
 			defer ctrl.Finish()
 
 			r, dbMock, _ := newProductRepoMock(t)
-			osClient := kitMock.NewMockOpensearchClient(ctrl)
+			searcher := mock.NewMockProductSearcher(ctrl)
 
-			err := r.InjectOpensearchClient(osClient)
+			err := r.InjectProductSearcher(searcher)
 			utils.ContinueOrFatal(err)
 
 			dbMock.ExpectBegin()
 			dbMock.ExpectExec("INSERT INTO \"products\"").
-				WithArgs(productID, tt.args.product.Name, tt.args.product.Description, tt.args.product.Price, tt.args.product.ThumbnailID, tt.args.product.OwnerID, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+				WithArgs(productID, tt.args.product.Name, tt.args.product.Description, tt.args.product.Price, tt.args.product.ThumbnailID, tt.args.product.OwnerID, tt.args.product.ExternalSKU, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 				WillReturnResult(sqlmock.NewResult(1, 1)).
 				WillReturnError(tt.mockErr)
 
 			if tt.mockIndex != nil {
-				stringReader := strings.NewReader("test")
-				stringReadCloser := ioutil.NopCloser(stringReader)
-				osClient.EXPECT().Index(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(&opensearchapi.Response{
-					Body: stringReadCloser,
-				}, tt.mockIndex.err)
+				searcher.EXPECT().Index(gomock.Any(), gomock.Any()).Times(1).Return(tt.mockIndex.err)
 			}
 
 			if tt.wantErr {
@@ -117,6 +113,11 @@ func Test_productRepository_Create(t *testing.T) {
 				dbMock.ExpectCommit()
 			}
 
+			if tt.mockIndex != nil {
+				dbMock.ExpectQuery("SELECT \"user_id\" FROM \"product_collaborators\"").
+					WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+			}
+
 			if err := r.Create(context.TODO(), tt.args.product); (err != nil) != tt.wantErr {
 				t.Errorf("productRepository.Create() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -157,6 +158,24 @@ func Test_productRepository_Update(t *testing.T) {
 			mockErr: nil,
 			wantErr: false,
 		},
+		{
+			name: "success with zero values",
+			args: args{
+				product: &model.Product{
+					ID:          productID,
+					Name:        "new product",
+					Description: "",
+					Price:       0,
+					ThumbnailID: "",
+					OwnerID:     utils.GenerateUUID(),
+				},
+			},
+			mockIndex: &mockIndex{
+				err: nil,
+			},
+			mockErr: nil,
+			wantErr: false,
+		},
 		{
 			name: "db error",
 			args: args{
@@ -179,23 +198,19 @@ func Test_productRepository_Update(t *testing.T) {
 			defer ctrl.Finish()
 
 			r, dbMock, _ := newProductRepoMock(t)
-			osClient := kitMock.NewMockOpensearchClient(ctrl)
+			searcher := mock.NewMockProductSearcher(ctrl)
 
-			err := r.InjectOpensearchClient(osClient)
+			err := r.InjectProductSearcher(searcher)
 			utils.ContinueOrFatal(err)
 
 			dbMock.ExpectBegin()
-			dbMock.ExpectExec("UPDATE \"products\"").
-				WithArgs(tt.args.product.Name, tt.args.product.Description, tt.args.product.Price, tt.args.product.ThumbnailID, tt.args.product.OwnerID, sqlmock.AnyArg(), productID).
+			dbMock.ExpectExec("UPDATE \"products\" SET \"name\"=\\$1,\"description\"=\\$2,\"price\"=\\$3,\"thumbnail_id\"=\\$4,\"updated_at\"=\\$5 WHERE").
+				WithArgs(tt.args.product.Name, tt.args.product.Description, tt.args.product.Price, tt.args.product.ThumbnailID, sqlmock.AnyArg(), productID).
 				WillReturnResult(sqlmock.NewResult(1, 1)).
 				WillReturnError(tt.mockErr)
 
 			if tt.mockIndex != nil {
-				stringReader := strings.NewReader("test")
-				stringReadCloser := ioutil.NopCloser(stringReader)
-				osClient.EXPECT().Index(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(&opensearchapi.Response{
-					Body: stringReadCloser,
-				}, tt.mockIndex.err)
+				searcher.EXPECT().Index(gomock.Any(), gomock.Any()).Times(1).Return(tt.mockIndex.err)
 			}
 
 			if tt.wantErr {
@@ -203,6 +218,11 @@ func Test_productRepository_Update(t *testing.T) {
 			} else {
 				dbMock.ExpectCommit()
 			}
+
+			if tt.mockIndex != nil {
+				dbMock.ExpectQuery("SELECT \"user_id\" FROM \"product_collaborators\"").
+					WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+			}
 			if err := r.Update(context.TODO(), tt.args.product); (err != nil) != tt.wantErr {
 				t.Errorf("productRepository.Update() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -251,9 +271,9 @@ func Test_productRepository_DeleteByID(t *testing.T) {
 			defer ctrl.Finish()
 
 			r, dbMock, _ := newProductRepoMock(t)
-			osClient := kitMock.NewMockOpensearchClient(ctrl)
+			searcher := mock.NewMockProductSearcher(ctrl)
 
-			err := r.InjectOpensearchClient(osClient)
+			err := r.InjectProductSearcher(searcher)
 			utils.ContinueOrFatal(err)
 
 			dbMock.ExpectBegin()
@@ -261,17 +281,13 @@ func Test_productRepository_DeleteByID(t *testing.T) {
 
 			row.AddRow(tt.args.id)
 
-			dbMock.ExpectQuery("UPDATE \"products\"").
-				WithArgs(sqlmock.AnyArg(), tt.args.id).
+			dbMock.ExpectQuery("^UPDATE \"products\" SET \"deleted_at\"=\\$1,\"updated_at\"=\\$2 WHERE id = \\$3 AND \"products\".\"deleted_at\" IS NULL RETURNING").
+				WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), tt.args.id).
 				WillReturnRows(row).
 				WillReturnError(tt.mockErr)
 
 			if tt.mockIndex != nil {
-				stringReader := strings.NewReader("test")
-				stringReadCloser := ioutil.NopCloser(stringReader)
-				osClient.EXPECT().Index(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(&opensearchapi.Response{
-					Body: stringReadCloser,
-				}, tt.mockIndex.err)
+				searcher.EXPECT().Index(gomock.Any(), gomock.Any()).Times(1).Return(tt.mockIndex.err)
 			}
 
 			if tt.wantErr {
@@ -279,6 +295,11 @@ func Test_productRepository_DeleteByID(t *testing.T) {
 			} else {
 				dbMock.ExpectCommit()
 			}
+
+			if tt.mockIndex != nil {
+				dbMock.ExpectQuery("SELECT \"user_id\" FROM \"product_collaborators\"").
+					WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+			}
 			if err := r.DeleteByID(context.TODO(), tt.args.id); (err != nil) != tt.wantErr {
 				t.Errorf("productRepository.DeleteByID() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -286,6 +307,89 @@ func Test_productRepository_DeleteByID(t *testing.T) {
 	}
 }
 
+func Test_productRepository_RestoreByID(t *testing.T) {
+	productID := utils.GenerateUUID()
+	type mockIndex struct {
+		err error
+	}
+	type args struct {
+		id string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		mockIndex   *mockIndex
+		mockErr     error
+		wantListing bool
+		wantErr     bool
+	}{
+		{
+			name: "success",
+			args: args{
+				id: productID,
+			},
+			mockIndex: &mockIndex{
+				err: nil,
+			},
+			mockErr:     nil,
+			wantListing: true,
+			wantErr:     false,
+		},
+		{
+			name: "db error",
+			args: args{
+				id: productID,
+			},
+			mockErr: errors.New("db error"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			r, dbMock, miniRedis := newProductRepoMock(t)
+			searcher := mock.NewMockProductSearcher(ctrl)
+
+			err := r.InjectProductSearcher(searcher)
+			utils.ContinueOrFatal(err)
+
+			dbMock.ExpectBegin()
+			row := sqlmock.NewRows([]string{"id", "price", "created_at"})
+
+			row.AddRow(tt.args.id, 17.17, time.Now())
+
+			dbMock.ExpectQuery("^UPDATE \"products\" SET \"deleted_at\"=\\$1,\"updated_at\"=\\$2 WHERE id = \\$3 RETURNING").
+				WithArgs(nil, sqlmock.AnyArg(), tt.args.id).
+				WillReturnRows(row).
+				WillReturnError(tt.mockErr)
+
+			if tt.mockIndex != nil {
+				searcher.EXPECT().Index(gomock.Any(), gomock.Any()).Times(1).Return(tt.mockIndex.err)
+			}
+
+			if tt.wantErr {
+				dbMock.ExpectRollback()
+			} else {
+				dbMock.ExpectCommit()
+			}
+
+			if tt.mockIndex != nil {
+				dbMock.ExpectQuery("SELECT \"user_id\" FROM \"product_collaborators\"").
+					WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+			}
+			if err := r.RestoreByID(context.TODO(), tt.args.id); (err != nil) != tt.wantErr {
+				t.Errorf("productRepository.RestoreByID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			_, err = miniRedis.ZScore(model.ProductListingPriceKey, tt.args.id)
+			if (err == nil) != tt.wantListing {
+				t.Errorf("productRepository.RestoreByID() listing error = %v, wantListing %v", err, tt.wantListing)
+			}
+		})
+	}
+}
+
 func Test_productRepository_FindPaginatedIDs(t *testing.T) {
 	productIds := []string{utils.GenerateUUID(), utils.GenerateUUID()}
 	type args struct {
@@ -304,6 +408,7 @@ func Test_productRepository_FindPaginatedIDs(t *testing.T) {
 		args       args
 		mockCount  *mockCount
 		mockSelect *mockSelect
+		wantSearch string // pattern bound to every search column
 		wantIds    []string
 		wantCount  int64
 		wantErr    bool
@@ -330,6 +435,52 @@ func Test_productRepository_FindPaginatedIDs(t *testing.T) {
 			wantCount: int64(len(productIds)),
 			wantErr:   false,
 		},
+		{
+			name: "search is bound and escaped",
+			args: args{
+				req: &model.PaginationPayload{
+					Search: `kopi' OR '1'='1 100%_\`,
+					Sort:   []string{},
+					Limit:  10,
+					Page:   1,
+				},
+			},
+			mockCount: &mockCount{
+				count: int64(len(productIds)),
+				err:   nil,
+			},
+			mockSelect: &mockSelect{
+				ids: productIds,
+				err: nil,
+			},
+			wantSearch: `%kopi' OR '1'='1 100\%\_\\%`,
+			wantIds:    productIds,
+			wantCount:  int64(len(productIds)),
+			wantErr:    false,
+		},
+		{
+			name: "success owned or shared",
+			args: args{
+				req: &model.PaginationPayload{
+					Sort:          []string{},
+					Limit:         10,
+					Page:          1,
+					OwnedOrShared: true,
+					UserID:        utils.GenerateUUID(),
+				},
+			},
+			mockCount: &mockCount{
+				count: int64(len(productIds)),
+				err:   nil,
+			},
+			mockSelect: &mockSelect{
+				ids: productIds,
+				err: nil,
+			},
+			wantIds:   productIds,
+			wantCount: int64(len(productIds)),
+			wantErr:   false,
+		},
 		{
 			name: "count error",
 			args: args{
@@ -379,9 +530,18 @@ func Test_productRepository_FindPaginatedIDs(t *testing.T) {
 			if tt.mockCount != nil {
 				row := sqlmock.NewRows([]string{"count"}).
 					AddRow(tt.mockCount.count)
-				dbMock.ExpectQuery("^SELECT COUNT.+ FROM \"products\"").
-					// WithArgs().
-					WillReturnRows(row).
+				sql := "^SELECT COUNT.+ FROM \"products\""
+				if tt.wantSearch != "" {
+					sql = `^SELECT COUNT.+ FROM "products" WHERE \(\(name ILIKE \$1 OR description ILIKE \$2\)\)`
+				}
+				query := dbMock.ExpectQuery(sql)
+				if tt.wantSearch != "" {
+					query.WithArgs(tt.wantSearch, tt.wantSearch)
+				}
+				if tt.args.req.OwnedOrShared {
+					query.WithArgs(tt.args.req.UserID, tt.args.req.UserID)
+				}
+				query.WillReturnRows(row).
 					WillReturnError(tt.mockCount.err)
 			}
 			if tt.mockSelect != nil {
@@ -389,7 +549,11 @@ func Test_productRepository_FindPaginatedIDs(t *testing.T) {
 				for _, id := range tt.mockSelect.ids {
 					row.AddRow(id)
 				}
-				dbMock.ExpectQuery("^SELECT .+ FROM \"products\"").
+				sql := "^SELECT .+ FROM \"products\""
+				if tt.args.req.OwnedOrShared {
+					sql = "^SELECT .+ FROM \"products\" WHERE .+owner_id = .+ OR id IN \\(SELECT product_id FROM product_collaborators WHERE user_id = "
+				}
+				dbMock.ExpectQuery(sql).
 					WillReturnRows(row).
 					WillReturnError(tt.mockSelect.err)
 			}
@@ -505,6 +669,294 @@ func Test_productRepository_FindByID(t *testing.T) {
 	}
 }
 
+func Test_productRepository_CountByOwnerID(t *testing.T) {
+	ownerID := utils.GenerateUUID()
+	type args struct {
+		ownerID string
+	}
+	type mockCount struct {
+		count int64
+		err   error
+	}
+	tests := []struct {
+		name      string
+		args      args
+		mockCount *mockCount
+		want      int64
+		wantErr   bool
+	}{
+		{
+			name: "success",
+			args: args{
+				ownerID: ownerID,
+			},
+			mockCount: &mockCount{
+				count: 3,
+				err:   nil,
+			},
+			want:    3,
+			wantErr: false,
+		},
+		{
+			name: "db error",
+			args: args{
+				ownerID: ownerID,
+			},
+			mockCount: &mockCount{
+				count: 0,
+				err:   errors.New("db error"),
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, dbMock, _ := newProductRepoMock(t)
+			if tt.mockCount != nil {
+				row := sqlmock.NewRows([]string{"count"}).
+					AddRow(tt.mockCount.count)
+				dbMock.ExpectQuery("^SELECT count\\(\\*\\) FROM \"products\" WHERE owner_id = .+ AND \"products\".\"deleted_at\" IS NULL").
+					WithArgs(tt.args.ownerID).
+					WillReturnRows(row).
+					WillReturnError(tt.mockCount.err)
+			}
+			got, err := r.CountByOwnerID(context.TODO(), tt.args.ownerID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productRepository.CountByOwnerID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("productRepository.CountByOwnerID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_productRepository_LockOwner(t *testing.T) {
+	ownerID := utils.GenerateUUID()
+	tests := []struct {
+		name    string
+		lockErr error
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			lockErr: nil,
+			wantErr: false,
+		},
+		{
+			name:    "db error",
+			lockErr: errors.New("db error"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, dbMock, _ := newProductRepoMock(t)
+			dbMock.ExpectBegin()
+			expectLock := dbMock.ExpectExec("^SELECT pg_advisory_xact_lock\\(hashtext\\(.+\\)\\)").
+				WithArgs(model.NewProductOwnerLockKey(ownerID))
+			if tt.lockErr != nil {
+				expectLock.WillReturnError(tt.lockErr)
+				dbMock.ExpectRollback()
+			} else {
+				expectLock.WillReturnResult(sqlmock.NewResult(0, 0))
+				dbMock.ExpectCommit()
+			}
+
+			err := utils.RunInTx(context.TODO(), r.(*productRepository).db, func(txCtx context.Context) error {
+				return r.LockOwner(txCtx, ownerID)
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productRepository.LockOwner() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err := dbMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("productRepository.LockOwner() %v", err)
+			}
+		})
+	}
+}
+
+func Test_productRepository_FindExportBatch(t *testing.T) {
+	ownerID := utils.GenerateUUID()
+	productID := utils.GenerateUUID()
+	updatedAt := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	type args struct {
+		req   *model.ExportProductsPayload
+		after *model.ProductExportCheckpoint
+		limit int
+	}
+	type mockSelect struct {
+		query string
+		args  []driver.Value
+		err   error
+	}
+	tests := []struct {
+		name       string
+		args       args
+		mockSelect *mockSelect
+		want       model.Products
+		wantErr    bool
+	}{
+		{
+			name: "first batch",
+			args: args{
+				req:   &model.ExportProductsPayload{},
+				limit: 2,
+			},
+			mockSelect: &mockSelect{
+				query: "^SELECT \\* FROM \"products\" WHERE \"deleted_at\" IS NULL ORDER BY updated_at,id LIMIT 2",
+				args:  []driver.Value{},
+				err:   nil,
+			},
+			want: model.Products{
+				{
+					ID:        productID,
+					OwnerID:   ownerID,
+					UpdatedAt: updatedAt,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "filtered batch after checkpoint",
+			args: args{
+				req: &model.ExportProductsPayload{
+					OwnerID:        ownerID,
+					UpdatedSince:   "2026-10-19T00:00:00Z",
+					IncludeDeleted: true,
+				},
+				after: &model.ProductExportCheckpoint{
+					UpdatedAt: updatedAt,
+					ID:        productID,
+				},
+				limit: 2,
+			},
+			mockSelect: &mockSelect{
+				query: "^SELECT \\* FROM \"products\" WHERE owner_id = .+ AND updated_at >= .+ AND \\(updated_at, id\\) > \\(.+, .+\\) ORDER BY updated_at,id LIMIT 2",
+				args:  []driver.Value{ownerID, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), updatedAt, productID},
+				err:   nil,
+			},
+			want: model.Products{
+				{
+					ID:        productID,
+					OwnerID:   ownerID,
+					UpdatedAt: updatedAt,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "db error",
+			args: args{
+				req:   &model.ExportProductsPayload{},
+				limit: 2,
+			},
+			mockSelect: &mockSelect{
+				query: "^SELECT \\* FROM \"products\"",
+				args:  []driver.Value{},
+				err:   errors.New("db error"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, dbMock, _ := newProductRepoMock(t)
+			if tt.mockSelect != nil {
+				row := sqlmock.NewRows([]string{"id", "owner_id", "updated_at"}).
+					AddRow(productID, ownerID, updatedAt)
+				dbMock.ExpectQuery(tt.mockSelect.query).
+					WithArgs(tt.mockSelect.args...).
+					WillReturnRows(row).
+					WillReturnError(tt.mockSelect.err)
+			}
+			got, err := r.FindExportBatch(context.TODO(), tt.args.req, tt.args.after, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productRepository.FindExportBatch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("productRepository.FindExportBatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_productRepository_FindByOwnerIDAndExternalSKUs(t *testing.T) {
+	productID := utils.GenerateUUID()
+	ownerID := utils.GenerateUUID()
+	tests := []struct {
+		name         string
+		externalSKUs []string
+		mockSelect   bool
+		mockErr      error
+		want         model.Products
+		wantErr      bool
+	}{
+		{
+			name:         "success",
+			externalSKUs: []string{"A-1", "A-2"},
+			mockSelect:   true,
+			mockErr:      nil,
+			want: model.Products{
+				{
+					ID:          productID,
+					OwnerID:     ownerID,
+					ExternalSKU: "A-1",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:         "without sku",
+			externalSKUs: []string{},
+			mockSelect:   false,
+			want:         model.Products{},
+			wantErr:      false,
+		},
+		{
+			name:         "db error",
+			externalSKUs: []string{"A-1"},
+			mockSelect:   true,
+			mockErr:      errors.New("db error"),
+			want:         nil,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, dbMock, _ := newProductRepoMock(t)
+			if tt.mockSelect {
+				args := []driver.Value{ownerID}
+				for _, sku := range tt.externalSKUs {
+					args = append(args, sku)
+				}
+				row := sqlmock.NewRows([]string{"id", "owner_id", "external_sku"}).
+					AddRow(productID, ownerID, "A-1")
+				dbMock.ExpectQuery("^SELECT \\* FROM \"products\" WHERE owner_id = .+ AND external_sku IN \\(.+\\)$").
+					WithArgs(args...).
+					WillReturnRows(row).
+					WillReturnError(tt.mockErr)
+			}
+			got, err := r.FindByOwnerIDAndExternalSKUs(context.TODO(), ownerID, tt.externalSKUs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productRepository.FindByOwnerIDAndExternalSKUs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("productRepository.FindByOwnerIDAndExternalSKUs() = %v, want %v", got, tt.want)
+			}
+			if err := dbMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("productRepository.FindByOwnerIDAndExternalSKUs() %v", err)
+			}
+		})
+	}
+}
+
 func Test_productRepository_UpdateAllThumbnail(t *testing.T) {
 	type args struct {
 		oldThumbnailID string
@@ -540,93 +992,125 @@ func Test_productRepository_UpdateAllThumbnail(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			r, dbMock, _ := newProductRepoMock(t)
+			r, dbMock, miniRedis := newProductRepoMock(t)
+			searcher := mock.NewMockProductSearcher(ctrl)
+
+			err := r.InjectProductSearcher(searcher)
+			utils.ContinueOrFatal(err)
+
+			productID := utils.GenerateUUID()
+			cacheKey := model.NewProductCacheKey(productID)
+			err = miniRedis.Set(cacheKey, "null")
+			utils.ContinueOrFatal(err)
 
 			dbMock.ExpectBegin()
-			dbMock.ExpectExec("UPDATE \"products\"").
+			dbMock.ExpectQuery("UPDATE \"products\" SET .+ RETURNING").
 				WithArgs(tt.args.newThumbnailID, sqlmock.AnyArg(), tt.args.oldThumbnailID).
-				WillReturnResult(sqlmock.NewResult(1, 1)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "thumbnail_id"}).AddRow(productID, tt.args.newThumbnailID)).
 				WillReturnError(tt.mockErr)
 
 			if tt.wantErr {
 				dbMock.ExpectRollback()
 			} else {
 				dbMock.ExpectCommit()
+				// the updated products are indexed once the update is committed
+				dbMock.ExpectQuery("SELECT \"product_id\",\"user_id\" FROM \"product_collaborators\" WHERE product_id IN").
+					WithArgs(productID).
+					WillReturnRows(sqlmock.NewRows([]string{"product_id", "user_id"}))
+				searcher.EXPECT().Index(gomock.Any(), gomock.Any()).Times(1).Return(nil)
 			}
 
-			if err := r.UpdateAllThumbnail(context.TODO(), tt.args.oldThumbnailID, tt.args.newThumbnailID); (err != nil) != tt.wantErr {
+			var got model.Products
+			err = utils.RunInTx(context.TODO(), r.(*productRepository).db, func(txCtx context.Context) error {
+				var err error
+				got, err = r.UpdateAllThumbnail(txCtx, tt.args.oldThumbnailID, tt.args.newThumbnailID)
+				return err
+			})
+			if (err != nil) != tt.wantErr {
 				t.Errorf("productRepository.UpdateAllThumbnail() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got.IDs(), []string{productID}) {
+				t.Errorf("productRepository.UpdateAllThumbnail() = %v, want %v", got.IDs(), []string{productID})
+			}
+			if err := dbMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("productRepository.UpdateAllThumbnail() %v", err)
+			}
+			if miniRedis.Exists(cacheKey) != tt.wantErr {
+				t.Errorf("productRepository.UpdateAllThumbnail() cache of %s exists = %v after the update", productID, miniRedis.Exists(cacheKey))
 			}
 		})
 	}
 }
-
-func Test_productRepository_FindOSPaginatedIDs(t *testing.T) {
+func Test_productRepository_FindRelatedIDs(t *testing.T) {
+	product := &model.Product{
+		ID:      "1fc34a8d-3d77-4f40-acbc-789c06b4fa5d",
+		Name:    "sample product",
+		Price:   100,
+		OwnerID: "cd9614c8-112a-4374-9737-eb62cc5d6aef",
+	}
 	type args struct {
-		req *model.PaginationPayload
+		product *model.Product
+		req     *model.RelatedPayload
 	}
-	type osMock struct {
-		resp string
-		err  error
+	type searcherMock struct {
+		ids []string
+		err error
 	}
 	tests := []struct {
-		name      string
-		args      args
-		osMock    *osMock
-		wantIds   []string
-		wantCount int64
-		wantErr   bool
+		name         string
+		args         args
+		cached       []string
+		searcherMock *searcherMock
+		wantIds      []string
+		wantErr      bool
 	}{
 		{
 			name: "success",
 			args: args{
-				req: &model.PaginationPayload{
-					Search: "sample product",
-					Sort:   []string{},
-					Limit:  10,
-					Page:   1,
+				product: product,
+				req: &model.RelatedPayload{
+					ID:        product.ID,
+					Limit:     10,
+					SameOwner: true,
+					PriceBand: 0.2,
 				},
 			},
-			osMock: &osMock{
-				resp: `{
-          "took": 33,
-          "timed_out": false,
-          "_shards": {
-            "total": 1,
-            "successful": 1,
-            "skipped": 0,
-            "failed": 0
-          },
-          "hits": {
-            "total": {
-              "value": 1,
-              "relation": "eq"
-            },
-            "max_score": 45.986057,
-            "hits": [
-              {
-                "_index": "products",
-                "_id": "1fc34a8d-3d77-4f40-acbc-789c06b4fa5d",
-                "_score": 45.986057,
-                "_source": {
-                  "id": "1fc34a8d-3d77-4f40-acbc-789c06b4fa5d",
-                  "name": "product-",
-                  "description": "description-1680269152107",
-                  "price": 5222789120,
-                  "owner_id": "cd9614c8-112a-4374-9737-eb62cc5d6aef",
-                  "created_at": "2023-03-31T13:25:52.448676961Z",
-                  "updated_at": "2023-03-31T13:25:52.448676961Z",
-                  "deleted_at": null
-                }
-              }
-            ]
-          }
-        }`,
+			searcherMock: &searcherMock{
+				ids: []string{"7b2b4fd5-4c4b-4bd4-9b0d-8e5a0f1e9e1a"},
 				err: nil,
 			},
-			wantIds:   []string{"1fc34a8d-3d77-4f40-acbc-789c06b4fa5d"},
-			wantCount: 1,
-			wantErr:   false,
+			wantIds: []string{"7b2b4fd5-4c4b-4bd4-9b0d-8e5a0f1e9e1a"},
+			wantErr: false,
+		},
+		{
+			name: "success from cache",
+			args: args{
+				product: product,
+				req: &model.RelatedPayload{
+					ID:    product.ID,
+					Limit: 10,
+				},
+			},
+			cached:  []string{"7b2b4fd5-4c4b-4bd4-9b0d-8e5a0f1e9e1a"},
+			wantIds: []string{"7b2b4fd5-4c4b-4bd4-9b0d-8e5a0f1e9e1a"},
+			wantErr: false,
+		},
+		{
+			name: "searcher error",
+			args: args{
+				product: product,
+				req: &model.RelatedPayload{
+					ID:    product.ID,
+					Limit: 10,
+				},
+			},
+			searcherMock: &searcherMock{
+				ids: []string{},
+				err: errors.New("searcher error"),
+			},
+			wantIds: []string{},
+			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
@@ -634,32 +1118,414 @@ func Test_productRepository_FindOSPaginatedIDs(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			r, _, _ := newProductRepoMock(t)
-			osClient := kitMock.NewMockOpensearchClient(ctrl)
+			r, _, miniRedis := newProductRepoMock(t)
+			searcher := mock.NewMockProductSearcher(ctrl)
 
-			err := r.InjectOpensearchClient(osClient)
+			err := r.InjectProductSearcher(searcher)
 			utils.ContinueOrFatal(err)
 
-			if tt.osMock != nil {
-				body := io.NopCloser(strings.NewReader(tt.osMock.resp))
-				osClient.EXPECT().Search(gomock.Any(), gomock.Any(), gomock.Any()).
+			if tt.cached != nil {
+				cachedData, _ := json.Marshal(tt.cached)
+				miniRedis.HSet(model.NewProductRelatedCacheKey(tt.args.product.ID), tt.args.req.CacheField(), string(cachedData))
+			}
+
+			if tt.searcherMock != nil {
+				searcher.EXPECT().FindRelatedIDs(gomock.Any(), tt.args.product, tt.args.req).
 					Times(1).
-					Return(&opensearchapi.Response{
-						StatusCode: 200,
-						Body:       body,
-					}, tt.osMock.err)
+					Return(tt.searcherMock.ids, tt.searcherMock.err)
 			}
 
-			gotIds, gotCount, err := r.FindOSPaginatedIDs(context.TODO(), tt.args.req)
+			gotIds, err := r.FindRelatedIDs(context.TODO(), tt.args.product, tt.args.req)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("productRepository.FindOSPaginatedIDs() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("productRepository.FindRelatedIDs() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if !reflect.DeepEqual(gotIds, tt.wantIds) {
-				t.Errorf("productRepository.FindOSPaginatedIDs() gotIds = %v, want %v", gotIds, tt.wantIds)
+				t.Errorf("productRepository.FindRelatedIDs() gotIds = %v, want %v", gotIds, tt.wantIds)
 			}
-			if gotCount != tt.wantCount {
-				t.Errorf("productRepository.FindOSPaginatedIDs() gotCount = %v, want %v", gotCount, tt.wantCount)
+		})
+	}
+}
+
+func Test_productRepository_FindRelatedIDs_Invalidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r, dbMock, miniRedis := newProductRepoMock(t)
+	searcher := mock.NewMockProductSearcher(ctrl)
+	err := r.InjectProductSearcher(searcher)
+	utils.ContinueOrFatal(err)
+
+	product := &model.Product{ID: utils.GenerateUUID()}
+	related := &model.Product{ID: utils.GenerateUUID(), Name: "related product"}
+	req := &model.RelatedPayload{Limit: 5}
+
+	searcher.EXPECT().FindRelatedIDs(gomock.Any(), product, req).Times(1).Return([]string{related.ID}, nil)
+	_, err = r.FindRelatedIDs(context.TODO(), product, req)
+	utils.ContinueOrFatal(err)
+	if !miniRedis.Exists(model.NewProductRelatedCacheKey(product.ID)) {
+		t.Fatalf("productRepository.FindRelatedIDs() didn't cache the related products")
+	}
+
+	// updating a listed product must drop the related products of the other product
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec("UPDATE \"products\"").WillReturnResult(sqlmock.NewResult(1, 1))
+	dbMock.ExpectCommit()
+	dbMock.ExpectQuery("SELECT \"user_id\" FROM \"product_collaborators\"").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+	searcher.EXPECT().Index(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+	err = r.Update(context.TODO(), related)
+	utils.ContinueOrFatal(err)
+
+	for _, key := range []string{model.NewProductRelatedCacheKey(product.ID), model.NewProductRelatedTagKey(related.ID)} {
+		if miniRedis.Exists(key) {
+			t.Errorf("productRepository.Update() kept %s", key)
+		}
+	}
+}
+
+func Test_productRepository_Reindex(t *testing.T) {
+	product := &model.Product{
+		ID:      utils.GenerateUUID(),
+		Name:    "sample product",
+		OwnerID: utils.GenerateUUID(),
+	}
+	collaboratorIDs := []string{utils.GenerateUUID()}
+	type mockIndex struct {
+		err error
+	}
+	tests := []struct {
+		name      string
+		mockErr   error
+		mockIndex *mockIndex
+		wantErr   bool
+	}{
+		{
+			name:    "success",
+			mockErr: nil,
+			mockIndex: &mockIndex{
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "error when load collaborators",
+			mockErr: errors.New("db error"),
+			wantErr: true,
+		},
+		{
+			name:    "error when index",
+			mockErr: nil,
+			mockIndex: &mockIndex{
+				err: errors.New("searcher error"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			r, dbMock, _ := newProductRepoMock(t)
+			searcher := mock.NewMockProductSearcher(ctrl)
+
+			err := r.InjectProductSearcher(searcher)
+			utils.ContinueOrFatal(err)
+
+			rows := sqlmock.NewRows([]string{"user_id"})
+			for _, collaboratorID := range collaboratorIDs {
+				rows.AddRow(collaboratorID)
+			}
+			dbMock.ExpectQuery("SELECT \"user_id\" FROM \"product_collaborators\"").
+				WithArgs(product.ID).
+				WillReturnRows(rows).
+				WillReturnError(tt.mockErr)
+
+			if tt.mockIndex != nil {
+				searcher.EXPECT().Index(gomock.Any(), &model.Product{
+					ID:              product.ID,
+					Name:            product.Name,
+					OwnerID:         product.OwnerID,
+					CollaboratorIDs: collaboratorIDs,
+				}).Times(1).Return(tt.mockIndex.err)
+			}
+
+			if err := r.Reindex(context.TODO(), product); (err != nil) != tt.wantErr {
+				t.Errorf("productRepository.Reindex() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_productRepository_TransferOwnership(t *testing.T) {
+	productID := utils.GenerateUUID()
+	otherProductID := utils.GenerateUUID()
+	fromOwnerID := utils.GenerateUUID()
+	toOwnerID := utils.GenerateUUID()
+	collaboratorID := utils.GenerateUUID()
+	type mockIndex struct {
+		err error
+	}
+	tests := []struct {
+		name      string
+		payload   *model.TransferOwnershipPayload
+		mockIDs   []string
+		mockErr   error
+		mockIndex *mockIndex
+		wantIDs   []string
+		wantErr   error
+	}{
+		{
+			name: "success single product",
+			payload: &model.TransferOwnershipPayload{
+				ID:          productID,
+				FromOwnerID: fromOwnerID,
+				ToOwnerID:   toOwnerID,
+			},
+			mockIDs: []string{productID},
+			mockErr: nil,
+			mockIndex: &mockIndex{
+				err: nil,
+			},
+			wantIDs: []string{productID},
+			wantErr: nil,
+		},
+		{
+			name: "success all products of owner",
+			payload: &model.TransferOwnershipPayload{
+				FromOwnerID: fromOwnerID,
+				ToOwnerID:   toOwnerID,
+			},
+			mockIDs: []string{productID, otherProductID},
+			mockErr: nil,
+			mockIndex: &mockIndex{
+				err: nil,
+			},
+			wantIDs: []string{productID, otherProductID},
+			wantErr: nil,
+		},
+		{
+			name: "db error",
+			payload: &model.TransferOwnershipPayload{
+				ID:          productID,
+				FromOwnerID: fromOwnerID,
+				ToOwnerID:   toOwnerID,
+			},
+			mockIDs: []string{productID},
+			mockErr: errors.New("db error"),
+			wantErr: errors.New("db error"),
+		},
+		{
+			name: "external sku already used by the new owner",
+			payload: &model.TransferOwnershipPayload{
+				ID:          productID,
+				FromOwnerID: fromOwnerID,
+				ToOwnerID:   toOwnerID,
+			},
+			mockIDs: []string{productID},
+			mockErr: &pgconn.PgError{
+				Code:           "23505",
+				ConstraintName: "idx_products_owner_id_external_sku",
+			},
+			wantErr: model.ErrExternalSKUConflict,
+		},
+		{
+			name: "error when index don't fail the committed transfer",
+			payload: &model.TransferOwnershipPayload{
+				FromOwnerID: fromOwnerID,
+				ToOwnerID:   toOwnerID,
+			},
+			mockIDs: []string{productID, otherProductID},
+			mockErr: nil,
+			mockIndex: &mockIndex{
+				err: errors.New("searcher error"),
+			},
+			wantIDs: []string{productID, otherProductID},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			r, dbMock, miniRedis := newProductRepoMock(t)
+			searcher := mock.NewMockProductSearcher(ctrl)
+
+			err := r.InjectProductSearcher(searcher)
+			utils.ContinueOrFatal(err)
+
+			cacheKey := model.NewProductCacheKey(productID)
+			err = miniRedis.Set(cacheKey, "null")
+			utils.ContinueOrFatal(err)
+
+			args := []driver.Value{toOwnerID, sqlmock.AnyArg(), fromOwnerID}
+			if !tt.payload.IsBulk() {
+				args = append(args, productID)
+			}
+
+			rows := sqlmock.NewRows([]string{"id", "owner_id"})
+			collaboratorArgs := make([]driver.Value, 0)
+			for _, id := range tt.mockIDs {
+				rows.AddRow(id, toOwnerID)
+				collaboratorArgs = append(collaboratorArgs, id)
+			}
+
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("UPDATE \"products\" SET \"owner_id\"=\\$1,\"updated_at\"=\\$2 WHERE owner_id = \\$3").
+				WithArgs(args...).
+				WillReturnRows(rows).
+				WillReturnError(tt.mockErr)
+			if tt.mockErr != nil {
+				dbMock.ExpectRollback()
+			} else {
+				dbMock.ExpectCommit()
+			}
+
+			if tt.mockIndex != nil {
+				// the whole batch is indexed once the transfer is committed
+				dbMock.ExpectQuery("SELECT \"product_id\",\"user_id\" FROM \"product_collaborators\" WHERE product_id IN").
+					WithArgs(collaboratorArgs...).
+					WillReturnRows(sqlmock.NewRows([]string{"product_id", "user_id"}).AddRow(productID, collaboratorID))
+				searcher.EXPECT().Index(gomock.Any(), gomock.Any()).Times(len(tt.mockIDs)).
+					DoAndReturn(func(_ context.Context, product *model.Product) error {
+						wantCollaboratorIDs := []string{}
+						if product.ID == productID {
+							wantCollaboratorIDs = []string{collaboratorID}
+						}
+						if !reflect.DeepEqual(product.CollaboratorIDs, wantCollaboratorIDs) {
+							t.Errorf("productRepository.TransferOwnership() indexed %s with collaborators %v, want %v", product.ID, product.CollaboratorIDs, wantCollaboratorIDs)
+						}
+						return tt.mockIndex.err
+					})
+			}
+
+			var got model.Products
+			err = utils.RunInTx(context.TODO(), r.(*productRepository).db, func(txCtx context.Context) error {
+				var err error
+				got, err = r.TransferOwnership(txCtx, tt.payload)
+				return err
+			})
+			if (err != nil) != (tt.wantErr != nil) || (tt.wantErr == model.ErrExternalSKUConflict && !errors.Is(err, tt.wantErr)) {
+				t.Errorf("productRepository.TransferOwnership() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got.IDs(), tt.wantIDs) {
+				t.Errorf("productRepository.TransferOwnership() = %v, want %v", got.IDs(), tt.wantIDs)
+			}
+			if err := dbMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("productRepository.TransferOwnership() %v", err)
+			}
+			if miniRedis.Exists(cacheKey) != (tt.wantErr != nil) {
+				t.Errorf("productRepository.TransferOwnership() cache of %s exists = %v after the transfer", productID, miniRedis.Exists(cacheKey))
+			}
+		})
+	}
+}
+
+func Test_productRepository_UpsertInBatches(t *testing.T) {
+	createdID := utils.GenerateUUID()
+	updatedID := utils.GenerateUUID()
+	ownerID := utils.GenerateUUID()
+	newProducts := func() model.Products {
+		return model.Products{
+			{ID: createdID, Name: "Coffee", Price: 10, ThumbnailID: utils.GenerateUUID(), OwnerID: ownerID, ExternalSKU: "A-1"},
+			{ID: updatedID, Name: "Green tea", Price: 5, ThumbnailID: utils.GenerateUUID(), OwnerID: ownerID, ExternalSKU: "A-2", CreatedAt: time.Now()},
+		}
+	}
+	tests := []struct {
+		name      string
+		products  model.Products
+		mockErr   error
+		wantIndex bool
+		wantErr   error
+	}{
+		{
+			name:      "success",
+			products:  newProducts(),
+			mockErr:   nil,
+			wantIndex: true,
+			wantErr:   nil,
+		},
+		{
+			name:     "nothing to write",
+			products: model.Products{},
+			wantErr:  nil,
+		},
+		{
+			name:     "db error",
+			products: newProducts(),
+			mockErr:  errors.New("db error"),
+			wantErr:  errors.New("db error"),
+		},
+		{
+			name:     "external sku created concurrently",
+			products: newProducts(),
+			mockErr: &pgconn.PgError{
+				Code:           "23505",
+				ConstraintName: "idx_products_owner_id_external_sku",
+			},
+			wantErr: model.ErrExternalSKUConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			r, dbMock, miniRedis := newProductRepoMock(t)
+			searcher := mock.NewMockProductSearcher(ctrl)
+
+			err := r.InjectProductSearcher(searcher)
+			utils.ContinueOrFatal(err)
+
+			cacheKey := model.NewProductCacheKey(updatedID)
+			err = miniRedis.Set(cacheKey, "null")
+			utils.ContinueOrFatal(err)
+
+			dbMock.ExpectBegin()
+			if len(tt.products) > 0 {
+				// both products are written by one statement, in the savepoint of the batches
+				dbMock.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+				dbMock.ExpectExec("INSERT INTO \"products\" .* VALUES \\(.*\\),\\(.*\\) ON CONFLICT \\(\"id\"\\) DO UPDATE SET \"name\"=\"excluded\".\"name\",\"description\"=\"excluded\".\"description\",\"price\"=\"excluded\".\"price\",\"thumbnail_id\"=\"excluded\".\"thumbnail_id\",\"updated_at\"=\"excluded\".\"updated_at\"").
+					WillReturnResult(sqlmock.NewResult(0, 2)).
+					WillReturnError(tt.mockErr)
+				if tt.mockErr != nil {
+					dbMock.ExpectExec("ROLLBACK TO SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+				}
+			}
+			if tt.mockErr != nil {
+				dbMock.ExpectRollback()
+			} else {
+				dbMock.ExpectCommit()
+			}
+
+			if tt.wantIndex {
+				// the batch is indexed once the upsert is committed
+				dbMock.ExpectQuery("SELECT \"product_id\",\"user_id\" FROM \"product_collaborators\" WHERE product_id IN").
+					WithArgs(createdID, updatedID).
+					WillReturnRows(sqlmock.NewRows([]string{"product_id", "user_id"}))
+				searcher.EXPECT().Index(gomock.Any(), gomock.Any()).Times(len(tt.products)).Return(nil)
+			}
+
+			err = utils.RunInTx(context.TODO(), r.(*productRepository).db, func(txCtx context.Context) error {
+				return r.UpsertInBatches(txCtx, tt.products)
+			})
+			if (err != nil) != (tt.wantErr != nil) || (tt.wantErr == model.ErrExternalSKUConflict && !errors.Is(err, tt.wantErr)) {
+				t.Errorf("productRepository.UpsertInBatches() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err := dbMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("productRepository.UpsertInBatches() %v", err)
+			}
+			if miniRedis.Exists(cacheKey) == tt.wantIndex {
+				t.Errorf("productRepository.UpsertInBatches() cache of %s exists = %v after the upsert", updatedID, miniRedis.Exists(cacheKey))
+			}
+			_, err = miniRedis.ZScore(model.ProductListingPriceKey, createdID)
+			if (err == nil) != tt.wantIndex {
+				t.Errorf("productRepository.UpsertInBatches() listing error = %v, wantListing %v", err, tt.wantIndex)
 			}
 		})
 	}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type productRevisionRepository struct {
+	db *gorm.DB
+}
+
+func NewProductRevisionRepository() model.ProductRevisionRepository {
+	return new(productRevisionRepository)
+}
+
+// Create snapshot the products with the next revision number of each product,
+// writers of the same product are serialized by the row lock taken when the product is updated.
+func (r *productRevisionRepository) Create(ctx context.Context, revisions model.ProductRevisions) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	for _, revision := range revisions {
+		err := db.WithContext(ctx).Raw(`INSERT INTO product_revisions
+			(product_id, revision, name, description, price, thumbnail_id, owner_id, deleted_at, created_by)
+			SELECT ?, COALESCE(MAX(revision), 0) + 1, ?, ?, ?, ?, ?, ?, ? FROM product_revisions WHERE product_id = ?
+			RETURNING revision, created_at`,
+			revision.ProductID, revision.Name, revision.Description, revision.Price, revision.ThumbnailID,
+			revision.OwnerID, revision.DeletedAt, revision.CreatedBy, revision.ProductID,
+		).Row().Scan(&revision.Revision, &revision.CreatedAt)
+		if err != nil {
+			log.WithContext(ctx).WithField("productID", revision.ProductID).Error(err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *productRevisionRepository) FindByProductIDAndRevision(ctx context.Context, productID string, revision int64) (*model.ProductRevision, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"productID": productID,
+		"revision":  revision,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	productRevision := new(model.ProductRevision)
+	err := db.WithContext(ctx).
+		Where("product_id = ? AND revision = ?", productID, revision).
+		First(productRevision).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return productRevision, nil
+}
+
+func (r *productRevisionRepository) FindPaginatedByProductID(ctx context.Context, req *model.ProductRevisionsPayload) (revisions model.ProductRevisions, count int64, err error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"productID": req.ProductID,
+		"page":      req.Page,
+		"limit":     req.Limit,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	err = db.WithContext(ctx).
+		Model(&model.ProductRevision{}).
+		Where("product_id = ?", req.ProductID).
+		Count(&count).Error
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, 0, err
+	}
+
+	revisions = make(model.ProductRevisions, 0)
+	err = db.WithContext(ctx).
+		Scopes(WithPagination(req.Page, req.Limit)).
+		Where("product_id = ?", req.ProductID).
+		Order("revision DESC").
+		Find(&revisions).Error
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, 0, err
+	}
+
+	return revisions, count, nil
+}
@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+)
+
+func newProductRevisionRepoMock() (model.ProductRevisionRepository, sqlmock.Sqlmock) {
+	db, sqlMock := utils.NewDBMock()
+	revisionRepo := NewProductRevisionRepository()
+	err := revisionRepo.InjectDB(db)
+	utils.ContinueOrFatal(err)
+
+	return revisionRepo, sqlMock
+}
+
+func Test_productRevisionRepository_Create(t *testing.T) {
+	productID := utils.GenerateUUID()
+	actorID := utils.GenerateUUID()
+	now := time.Now()
+	tests := []struct {
+		name         string
+		mockErr      error
+		wantRevision int64
+		wantErr      bool
+	}{
+		{
+			name:         "success",
+			mockErr:      nil,
+			wantRevision: 3,
+			wantErr:      false,
+		},
+		{
+			name:    "db error",
+			mockErr: errors.New("db error"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, dbMock := newProductRevisionRepoMock()
+
+			revision := model.NewProductRevision(&model.Product{
+				ID:          productID,
+				Name:        "sample product",
+				Description: "red cotton shirt",
+				Price:       100,
+				ThumbnailID: utils.GenerateUUID(),
+				OwnerID:     actorID,
+			}, actorID)
+
+			dbMock.ExpectQuery("(?s)INSERT INTO product_revisions.+SELECT.+COALESCE\\(MAX\\(revision\\), 0\\) \\+ 1.+RETURNING revision, created_at").
+				WithArgs(productID, revision.Name, revision.Description, revision.Price, revision.ThumbnailID, revision.OwnerID, nil, actorID, productID).
+				WillReturnRows(sqlmock.NewRows([]string{"revision", "created_at"}).AddRow(3, now)).
+				WillReturnError(tt.mockErr)
+
+			err := r.Create(context.TODO(), model.ProductRevisions{revision})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productRevisionRepository.Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if revision.Revision != tt.wantRevision {
+				t.Errorf("productRevisionRepository.Create() revision = %v, want %v", revision.Revision, tt.wantRevision)
+			}
+		})
+	}
+}
+
+func Test_productRevisionRepository_FindByProductIDAndRevision(t *testing.T) {
+	productID := utils.GenerateUUID()
+	actorID := utils.GenerateUUID()
+	now := time.Now()
+	tests := []struct {
+		name     string
+		mockRows *sqlmock.Rows
+		mockErr  error
+		want     *model.ProductRevision
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockRows: sqlmock.NewRows([]string{"product_id", "revision", "name", "price", "created_by", "created_at"}).
+				AddRow(productID, 2, "sample product", 100, actorID, now),
+			want: &model.ProductRevision{
+				ProductID: productID,
+				Revision:  2,
+				Name:      "sample product",
+				Price:     100,
+				CreatedBy: actorID,
+				CreatedAt: now,
+			},
+			wantErr: false,
+		},
+		{
+			name:     "not found",
+			mockRows: sqlmock.NewRows([]string{"product_id", "revision"}),
+			want:     nil,
+			wantErr:  false,
+		},
+		{
+			name:     "db error",
+			mockRows: sqlmock.NewRows([]string{"product_id", "revision"}),
+			mockErr:  errors.New("db error"),
+			want:     nil,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, dbMock := newProductRevisionRepoMock()
+
+			dbMock.ExpectQuery("SELECT \\* FROM \"product_revisions\" WHERE product_id = \\$1 AND revision = \\$2").
+				WithArgs(productID, 2).
+				WillReturnRows(tt.mockRows).
+				WillReturnError(tt.mockErr)
+
+			got, err := r.FindByProductIDAndRevision(context.TODO(), productID, 2)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productRevisionRepository.FindByProductIDAndRevision() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("productRevisionRepository.FindByProductIDAndRevision() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_productRevisionRepository_FindPaginatedByProductID(t *testing.T) {
+	productID := utils.GenerateUUID()
+	now := time.Now()
+	tests := []struct {
+		name          string
+		mockCountErr  error
+		mockFindErr   error
+		wantRevisions model.ProductRevisions
+		wantCount     int64
+		wantErr       bool
+	}{
+		{
+			name: "success",
+			wantRevisions: model.ProductRevisions{
+				{
+					ProductID: productID,
+					Revision:  2,
+					CreatedAt: now,
+				},
+			},
+			wantCount: 2,
+			wantErr:   false,
+		},
+		{
+			name:         "error when count",
+			mockCountErr: errors.New("db error"),
+			wantErr:      true,
+		},
+		{
+			name:        "error when find",
+			mockFindErr: errors.New("db error"),
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, dbMock := newProductRevisionRepoMock()
+
+			dbMock.ExpectQuery("SELECT count\\(\\*\\) FROM \"product_revisions\" WHERE product_id = \\$1").
+				WithArgs(productID).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2)).
+				WillReturnError(tt.mockCountErr)
+
+			if tt.mockCountErr == nil {
+				dbMock.ExpectQuery("SELECT \\* FROM \"product_revisions\" WHERE product_id = \\$1 ORDER BY revision DESC LIMIT 1 OFFSET 1").
+					WithArgs(productID).
+					WillReturnRows(sqlmock.NewRows([]string{"product_id", "revision", "created_at"}).AddRow(productID, 2, now)).
+					WillReturnError(tt.mockFindErr)
+			}
+
+			gotRevisions, gotCount, err := r.FindPaginatedByProductID(context.TODO(), &model.ProductRevisionsPayload{
+				ProductID: productID,
+				Limit:     1,
+				Page:      2,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productRevisionRepository.FindPaginatedByProductID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotRevisions, tt.wantRevisions) {
+				t.Errorf("productRevisionRepository.FindPaginatedByProductID() gotRevisions = %v, want %v", gotRevisions, tt.wantRevisions)
+			}
+			if gotCount != tt.wantCount {
+				t.Errorf("productRevisionRepository.FindPaginatedByProductID() gotCount = %v, want %v", gotCount, tt.wantCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,300 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search/highlight/highlighter/html"
+	"github.com/blevesearch/bleve/v2/search/query"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	bleveHighlightPreTag  = "<mark>"
+	bleveHighlightPostTag = "</mark>"
+)
+
+var (
+	// text fields are sorted by their keyword sub field, just like the opensearch index.
+	bleveKeywordSortFields = map[string]string{
+		"name":        "name.keyword",
+		"description": "description.keyword",
+	}
+)
+
+type bleveProductSearcher struct {
+	index bleve.Index
+}
+
+func NewBleveProductSearcher(index bleve.Index) (model.ProductSearcher, error) {
+	if index == nil {
+		return nil, errors.New("invalid bleve index")
+	}
+	return &bleveProductSearcher{
+		index: index,
+	}, nil
+}
+
+func (s *bleveProductSearcher) InitIndex(ctx context.Context) error {
+	// the embedded index is created with its mapping when it is opened
+	log.WithContext(ctx).WithField("index", s.index.Name()).Info("bleve index ready")
+	return nil
+}
+
+func (s *bleveProductSearcher) Index(ctx context.Context, product *model.Product) error {
+	_, _, fn := utils.Trace()
+	_, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"productID": product.ID,
+	})
+
+	err := s.index.Index(product.ID, product.ToBleveDoc())
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (s *bleveProductSearcher) FindPaginatedIDs(ctx context.Context, req *model.PaginationPayload) (ids []string, highlights []*model.ProductHighlight, count int64, err error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"search": req.Search,
+		"sort":   req.Sort,
+		"page":   req.Page,
+		"limit":  req.Limit,
+	})
+	productIds := make([]string, 0)
+	highlights = make([]*model.ProductHighlight, 0)
+
+	var searchQuery query.Query = bleve.NewMatchAllQuery()
+	if req.Search != "" {
+		searchQuery = newBleveSearchQuery(req.Search, model.ProductSearchColumns)
+	}
+
+	boolQuery := bleve.NewBooleanQuery()
+	boolQuery.AddMust(searchQuery)
+	if !req.IncludeDeleted {
+		boolQuery.AddMust(newBleveNotDeletedQuery())
+	}
+	if req.OwnedOrShared {
+		boolQuery.AddMust(newBleveOwnedOrSharedQuery(req.UserID))
+	}
+
+	searchRequest := bleve.NewSearchRequestOptions(boolQuery, req.Limit, (req.Page-1)*req.Limit, false)
+	if len(req.Sort) > 0 {
+		searchRequest.SortBy(parseBleveSort(req.Sort))
+	}
+	if req.Highlight {
+		searchRequest.Highlight = bleve.NewHighlightWithStyle(html.Name)
+		for _, column := range model.ProductSearchColumns {
+			searchRequest.Highlight.AddField(column)
+		}
+	}
+
+	result, err := s.index.SearchInContext(ctx, searchRequest)
+	if err != nil {
+		logger.Error(err.Error())
+		return productIds, highlights, count, err
+	}
+
+	for _, hit := range result.Hits {
+		productIds = append(productIds, hit.ID)
+		highlight := &model.ProductHighlight{
+			ID:          hit.ID,
+			Name:        replaceBleveHighlightTags(hit.Fragments["name"], req),
+			Description: replaceBleveHighlightTags(hit.Fragments["description"], req),
+		}
+		if len(highlight.Name) == 0 && len(highlight.Description) == 0 {
+			continue
+		}
+		highlights = append(highlights, highlight)
+	}
+
+	return productIds, highlights, int64(result.Total), nil
+}
+
+func (s *bleveProductSearcher) FindRelatedIDs(ctx context.Context, product *model.Product, req *model.RelatedPayload) (ids []string, err error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"productID": product.ID,
+		"limit":     req.Limit,
+		"sameOwner": req.SameOwner,
+		"priceBand": req.PriceBand,
+	})
+	productIds := make([]string, 0)
+
+	// bleve has no more_like_this query, match any term of the source product instead
+	boolQuery := bleve.NewBooleanQuery()
+	boolQuery.AddMust(newBleveMatchQuery(strings.Join([]string{product.Name, product.Description}, " "), model.ProductSearchColumns))
+	boolQuery.AddMust(newBleveNotDeletedQuery())
+	boolQuery.AddMustNot(bleve.NewDocIDQuery([]string{product.ID}))
+
+	if req.SameOwner {
+		ownerQuery := bleve.NewTermQuery(product.OwnerID)
+		ownerQuery.SetField("owner_id")
+		boolQuery.AddMust(ownerQuery)
+	}
+
+	if req.PriceBand > 0 {
+		inclusive := true
+		minPrice := product.Price * (1 - req.PriceBand)
+		maxPrice := product.Price * (1 + req.PriceBand)
+		priceQuery := bleve.NewNumericRangeInclusiveQuery(&minPrice, &maxPrice, &inclusive, &inclusive)
+		priceQuery.SetField("price")
+		boolQuery.AddMust(priceQuery)
+	}
+
+	result, err := s.index.SearchInContext(ctx, bleve.NewSearchRequestOptions(boolQuery, req.Limit, 0, false))
+	if err != nil {
+		logger.Error(err.Error())
+		return productIds, err
+	}
+
+	for _, hit := range result.Hits {
+		productIds = append(productIds, hit.ID)
+	}
+
+	return productIds, nil
+}
+
+// Ping make sure the embedded index is still open, used by the readiness check.
+func (s *bleveProductSearcher) Ping(ctx context.Context) error {
+	_, err := s.index.DocCount()
+	return err
+}
+
+func (s *bleveProductSearcher) Close() error {
+	return s.index.Close()
+}
+
+func newBleveMatchQuery(value string, columns []string) query.Query {
+	disjuncts := make([]query.Query, 0)
+	for _, column := range columns {
+		matchQuery := bleve.NewMatchQuery(value)
+		matchQuery.SetField(column)
+		disjuncts = append(disjuncts, matchQuery)
+	}
+	return bleve.NewDisjunctionQuery(disjuncts...)
+}
+
+// newBleveSearchQuery mirror the opensearch multi_match, a product match when a column contain half of the search ngrams.
+// A short search has no ngram, its words are matched as prefixes of the indexed ngrams.
+func newBleveSearchQuery(value string, columns []string) query.Query {
+	terms := make([]string, 0)
+	seen := make(map[string]bool)
+	short := model.IsShortSearch(value)
+	for _, word := range model.SearchWords(strings.ToLower(value)) {
+		grams := []string{word}
+		if !short {
+			grams = bleveNgrams(word)
+		}
+		for _, gram := range grams {
+			if !seen[gram] {
+				seen[gram] = true
+				terms = append(terms, gram)
+			}
+		}
+	}
+
+	disjuncts := make([]query.Query, 0, len(columns))
+	for _, column := range columns {
+		termQueries := make([]query.Query, 0, len(terms))
+		for _, term := range terms {
+			if short {
+				prefixQuery := bleve.NewPrefixQuery(term)
+				prefixQuery.SetField(column)
+				termQueries = append(termQueries, prefixQuery)
+				continue
+			}
+			termQuery := bleve.NewTermQuery(term)
+			termQuery.SetField(column)
+			termQueries = append(termQueries, termQuery)
+		}
+		columnQuery := bleve.NewDisjunctionQuery(termQueries...)
+		columnQuery.SetMin(float64(bleveMinimumShouldMatch(len(termQueries))))
+		disjuncts = append(disjuncts, columnQuery)
+	}
+	return bleve.NewDisjunctionQuery(disjuncts...)
+}
+
+// bleveNgrams split the word like the product ngram token filter.
+func bleveNgrams(word string) []string {
+	runes := []rune(word)
+	grams := make([]string, 0)
+	for size := model.BleveProductNgramMin; size <= model.BleveProductNgramMax; size++ {
+		for i := 0; i+size <= len(runes); i++ {
+			grams = append(grams, string(runes[i:i+size]))
+		}
+	}
+	return grams
+}
+
+// bleveMinimumShouldMatch round down half of the clauses like opensearch, at least one clause must match.
+func bleveMinimumShouldMatch(clauses int) int {
+	if clauses < 2 {
+		return 1
+	}
+	return clauses / 2
+}
+
+func newBleveNotDeletedQuery() query.Query {
+	deletedQuery := bleve.NewBoolFieldQuery(false)
+	deletedQuery.SetField("deleted")
+	return deletedQuery
+}
+
+func newBleveOwnedOrSharedQuery(userID string) query.Query {
+	ownerQuery := bleve.NewTermQuery(userID)
+	ownerQuery.SetField("owner_id")
+	collaboratorQuery := bleve.NewTermQuery(userID)
+	collaboratorQuery.SetField("collaborator_ids")
+	return bleve.NewDisjunctionQuery(ownerQuery, collaboratorQuery)
+}
+
+// parseBleveSort follow the opensearch sort format.
+// -created_at = created_at desc.
+// +created_at = created_at asc.
+func parseBleveSort(sorts []string) []string {
+	results := make([]string, 0)
+	re := regexp.MustCompile(`[+-]`)
+	for _, sort := range sorts {
+		isAscOrder := strings.Contains(sort, "+")
+		sort = re.ReplaceAllString(sort, "")
+		if field, ok := bleveKeywordSortFields[sort]; ok {
+			sort = field
+		}
+		if !isAscOrder {
+			sort = "-" + sort
+		}
+		results = append(results, sort)
+	}
+	return results
+}
+
+// replaceBleveHighlightTags drop fragments without any match, opensearch only return matched fields.
+func replaceBleveHighlightTags(fragments []string, req *model.PaginationPayload) []string {
+	var results []string
+	replacer := strings.NewReplacer(bleveHighlightPreTag, req.HighlightPreTag, bleveHighlightPostTag, req.HighlightPostTag)
+	for _, fragment := range fragments {
+		if !strings.Contains(fragment, bleveHighlightPreTag) {
+			continue
+		}
+		results = append(results, replacer.Replace(fragment))
+	}
+	return results
+}
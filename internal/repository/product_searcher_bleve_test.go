@@ -0,0 +1,375 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/krobus00/product-service/internal/infrastructure"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+func newBleveProductSearcherMock(t *testing.T, products []*model.Product) model.ProductSearcher {
+	indexMapping, err := infrastructure.NewBleveIndexMapping()
+	utils.ContinueOrFatal(err)
+	index, err := bleve.NewMemOnly(indexMapping)
+	utils.ContinueOrFatal(err)
+	s, err := NewBleveProductSearcher(index)
+	utils.ContinueOrFatal(err)
+	t.Cleanup(func() {
+		_ = s.Close()
+	})
+
+	for _, product := range products {
+		err = s.Index(context.TODO(), product)
+		utils.ContinueOrFatal(err)
+	}
+
+	return s
+}
+
+func newBleveProducts() []*model.Product {
+	now := time.Now()
+	return []*model.Product{
+		{
+			ID:          "1fc34a8d-3d77-4f40-acbc-789c06b4fa5d",
+			Name:        "sample product",
+			Description: "red cotton shirt",
+			Price:       100,
+			OwnerID:     "cd9614c8-112a-4374-9737-eb62cc5d6aef",
+			CreatedAt:   now.Add(-3 * time.Hour),
+			UpdatedAt:   now.Add(-3 * time.Hour),
+		},
+		{
+			ID:          "7b2b4fd5-4c4b-4bd4-9b0d-8e5a0f1e9e1a",
+			Name:        "sample product 2",
+			Description: "blue cotton shirt",
+			Price:       110,
+			OwnerID:     "cd9614c8-112a-4374-9737-eb62cc5d6aef",
+			CreatedAt:   now.Add(-2 * time.Hour),
+			UpdatedAt:   now.Add(-2 * time.Hour),
+		},
+		{
+			ID:          "a4a7c0a6-1a8e-4a63-8f1d-8f5d2c9b7e11",
+			Name:        "another product",
+			Description: "cotton shirt",
+			Price:       500,
+			OwnerID:     "5b8f6f0e-0a0e-4c49-9a0c-7c6a3a2b1d00",
+			CreatedAt:   now.Add(-1 * time.Hour),
+			UpdatedAt:   now.Add(-1 * time.Hour),
+
+			CollaboratorIDs: []string{"cd9614c8-112a-4374-9737-eb62cc5d6aef"},
+		},
+		{
+			ID:          "e2f1c7d3-9b4a-4f0e-8c2d-1a3b5c7d9e0f",
+			Name:        "deleted sample",
+			Description: "cotton shirt",
+			Price:       100,
+			OwnerID:     "cd9614c8-112a-4374-9737-eb62cc5d6aef",
+			CreatedAt:   now,
+			UpdatedAt:   now,
+			DeletedAt: gorm.DeletedAt{
+				Time:  now,
+				Valid: true,
+			},
+		},
+	}
+}
+
+func Test_bleveProductSearcher_FindPaginatedIDs(t *testing.T) {
+	type args struct {
+		req *model.PaginationPayload
+	}
+	tests := []struct {
+		name           string
+		args           args
+		wantIds        []string
+		wantHighlights []*model.ProductHighlight
+		wantCount      int64
+		wantErr        bool
+	}{
+		{
+			name: "success without search",
+			args: args{
+				req: &model.PaginationPayload{
+					Sort:  []string{"-created_at"},
+					Limit: 10,
+					Page:  1,
+				},
+			},
+			wantIds: []string{
+				"a4a7c0a6-1a8e-4a63-8f1d-8f5d2c9b7e11",
+				"7b2b4fd5-4c4b-4bd4-9b0d-8e5a0f1e9e1a",
+				"1fc34a8d-3d77-4f40-acbc-789c06b4fa5d",
+			},
+			wantHighlights: []*model.ProductHighlight{},
+			wantCount:      3,
+			wantErr:        false,
+		},
+		{
+			name: "success with search and pagination",
+			args: args{
+				req: &model.PaginationPayload{
+					Search: "sample",
+					Sort:   []string{"+price"},
+					Limit:  1,
+					Page:   2,
+				},
+			},
+			wantIds:        []string{"7b2b4fd5-4c4b-4bd4-9b0d-8e5a0f1e9e1a"},
+			wantHighlights: []*model.ProductHighlight{},
+			wantCount:      2,
+			wantErr:        false,
+		},
+		{
+			name: "success include deleted",
+			args: args{
+				req: &model.PaginationPayload{
+					Search:         "sample",
+					Sort:           []string{"+name"},
+					Limit:          10,
+					Page:           1,
+					IncludeDeleted: true,
+				},
+			},
+			wantIds: []string{
+				"e2f1c7d3-9b4a-4f0e-8c2d-1a3b5c7d9e0f",
+				"1fc34a8d-3d77-4f40-acbc-789c06b4fa5d",
+				"7b2b4fd5-4c4b-4bd4-9b0d-8e5a0f1e9e1a",
+			},
+			wantHighlights: []*model.ProductHighlight{},
+			wantCount:      3,
+			wantErr:        false,
+		},
+		{
+			name: "success owned or shared",
+			args: args{
+				req: &model.PaginationPayload{
+					Sort:          []string{"-created_at"},
+					Limit:         10,
+					Page:          1,
+					OwnedOrShared: true,
+					UserID:        "cd9614c8-112a-4374-9737-eb62cc5d6aef",
+				},
+			},
+			wantIds: []string{
+				"a4a7c0a6-1a8e-4a63-8f1d-8f5d2c9b7e11",
+				"7b2b4fd5-4c4b-4bd4-9b0d-8e5a0f1e9e1a",
+				"1fc34a8d-3d77-4f40-acbc-789c06b4fa5d",
+			},
+			wantHighlights: []*model.ProductHighlight{},
+			wantCount:      3,
+			wantErr:        false,
+		},
+		{
+			name: "success owned only",
+			args: args{
+				req: &model.PaginationPayload{
+					Limit:         10,
+					Page:          1,
+					OwnedOrShared: true,
+					UserID:        "5b8f6f0e-0a0e-4c49-9a0c-7c6a3a2b1d00",
+				},
+			},
+			wantIds:        []string{"a4a7c0a6-1a8e-4a63-8f1d-8f5d2c9b7e11"},
+			wantHighlights: []*model.ProductHighlight{},
+			wantCount:      1,
+			wantErr:        false,
+		},
+		{
+			name: "success with a search shorter than the ngrams",
+			args: args{
+				req: &model.PaginationPayload{
+					Search: "An",
+					Limit:  10,
+					Page:   1,
+				},
+			},
+			wantIds:        []string{"a4a7c0a6-1a8e-4a63-8f1d-8f5d2c9b7e11"},
+			wantHighlights: []*model.ProductHighlight{},
+			wantCount:      1,
+			wantErr:        false,
+		},
+		{
+			name: "success with a one character search",
+			args: args{
+				req: &model.PaginationPayload{
+					Search: "b",
+					Limit:  10,
+					Page:   1,
+				},
+			},
+			wantIds:        []string{"7b2b4fd5-4c4b-4bd4-9b0d-8e5a0f1e9e1a"},
+			wantHighlights: []*model.ProductHighlight{},
+			wantCount:      1,
+			wantErr:        false,
+		},
+		{
+			name: "half of the ngrams must match",
+			args: args{
+				req: &model.PaginationPayload{
+					Search: "sample qwertyuiop",
+					Limit:  10,
+					Page:   1,
+				},
+			},
+			wantIds:        []string{},
+			wantHighlights: []*model.ProductHighlight{},
+			wantCount:      0,
+			wantErr:        false,
+		},
+		{
+			name: "success with highlight",
+			args: args{
+				req: &model.PaginationPayload{
+					Search:           "another",
+					Limit:            10,
+					Page:             1,
+					Highlight:        true,
+					HighlightPreTag:  "<b>",
+					HighlightPostTag: "</b>",
+				},
+			},
+			wantIds: []string{"a4a7c0a6-1a8e-4a63-8f1d-8f5d2c9b7e11"},
+			wantHighlights: []*model.ProductHighlight{
+				{
+					ID:   "a4a7c0a6-1a8e-4a63-8f1d-8f5d2c9b7e11",
+					Name: []string{"<b>another</b> product"},
+				},
+			},
+			wantCount: 1,
+			wantErr:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newBleveProductSearcherMock(t, newBleveProducts())
+
+			gotIds, gotHighlights, gotCount, err := s.FindPaginatedIDs(context.TODO(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("bleveProductSearcher.FindPaginatedIDs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotIds, tt.wantIds) {
+				t.Errorf("bleveProductSearcher.FindPaginatedIDs() gotIds = %v, want %v", gotIds, tt.wantIds)
+			}
+			if !reflect.DeepEqual(gotHighlights, tt.wantHighlights) {
+				t.Errorf("bleveProductSearcher.FindPaginatedIDs() gotHighlights = %v, want %v", gotHighlights, tt.wantHighlights)
+			}
+			if gotCount != tt.wantCount {
+				t.Errorf("bleveProductSearcher.FindPaginatedIDs() gotCount = %v, want %v", gotCount, tt.wantCount)
+			}
+		})
+	}
+}
+
+func Test_bleveProductSearcher_FindRelatedIDs(t *testing.T) {
+	products := newBleveProducts()
+	type args struct {
+		product *model.Product
+		req     *model.RelatedPayload
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantIds []string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: args{
+				product: products[0],
+				req: &model.RelatedPayload{
+					ID:    products[0].ID,
+					Limit: 10,
+				},
+			},
+			wantIds: []string{
+				"7b2b4fd5-4c4b-4bd4-9b0d-8e5a0f1e9e1a",
+				"a4a7c0a6-1a8e-4a63-8f1d-8f5d2c9b7e11",
+			},
+			wantErr: false,
+		},
+		{
+			name: "success same owner and price band",
+			args: args{
+				product: products[0],
+				req: &model.RelatedPayload{
+					ID:        products[0].ID,
+					Limit:     10,
+					SameOwner: true,
+					PriceBand: 0.2,
+				},
+			},
+			wantIds: []string{"7b2b4fd5-4c4b-4bd4-9b0d-8e5a0f1e9e1a"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newBleveProductSearcherMock(t, products)
+
+			gotIds, err := s.FindRelatedIDs(context.TODO(), tt.args.product, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("bleveProductSearcher.FindRelatedIDs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotIds, tt.wantIds) {
+				t.Errorf("bleveProductSearcher.FindRelatedIDs() gotIds = %v, want %v", gotIds, tt.wantIds)
+			}
+		})
+	}
+}
+
+func Test_bleveProductSearcher_Ping(t *testing.T) {
+	tests := []struct {
+		name    string
+		closed  bool
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			wantErr: false,
+		},
+		{
+			name:    "error index closed",
+			closed:  true,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newBleveProductSearcherMock(t, nil)
+			if tt.closed {
+				_ = s.Close()
+			}
+
+			if err := s.Ping(context.TODO()); (err != nil) != tt.wantErr {
+				t.Errorf("bleveProductSearcher.Ping() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_bleveIndex_Locked(t *testing.T) {
+	viper.Set("search.bleve.path", filepath.Join(t.TempDir(), "products.bleve"))
+	viper.Set("search.bleve.open_timeout", "100ms")
+	defer viper.Set("search.bleve.open_timeout", "")
+
+	index, err := infrastructure.NewBleveIndex()
+	utils.ContinueOrFatal(err)
+	defer index.Close()
+
+	// a second process, the worker next to the server, must fail fast rather than share the index
+	_, err = infrastructure.NewBleveIndex()
+	if !errors.Is(err, model.ErrBleveIndexLocked) {
+		t.Errorf("infrastructure.NewBleveIndex() error = %v, want %v", err, model.ErrBleveIndexLocked)
+	}
+}
@@ -0,0 +1,310 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/goccy/go-json"
+	kit "github.com/krobus00/krokit"
+	"github.com/krobus00/product-service/internal/constant"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+type opensearchProductSearcher struct {
+	osClient kit.OpensearchClient
+}
+
+func NewOpensearchProductSearcher(client kit.OpensearchClient) (model.ProductSearcher, error) {
+	if client == nil {
+		return nil, errors.New("invalid opensearch client")
+	}
+	return &opensearchProductSearcher{
+		osClient: client,
+	}, nil
+}
+
+func (s *opensearchProductSearcher) InitIndex(ctx context.Context) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"index": model.OSProductIndex,
+	})
+
+	resCreateIndices, err := s.osClient.CreateIndices(ctx, model.OSProductIndex, constant.InitIndex)
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+	defer resCreateIndices.Body.Close()
+
+	if resCreateIndices.IsError() {
+		logger.Error("error init index")
+		logger.Info(resCreateIndices)
+	} else {
+		logger.Info("success init index")
+	}
+
+	resUpdateMapping, err := s.osClient.PutIndicesMapping(ctx, []string{model.OSProductIndex}, constant.IndexMapping)
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+	defer resUpdateMapping.Body.Close()
+
+	if resUpdateMapping.IsError() {
+		logger.Error("error update mapping")
+		logger.Info(resUpdateMapping)
+	} else {
+		logger.Info("success update mapping")
+	}
+
+	return nil
+}
+
+func (s *opensearchProductSearcher) Index(ctx context.Context, product *model.Product) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"productID": product.ID,
+	})
+
+	res, err := s.osClient.Index(ctx, model.OSProductIndex, product.ToDoc())
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+	defer res.Body.Close()
+
+	return nil
+}
+
+func (s *opensearchProductSearcher) FindPaginatedIDs(ctx context.Context, req *model.PaginationPayload) (ids []string, highlights []*model.ProductHighlight, count int64, err error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"search": req.Search,
+		"sort":   req.Sort,
+		"page":   req.Page,
+		"limit":  req.Limit,
+	})
+	productIds := make([]string, 0)
+	highlights = make([]*model.ProductHighlight, 0)
+
+	paginationRequest := &model.OSPaginationRequest{
+		From:           int64((req.Page - 1) * req.Limit),
+		Size:           int64(req.Limit),
+		TrackTotalHits: true,
+		Query: model.Query{
+			Bool: model.Bool{
+				Must: model.Must{
+					MultiMatch: &model.MultiMatch{
+						Query:              req.Search,
+						Analyzer:           model.OSProductAnalyzer,
+						Fields:             model.ProductSearchColumns,
+						MinimumShouldMatch: model.OSProductMinimumShouldMatch,
+					},
+				},
+			},
+		},
+	}
+
+	if !req.IncludeDeleted {
+		paginationRequest.Query.Bool.Filter = append(paginationRequest.Query.Bool.Filter, model.Filter{
+			Term: map[string]string{
+				"deleted_at.keyword": "null",
+			},
+		})
+	}
+
+	if req.OwnedOrShared {
+		paginationRequest.Query.Bool.Filter = append(paginationRequest.Query.Bool.Filter, model.Filter{
+			Bool: &model.FilterBool{
+				Should: []model.Filter{
+					{Term: map[string]string{"owner_id.keyword": req.UserID}},
+					{Term: map[string]string{"collaborator_ids": req.UserID}},
+				},
+				MinimumShouldMatch: 1,
+			},
+		})
+	}
+
+	if req.Search != "" && model.IsShortSearch(req.Search) {
+		// the ngram analyzer drop the whole search, its words are matched as prefixes of the indexed ngrams instead
+		paginationRequest.Query.Bool.Must.MultiMatch.Type = model.OSProductShortSearchType
+		paginationRequest.Query.Bool.Must.MultiMatch.Analyzer = model.OSProductShortSearchAnalyzer
+	}
+
+	paginationRequest.ParseSort(req)
+	paginationRequest.ParseHighlight(req, model.ProductSearchColumns)
+
+	docData, err := json.Marshal(paginationRequest)
+	if err != nil {
+		logger.Error(err.Error())
+		return productIds, highlights, count, err
+	}
+
+	body := strings.NewReader(string(docData))
+
+	res, err := s.osClient.Search(ctx, []string{model.OSProductIndex}, body)
+	if err != nil {
+		logger.Error(err.Error())
+		return productIds, highlights, count, err
+	}
+	defer res.Body.Close()
+
+	osProducts := new(model.OSPaginationResponse[model.Product])
+
+	bytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		logger.Error(err.Error())
+		return productIds, highlights, count, err
+	}
+
+	err = json.Unmarshal(bytes, &osProducts)
+	if err != nil {
+		logger.Error(err.Error())
+		return productIds, highlights, count, err
+	}
+
+	for _, hit := range osProducts.Hits.Hits {
+		productIds = append(productIds, hit.Source.ID)
+		if len(hit.Highlight) == 0 {
+			continue
+		}
+		highlights = append(highlights, &model.ProductHighlight{
+			ID:          hit.Source.ID,
+			Name:        hit.Highlight["name"],
+			Description: hit.Highlight["description"],
+		})
+	}
+
+	return productIds, highlights, osProducts.GetCount(), nil
+}
+
+func (s *opensearchProductSearcher) FindRelatedIDs(ctx context.Context, product *model.Product, req *model.RelatedPayload) (ids []string, err error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"productID": product.ID,
+		"limit":     req.Limit,
+		"sameOwner": req.SameOwner,
+		"priceBand": req.PriceBand,
+	})
+	productIds := make([]string, 0)
+
+	relatedRequest := &model.OSRelatedRequest{
+		Size: int64(req.Limit),
+		Query: model.Query{
+			Bool: model.Bool{
+				Must: model.Must{
+					MoreLikeThis: &model.MoreLikeThis{
+						Fields: model.ProductSearchColumns,
+						Like: []model.MoreLikeThisLike{
+							{
+								Index: model.OSProductIndex,
+								ID:    product.ID,
+							},
+						},
+						MinTermFreq:   model.OSProductRelatedMinTermFreq,
+						MinDocFreq:    model.OSProductRelatedMinDocFreq,
+						MaxQueryTerms: model.OSProductRelatedMaxQueryTerms,
+					},
+				},
+				MustNot: []model.MustNot{
+					{
+						IDs: &model.IDs{Values: []string{product.ID}},
+					},
+				},
+				Filter: []model.Filter{
+					{
+						Term: map[string]string{
+							"deleted_at.keyword": "null",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if req.SameOwner {
+		relatedRequest.Query.Bool.Filter = append(relatedRequest.Query.Bool.Filter, model.Filter{
+			Term: map[string]string{
+				"owner_id.keyword": product.OwnerID,
+			},
+		})
+	}
+
+	if req.PriceBand > 0 {
+		relatedRequest.Query.Bool.Filter = append(relatedRequest.Query.Bool.Filter, model.Filter{
+			Range: map[string]model.Range{
+				"price": {
+					Gte: product.Price * (1 - req.PriceBand),
+					Lte: product.Price * (1 + req.PriceBand),
+				},
+			},
+		})
+	}
+
+	docData, err := json.Marshal(relatedRequest)
+	if err != nil {
+		logger.Error(err.Error())
+		return productIds, err
+	}
+
+	res, err := s.osClient.Search(ctx, []string{model.OSProductIndex}, strings.NewReader(string(docData)))
+	if err != nil {
+		logger.Error(err.Error())
+		return productIds, err
+	}
+	defer res.Body.Close()
+
+	bytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		logger.Error(err.Error())
+		return productIds, err
+	}
+
+	osProducts := new(model.OSPaginationResponse[model.Product])
+	err = json.Unmarshal(bytes, &osProducts)
+	if err != nil {
+		logger.Error(err.Error())
+		return productIds, err
+	}
+
+	for _, item := range osProducts.GetItems() {
+		productIds = append(productIds, item.ID)
+	}
+
+	return productIds, nil
+}
+
+// Ping run an empty search against the product index, used by the readiness check.
+func (s *opensearchProductSearcher) Ping(ctx context.Context) error {
+	res, err := s.osClient.Search(ctx, []string{model.OSProductIndex}, strings.NewReader(`{"size":0}`))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("opensearch ping failed: %s", res.Status())
+	}
+	return nil
+}
+
+func (s *opensearchProductSearcher) Close() error {
+	return nil
+}
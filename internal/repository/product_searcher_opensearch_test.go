@@ -0,0 +1,340 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	kitMock "github.com/krobus00/krokit/mock"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+	"github.com/opensearch-project/opensearch-go/opensearchapi"
+)
+
+func Test_opensearchProductSearcher_FindPaginatedIDs(t *testing.T) {
+	type args struct {
+		req *model.PaginationPayload
+	}
+	type osMock struct {
+		resp string
+		err  error
+	}
+	tests := []struct {
+		name           string
+		args           args
+		osMock         *osMock
+		wantIds        []string
+		wantHighlights []*model.ProductHighlight
+		wantCount      int64
+		wantErr        bool
+	}{
+		{
+			name: "success",
+			args: args{
+				req: &model.PaginationPayload{
+					Search: "sample product",
+					Sort:   []string{},
+					Limit:  10,
+					Page:   1,
+				},
+			},
+			osMock: &osMock{
+				resp: `{
+          "took": 33,
+          "timed_out": false,
+          "_shards": {
+            "total": 1,
+            "successful": 1,
+            "skipped": 0,
+            "failed": 0
+          },
+          "hits": {
+            "total": {
+              "value": 1,
+              "relation": "eq"
+            },
+            "max_score": 45.986057,
+            "hits": [
+              {
+                "_index": "products",
+                "_id": "1fc34a8d-3d77-4f40-acbc-789c06b4fa5d",
+                "_score": 45.986057,
+                "_source": {
+                  "id": "1fc34a8d-3d77-4f40-acbc-789c06b4fa5d",
+                  "name": "product-",
+                  "description": "description-1680269152107",
+                  "price": 5222789120,
+                  "owner_id": "cd9614c8-112a-4374-9737-eb62cc5d6aef",
+                  "created_at": "2023-03-31T13:25:52.448676961Z",
+                  "updated_at": "2023-03-31T13:25:52.448676961Z",
+                  "deleted_at": null
+                }
+              }
+            ]
+          }
+        }`,
+				err: nil,
+			},
+			wantIds:        []string{"1fc34a8d-3d77-4f40-acbc-789c06b4fa5d"},
+			wantHighlights: []*model.ProductHighlight{},
+			wantCount:      1,
+			wantErr:        false,
+		},
+		{
+			name: "success with highlight",
+			args: args{
+				req: &model.PaginationPayload{
+					Search:           "sample product",
+					Sort:             []string{},
+					Limit:            10,
+					Page:             1,
+					Highlight:        true,
+					HighlightPreTag:  "<b>",
+					HighlightPostTag: "</b>",
+				},
+			},
+			osMock: &osMock{
+				resp: `{
+          "hits": {
+            "total": {
+              "value": 1,
+              "relation": "eq"
+            },
+            "hits": [
+              {
+                "_index": "products",
+                "_id": "1fc34a8d-3d77-4f40-acbc-789c06b4fa5d",
+                "_source": {
+                  "id": "1fc34a8d-3d77-4f40-acbc-789c06b4fa5d",
+                  "name": "sample product",
+                  "description": "description-1680269152107",
+                  "deleted_at": null
+                },
+                "highlight": {
+                  "name": ["<b>sample</b> <b>product</b>"]
+                }
+              }
+            ]
+          }
+        }`,
+				err: nil,
+			},
+			wantIds: []string{"1fc34a8d-3d77-4f40-acbc-789c06b4fa5d"},
+			wantHighlights: []*model.ProductHighlight{
+				{
+					ID:   "1fc34a8d-3d77-4f40-acbc-789c06b4fa5d",
+					Name: []string{"<b>sample</b> <b>product</b>"},
+				},
+			},
+			wantCount: 1,
+			wantErr:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			osClient := kitMock.NewMockOpensearchClient(ctrl)
+			s, err := NewOpensearchProductSearcher(osClient)
+			utils.ContinueOrFatal(err)
+
+			if tt.osMock != nil {
+				body := io.NopCloser(strings.NewReader(tt.osMock.resp))
+				osClient.EXPECT().Search(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&opensearchapi.Response{
+						StatusCode: 200,
+						Body:       body,
+					}, tt.osMock.err)
+			}
+
+			gotIds, gotHighlights, gotCount, err := s.FindPaginatedIDs(context.TODO(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("opensearchProductSearcher.FindPaginatedIDs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotIds, tt.wantIds) {
+				t.Errorf("opensearchProductSearcher.FindPaginatedIDs() gotIds = %v, want %v", gotIds, tt.wantIds)
+			}
+			if !reflect.DeepEqual(gotHighlights, tt.wantHighlights) {
+				t.Errorf("opensearchProductSearcher.FindPaginatedIDs() gotHighlights = %v, want %v", gotHighlights, tt.wantHighlights)
+			}
+			if gotCount != tt.wantCount {
+				t.Errorf("opensearchProductSearcher.FindPaginatedIDs() gotCount = %v, want %v", gotCount, tt.wantCount)
+			}
+		})
+	}
+}
+
+func Test_opensearchProductSearcher_FindRelatedIDs(t *testing.T) {
+	product := &model.Product{
+		ID:      "1fc34a8d-3d77-4f40-acbc-789c06b4fa5d",
+		Name:    "sample product",
+		Price:   100,
+		OwnerID: "cd9614c8-112a-4374-9737-eb62cc5d6aef",
+	}
+	type args struct {
+		product *model.Product
+		req     *model.RelatedPayload
+	}
+	type osMock struct {
+		resp string
+		err  error
+	}
+	tests := []struct {
+		name    string
+		args    args
+		osMock  *osMock
+		wantIds []string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: args{
+				product: product,
+				req: &model.RelatedPayload{
+					ID:        product.ID,
+					Limit:     10,
+					SameOwner: true,
+					PriceBand: 0.2,
+				},
+			},
+			osMock: &osMock{
+				resp: `{
+          "hits": {
+            "total": {
+              "value": 1,
+              "relation": "eq"
+            },
+            "hits": [
+              {
+                "_index": "products",
+                "_id": "7b2b4fd5-4c4b-4bd4-9b0d-8e5a0f1e9e1a",
+                "_source": {
+                  "id": "7b2b4fd5-4c4b-4bd4-9b0d-8e5a0f1e9e1a",
+                  "name": "sample product 2",
+                  "deleted_at": null
+                }
+              }
+            ]
+          }
+        }`,
+				err: nil,
+			},
+			wantIds: []string{"7b2b4fd5-4c4b-4bd4-9b0d-8e5a0f1e9e1a"},
+			wantErr: false,
+		},
+		{
+			name: "opensearch error",
+			args: args{
+				product: product,
+				req: &model.RelatedPayload{
+					ID:    product.ID,
+					Limit: 10,
+				},
+			},
+			osMock: &osMock{
+				resp: "",
+				err:  errors.New("opensearch error"),
+			},
+			wantIds: []string{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			osClient := kitMock.NewMockOpensearchClient(ctrl)
+			s, err := NewOpensearchProductSearcher(osClient)
+			utils.ContinueOrFatal(err)
+
+			if tt.osMock != nil {
+				body := io.NopCloser(strings.NewReader(tt.osMock.resp))
+				osClient.EXPECT().Search(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&opensearchapi.Response{
+						StatusCode: 200,
+						Body:       body,
+					}, tt.osMock.err)
+			}
+
+			gotIds, err := s.FindRelatedIDs(context.TODO(), tt.args.product, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("opensearchProductSearcher.FindRelatedIDs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotIds, tt.wantIds) {
+				t.Errorf("opensearchProductSearcher.FindRelatedIDs() gotIds = %v, want %v", gotIds, tt.wantIds)
+			}
+		})
+	}
+}
+
+func Test_opensearchProductSearcher_Ping(t *testing.T) {
+	type osMock struct {
+		statusCode int
+		err        error
+	}
+	tests := []struct {
+		name    string
+		osMock  *osMock
+		wantErr bool
+	}{
+		{
+			name: "success",
+			osMock: &osMock{
+				statusCode: 200,
+				err:        nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "error unavailable",
+			osMock: &osMock{
+				statusCode: 503,
+				err:        nil,
+			},
+			wantErr: true,
+		},
+		{
+			name: "error connection",
+			osMock: &osMock{
+				statusCode: 0,
+				err:        errors.New("connection refused"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			osClient := kitMock.NewMockOpensearchClient(ctrl)
+			s, err := NewOpensearchProductSearcher(osClient)
+			utils.ContinueOrFatal(err)
+
+			var res *opensearchapi.Response
+			if tt.osMock.err == nil {
+				res = &opensearchapi.Response{
+					StatusCode: tt.osMock.statusCode,
+					Body:       io.NopCloser(strings.NewReader(`{}`)),
+				}
+			}
+			osClient.EXPECT().Search(gomock.Any(), []string{model.OSProductIndex}, gomock.Any()).
+				Times(1).
+				Return(res, tt.osMock.err)
+
+			if err := s.Ping(context.TODO()); (err != nil) != tt.wantErr {
+				t.Errorf("opensearchProductSearcher.Ping() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
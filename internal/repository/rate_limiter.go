@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+)
+
+// tokenBucketScript refill the bucket from the elapsed time then take a token, the bucket expire once it is full again.
+// The clock of the caller is used, replicas are expected to be kept in sync.
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "updated_at")
+local tokens = tonumber(bucket[1]) or burst
+local updatedAt = tonumber(bucket[2]) or now
+
+tokens = math.min(burst, tokens + math.max(0, now - updatedAt) / 1000 * rate)
+local allowed = 0
+local retryAfter = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retryAfter = math.ceil((1 - tokens) / rate * 1000)
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "updated_at", tostring(now))
+redis.call("PEXPIRE", KEYS[1], math.ceil(burst / rate * 1000))
+return {allowed, retryAfter}
+`)
+
+type redisRateLimiter struct {
+	redisClient *redis.Client
+}
+
+// NewRedisRateLimiter share the buckets across replicas.
+func NewRedisRateLimiter(client *redis.Client) (model.RateLimiter, error) {
+	if client == nil {
+		return nil, errors.New("invalid redis client")
+	}
+	return &redisRateLimiter{
+		redisClient: client,
+	}, nil
+}
+
+func (r *redisRateLimiter) Allow(ctx context.Context, key string, limit model.RateLimit) (allowed bool, retryAfter time.Duration, err error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	res, err := tokenBucketScript.Run(ctx, r.redisClient, []string{key}, limit.Rate, limit.Burst, time.Now().UnixMilli()).Int64Slice()
+	if err != nil {
+		log.WithContext(ctx).WithField("key", key).Error(err.Error())
+		return false, 0, err
+	}
+	if len(res) != 2 {
+		return false, 0, errors.New("invalid rate limit result")
+	}
+
+	return res[0] == 1, time.Duration(res[1]) * time.Millisecond, nil
+}
+
+const localRateLimiterSweepInterval = time.Minute
+
+type localBucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+	// idle is the time the bucket take to be full again, it is then the same as a new bucket
+	idle time.Duration
+}
+
+type localRateLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*localBucket
+	lastSweep time.Time
+}
+
+// NewLocalRateLimiter keep the buckets in memory, every replica enforce the limit on its own.
+func NewLocalRateLimiter() model.RateLimiter {
+	return &localRateLimiter{
+		buckets:   make(map[string]*localBucket),
+		lastSweep: time.Now(),
+	}
+}
+
+func (r *localRateLimiter) Allow(ctx context.Context, key string, limit model.RateLimit) (allowed bool, retryAfter time.Duration, err error) {
+	now := time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sweep(now)
+
+	bucket, ok := r.buckets[key]
+	if !ok {
+		bucket = &localBucket{
+			limiter: rate.NewLimiter(rate.Limit(limit.Rate), limit.Burst),
+			idle:    limit.RefillDuration(),
+		}
+		r.buckets[key] = bucket
+	}
+	bucket.lastSeen = now
+
+	reservation := bucket.limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return false, delay, nil
+	}
+
+	return true, 0, nil
+}
+
+// sweep drop the buckets idle long enough to be full again so the map doesn't grow with every caller.
+func (r *localRateLimiter) sweep(now time.Time) {
+	if now.Sub(r.lastSweep) < localRateLimiterSweepInterval {
+		return
+	}
+	for key, bucket := range r.buckets {
+		if now.Sub(bucket.lastSeen) >= bucket.idle {
+			delete(r.buckets, key)
+		}
+	}
+	r.lastSweep = now
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/krobus00/product-service/internal/infrastructure"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+	"github.com/spf13/viper"
+)
+
+func newRedisRateLimiterMock(t *testing.T) (model.RateLimiter, *miniredis.Miniredis) {
+	miniRedis := miniredis.RunT(t)
+	viper.Set("redis.cache_host", fmt.Sprintf("redis://%s", miniRedis.Addr()))
+	redisClient, err := infrastructure.NewRedisClient()
+	utils.ContinueOrFatal(err)
+	rateLimiter, err := NewRedisRateLimiter(redisClient)
+	utils.ContinueOrFatal(err)
+
+	return rateLimiter, miniRedis
+}
+
+func Test_rateLimiter_Allow(t *testing.T) {
+	key := model.NewRateLimitKey(utils.GenerateUUID(), "/pb.product.ProductService/Create")
+	limit := model.RateLimit{
+		Rate:  1,
+		Burst: 2,
+	}
+
+	tests := []struct {
+		name        string
+		newLimiter  func(t *testing.T) model.RateLimiter
+		calls       int
+		wantAllowed []bool
+	}{
+		{
+			name: "local limiter allow the burst then reject",
+			newLimiter: func(t *testing.T) model.RateLimiter {
+				return NewLocalRateLimiter()
+			},
+			calls:       3,
+			wantAllowed: []bool{true, true, false},
+		},
+		{
+			name: "redis limiter allow the burst then reject",
+			newLimiter: func(t *testing.T) model.RateLimiter {
+				rateLimiter, _ := newRedisRateLimiterMock(t)
+				return rateLimiter
+			},
+			calls:       3,
+			wantAllowed: []bool{true, true, false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := tt.newLimiter(t)
+			for i := 0; i < tt.calls; i++ {
+				allowed, retryAfter, err := r.Allow(context.TODO(), key, limit)
+				if err != nil {
+					t.Errorf("rateLimiter.Allow() error = %v", err)
+					return
+				}
+				if allowed != tt.wantAllowed[i] {
+					t.Errorf("rateLimiter.Allow() call %d allowed = %v, want %v", i, allowed, tt.wantAllowed[i])
+				}
+				if !allowed && (retryAfter <= 0 || retryAfter > time.Second) {
+					t.Errorf("rateLimiter.Allow() call %d retryAfter = %v, want between 0 and 1s", i, retryAfter)
+				}
+			}
+		})
+	}
+}
+
+func Test_redisRateLimiter_Allow_expire(t *testing.T) {
+	r, miniRedis := newRedisRateLimiterMock(t)
+	key := model.NewRateLimitKey(utils.GenerateUUID(), "/pb.product.ProductService/Create")
+	limit := model.RateLimit{
+		Rate:  1,
+		Burst: 2,
+	}
+
+	_, _, err := r.Allow(context.TODO(), key, limit)
+	if err != nil {
+		t.Errorf("redisRateLimiter.Allow() error = %v", err)
+		return
+	}
+	if ttl := miniRedis.TTL(key); ttl <= 0 || ttl > limit.RefillDuration() {
+		t.Errorf("redisRateLimiter.Allow() ttl = %v, want up to %v", ttl, limit.RefillDuration())
+	}
+}
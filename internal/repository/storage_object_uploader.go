@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/goccy/go-json"
+	"github.com/krobus00/product-service/internal/config"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+const storageUploadPath = "/api/storage/upload"
+
+// storageUploadResponse is the envelope of the storage-service http api.
+type storageUploadResponse struct {
+	Message string `json:"message"`
+	Data    struct {
+		ID string `json:"id"`
+	} `json:"data"`
+}
+
+// storageObjectUploader use the storage-service http api since the grpc api has no upload,
+// the file is streamed as a private object of the export.catalog.object_type type.
+type storageObjectUploader struct {
+	httpClient *http.Client
+}
+
+func NewStorageObjectUploader() model.ObjectUploader {
+	return new(storageObjectUploader)
+}
+
+func (r *storageObjectUploader) Upload(ctx context.Context, fileName string, content io.Reader) (string, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"fileName": fileName,
+	})
+
+	body, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+	go func() {
+		writer.CloseWithError(writeStorageUploadForm(form, fileName, content))
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(config.StorageHTTPHost(), "/")+storageUploadPath, body)
+	if err != nil {
+		_ = body.Close()
+		logger.Error(err.Error())
+		return "", err
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+config.StorageUploadToken())
+
+	res, err := r.httpClient.Do(req)
+	if err != nil {
+		logger.Error(err.Error())
+		return "", err
+	}
+	defer res.Body.Close()
+
+	resBody := new(storageUploadResponse)
+	err = json.NewDecoder(res.Body).Decode(resBody)
+	if res.StatusCode != http.StatusCreated {
+		err = fmt.Errorf("storage-service upload failed with status %s: %s", res.Status, resBody.Message)
+		logger.Error(err.Error())
+		return "", err
+	}
+	if err != nil {
+		logger.Error(err.Error())
+		return "", err
+	}
+	if resBody.Data.ID == "" {
+		err = errors.New("storage-service upload response without object id")
+		logger.Error(err.Error())
+		return "", err
+	}
+
+	return resBody.Data.ID, nil
+}
+
+func writeStorageUploadForm(form *multipart.Writer, fileName string, content io.Reader) error {
+	fields := [][2]string{
+		{"type", config.CatalogExportObjectType()},
+		{"fileName", fileName},
+		{"isPublic", "false"},
+	}
+	for _, field := range fields {
+		err := form.WriteField(field[0], field[1])
+		if err != nil {
+			return err
+		}
+	}
+
+	part, err := form.CreateFormFile("file", fileName)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(part, content)
+	if err != nil {
+		return err
+	}
+	return form.Close()
+}
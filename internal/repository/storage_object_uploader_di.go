@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"errors"
+	"net/http"
+)
+
+func (r *storageObjectUploader) InjectHTTPClient(client *http.Client) error {
+	if client == nil {
+		return errors.New("invalid http client")
+	}
+	r.httpClient = client
+	return nil
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func Test_storageObjectUploader_Upload(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		response   string
+		want       string
+		wantErr    bool
+		wantFields map[string]string
+	}{
+		{
+			name:     "success",
+			status:   http.StatusCreated,
+			response: `{"message":"created","data":{"id":"object-1"}}`,
+			want:     "object-1",
+			wantErr:  false,
+			wantFields: map[string]string{
+				"type":     "CATALOG_EXPORT",
+				"fileName": "catalog.csv",
+				"isPublic": "false",
+			},
+		},
+		{
+			name:     "rejected",
+			status:   http.StatusBadRequest,
+			response: `{"message":"invalid extension"}`,
+			wantErr:  true,
+		},
+		{
+			name:     "without object id",
+			status:   http.StatusCreated,
+			response: `{"message":"created","data":{}}`,
+			wantErr:  true,
+		},
+		{
+			name:     "invalid response",
+			status:   http.StatusCreated,
+			response: `<html>`,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != storageUploadPath {
+					t.Errorf("upload path = %v, want %v", req.URL.Path, storageUploadPath)
+				}
+				if got := req.Header.Get("Authorization"); got != "Bearer upload-token" {
+					t.Errorf("upload authorization = %v", got)
+				}
+				if err := req.ParseMultipartForm(1 << 20); err != nil {
+					t.Errorf("upload form error = %v", err)
+				}
+				for key, want := range tt.wantFields {
+					if got := req.FormValue(key); got != want {
+						t.Errorf("upload field %s = %v, want %v", key, got, want)
+					}
+				}
+				if tt.wantFields != nil {
+					file, _, err := req.FormFile("file")
+					if err != nil {
+						t.Fatalf("upload file error = %v", err)
+					}
+					content, _ := io.ReadAll(file)
+					if string(content) != "id,name\n" {
+						t.Errorf("upload file = %v", string(content))
+					}
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			viper.Set("services.storage_http", server.URL+"/")
+			viper.Set("services.storage_upload_token", "upload-token")
+
+			r := NewStorageObjectUploader()
+			if err := r.InjectHTTPClient(server.Client()); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := r.Upload(context.TODO(), "catalog.csv", strings.NewReader("id,name\n"))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("storageObjectUploader.Upload() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("storageObjectUploader.Upload() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -18,6 +18,8 @@ func NewDelivery() *Delivery {
 func (t *Delivery) InitRoutes() error {
 	logrus.Info("register asynq handler")
 	t.asynqMux.HandleFunc(model.TaskProductUpdateThumbnail, t.productUC.HandleUpdateThumbnailTask)
+	t.asynqMux.HandleFunc(model.TaskProductImport, t.productUC.HandleImportProductsTask)
+	t.asynqMux.HandleFunc(model.TaskProductCatalogExport, t.productUC.HandleExportCatalogTask)
 
 	return nil
 }
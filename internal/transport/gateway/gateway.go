@@ -0,0 +1,69 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/krobus00/product-service/internal/constant"
+	pb "github.com/krobus00/product-service/pb/product"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Gateway translate REST/JSON requests into calls to the grpc server, so they go through the same
+// interceptors and error mapping as grpc clients. The routes are declared in pb/product/product_service.yaml.
+type Gateway struct {
+	conn *grpc.ClientConn
+}
+
+func NewGateway() *Gateway {
+	return new(Gateway)
+}
+
+func (g *Gateway) Handler(ctx context.Context) (http.Handler, error) {
+	gwMux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				UseProtoNames:   true,
+				EmitUnpopulated: true,
+			},
+			UnmarshalOptions: protojson.UnmarshalOptions{
+				DiscardUnknown: true,
+			},
+		}),
+	)
+	err := pb.RegisterProductServiceHandler(ctx, gwMux, g.conn)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/v1/", gwMux)
+	mux.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(pb.OpenAPIDocument)
+	})
+	return mux, nil
+}
+
+// incomingHeaderMatcher forward the headers read by the grpc interceptors as is, authorization is always forwarded.
+func incomingHeaderMatcher(key string) (string, bool) {
+	switch key = strings.ToLower(key); key {
+	case constant.HeaderRequestID, constant.HeaderDataSource, constant.HeaderIdempotency:
+		return key, true
+	default:
+		return runtime.DefaultHeaderMatcher(key)
+	}
+}
+
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if key == constant.HeaderRequestID {
+		return key, true
+	}
+	return fmt.Sprintf("%s%s", runtime.MetadataHeaderPrefix, key), true
+}
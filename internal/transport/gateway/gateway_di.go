@@ -0,0 +1,15 @@
+package gateway
+
+import (
+	"errors"
+
+	"google.golang.org/grpc"
+)
+
+func (g *Gateway) InjectGRPCConn(conn *grpc.ClientConn) error {
+	if conn == nil {
+		return errors.New("invalid grpc connection")
+	}
+	g.conn = conn
+	return nil
+}
@@ -0,0 +1,225 @@
+package gateway
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/krobus00/product-service/internal/constant"
+	pb "github.com/krobus00/product-service/pb/product"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeProductServer struct {
+	pb.UnimplementedProductServiceServer
+	err      error
+	incoming metadata.MD
+}
+
+func (s *fakeProductServer) FindByID(ctx context.Context, req *pb.FindByIDRequest) (*pb.Product, error) {
+	s.incoming, _ = metadata.FromIncomingContext(ctx)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(constant.HeaderRequestID, "request-1", "x-product-version", "2"))
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &pb.Product{Id: req.GetId()}, nil
+}
+
+func newTestGateway(t *testing.T, srv *fakeProductServer) http.Handler {
+	lis := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	pb.RegisterProductServiceServer(grpcServer, srv)
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	gateway := NewGateway()
+	if err := gateway.InjectGRPCConn(conn); err != nil {
+		t.Fatal(err)
+	}
+	handler, err := gateway.Handler(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return handler
+}
+
+func Test_incomingHeaderMatcher(t *testing.T) {
+	tests := []struct {
+		header  string
+		wantKey string
+		wantOk  bool
+	}{
+		{header: "X-Request-Id", wantKey: constant.HeaderRequestID, wantOk: true},
+		{header: "X-Data-Source", wantKey: constant.HeaderDataSource, wantOk: true},
+		{header: "Idempotency-Key", wantKey: constant.HeaderIdempotency, wantOk: true},
+		{header: "Authorization", wantKey: "grpcgateway-Authorization", wantOk: true},
+		{header: "Grpc-Metadata-Foo", wantKey: "Foo", wantOk: true},
+		{header: "X-Unknown", wantKey: "", wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			gotKey, gotOk := incomingHeaderMatcher(tt.header)
+			if gotKey != tt.wantKey || gotOk != tt.wantOk {
+				t.Errorf("incomingHeaderMatcher() = %v, %v, want %v, %v", gotKey, gotOk, tt.wantKey, tt.wantOk)
+			}
+		})
+	}
+}
+
+func Test_outgoingHeaderMatcher(t *testing.T) {
+	tests := []struct {
+		key     string
+		wantKey string
+	}{
+		{key: constant.HeaderRequestID, wantKey: constant.HeaderRequestID},
+		{key: "x-product-version", wantKey: "Grpc-Metadata-x-product-version"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			gotKey, gotOk := outgoingHeaderMatcher(tt.key)
+			if gotKey != tt.wantKey || !gotOk {
+				t.Errorf("outgoingHeaderMatcher() = %v, %v, want %v, true", gotKey, gotOk, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestGateway_Handler(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantCode   int
+		wantReason string
+	}{
+		{
+			name:     "success",
+			err:      nil,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "invalid argument",
+			err:      status.Error(codes.InvalidArgument, "invalid argument"),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "unauthenticated",
+			err:      status.Error(codes.Unauthenticated, "invalid token"),
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "permission denied",
+			err:      status.Error(codes.PermissionDenied, "unauthorized access"),
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:       "not found with details",
+			err:        newStatusError(t, codes.NotFound, "product not found", "PRODUCT_NOT_FOUND"),
+			wantCode:   http.StatusNotFound,
+			wantReason: "PRODUCT_NOT_FOUND",
+		},
+		{
+			name:     "resource exhausted",
+			err:      status.Error(codes.ResourceExhausted, "rate limited"),
+			wantCode: http.StatusTooManyRequests,
+		},
+		{
+			name:     "unavailable",
+			err:      status.Error(codes.Unavailable, "auth service unavailable"),
+			wantCode: http.StatusServiceUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &fakeProductServer{err: tt.err}
+			handler := newTestGateway(t, srv)
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/products/product-1", nil)
+			req.Header.Set("Authorization", "Bearer token")
+			req.Header.Set("X-Request-Id", "request-1")
+			req.Header.Set("X-Data-Source", "db")
+			req.Header.Set("Idempotency-Key", "key-1")
+			req.Header.Set("X-Unknown", "dropped")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Errorf("Gateway.Handler() code = %d, want %d, body %s", rec.Code, tt.wantCode, rec.Body.String())
+			}
+
+			wantMetadata := map[string]string{
+				"authorization":            "Bearer token",
+				constant.HeaderRequestID:   "request-1",
+				constant.HeaderDataSource:  "db",
+				constant.HeaderIdempotency: "key-1",
+			}
+			for key, want := range wantMetadata {
+				if got := srv.incoming.Get(key); len(got) != 1 || got[0] != want {
+					t.Errorf("Gateway.Handler() forwarded %s = %v, want %s", key, got, want)
+				}
+			}
+			if got := srv.incoming.Get("x-unknown"); len(got) != 0 {
+				t.Errorf("Gateway.Handler() forwarded x-unknown = %v, want none", got)
+			}
+
+			if got := rec.Header().Get(constant.HeaderRequestID); got != "request-1" {
+				t.Errorf("Gateway.Handler() response %s = %q, want request-1", constant.HeaderRequestID, got)
+			}
+			if got := rec.Header().Get("Grpc-Metadata-X-Product-Version"); got != "2" {
+				t.Errorf("Gateway.Handler() response Grpc-Metadata-X-Product-Version = %q, want 2", got)
+			}
+
+			if tt.wantReason == "" {
+				return
+			}
+			body := struct {
+				Code    int32  `json:"code"`
+				Message string `json:"message"`
+				Details []struct {
+					Reason string `json:"reason"`
+				} `json:"details"`
+			}{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if len(body.Details) != 1 || body.Details[0].Reason != tt.wantReason || !strings.Contains(body.Message, "not found") {
+				t.Errorf("Gateway.Handler() body = %s, want the %s reason", rec.Body.String(), tt.wantReason)
+			}
+		})
+	}
+}
+
+func newStatusError(t *testing.T, code codes.Code, message string, reason string) error {
+	st, err := status.New(code, message).WithDetails(&errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: constant.ErrorDomain,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return st.Err()
+}
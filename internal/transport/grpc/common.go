@@ -4,8 +4,51 @@ import (
 	"context"
 
 	"github.com/krobus00/product-service/internal/constant"
+	"google.golang.org/grpc/metadata"
 )
 
-func setUserIDCtx(ctx context.Context, userID string) context.Context {
-	return context.WithValue(ctx, constant.KeyUserIDCtx, userID)
+type userIDRequest interface {
+	GetUserId() string
+}
+
+// setUserIDCtx honor the deprecated request user_id only when a trusted service act on behalf of the user,
+// other callers keep the user id verified by the auth interceptor.
+func setUserIDCtx(ctx context.Context, in userIDRequest) context.Context {
+	isTrustedService, _ := ctx.Value(constant.KeyTrustedServiceCtx).(bool)
+	if !isTrustedService || in.GetUserId() == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, constant.KeyUserIDCtx, in.GetUserId())
+}
+
+// setDataSourceCtx forward the data source chosen by the caller through the x-data-source metadata.
+func setDataSourceCtx(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	values := md.Get(constant.HeaderDataSource)
+	if len(values) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, constant.KeyDataSource, values[0])
+}
+
+type idempotentRequest interface {
+	GetIdempotencyKey() string
+}
+
+// setIdempotencyKeyCtx read the idempotency key from the request, or from the idempotency-key metadata when empty.
+func setIdempotencyKeyCtx(ctx context.Context, in idempotentRequest) context.Context {
+	key := in.GetIdempotencyKey()
+	if key == "" {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if values := md.Get(constant.HeaderIdempotency); len(values) > 0 {
+			key = values[0]
+		}
+	}
+	if key == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, constant.KeyIdempotencyCtx, key)
 }
@@ -0,0 +1,120 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/krobus00/product-service/internal/constant"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	reasonInvalidArgument  = "INVALID_ARGUMENT"
+	reasonQuotaExceeded    = "PRODUCT_QUOTA_EXCEEDED"
+	reasonCanceled         = "CANCELED"
+	reasonDeadlineExceeded = "DEADLINE_EXCEEDED"
+	reasonInternal         = "INTERNAL"
+)
+
+type errorStatus struct {
+	err    error
+	code   codes.Code
+	reason string
+}
+
+// errorStatuses map the usecase errors to the status returned to clients, the reason is sent in the ErrorInfo details.
+var errorStatuses = []errorStatus{
+	{model.ErrUnauthorizedAccess, codes.Unauthenticated, "UNAUTHORIZED_ACCESS"},
+	{model.ErrInvalidToken, codes.Unauthenticated, "INVALID_TOKEN"},
+	{model.ErrProductNotFound, codes.NotFound, "PRODUCT_NOT_FOUND"},
+	{model.ErrProductRevisionNotFound, codes.NotFound, "PRODUCT_REVISION_NOT_FOUND"},
+	{model.ErrCollaboratorNotFound, codes.NotFound, "COLLABORATOR_NOT_FOUND"},
+	{model.ErrImportJobNotFound, codes.NotFound, "IMPORT_JOB_NOT_FOUND"},
+	{model.ErrImportObjectNotFound, codes.NotFound, "IMPORT_OBJECT_NOT_FOUND"},
+	{model.ErrThumbnailNotFound, codes.NotFound, "THUMBNAIL_NOT_FOUND"},
+	{model.ErrExternalSKUConflict, codes.AlreadyExists, "EXTERNAL_SKU_CONFLICT"},
+	{model.ErrThumbnailTypeNotAllowed, codes.FailedPrecondition, "THUMBNAIL_TYPE_NOT_ALLOWED"},
+	{model.ErrThumbnailNotAllowed, codes.FailedPrecondition, "THUMBNAIL_NOT_ALLOWED"},
+	{model.ErrInvalidCollaborator, codes.InvalidArgument, "INVALID_COLLABORATOR"},
+	{model.ErrInvalidCollaboratorRole, codes.InvalidArgument, "INVALID_COLLABORATOR_ROLE"},
+	{model.ErrInvalidOwnershipTransfer, codes.InvalidArgument, "INVALID_OWNERSHIP_TRANSFER"},
+	{model.ErrInvalidDataSource, codes.InvalidArgument, "INVALID_DATA_SOURCE"},
+	{model.ErrInvalidCursor, codes.InvalidArgument, "INVALID_CURSOR"},
+	{model.ErrInvalidArgument, codes.InvalidArgument, reasonInvalidArgument},
+	{model.ErrIdempotencyKeyReused, codes.FailedPrecondition, "IDEMPOTENCY_KEY_REUSED"},
+	{model.ErrWatchSequenceExpired, codes.FailedPrecondition, "WATCH_SEQUENCE_EXPIRED"},
+	{model.ErrIdempotencyKeyInProgress, codes.Aborted, "IDEMPOTENCY_KEY_IN_PROGRESS"},
+	{model.ErrAuthServiceUnavailable, codes.Unavailable, "AUTH_SERVICE_UNAVAILABLE"},
+	{model.ErrStorageServiceUnavailable, codes.Unavailable, "STORAGE_SERVICE_UNAVAILABLE"},
+	{context.Canceled, codes.Canceled, reasonCanceled},
+	{context.DeadlineExceeded, codes.DeadlineExceeded, reasonDeadlineExceeded},
+}
+
+// toStatusError convert a usecase error to a grpc status with ErrorInfo details, validation errors also carry
+// a BadRequest with every field violation. Unknown errors are logged and hidden behind codes.Internal.
+func toStatusError(ctx context.Context, err error) error {
+	var validationErr *model.ValidationError
+	if errors.As(err, &validationErr) {
+		badRequest := &errdetails.BadRequest{}
+		for _, violation := range validationErr.Violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       violation.Field,
+				Description: violation.Description,
+			})
+		}
+		return newStatusError(codes.InvalidArgument, reasonInvalidArgument, validationErr.Error(), badRequest)
+	}
+
+	var quotaErr *model.ProductQuotaError
+	if errors.As(err, &quotaErr) {
+		quotaFailure := &errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailure_Violation{
+				{
+					Subject:     fmt.Sprintf("owner:%s", quotaErr.OwnerID),
+					Description: fmt.Sprintf("owner can have at most %d products, delete a product or transfer its ownership", quotaErr.Limit),
+				},
+			},
+		}
+		if quotaErr.RetryAfter <= 0 {
+			return newStatusError(codes.ResourceExhausted, reasonQuotaExceeded, quotaErr.Error(), quotaFailure)
+		}
+		retryInfo := &errdetails.RetryInfo{
+			RetryDelay: durationpb.New(quotaErr.RetryAfter),
+		}
+		return newStatusError(codes.ResourceExhausted, reasonQuotaExceeded, quotaErr.Error(), quotaFailure, retryInfo)
+	}
+
+	for _, errStatus := range errorStatuses {
+		if errors.Is(err, errStatus.err) {
+			return newStatusError(errStatus.code, errStatus.reason, err.Error())
+		}
+	}
+
+	logrus.WithContext(ctx).Error(err.Error())
+	return newStatusError(codes.Internal, reasonInternal, codes.Internal.String())
+}
+
+func newStatusError(code codes.Code, reason string, message string, details ...protoiface.MessageV1) error {
+	st := status.New(code, message)
+	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: constant.ErrorDomain,
+	})
+	if err != nil {
+		return st.Err()
+	}
+	for _, detail := range details {
+		withDetails, err = withDetails.WithDetails(detail)
+		if err != nil {
+			return st.Err()
+		}
+	}
+	return withDetails.Err()
+}
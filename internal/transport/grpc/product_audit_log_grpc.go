@@ -0,0 +1,21 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/krobus00/product-service/internal/model"
+	pb "github.com/krobus00/product-service/pb/product"
+)
+
+func (t *Delivery) ListProductHistory(ctx context.Context, in *pb.ListProductHistoryRequest) (*pb.ListProductHistoryResponse, error) {
+	ctx = setUserIDCtx(ctx, in)
+
+	payload := model.NewProductHistoryPayloadFromProto(in)
+
+	res, err := t.productUC.FindHistory(ctx, payload)
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return res.ToProto(), nil
+}
@@ -0,0 +1,45 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/krobus00/product-service/internal/model"
+	pb "github.com/krobus00/product-service/pb/product"
+)
+
+func (t *Delivery) AddCollaborator(ctx context.Context, in *pb.AddCollaboratorRequest) (*pb.ProductCollaborator, error) {
+	ctx = setUserIDCtx(ctx, in)
+
+	payload := model.NewAddCollaboratorPayloadFromProto(in)
+
+	collaborator, err := t.productUC.AddCollaborator(ctx, payload)
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return collaborator.ToProto(), nil
+}
+
+func (t *Delivery) RemoveCollaborator(ctx context.Context, in *pb.RemoveCollaboratorRequest) (*pb.Empty, error) {
+	ctx = setUserIDCtx(ctx, in)
+
+	err := t.productUC.RemoveCollaborator(ctx, in.GetId(), in.GetCollaboratorId())
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return &pb.Empty{}, nil
+}
+
+func (t *Delivery) ListCollaborators(ctx context.Context, in *pb.ListCollaboratorsRequest) (*pb.ListCollaboratorsResponse, error) {
+	ctx = setUserIDCtx(ctx, in)
+
+	collaborators, err := t.productUC.FindCollaborators(ctx, in.GetId())
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return &pb.ListCollaboratorsResponse{
+		Items: collaborators.ToProto(),
+	}, nil
+}
@@ -0,0 +1,27 @@
+package grpc
+
+import (
+	"github.com/krobus00/product-service/internal/model"
+	pb "github.com/krobus00/product-service/pb/product"
+)
+
+func (t *Delivery) ExportProducts(in *pb.ExportProductsRequest, stream pb.ProductService_ExportProductsServer) error {
+	ctx := setUserIDCtx(stream.Context(), in)
+
+	payload := model.NewExportProductsPayloadFromProto(in)
+
+	// Send block while the client flow control window is full, its error already carry a grpc status
+	var sendErr error
+	err := t.productUC.ExportProducts(ctx, payload, func(product *model.ExportedProduct) error {
+		sendErr = stream.Send(product.ToProto())
+		return sendErr
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+	if err != nil {
+		return toStatusError(ctx, err)
+	}
+
+	return nil
+}
@@ -4,120 +4,73 @@ import (
 	"context"
 
 	"github.com/krobus00/product-service/internal/model"
-	"github.com/krobus00/product-service/internal/utils"
 	pb "github.com/krobus00/product-service/pb/product"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 func (t *Delivery) Create(ctx context.Context, in *pb.CreateProductRequest) (*pb.Product, error) {
-	ctx = setUserIDCtx(ctx, in.GetUserId())
-
-	_, _, fn := utils.Trace()
-	ctx, span := utils.NewSpan(ctx, fn)
-	defer span.End()
+	ctx = setUserIDCtx(ctx, in)
+	ctx = setIdempotencyKeyCtx(ctx, in)
 
 	payload := model.NewCreateProductPayloadFromProto(in)
 	product, err := t.productUC.Create(ctx, payload)
-	switch err {
-	case nil:
-	case model.ErrUnauthorizedAccess:
-		return nil, status.Error(codes.Unauthenticated, err.Error())
-	case model.ErrThumbnailNotFound:
-		return nil, status.Error(codes.NotFound, err.Error())
-	case model.ErrThumbnailTypeNotAllowed:
-		return nil, status.Error(codes.FailedPrecondition, err.Error())
-	default:
-		return nil, status.Error(codes.Internal, codes.Internal.String())
+	if err != nil {
+		return nil, toStatusError(ctx, err)
 	}
 
 	return product.ToProto(), nil
 }
 
 func (t *Delivery) Update(ctx context.Context, in *pb.UpdateProductRequest) (*pb.Product, error) {
-	ctx = setUserIDCtx(ctx, in.GetUserId())
-
-	_, _, fn := utils.Trace()
-	ctx, span := utils.NewSpan(ctx, fn)
-	defer span.End()
+	ctx = setUserIDCtx(ctx, in)
 
 	payload := model.NewUpdateProductPayloadFromProto(in)
 	product, err := t.productUC.Update(ctx, payload)
-	switch err {
-	case nil:
-	case model.ErrUnauthorizedAccess:
-		return nil, status.Error(codes.Unauthenticated, err.Error())
-	case model.ErrProductNotFound:
-		return nil, status.Error(codes.NotFound, err.Error())
-	case model.ErrThumbnailNotFound:
-		return nil, status.Error(codes.NotFound, err.Error())
-	case model.ErrThumbnailTypeNotAllowed:
-		return nil, status.Error(codes.FailedPrecondition, err.Error())
-	default:
-		return nil, status.Error(codes.Internal, codes.Internal.String())
+	if err != nil {
+		return nil, toStatusError(ctx, err)
 	}
 
 	return product.ToProto(), nil
 }
 
 func (t *Delivery) Delete(ctx context.Context, in *pb.DeleteProductRequest) (*pb.Empty, error) {
-	ctx = setUserIDCtx(ctx, in.GetUserId())
-
-	_, _, fn := utils.Trace()
-	ctx, span := utils.NewSpan(ctx, fn)
-	defer span.End()
+	ctx = setUserIDCtx(ctx, in)
 
 	err := t.productUC.Delete(ctx, in.GetId())
-	switch err {
-	case nil:
-	case model.ErrUnauthorizedAccess:
-		return nil, status.Error(codes.Unauthenticated, err.Error())
-	case model.ErrProductNotFound:
-		return nil, status.Error(codes.NotFound, err.Error())
-	default:
-		return nil, status.Error(codes.Internal, codes.Internal.String())
+	if err != nil {
+		return nil, toStatusError(ctx, err)
 	}
 
 	return &pb.Empty{}, nil
 }
 
-func (t *Delivery) FindByID(ctx context.Context, in *pb.FindByIDRequest) (*pb.Product, error) {
-	ctx = setUserIDCtx(ctx, in.GetUserId())
+func (t *Delivery) Restore(ctx context.Context, in *pb.RestoreProductRequest) (*pb.Product, error) {
+	ctx = setUserIDCtx(ctx, in)
+
+	product, err := t.productUC.Restore(ctx, in.GetId())
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return product.ToProto(), nil
+}
 
-	_, _, fn := utils.Trace()
-	ctx, span := utils.NewSpan(ctx, fn)
-	defer span.End()
+func (t *Delivery) FindByID(ctx context.Context, in *pb.FindByIDRequest) (*pb.Product, error) {
+	ctx = setUserIDCtx(ctx, in)
 
 	product, err := t.productUC.FindByID(ctx, in.GetId())
-	switch err {
-	case nil:
-	case model.ErrUnauthorizedAccess:
-		return nil, status.Error(codes.Unauthenticated, err.Error())
-	case model.ErrProductNotFound:
-		return nil, status.Error(codes.NotFound, err.Error())
-	default:
-		return nil, status.Error(codes.Internal, codes.Internal.String())
+	if err != nil {
+		return nil, toStatusError(ctx, err)
 	}
 
 	return product.ToProto(), nil
 }
 
 func (t *Delivery) FindByIDs(ctx context.Context, in *pb.FindByIDsRequest) (*pb.FindByIDsResponse, error) {
-	ctx = setUserIDCtx(ctx, in.GetUserId())
-
-	_, _, fn := utils.Trace()
-	ctx, span := utils.NewSpan(ctx, fn)
-	defer span.End()
+	ctx = setUserIDCtx(ctx, in)
 
 	product, err := t.productUC.FindByIDs(ctx, in.GetIds())
-	switch err {
-	case nil:
-	case model.ErrUnauthorizedAccess:
-		return nil, status.Error(codes.Unauthenticated, err.Error())
-	case model.ErrProductNotFound:
-		return nil, status.Error(codes.NotFound, err.Error())
-	default:
-		return nil, status.Error(codes.Internal, codes.Internal.String())
+	if err != nil {
+		return nil, toStatusError(ctx, err)
 	}
 
 	return &pb.FindByIDsResponse{
@@ -126,21 +79,28 @@ func (t *Delivery) FindByIDs(ctx context.Context, in *pb.FindByIDsRequest) (*pb.
 }
 
 func (t *Delivery) FindPaginatedIDs(ctx context.Context, in *pb.PaginationRequest) (*pb.PaginationResponse, error) {
-	ctx = setUserIDCtx(ctx, in.GetUserId())
-
-	_, _, fn := utils.Trace()
-	ctx, span := utils.NewSpan(ctx, fn)
-	defer span.End()
+	ctx = setUserIDCtx(ctx, in)
+	ctx = setDataSourceCtx(ctx)
 
 	payload := model.NewPaginationPayloadFromProto(in)
 	res, err := t.productUC.FindPaginatedIDs(ctx, payload)
-	switch err {
-	case nil:
-	case model.ErrUnauthorizedAccess:
-		return nil, status.Error(codes.Unauthenticated, err.Error())
-	default:
-		return nil, status.Error(codes.Internal, codes.Internal.String())
+	if err != nil {
+		return nil, toStatusError(ctx, err)
 	}
 
 	return res.ToProto(), nil
 }
+
+func (t *Delivery) FindRelated(ctx context.Context, in *pb.FindRelatedRequest) (*pb.FindRelatedResponse, error) {
+	ctx = setUserIDCtx(ctx, in)
+
+	payload := model.NewRelatedPayloadFromProto(in)
+	ids, err := t.productUC.FindRelated(ctx, payload)
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return &pb.FindRelatedResponse{
+		Items: ids,
+	}, nil
+}
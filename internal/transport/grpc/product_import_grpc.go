@@ -0,0 +1,32 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/krobus00/product-service/internal/model"
+	pb "github.com/krobus00/product-service/pb/product"
+)
+
+func (t *Delivery) ImportProducts(ctx context.Context, in *pb.ImportProductsRequest) (*pb.ImportJob, error) {
+	ctx = setUserIDCtx(ctx, in)
+
+	payload := model.NewImportProductsPayloadFromProto(in)
+
+	job, err := t.productUC.ImportProducts(ctx, payload)
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return job.ToProto(), nil
+}
+
+func (t *Delivery) GetImportJob(ctx context.Context, in *pb.GetImportJobRequest) (*pb.ImportJob, error) {
+	ctx = setUserIDCtx(ctx, in)
+
+	res, err := t.productUC.GetImportJob(ctx, in.GetId(), in.GetIncludeErrorReport())
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return res.ToProto(), nil
+}
@@ -0,0 +1,24 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/krobus00/product-service/internal/model"
+	pb "github.com/krobus00/product-service/pb/product"
+)
+
+func (t *Delivery) TransferOwnership(ctx context.Context, in *pb.TransferOwnershipRequest) (*pb.TransferOwnershipResponse, error) {
+	ctx = setUserIDCtx(ctx, in)
+	ctx = setIdempotencyKeyCtx(ctx, in)
+
+	payload := model.NewTransferOwnershipPayloadFromProto(in)
+
+	products, err := t.productUC.TransferOwnership(ctx, payload)
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return &pb.TransferOwnershipResponse{
+		Ids: products.IDs(),
+	}, nil
+}
@@ -0,0 +1,43 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/krobus00/product-service/internal/model"
+	pb "github.com/krobus00/product-service/pb/product"
+)
+
+func (t *Delivery) GetProductRevision(ctx context.Context, in *pb.GetProductRevisionRequest) (*pb.ProductRevision, error) {
+	ctx = setUserIDCtx(ctx, in)
+
+	revision, err := t.productUC.FindRevision(ctx, in.GetId(), in.GetRevision())
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return revision.ToProto(), nil
+}
+
+func (t *Delivery) ListProductRevisions(ctx context.Context, in *pb.ListProductRevisionsRequest) (*pb.ListProductRevisionsResponse, error) {
+	ctx = setUserIDCtx(ctx, in)
+
+	payload := model.NewProductRevisionsPayloadFromProto(in)
+
+	res, err := t.productUC.FindRevisions(ctx, payload)
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return res.ToProto(), nil
+}
+
+func (t *Delivery) RevertProduct(ctx context.Context, in *pb.RevertProductRequest) (*pb.Product, error) {
+	ctx = setUserIDCtx(ctx, in)
+
+	product, err := t.productUC.Revert(ctx, in.GetId(), in.GetRevision())
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return product.ToProto(), nil
+}
@@ -0,0 +1,27 @@
+package grpc
+
+import (
+	"github.com/krobus00/product-service/internal/model"
+	pb "github.com/krobus00/product-service/pb/product"
+)
+
+func (t *Delivery) WatchProducts(in *pb.WatchProductsRequest, stream pb.ProductService_WatchProductsServer) error {
+	ctx := setUserIDCtx(stream.Context(), in)
+
+	payload := model.NewWatchProductsPayloadFromProto(in)
+
+	// Send error already carry a grpc status
+	var sendErr error
+	err := t.productUC.WatchProducts(ctx, payload, func(event *model.ProductEvent) error {
+		sendErr = stream.Send(event.ToProto())
+		return sendErr
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+	if err != nil {
+		return toStatusError(ctx, err)
+	}
+
+	return nil
+}
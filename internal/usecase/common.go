@@ -2,51 +2,372 @@ package usecase
 
 import (
 	"context"
-	"fmt"
-	"strconv"
+	"errors"
+	"time"
 
+	"github.com/goccy/go-json"
 	authPB "github.com/krobus00/auth-service/pb/auth"
+	"github.com/krobus00/product-service/internal/config"
 	"github.com/krobus00/product-service/internal/constant"
 	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+	"github.com/sirupsen/logrus"
+	"github.com/sony/gobreaker"
 )
 
 func getUserIDFromCtx(ctx context.Context) string {
-	ctxUserID := ctx.Value(constant.KeyUserIDCtx)
-
-	userID := fmt.Sprintf("%v", ctxUserID)
-	if userID == "" {
+	userID, ok := ctx.Value(constant.KeyUserIDCtx).(string)
+	if !ok || userID == "" {
 		return constant.GuestID
 	}
 	return userID
 }
 
-func getDataSource(ctx context.Context) int {
-	ctxData := ctx.Value(constant.KeyDataSource)
+func getOriginFromCtx(ctx context.Context) string {
+	origin, ok := ctx.Value(constant.KeyOriginCtx).(string)
+	if !ok || origin == "" {
+		return model.UnknownOrigin
+	}
+	return origin
+}
 
-	data, err := strconv.Atoi(fmt.Sprintf("%v", ctxData))
-	if err != nil {
-		return constant.SourceOS
+// getDataSource return the data source chosen by the caller, ok is false when the caller didn't choose any.
+func getDataSource(ctx context.Context) (dataSource int, ok bool, err error) {
+	ctxData, ok := ctx.Value(constant.KeyDataSource).(string)
+	if !ok {
+		return constant.SourceOS, false, nil
 	}
-	return data
+
+	dataSource, ok = constant.DataSources[ctxData]
+	if !ok {
+		return constant.SourceOS, false, model.ErrInvalidDataSource
+	}
+	return dataSource, true, nil
 }
 
-func hasAccess(ctx context.Context, authClient authPB.AuthServiceClient, permissions []string) error {
+// hasPermission check whether the caller hold any of the permissions, decisions are cached per user.
+func (uc *productUsecase) hasPermission(ctx context.Context, permissions []string) error {
 	userID := getUserIDFromCtx(ctx)
-
 	permissions = append(permissions, constant.PermissionFullAccess)
-	res, err := authClient.HasAccess(ctx, &authPB.HasAccessRequest{
+
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"userID":      userID,
+		"permissions": permissions,
+	})
+
+	if uc.permissionCacheRepo != nil {
+		allowed, found, err := uc.permissionCacheRepo.Get(ctx, userID, permissions)
+		if err != nil {
+			logger.Warn(err.Error())
+		}
+		if found {
+			return accessError(allowed)
+		}
+	}
+
+	res, err := uc.authClient.HasAccess(ctx, &authPB.HasAccessRequest{
 		UserId:      userID,
 		Permissions: permissions,
 	})
-
 	if err != nil {
-		return model.ErrUnauthorizedAccess
+		logger.Error(err.Error())
+		return dependencyError(ctx, err, model.ErrAuthServiceUnavailable, model.ErrUnauthorizedAccess)
 	}
 	if res == nil {
 		return model.ErrUnauthorizedAccess
 	}
-	if !res.Value {
+
+	if uc.permissionCacheRepo != nil {
+		err = uc.permissionCacheRepo.Set(ctx, userID, permissions, res.Value)
+		if err != nil {
+			logger.Warn(err.Error())
+		}
+	}
+
+	return accessError(res.Value)
+}
+
+// hasReadAccess check the read permission, anonymous is true when a guest is allowed by the anonymous read mode
+// and must only see not deleted products.
+func (uc *productUsecase) hasReadAccess(ctx context.Context) (anonymous bool, err error) {
+	if config.AuthAnonymousRead() && getUserIDFromCtx(ctx) == constant.GuestID {
+		return true, nil
+	}
+
+	return false, uc.hasPermission(ctx, []string{
+		constant.PermissionProductAll,
+		constant.PermissionProductRead,
+	})
+}
+
+func getIdempotencyKeyFromCtx(ctx context.Context) string {
+	key, _ := ctx.Value(constant.KeyIdempotencyCtx).(string)
+	return key
+}
+
+// errIdempotencyKeyTaken roll back a write when a concurrent request with the same key committed first.
+var errIdempotencyKeyTaken = errors.New("idempotency key taken by a concurrent request")
+
+// idempotencyWrite is the record of a write made with an idempotency key, response is marshaled when the
+// write store the record in its transaction.
+type idempotencyWrite struct {
+	record   *model.IdempotencyRecord
+	response any
+	stored   bool
+}
+
+// withIdempotency run the write once per idempotency key of the caller, response must be a pointer set by the write.
+// The record is stored by withProductChanges in the transaction of the write, so a committed write always has its
+// record and a failed one never. A replay with the same request get the stored response back.
+func (uc *productUsecase) withIdempotency(ctx context.Context, operation string, request any, response any, write func(ctx context.Context) error) error {
+	key := getIdempotencyKeyFromCtx(ctx)
+	if key == "" || uc.idempotencyRepo == nil {
+		return write(ctx)
+	}
+
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"operation":      operation,
+		"idempotencyKey": key,
+	})
+
+	err := model.ValidateIdempotencyKey(key)
+	if err != nil {
+		return err
+	}
+
+	requestHash, err := model.NewIdempotencyRequestHash(request)
+	if err != nil {
+		return err
+	}
+
+	scopedKey := model.NewIdempotencyKey(getUserIDFromCtx(ctx), operation, key)
+	replay := func() (bool, error) {
+		record, err := uc.idempotencyRepo.FindByKey(ctx, scopedKey)
+		if err != nil || record == nil {
+			return false, err
+		}
+		if record.RequestHash != requestHash {
+			return true, model.ErrIdempotencyKeyReused
+		}
+		logger.Info("replay idempotent request")
+		return true, json.Unmarshal(record.Response, response)
+	}
+
+	replayed, err := replay()
+	if replayed || err != nil {
+		return err
+	}
+
+	pending := &idempotencyWrite{
+		record: &model.IdempotencyRecord{
+			Key:         scopedKey,
+			RequestHash: requestHash,
+			ExpiresAt:   time.Now().Add(config.IdempotencyTTL()),
+		},
+		response: response,
+	}
+	err = write(context.WithValue(ctx, constant.KeyIdempotencyRecordCtx, pending))
+	if errors.Is(err, errIdempotencyKeyTaken) {
+		replayed, err = replay()
+		if !replayed && err == nil {
+			// the other record expired in between
+			return model.ErrIdempotencyKeyInProgress
+		}
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if !pending.stored {
+		logger.Warn("idempotent write didn't store its record")
+	}
+	return nil
+}
+
+// storeIdempotencyRecord store the record of the idempotent write of ctx in its transaction.
+func (uc *productUsecase) storeIdempotencyRecord(txCtx context.Context) error {
+	pending, ok := txCtx.Value(constant.KeyIdempotencyRecordCtx).(*idempotencyWrite)
+	if !ok || pending.stored {
+		return nil
+	}
+
+	data, err := json.Marshal(pending.response)
+	if err != nil {
+		return err
+	}
+	pending.record.Response = data
+
+	created, err := uc.idempotencyRepo.Create(txCtx, pending.record)
+	if err != nil {
+		return err
+	}
+	if !created {
+		return errIdempotencyKeyTaken
+	}
+
+	pending.stored = true
+	return nil
+}
+
+// DeleteExpiredIdempotencyKeys delete the expired records in batches so a backlog doesn't hold a long transaction.
+func (uc *productUsecase) DeleteExpiredIdempotencyKeys(ctx context.Context) error {
+	var total int64
+	for {
+		deleted, err := uc.idempotencyRepo.DeleteExpired(ctx, model.IdempotencyCleanupBatchSize)
+		if err != nil {
+			return err
+		}
+		total += deleted
+		if deleted < model.IdempotencyCleanupBatchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		logrus.WithContext(ctx).WithField("deleted", total).Info("expired idempotency keys deleted")
+	}
+	return nil
+}
+
+// checkProductQuota reject a write leaving the owner with more than quota.max_products_per_owner products,
+// added is the number of products the write is about to add. ctx must carry the transaction of the write,
+// the owner stay locked until it end so concurrent writes can't both pass the check.
+func (uc *productUsecase) checkProductQuota(ctx context.Context, ownerID string, added int64) error {
+	remaining, limit, err := uc.remainingProductQuota(ctx, ownerID)
+	if err != nil {
+		return err
+	}
+	if limit > 0 && added > remaining {
+		return &model.ProductQuotaError{
+			OwnerID:    ownerID,
+			Limit:      limit,
+			RetryAfter: config.QuotaRetryAfter(),
+		}
+	}
+	return nil
+}
+
+// remainingProductQuota lock the owner until the transaction of ctx end and count the products it can still have,
+// remaining is negative when the owner is already over the limit. There is no quota when limit is not positive.
+func (uc *productUsecase) remainingProductQuota(ctx context.Context, ownerID string) (remaining int64, limit int64, err error) {
+	limit = config.QuotaMaxProductsPerOwner()
+	if limit <= 0 {
+		return 0, limit, nil
+	}
+
+	err = uc.productRepo.LockOwner(ctx, ownerID)
+	if err != nil {
+		return 0, limit, err
+	}
+
+	count, err := uc.productRepo.CountByOwnerID(ctx, ownerID)
+	if err != nil {
+		return 0, limit, err
+	}
+	return limit - count, limit, nil
+}
+
+// dependencyError tell apart a service that can't be reached from the business error it answered,
+// unavailable is returned for the former so callers don't mistake an outage for a denial.
+// The caller own cancellation or deadline is returned as is.
+func dependencyError(ctx context.Context, err error, unavailable error, fallback error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if utils.IsUnavailableError(ctx, err) {
+		return unavailable
+	}
+	return fallback
+}
+
+func accessError(allowed bool) error {
+	if !allowed {
 		return model.ErrUnauthorizedAccess
 	}
 	return nil
 }
+
+type searchResult struct {
+	ids        []string
+	highlights []*model.ProductHighlight
+	count      int64
+}
+
+// newSearchBreaker open the circuit after consecutive search failures, listing will use the database until it is half-open.
+func newSearchBreaker() *gobreaker.CircuitBreaker {
+	return gobreaker.NewCircuitBreaker(gobreaker.Settings{
+		Name:    "search",
+		Timeout: config.SearchBreakerTimeout(),
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return counts.ConsecutiveFailures >= config.SearchBreakerFailures()
+		},
+		OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
+			logrus.WithFields(logrus.Fields{
+				"breaker": name,
+				"from":    from.String(),
+				"to":      to.String(),
+			}).Warn("circuit breaker state changed")
+		},
+	})
+}
+
+// findSearchPaginatedIDs call the search backend through the circuit breaker with a deadline.
+func (uc *productUsecase) findSearchPaginatedIDs(ctx context.Context, req *model.PaginationPayload) (ids []string, highlights []*model.ProductHighlight, count int64, err error) {
+	result, err := uc.searchBreaker.Execute(func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(ctx, config.SearchTimeout())
+		defer cancel()
+
+		ids, highlights, count, err := uc.productRepo.FindSearchPaginatedIDs(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return &searchResult{
+			ids:        ids,
+			highlights: highlights,
+			count:      count,
+		}, nil
+	})
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	res, ok := result.(*searchResult)
+	if !ok {
+		return nil, nil, 0, errors.New("invalid search result")
+	}
+	return res.ids, res.highlights, res.count, nil
+}
+
+// withProductChanges run the product write and record the audit logs, revisions and change events of the changes it
+// returns in the same transaction, the events are published to the change stream by the worker relay.
+func (uc *productUsecase) withProductChanges(ctx context.Context, actorID string, origin string, write func(txCtx context.Context) (model.ProductChanges, error)) error {
+	return utils.RunInTx(ctx, uc.db, func(txCtx context.Context) error {
+		changes, err := write(txCtx)
+		if err != nil {
+			return err
+		}
+
+		err = uc.auditLogRepo.Create(txCtx, changes.ToAuditLogs(actorID, origin))
+		if err != nil {
+			return err
+		}
+
+		err = uc.revisionRepo.Create(txCtx, changes.ToRevisions(actorID))
+		if err != nil {
+			return err
+		}
+
+		if uc.outboxRepo != nil {
+			events, err := changes.ToOutboxEvents()
+			if err != nil {
+				return err
+			}
+			err = uc.outboxRepo.Create(txCtx, events)
+			if err != nil {
+				return err
+			}
+		}
+
+		return uc.storeIdempotencyRecord(txCtx)
+	})
+}
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/krobus00/product-service/internal/constant"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/model/mock"
+	"github.com/krobus00/product-service/internal/utils"
+)
+
+func Test_getUserIDFromCtx(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{
+			name: "user from context",
+			ctx:  context.WithValue(context.TODO(), constant.KeyUserIDCtx, "3b2cbc6a-0a35-4f4e-9b5c-5b7c4e8f2c11"),
+			want: "3b2cbc6a-0a35-4f4e-9b5c-5b7c4e8f2c11",
+		},
+		{
+			name: "guest when user is not set",
+			ctx:  context.TODO(),
+			want: constant.GuestID,
+		},
+		{
+			name: "guest when user is empty",
+			ctx:  context.WithValue(context.TODO(), constant.KeyUserIDCtx, ""),
+			want: constant.GuestID,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getUserIDFromCtx(tt.ctx); got != tt.want {
+				t.Errorf("getUserIDFromCtx() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_productUsecase_withProductChanges(t *testing.T) {
+	product := &model.Product{ID: utils.GenerateUUID(), Name: "product", OwnerID: utils.GenerateUUID()}
+	changes := model.ProductChanges{
+		model.NewProductChange(model.ProductAuditActionCreate, nil, product),
+	}
+	wantEvents, err := changes.ToOutboxEvents()
+	utils.ContinueOrFatal(err)
+
+	tests := []struct {
+		name      string
+		outboxErr error
+		wantErr   bool
+	}{
+		{
+			name:      "change events written in the transaction",
+			outboxErr: nil,
+			wantErr:   false,
+		},
+		{
+			name:      "rollback when the change events can't be written",
+			outboxErr: errors.New("db error"),
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := NewProductUsecase()
+			db, dbMock := utils.NewDBMock()
+			err := uc.InjectDB(db)
+			utils.ContinueOrFatal(err)
+			mockAuditLogRepo := mock.NewMockProductAuditLogRepository(ctrl)
+			err = uc.InjectProductAuditLogRepo(mockAuditLogRepo)
+			utils.ContinueOrFatal(err)
+			mockRevisionRepo := mock.NewMockProductRevisionRepository(ctrl)
+			err = uc.InjectProductRevisionRepo(mockRevisionRepo)
+			utils.ContinueOrFatal(err)
+			mockOutboxRepo := mock.NewMockProductOutboxRepository(ctrl)
+			err = uc.InjectProductOutboxRepo(mockOutboxRepo)
+			utils.ContinueOrFatal(err)
+
+			dbMock.ExpectBegin()
+			mockAuditLogRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			mockRevisionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			mockOutboxRepo.EXPECT().Create(gomock.Any(), wantEvents).Times(1).DoAndReturn(func(txCtx context.Context, _ model.ProductOutboxEvents) error {
+				if !utils.InTx(txCtx) {
+					t.Errorf("productUsecase.withProductChanges() change events written outside the transaction")
+				}
+				return tt.outboxErr
+			})
+			if tt.wantErr {
+				dbMock.ExpectRollback()
+			} else {
+				dbMock.ExpectCommit()
+			}
+
+			err = uc.(*productUsecase).withProductChanges(context.TODO(), utils.GenerateUUID(), model.UnknownOrigin, func(txCtx context.Context) (model.ProductChanges, error) {
+				return changes, nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productUsecase.withProductChanges() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err := dbMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("productUsecase.withProductChanges() %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/krobus00/product-service/internal/constant"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+	"github.com/sirupsen/logrus"
+)
+
+func (uc *productUsecase) FindHistory(ctx context.Context, req *model.ProductHistoryPayload) (*model.ProductHistoryResponse, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	userID := getUserIDFromCtx(ctx)
+
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"userID":    userID,
+		"productID": req.ProductID,
+		"page":      req.Page,
+		"limit":     req.Limit,
+	})
+
+	product, err := uc.productRepo.FindByID(ctx, req.ProductID)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+	if product == nil {
+		return nil, model.ErrProductNotFound
+	}
+
+	err = uc.hasAccess(ctx, []string{
+		constant.PermissionProductAll,
+		constant.PermissionProductRead,
+	}, product, model.CollaboratorRoleEditor, model.CollaboratorRoleViewer)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	req = req.Sanitize()
+	logs, count, err := uc.auditLogRepo.FindPaginatedByProductID(ctx, req)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return model.NewProductHistoryResponse(req, logs, count), nil
+}
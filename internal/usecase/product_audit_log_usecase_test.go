@@ -0,0 +1,227 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	authMock "github.com/krobus00/auth-service/pb/auth/mock"
+	"github.com/krobus00/product-service/internal/constant"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/model/mock"
+	"github.com/krobus00/product-service/internal/utils"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func Test_productUsecase_FindHistory(t *testing.T) {
+	userID := utils.GenerateUUID()
+	ownerID := utils.GenerateUUID()
+	productID := utils.GenerateUUID()
+	logs := model.ProductAuditLogs{
+		{
+			ID:        utils.GenerateUUID(),
+			ProductID: productID,
+			ActorID:   userID,
+			Action:    model.ProductAuditActionCreate,
+		},
+	}
+
+	type mockSelect struct {
+		product *model.Product
+		err     error
+	}
+	type mockAuth struct {
+		hasAccess bool
+		err       error
+	}
+	type mockFind struct {
+		logs  model.ProductAuditLogs
+		count int64
+		err   error
+	}
+
+	tests := []struct {
+		name       string
+		req        *model.ProductHistoryPayload
+		mockSelect *mockSelect
+		mockAuth   *mockAuth
+		// mockRole is the collaborator role of the user when the product is owned by someone else
+		mockRole string
+		mockFind *mockFind
+		want     *model.ProductHistoryResponse
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			req: &model.ProductHistoryPayload{
+				ProductID: productID,
+			},
+			mockSelect: &mockSelect{
+				product: &model.Product{
+					ID:      productID,
+					OwnerID: userID,
+				},
+				err: nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockFind: &mockFind{
+				logs:  logs,
+				count: 11,
+				err:   nil,
+			},
+			want: &model.ProductHistoryResponse{
+				Count:   11,
+				MaxPage: 2,
+				Items:   logs,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success as viewer collaborator",
+			req: &model.ProductHistoryPayload{
+				ProductID: productID,
+			},
+			mockSelect: &mockSelect{
+				product: &model.Product{
+					ID:      productID,
+					OwnerID: ownerID,
+				},
+				err: nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockRole: model.CollaboratorRoleViewer,
+			mockFind: &mockFind{
+				logs:  logs,
+				count: 1,
+				err:   nil,
+			},
+			want: &model.ProductHistoryResponse{
+				Count:   1,
+				MaxPage: 1,
+				Items:   logs,
+			},
+			wantErr: false,
+		},
+		{
+			name: "product not found",
+			req: &model.ProductHistoryPayload{
+				ProductID: productID,
+			},
+			mockSelect: &mockSelect{
+				product: nil,
+				err:     nil,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "permission denied",
+			req: &model.ProductHistoryPayload{
+				ProductID: productID,
+			},
+			mockSelect: &mockSelect{
+				product: &model.Product{
+					ID:      productID,
+					OwnerID: userID,
+				},
+				err: nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: false,
+				err:       nil,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "db error",
+			req: &model.ProductHistoryPayload{
+				ProductID: productID,
+			},
+			mockSelect: &mockSelect{
+				product: &model.Product{
+					ID:      productID,
+					OwnerID: userID,
+				},
+				err: nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockFind: &mockFind{
+				logs:  nil,
+				count: 0,
+				err:   errors.New("db error"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.TODO()
+			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, userID)
+
+			uc := NewProductUsecase()
+			mockAuthClient := authMock.NewMockAuthServiceClient(ctrl)
+			err := uc.InjectAuthClient(mockAuthClient)
+			utils.ContinueOrFatal(err)
+			mockProductRepo := mock.NewMockProductRepository(ctrl)
+			err = uc.InjectProductRepo(mockProductRepo)
+			utils.ContinueOrFatal(err)
+			mockAuditLogRepo := mock.NewMockProductAuditLogRepository(ctrl)
+			err = uc.InjectProductAuditLogRepo(mockAuditLogRepo)
+			utils.ContinueOrFatal(err)
+			mockCollaboratorRepo := mock.NewMockProductCollaboratorRepository(ctrl)
+			err = uc.InjectProductCollaboratorRepo(mockCollaboratorRepo)
+			utils.ContinueOrFatal(err)
+
+			if tt.mockSelect != nil {
+				mockProductRepo.EXPECT().FindByID(gomock.Any(), productID).Times(1).Return(tt.mockSelect.product, tt.mockSelect.err)
+			}
+
+			if tt.mockAuth != nil {
+				mockAuthClient.EXPECT().HasAccess(gomock.Any(), gomock.Any()).Times(1).Return(&wrapperspb.BoolValue{
+					Value: tt.mockAuth.hasAccess,
+				}, tt.mockAuth.err)
+			}
+
+			if tt.mockRole != "" {
+				mockCollaboratorRepo.EXPECT().FindByProductIDAndUserID(gomock.Any(), productID, userID).Times(1).Return(&model.ProductCollaborator{
+					ProductID: productID,
+					UserID:    userID,
+					Role:      tt.mockRole,
+				}, nil)
+			}
+
+			if tt.mockFind != nil {
+				mockAuditLogRepo.EXPECT().FindPaginatedByProductID(gomock.Any(), &model.ProductHistoryPayload{
+					ProductID: productID,
+					Limit:     10,
+					Page:      1,
+				}).Times(1).Return(tt.mockFind.logs, tt.mockFind.count, tt.mockFind.err)
+			}
+
+			got, err := uc.FindHistory(ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productUsecase.FindHistory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("productUsecase.FindHistory() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
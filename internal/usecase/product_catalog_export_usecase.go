@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/krobus00/product-service/internal/config"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// ExportCatalog write the products matching the filters to a temporary file of the output dir,
+// the batches are read in a single repeatable read transaction so a product updated during the export
+// is neither written twice nor missed. The snapshot is only moved to its name, or uploaded, once complete.
+func (uc *productUsecase) ExportCatalog(ctx context.Context, payload *model.CatalogExportPayload) (*model.CatalogExportManifest, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"format":         payload.Format,
+		"destination":    payload.Destination,
+		"ownerID":        payload.OwnerID,
+		"updatedSince":   payload.UpdatedSince,
+		"includeDeleted": payload.IncludeDeleted,
+	})
+
+	err := payload.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	outputDir := payload.OutputDir
+	if outputDir == "" {
+		outputDir = config.CatalogExportOutputDir()
+	}
+	err = os.MkdirAll(outputDir, 0o755)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	manifest := model.NewCatalogExportManifest(payload, time.Now())
+	logger = logger.WithField("fileName", manifest.FileName)
+
+	file, err := os.CreateTemp(outputDir, "."+manifest.FileName+".*.tmp")
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+	}()
+
+	err = uc.writeCatalogExport(ctx, payload, manifest, file)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	switch payload.Destination {
+	case model.CatalogExportDestinationStorage:
+		err = uc.uploadCatalogExport(ctx, manifest, file)
+	default:
+		err = saveCatalogExport(outputDir, manifest, file)
+	}
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"rows":        manifest.Rows,
+		"deletedRows": manifest.DeletedRows,
+		"bytes":       manifest.Bytes,
+		"path":        manifest.Path,
+		"objectID":    manifest.ObjectID,
+	}).Info("catalog export completed")
+
+	return manifest, nil
+}
+
+// writeCatalogExport encode the snapshot to file and fill the counts and checksum of the manifest.
+func (uc *productUsecase) writeCatalogExport(ctx context.Context, payload *model.CatalogExportPayload, manifest *model.CatalogExportManifest, file *os.File) error {
+	hash := sha256.New()
+	buf := bufio.NewWriter(io.MultiWriter(file, hash))
+
+	writer, err := model.NewCatalogExportWriter(payload.Format, buf, config.CatalogExportParquetRowGroupSize())
+	if err != nil {
+		return err
+	}
+
+	req := payload.ExportProductsPayload()
+	batchSize := config.ExportBatchSize()
+	err = utils.RunInTx(ctx, uc.db, func(txCtx context.Context) error {
+		var after *model.ProductExportCheckpoint
+		for {
+			products, err := uc.productRepo.FindExportBatch(txCtx, req, after, batchSize)
+			if err != nil {
+				return err
+			}
+
+			for _, product := range products {
+				err = writer.Write(product)
+				if err != nil {
+					return err
+				}
+				manifest.Count(product)
+				after = product.ExportCheckpoint()
+			}
+
+			if len(products) < batchSize {
+				return nil
+			}
+		}
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return err
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return err
+	}
+	err = buf.Flush()
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	manifest.Bytes = info.Size()
+	manifest.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	manifest.FinishedAt = time.Now().UTC()
+	return nil
+}
+
+// saveCatalogExport rename the temporary file and write the manifest next to it.
+func saveCatalogExport(outputDir string, manifest *model.CatalogExportManifest, file *os.File) error {
+	err := file.Sync()
+	if err != nil {
+		return err
+	}
+
+	manifest.Path = filepath.Join(outputDir, manifest.FileName)
+	err = os.Rename(file.Name(), manifest.Path)
+	if err != nil {
+		return err
+	}
+
+	content, err := manifest.JSON()
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(outputDir, manifest.ManifestFileName()), content)
+}
+
+// writeFileAtomic write content to a temporary file renamed to name, a reader never see a partial file.
+func writeFileAtomic(name string, content []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+	}()
+
+	_, err = file.Write(content)
+	if err != nil {
+		return err
+	}
+	err = file.Sync()
+	if err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), name)
+}
+
+// uploadCatalogExport upload the snapshot then its manifest to storage-service.
+func (uc *productUsecase) uploadCatalogExport(ctx context.Context, manifest *model.CatalogExportManifest, file *os.File) error {
+	_, err := file.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	manifest.ObjectID, err = uc.objectUploader.Upload(ctx, manifest.FileName, file)
+	if err != nil {
+		return err
+	}
+
+	content, err := manifest.JSON()
+	if err != nil {
+		return err
+	}
+	_, err = uc.objectUploader.Upload(ctx, manifest.ManifestFileName(), bytes.NewReader(content))
+	return err
+}
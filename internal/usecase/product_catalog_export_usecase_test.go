@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/model/mock"
+	"github.com/krobus00/product-service/internal/utils"
+	"github.com/spf13/viper"
+)
+
+func Test_productUsecase_ExportCatalog(t *testing.T) {
+	updatedAt := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	products := model.Products{
+		{ID: utils.GenerateUUID(), Name: "Kopi", CreatedAt: updatedAt, UpdatedAt: updatedAt},
+		{ID: utils.GenerateUUID(), Name: "Teh", CreatedAt: updatedAt, UpdatedAt: updatedAt},
+		{ID: utils.GenerateUUID(), Name: "Susu", CreatedAt: updatedAt, UpdatedAt: updatedAt.Add(time.Second)},
+	}
+
+	type mockBatch struct {
+		after    *model.ProductExportCheckpoint
+		products model.Products
+		err      error
+	}
+	type mockUpload struct {
+		fileName string
+		objectID string
+		err      error
+	}
+
+	tests := []struct {
+		name         string
+		payload      *model.CatalogExportPayload
+		mockBatches  []*mockBatch
+		mockUploads  []*mockUpload
+		wantRows     int64
+		wantObjectID string
+		wantErr      bool
+	}{
+		{
+			name: "local",
+			payload: &model.CatalogExportPayload{
+				Format:      model.CatalogExportFormatCSV,
+				Destination: model.CatalogExportDestinationLocal,
+			},
+			mockBatches: []*mockBatch{
+				{after: nil, products: products[:2], err: nil},
+				{after: products[1].ExportCheckpoint(), products: products[2:], err: nil},
+			},
+			wantRows: 3,
+			wantErr:  false,
+		},
+		{
+			name: "storage",
+			payload: &model.CatalogExportPayload{
+				Format:      model.CatalogExportFormatJSONL,
+				Destination: model.CatalogExportDestinationStorage,
+			},
+			mockBatches: []*mockBatch{
+				{after: nil, products: products[:1], err: nil},
+			},
+			mockUploads: []*mockUpload{
+				{fileName: ".jsonl", objectID: "snapshot-object", err: nil},
+				{fileName: ".manifest.json", objectID: "manifest-object", err: nil},
+			},
+			wantRows:     1,
+			wantObjectID: "snapshot-object",
+			wantErr:      false,
+		},
+		{
+			name: "upload error",
+			payload: &model.CatalogExportPayload{
+				Format:      model.CatalogExportFormatParquet,
+				Destination: model.CatalogExportDestinationStorage,
+			},
+			mockBatches: []*mockBatch{
+				{after: nil, products: model.Products{}, err: nil},
+			},
+			mockUploads: []*mockUpload{
+				{fileName: ".parquet", objectID: "", err: errors.New("storage error")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "db error",
+			payload: &model.CatalogExportPayload{
+				Format:      model.CatalogExportFormatCSV,
+				Destination: model.CatalogExportDestinationLocal,
+			},
+			mockBatches: []*mockBatch{
+				{after: nil, products: nil, err: errors.New("db error")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid payload",
+			payload: &model.CatalogExportPayload{
+				Format:      "xlsx",
+				Destination: model.CatalogExportDestinationLocal,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			outputDir := t.TempDir()
+			tt.payload.OutputDir = outputDir
+			viper.Set("export.batch_size", 2)
+			defer viper.Set("export.batch_size", 0)
+
+			uc := NewProductUsecase()
+			db, dbMock := utils.NewDBMock()
+			err := uc.InjectDB(db)
+			utils.ContinueOrFatal(err)
+			mockProductRepo := mock.NewMockProductRepository(ctrl)
+			err = uc.InjectProductRepo(mockProductRepo)
+			utils.ContinueOrFatal(err)
+			mockObjectUploader := mock.NewMockObjectUploader(ctrl)
+			err = uc.InjectObjectUploader(mockObjectUploader)
+			utils.ContinueOrFatal(err)
+
+			if tt.mockBatches != nil {
+				dbMock.ExpectBegin()
+				batchErr := false
+				calls := make([]*gomock.Call, 0)
+				for _, batch := range tt.mockBatches {
+					batchErr = batchErr || batch.err != nil
+					calls = append(calls, mockProductRepo.EXPECT().FindExportBatch(gomock.Any(), tt.payload.ExportProductsPayload(), batch.after, 2).Times(1).Return(batch.products, batch.err))
+				}
+				gomock.InOrder(calls...)
+				if batchErr {
+					dbMock.ExpectRollback()
+				} else {
+					dbMock.ExpectCommit()
+				}
+			}
+
+			calls := make([]*gomock.Call, 0)
+			for _, upload := range tt.mockUploads {
+				upload := upload
+				calls = append(calls, mockObjectUploader.EXPECT().Upload(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, fileName string, content io.Reader) (string, error) {
+					if !strings.HasSuffix(fileName, upload.fileName) {
+						t.Errorf("productUsecase.ExportCatalog() uploaded %v, want %v", fileName, upload.fileName)
+					}
+					_, _ = io.Copy(io.Discard, content)
+					return upload.objectID, upload.err
+				}))
+			}
+			gomock.InOrder(calls...)
+
+			got, err := uc.ExportCatalog(context.TODO(), tt.payload)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productUsecase.ExportCatalog() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err := dbMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+
+			// only a complete local snapshot is left in the output dir, with its manifest
+			entries, err := os.ReadDir(outputDir)
+			utils.ContinueOrFatal(err)
+			wantEntries := 0
+			if !tt.wantErr && tt.payload.Destination == model.CatalogExportDestinationLocal {
+				wantEntries = 2
+			}
+			if len(entries) != wantEntries {
+				t.Errorf("productUsecase.ExportCatalog() left %d files, want %d", len(entries), wantEntries)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if got.Rows != tt.wantRows || got.ObjectID != tt.wantObjectID || got.SHA256 == "" || got.Bytes == 0 {
+				t.Errorf("productUsecase.ExportCatalog() = %+v", got)
+			}
+			if tt.payload.Destination == model.CatalogExportDestinationLocal {
+				info, err := os.Stat(got.Path)
+				utils.ContinueOrFatal(err)
+				if info.Size() != got.Bytes {
+					t.Errorf("productUsecase.ExportCatalog() wrote %d bytes, manifest %d", info.Size(), got.Bytes)
+				}
+				if _, err := os.Stat(filepath.Join(outputDir, got.ManifestFileName())); err != nil {
+					t.Errorf("productUsecase.ExportCatalog() manifest error = %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/krobus00/product-service/internal/constant"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+	"github.com/sirupsen/logrus"
+)
+
+func (uc *productUsecase) AddCollaborator(ctx context.Context, payload *model.AddCollaboratorPayload) (*model.ProductCollaborator, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	userID := getUserIDFromCtx(ctx)
+
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"userID":         userID,
+		"productID":      payload.ProductID,
+		"collaboratorID": payload.UserID,
+		"role":           payload.Role,
+	})
+
+	if !model.CollaboratorRoles[payload.Role] {
+		return nil, model.ErrInvalidCollaboratorRole
+	}
+	if payload.UserID == "" || payload.UserID == constant.GuestID {
+		return nil, model.ErrInvalidCollaborator
+	}
+
+	product, err := uc.findCollaboratorProduct(ctx, payload.ProductID)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	if product.OwnerID == payload.UserID {
+		return nil, model.ErrInvalidCollaborator
+	}
+
+	collaborator := payload.ToProductCollaborator()
+	err = utils.RunInTx(ctx, uc.db, func(txCtx context.Context) error {
+		err := uc.collaboratorRepo.Upsert(txCtx, collaborator)
+		if err != nil {
+			return err
+		}
+
+		return uc.productRepo.Reindex(txCtx, product)
+	})
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return collaborator, nil
+}
+
+func (uc *productUsecase) RemoveCollaborator(ctx context.Context, productID string, collaboratorID string) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	userID := getUserIDFromCtx(ctx)
+
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"userID":         userID,
+		"productID":      productID,
+		"collaboratorID": collaboratorID,
+	})
+
+	product, err := uc.findCollaboratorProduct(ctx, productID)
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	err = utils.RunInTx(ctx, uc.db, func(txCtx context.Context) error {
+		err := uc.collaboratorRepo.Delete(txCtx, productID, collaboratorID)
+		if err != nil {
+			return err
+		}
+
+		return uc.productRepo.Reindex(txCtx, product)
+	})
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (uc *productUsecase) FindCollaborators(ctx context.Context, productID string) (model.ProductCollaborators, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	userID := getUserIDFromCtx(ctx)
+
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"userID":    userID,
+		"productID": productID,
+	})
+
+	product, err := uc.productRepo.FindByID(ctx, productID)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+	if product == nil || product.DeletedAt.Valid {
+		return nil, model.ErrProductNotFound
+	}
+
+	err = uc.hasAccess(ctx, []string{
+		constant.PermissionProductAll,
+		constant.PermissionProductRead,
+	}, product, model.CollaboratorRoleEditor, model.CollaboratorRoleViewer)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	collaborators, err := uc.collaboratorRepo.FindByProductID(ctx, productID)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return collaborators, nil
+}
+
+// findCollaboratorProduct find a not deleted product whose collaborators can be managed by the caller,
+// only the owner or users allowed to modify other users products can share it.
+func (uc *productUsecase) findCollaboratorProduct(ctx context.Context, productID string) (*model.Product, error) {
+	product, err := uc.productRepo.FindByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil || product.DeletedAt.Valid {
+		return nil, model.ErrProductNotFound
+	}
+
+	err = uc.hasAccess(ctx, []string{
+		constant.PermissionProductAll,
+		constant.PermissionProductUpdate,
+	}, product)
+	if err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
@@ -0,0 +1,500 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	authMock "github.com/krobus00/auth-service/pb/auth/mock"
+	"github.com/krobus00/product-service/internal/constant"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/model/mock"
+	"github.com/krobus00/product-service/internal/utils"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func Test_productUsecase_AddCollaborator(t *testing.T) {
+	userID := utils.GenerateUUID()
+	productID := utils.GenerateUUID()
+	collaboratorID := utils.GenerateUUID()
+
+	type args struct {
+		payload *model.AddCollaboratorPayload
+	}
+	type mockSelect struct {
+		product *model.Product
+		err     error
+	}
+	type mockAuth struct {
+		hasAccess bool
+		err       error
+	}
+	type mockUpsert struct {
+		err error
+	}
+	type mockReindex struct {
+		err error
+	}
+
+	tests := []struct {
+		name        string
+		args        args
+		mockSelect  *mockSelect
+		mockAuth    *mockAuth
+		mockUpsert  *mockUpsert
+		mockReindex *mockReindex
+		want        *model.ProductCollaborator
+		wantErr     error
+	}{
+		{
+			name: "success",
+			args: args{
+				payload: &model.AddCollaboratorPayload{
+					ProductID: productID,
+					UserID:    collaboratorID,
+					Role:      model.CollaboratorRoleEditor,
+				},
+			},
+			mockSelect: &mockSelect{
+				product: &model.Product{
+					ID:      productID,
+					OwnerID: userID,
+				},
+				err: nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockUpsert: &mockUpsert{
+				err: nil,
+			},
+			mockReindex: &mockReindex{
+				err: nil,
+			},
+			want: &model.ProductCollaborator{
+				ProductID: productID,
+				UserID:    collaboratorID,
+				Role:      model.CollaboratorRoleEditor,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "invalid role",
+			args: args{
+				payload: &model.AddCollaboratorPayload{
+					ProductID: productID,
+					UserID:    collaboratorID,
+					Role:      "OWNER",
+				},
+			},
+			want:    nil,
+			wantErr: model.ErrInvalidCollaboratorRole,
+		},
+		{
+			name: "owner can't be a collaborator",
+			args: args{
+				payload: &model.AddCollaboratorPayload{
+					ProductID: productID,
+					UserID:    userID,
+					Role:      model.CollaboratorRoleViewer,
+				},
+			},
+			mockSelect: &mockSelect{
+				product: &model.Product{
+					ID:      productID,
+					OwnerID: userID,
+				},
+				err: nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			want:    nil,
+			wantErr: model.ErrInvalidCollaborator,
+		},
+		{
+			name: "product not found",
+			args: args{
+				payload: &model.AddCollaboratorPayload{
+					ProductID: productID,
+					UserID:    collaboratorID,
+					Role:      model.CollaboratorRoleViewer,
+				},
+			},
+			mockSelect: &mockSelect{
+				product: nil,
+				err:     nil,
+			},
+			want:    nil,
+			wantErr: model.ErrProductNotFound,
+		},
+		{
+			name: "permission denied",
+			args: args{
+				payload: &model.AddCollaboratorPayload{
+					ProductID: productID,
+					UserID:    collaboratorID,
+					Role:      model.CollaboratorRoleViewer,
+				},
+			},
+			mockSelect: &mockSelect{
+				product: &model.Product{
+					ID:      productID,
+					OwnerID: userID,
+				},
+				err: nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: false,
+				err:       nil,
+			},
+			want:    nil,
+			wantErr: model.ErrUnauthorizedAccess,
+		},
+		{
+			name: "rollback when reindex error",
+			args: args{
+				payload: &model.AddCollaboratorPayload{
+					ProductID: productID,
+					UserID:    collaboratorID,
+					Role:      model.CollaboratorRoleEditor,
+				},
+			},
+			mockSelect: &mockSelect{
+				product: &model.Product{
+					ID:      productID,
+					OwnerID: userID,
+				},
+				err: nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockUpsert: &mockUpsert{
+				err: nil,
+			},
+			mockReindex: &mockReindex{
+				err: errors.New("searcher error"),
+			},
+			want:    nil,
+			wantErr: errors.New("searcher error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.TODO()
+			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, userID)
+
+			uc := NewProductUsecase()
+			db, dbMock := utils.NewDBMock()
+			err := uc.InjectDB(db)
+			utils.ContinueOrFatal(err)
+			mockAuthClient := authMock.NewMockAuthServiceClient(ctrl)
+			err = uc.InjectAuthClient(mockAuthClient)
+			utils.ContinueOrFatal(err)
+			mockProductRepo := mock.NewMockProductRepository(ctrl)
+			err = uc.InjectProductRepo(mockProductRepo)
+			utils.ContinueOrFatal(err)
+			mockCollaboratorRepo := mock.NewMockProductCollaboratorRepository(ctrl)
+			err = uc.InjectProductCollaboratorRepo(mockCollaboratorRepo)
+			utils.ContinueOrFatal(err)
+
+			if tt.mockSelect != nil {
+				mockProductRepo.EXPECT().FindByID(gomock.Any(), productID).Times(1).Return(tt.mockSelect.product, tt.mockSelect.err)
+			}
+
+			if tt.mockAuth != nil {
+				mockAuthClient.EXPECT().HasAccess(gomock.Any(), gomock.Any()).Times(1).Return(&wrapperspb.BoolValue{
+					Value: tt.mockAuth.hasAccess,
+				}, tt.mockAuth.err)
+			}
+
+			if tt.mockUpsert != nil {
+				dbMock.ExpectBegin()
+				mockCollaboratorRepo.EXPECT().Upsert(gomock.Any(), tt.args.payload.ToProductCollaborator()).Times(1).Return(tt.mockUpsert.err)
+			}
+
+			if tt.mockReindex != nil {
+				mockProductRepo.EXPECT().Reindex(gomock.Any(), tt.mockSelect.product).Times(1).Return(tt.mockReindex.err)
+				if tt.mockReindex.err != nil {
+					dbMock.ExpectRollback()
+				} else {
+					dbMock.ExpectCommit()
+				}
+			}
+
+			got, err := uc.AddCollaborator(ctx, tt.args.payload)
+			if (err != nil) != (tt.wantErr != nil) || (err != nil && err.Error() != tt.wantErr.Error()) {
+				t.Errorf("productUsecase.AddCollaborator() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("productUsecase.AddCollaborator() = %v, want %v", got, tt.want)
+			}
+			if err := dbMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("productUsecase.AddCollaborator() %v", err)
+			}
+		})
+	}
+}
+
+func Test_productUsecase_RemoveCollaborator(t *testing.T) {
+	userID := utils.GenerateUUID()
+	productID := utils.GenerateUUID()
+	collaboratorID := utils.GenerateUUID()
+
+	type mockAuth struct {
+		hasAccess bool
+		err       error
+	}
+	type mockDelete struct {
+		err error
+	}
+
+	tests := []struct {
+		name        string
+		product     *model.Product
+		mockAuth    *mockAuth
+		mockDelete  *mockDelete
+		wantReindex bool
+		wantErr     error
+	}{
+		{
+			name: "success",
+			product: &model.Product{
+				ID:      productID,
+				OwnerID: userID,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockDelete: &mockDelete{
+				err: nil,
+			},
+			wantReindex: true,
+			wantErr:     nil,
+		},
+		{
+			name: "collaborator not found",
+			product: &model.Product{
+				ID:      productID,
+				OwnerID: userID,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockDelete: &mockDelete{
+				err: model.ErrCollaboratorNotFound,
+			},
+			wantErr: model.ErrCollaboratorNotFound,
+		},
+		{
+			name:    "product not found",
+			product: nil,
+			wantErr: model.ErrProductNotFound,
+		},
+		{
+			name: "permission denied",
+			product: &model.Product{
+				ID:      productID,
+				OwnerID: userID,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: false,
+				err:       nil,
+			},
+			wantErr: model.ErrUnauthorizedAccess,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.TODO()
+			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, userID)
+
+			uc := NewProductUsecase()
+			db, dbMock := utils.NewDBMock()
+			err := uc.InjectDB(db)
+			utils.ContinueOrFatal(err)
+			mockAuthClient := authMock.NewMockAuthServiceClient(ctrl)
+			err = uc.InjectAuthClient(mockAuthClient)
+			utils.ContinueOrFatal(err)
+			mockProductRepo := mock.NewMockProductRepository(ctrl)
+			err = uc.InjectProductRepo(mockProductRepo)
+			utils.ContinueOrFatal(err)
+			mockCollaboratorRepo := mock.NewMockProductCollaboratorRepository(ctrl)
+			err = uc.InjectProductCollaboratorRepo(mockCollaboratorRepo)
+			utils.ContinueOrFatal(err)
+
+			mockProductRepo.EXPECT().FindByID(gomock.Any(), productID).Times(1).Return(tt.product, nil)
+
+			if tt.mockAuth != nil {
+				mockAuthClient.EXPECT().HasAccess(gomock.Any(), gomock.Any()).Times(1).Return(&wrapperspb.BoolValue{
+					Value: tt.mockAuth.hasAccess,
+				}, tt.mockAuth.err)
+			}
+
+			if tt.mockDelete != nil {
+				dbMock.ExpectBegin()
+				mockCollaboratorRepo.EXPECT().Delete(gomock.Any(), productID, collaboratorID).Times(1).Return(tt.mockDelete.err)
+				if tt.mockDelete.err != nil {
+					dbMock.ExpectRollback()
+				}
+			}
+
+			if tt.wantReindex {
+				mockProductRepo.EXPECT().Reindex(gomock.Any(), tt.product).Times(1).Return(nil)
+				dbMock.ExpectCommit()
+			}
+
+			if err := uc.RemoveCollaborator(ctx, productID, collaboratorID); !errors.Is(err, tt.wantErr) {
+				t.Errorf("productUsecase.RemoveCollaborator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := dbMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("productUsecase.RemoveCollaborator() %v", err)
+			}
+		})
+	}
+}
+
+func Test_productUsecase_FindCollaborators(t *testing.T) {
+	userID := utils.GenerateUUID()
+	ownerID := utils.GenerateUUID()
+	productID := utils.GenerateUUID()
+	collaborators := model.ProductCollaborators{
+		{
+			ProductID: productID,
+			UserID:    userID,
+			Role:      model.CollaboratorRoleViewer,
+		},
+	}
+
+	type mockFindCollaborator struct {
+		collaborator *model.ProductCollaborator
+		err          error
+	}
+	type mockAuth struct {
+		hasAccess bool
+		err       error
+	}
+
+	tests := []struct {
+		name                 string
+		product              *model.Product
+		mockAuth             *mockAuth
+		mockFindCollaborator *mockFindCollaborator
+		mockModifyOther      *mockAuth
+		mockFind             bool
+		want                 model.ProductCollaborators
+		wantErr              bool
+	}{
+		{
+			name: "success as viewer",
+			product: &model.Product{
+				ID:      productID,
+				OwnerID: ownerID,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockFindCollaborator: &mockFindCollaborator{
+				collaborator: collaborators[0],
+				err:          nil,
+			},
+			mockFind: true,
+			want:     collaborators,
+			wantErr:  false,
+		},
+		{
+			name: "permission denied when not shared",
+			product: &model.Product{
+				ID:      productID,
+				OwnerID: ownerID,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockFindCollaborator: &mockFindCollaborator{
+				collaborator: nil,
+				err:          nil,
+			},
+			mockModifyOther: &mockAuth{
+				hasAccess: false,
+				err:       nil,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "product not found",
+			product: nil,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.TODO()
+			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, userID)
+
+			uc := NewProductUsecase()
+			mockAuthClient := authMock.NewMockAuthServiceClient(ctrl)
+			err := uc.InjectAuthClient(mockAuthClient)
+			utils.ContinueOrFatal(err)
+			mockProductRepo := mock.NewMockProductRepository(ctrl)
+			err = uc.InjectProductRepo(mockProductRepo)
+			utils.ContinueOrFatal(err)
+			mockCollaboratorRepo := mock.NewMockProductCollaboratorRepository(ctrl)
+			err = uc.InjectProductCollaboratorRepo(mockCollaboratorRepo)
+			utils.ContinueOrFatal(err)
+
+			mockProductRepo.EXPECT().FindByID(gomock.Any(), productID).Times(1).Return(tt.product, nil)
+
+			if tt.mockAuth != nil {
+				mockAuthClient.EXPECT().HasAccess(gomock.Any(), gomock.Any()).Times(1).Return(&wrapperspb.BoolValue{
+					Value: tt.mockAuth.hasAccess,
+				}, tt.mockAuth.err)
+			}
+
+			if tt.mockFindCollaborator != nil {
+				mockCollaboratorRepo.EXPECT().FindByProductIDAndUserID(gomock.Any(), productID, userID).Times(1).Return(tt.mockFindCollaborator.collaborator, tt.mockFindCollaborator.err)
+			}
+
+			if tt.mockModifyOther != nil {
+				mockAuthClient.EXPECT().HasAccess(gomock.Any(), gomock.Any()).Times(1).Return(&wrapperspb.BoolValue{
+					Value: tt.mockModifyOther.hasAccess,
+				}, tt.mockModifyOther.err)
+			}
+
+			if tt.mockFind {
+				mockCollaboratorRepo.EXPECT().FindByProductID(gomock.Any(), productID).Times(1).Return(collaborators, nil)
+			}
+
+			got, err := uc.FindCollaborators(ctx, productID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productUsecase.FindCollaborators() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("productUsecase.FindCollaborators() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/krobus00/product-service/internal/config"
+	"github.com/krobus00/product-service/internal/constant"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// ExportProducts walk the products matching the filters in batches and send them one by one,
+// the next batch is only read once send returned so a slow client hold back the database reads.
+func (uc *productUsecase) ExportProducts(ctx context.Context, req *model.ExportProductsPayload, send func(*model.ExportedProduct) error) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"userID":         getUserIDFromCtx(ctx),
+		"ownerID":        req.OwnerID,
+		"updatedSince":   req.UpdatedSince,
+		"includeDeleted": req.IncludeDeleted,
+		"checkpoint":     req.Checkpoint,
+	})
+
+	err := uc.hasPermission(ctx, []string{
+		constant.PermissionProductExport,
+	})
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return err
+	}
+
+	if req.IncludeDeleted {
+		err = uc.hasPermission(ctx, []string{
+			constant.PermissionProductAll,
+			constant.PermissionProductReadDeleted,
+		})
+		if err != nil {
+			logger.Error(err.Error())
+			return err
+		}
+	}
+
+	var after *model.ProductExportCheckpoint
+	if req.Checkpoint != "" {
+		after, err = model.NewProductExportCheckpoint(req.Checkpoint)
+		if err != nil {
+			return err
+		}
+	}
+
+	batchSize := config.ExportBatchSize()
+	exported := 0
+	for {
+		products, err := uc.productRepo.FindExportBatch(ctx, req, after, batchSize)
+		if err != nil {
+			logger.Error(err.Error())
+			return err
+		}
+
+		for _, product := range products {
+			after = product.ExportCheckpoint()
+			err = send(&model.ExportedProduct{
+				Product:    product,
+				Checkpoint: after.String(),
+			})
+			if err != nil {
+				logger.WithField("exported", exported).Warn(err.Error())
+				return err
+			}
+			exported++
+		}
+
+		if len(products) < batchSize {
+			logger.WithField("exported", exported).Info("export completed")
+			return nil
+		}
+	}
+}
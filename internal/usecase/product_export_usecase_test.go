@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	authMock "github.com/krobus00/auth-service/pb/auth/mock"
+	"github.com/krobus00/product-service/internal/constant"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/model/mock"
+	"github.com/krobus00/product-service/internal/utils"
+	"github.com/spf13/viper"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func Test_productUsecase_ExportProducts(t *testing.T) {
+	userID := utils.GenerateUUID()
+	updatedAt := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	products := model.Products{
+		{ID: utils.GenerateUUID(), UpdatedAt: updatedAt},
+		{ID: utils.GenerateUUID(), UpdatedAt: updatedAt},
+		{ID: utils.GenerateUUID(), UpdatedAt: updatedAt.Add(time.Second)},
+	}
+
+	type mockAuth struct {
+		hasAccess bool
+		err       error
+	}
+	type mockBatch struct {
+		after    *model.ProductExportCheckpoint
+		products model.Products
+		err      error
+	}
+
+	tests := []struct {
+		name        string
+		payload     *model.ExportProductsPayload
+		mockAuth    []*mockAuth
+		mockBatches []*mockBatch
+		sendErr     error
+		want        []string
+		wantErr     bool
+	}{
+		{
+			name:    "success in batches",
+			payload: &model.ExportProductsPayload{},
+			mockAuth: []*mockAuth{
+				{hasAccess: true, err: nil},
+			},
+			mockBatches: []*mockBatch{
+				{after: nil, products: products[:2], err: nil},
+				{after: products[1].ExportCheckpoint(), products: products[2:], err: nil},
+			},
+			want:    products.IDs(),
+			wantErr: false,
+		},
+		{
+			name: "full last batch",
+			payload: &model.ExportProductsPayload{
+				Checkpoint: products[0].ExportCheckpoint().String(),
+			},
+			mockAuth: []*mockAuth{
+				{hasAccess: true, err: nil},
+			},
+			mockBatches: []*mockBatch{
+				{after: products[0].ExportCheckpoint(), products: products[1:], err: nil},
+				{after: products[2].ExportCheckpoint(), products: model.Products{}, err: nil},
+			},
+			want:    products[1:].IDs(),
+			wantErr: false,
+		},
+		{
+			name: "include deleted",
+			payload: &model.ExportProductsPayload{
+				IncludeDeleted: true,
+			},
+			mockAuth: []*mockAuth{
+				{hasAccess: true, err: nil},
+				{hasAccess: true, err: nil},
+			},
+			mockBatches: []*mockBatch{
+				{after: nil, products: products[:1], err: nil},
+			},
+			want:    products[:1].IDs(),
+			wantErr: false,
+		},
+		{
+			name: "include deleted not allowed",
+			payload: &model.ExportProductsPayload{
+				IncludeDeleted: true,
+			},
+			mockAuth: []*mockAuth{
+				{hasAccess: true, err: nil},
+				{hasAccess: false, err: nil},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "permission denied",
+			payload: &model.ExportProductsPayload{},
+			mockAuth: []*mockAuth{
+				{hasAccess: false, err: nil},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "invalid payload",
+			payload: &model.ExportProductsPayload{
+				Checkpoint: "not-a-checkpoint",
+			},
+			mockAuth: []*mockAuth{
+				{hasAccess: true, err: nil},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "db error",
+			payload: &model.ExportProductsPayload{},
+			mockAuth: []*mockAuth{
+				{hasAccess: true, err: nil},
+			},
+			mockBatches: []*mockBatch{
+				{after: nil, products: nil, err: errors.New("db error")},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "client gone",
+			payload: &model.ExportProductsPayload{},
+			mockAuth: []*mockAuth{
+				{hasAccess: true, err: nil},
+			},
+			mockBatches: []*mockBatch{
+				{after: nil, products: products[:2], err: nil},
+			},
+			sendErr: context.Canceled,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.TODO()
+			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, userID)
+			viper.Set("export.batch_size", 2)
+			defer viper.Set("export.batch_size", 0)
+
+			uc := NewProductUsecase()
+			mockAuthClient := authMock.NewMockAuthServiceClient(ctrl)
+			err := uc.InjectAuthClient(mockAuthClient)
+			utils.ContinueOrFatal(err)
+			mockProductRepo := mock.NewMockProductRepository(ctrl)
+			err = uc.InjectProductRepo(mockProductRepo)
+			utils.ContinueOrFatal(err)
+
+			for _, auth := range tt.mockAuth {
+				mockAuthClient.EXPECT().HasAccess(gomock.Any(), gomock.Any()).Times(1).Return(&wrapperspb.BoolValue{
+					Value: auth.hasAccess,
+				}, auth.err)
+			}
+
+			calls := make([]*gomock.Call, 0)
+			for _, batch := range tt.mockBatches {
+				calls = append(calls, mockProductRepo.EXPECT().FindExportBatch(gomock.Any(), tt.payload, batch.after, 2).Times(1).Return(batch.products, batch.err))
+			}
+			gomock.InOrder(calls...)
+
+			var got []string
+			err = uc.ExportProducts(ctx, tt.payload, func(product *model.ExportedProduct) error {
+				if tt.sendErr != nil {
+					return tt.sendErr
+				}
+				if product.Checkpoint != product.Product.ExportCheckpoint().String() {
+					t.Errorf("productUsecase.ExportProducts() checkpoint = %v, want %v", product.Checkpoint, product.Product.ExportCheckpoint().String())
+				}
+				got = append(got, product.Product.ID)
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productUsecase.ExportProducts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("productUsecase.ExportProducts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,569 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/goccy/go-json"
+	"github.com/hibiken/asynq"
+	"github.com/krobus00/product-service/internal/config"
+	"github.com/krobus00/product-service/internal/constant"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+	storagePB "github.com/krobus00/storage-service/pb/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// importState is carried from one batch of a job to the next.
+type importState struct {
+	// skuRows hold the row of the first occurrence of every external sku
+	skuRows map[string]int64
+	// thumbnails cache the check of every thumbnail, nil when it is allowed
+	thumbnails   map[string]error
+	storedErrors int
+}
+
+func newImportState() *importState {
+	return &importState{
+		skuRows:    make(map[string]int64),
+		thumbnails: make(map[string]error),
+	}
+}
+
+// reserveSKU reject a row repeating the external sku of a previous row of the file.
+func (s *importState) reserveSKU(row *model.ImportRow) error {
+	sku := row.ExternalSKU()
+	if sku == "" {
+		return nil
+	}
+	if first, ok := s.skuRows[sku]; ok {
+		return model.NewImportFieldError(model.ImportFieldExternalSKU, fmt.Sprintf("is already used by row %d", first))
+	}
+	s.skuRows[sku] = row.Number
+	return nil
+}
+
+type importedRow struct {
+	row     *model.ImportRow
+	payload *model.CreateProductPayload
+}
+
+type importWrite struct {
+	row     *model.ImportRow
+	before  *model.Product // nil when the product is created
+	product *model.Product
+}
+
+// ImportProducts create the job and enqueue it, the file is read by the worker.
+func (uc *productUsecase) ImportProducts(ctx context.Context, payload *model.ImportProductsPayload) (*model.ProductImportJob, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	userID := getUserIDFromCtx(ctx)
+
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"userID":   userID,
+		"ownerID":  payload.OwnerID,
+		"objectID": payload.ObjectID,
+	})
+
+	err := uc.hasPermission(ctx, []string{
+		constant.PermissionProductImport,
+	})
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	err = payload.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	ownerID := payload.OwnerID
+	if ownerID == "" {
+		ownerID = userID
+	}
+	if ownerID != userID {
+		err = uc.hasPermission(ctx, []string{
+			constant.PermissionProductModifyOther,
+		})
+		if err != nil {
+			logger.Error(err.Error())
+			return nil, err
+		}
+	}
+
+	job, err := uc.createImportJob(ctx, payload, ownerID, userID)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	taskPayload, err := json.Marshal(&model.TaskImportProductsPayload{
+		JobID:   job.ID,
+		Content: payload.Content,
+	})
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	_, err = uc.asynqClient.Enqueue(
+		asynq.NewTask(model.TaskProductImport, taskPayload),
+		asynq.MaxRetry(config.AsynqRetry()),
+		asynq.Retention(config.AsynqRetention()),
+	)
+	if err != nil {
+		logger.Error(err.Error())
+		job.Finish(err)
+		if updateErr := uc.importJobRepo.Update(ctx, job); updateErr != nil {
+			logger.Error(updateErr.Error())
+		}
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// RunImport skip the permission checks, createdBy is recorded as the author of the job and of the products writes.
+func (uc *productUsecase) RunImport(ctx context.Context, payload *model.ImportProductsPayload, createdBy string) (*model.ProductImportJob, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"createdBy": createdBy,
+		"ownerID":   payload.OwnerID,
+		"objectID":  payload.ObjectID,
+	})
+
+	err := payload.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	ownerID := payload.OwnerID
+	if ownerID == "" {
+		ownerID = createdBy
+	}
+
+	job, err := uc.createImportJob(ctx, payload, ownerID, createdBy)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	err = uc.runImportJob(ctx, job, payload.Content)
+	if err != nil {
+		logger.Error(err.Error())
+		return job, err
+	}
+
+	return job, nil
+}
+
+func (uc *productUsecase) GetImportJob(ctx context.Context, id string, includeErrorReport bool) (*model.ProductImportJobResponse, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	userID := getUserIDFromCtx(ctx)
+
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"userID": userID,
+		"jobID":  id,
+	})
+
+	err := uc.hasPermission(ctx, []string{
+		constant.PermissionProductImport,
+	})
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	job, err := uc.importJobRepo.FindByID(ctx, id)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+	if job == nil {
+		return nil, model.ErrImportJobNotFound
+	}
+
+	if job.OwnerID != userID && job.CreatedBy != userID {
+		err = uc.hasPermission(ctx, []string{
+			constant.PermissionProductModifyOther,
+		})
+		if err != nil {
+			logger.Error(err.Error())
+			return nil, err
+		}
+	}
+
+	importErrors, err := uc.importJobRepo.FindErrorsByJobID(ctx, id, model.ImportJobErrorsLimit)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	res := &model.ProductImportJobResponse{
+		Job:    job,
+		Errors: importErrors,
+	}
+	if !includeErrorReport {
+		return res, nil
+	}
+
+	allErrors, err := uc.importJobRepo.FindErrorsByJobID(ctx, id, 0)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+	res.ErrorReport, err = allErrors.CSV()
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// createImportJob check the storage object is readable by the author and resolve the format from its file name.
+func (uc *productUsecase) createImportJob(ctx context.Context, payload *model.ImportProductsPayload, ownerID string, createdBy string) (*model.ProductImportJob, error) {
+	objectFileName := ""
+	if payload.ObjectID != "" {
+		object, err := uc.storageClient.GetObjectByID(ctx, &storagePB.GetObjectByIDRequest{
+			UserId:   createdBy,
+			ObjectId: payload.ObjectID,
+		})
+		if err != nil {
+			return nil, dependencyError(ctx, err, model.ErrStorageServiceUnavailable, model.ErrImportObjectNotFound)
+		}
+		objectFileName = object.GetFileName()
+	}
+
+	err := payload.ResolveFormat(objectFileName)
+	if err != nil {
+		return nil, err
+	}
+
+	job := model.NewProductImportJob(payload, ownerID, createdBy)
+	err = uc.importJobRepo.Create(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// runImportJob record the outcome on the job, an error is only returned once the job is failed
+// unless the job could not be started at all, in which case it is still pending.
+func (uc *productUsecase) runImportJob(ctx context.Context, job *model.ProductImportJob, content []byte) error {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"jobID":   job.ID,
+		"ownerID": job.OwnerID,
+	})
+
+	job.Start()
+	err := uc.importJobRepo.Update(ctx, job)
+	if err != nil {
+		job.Status = model.ImportJobStatusPending
+		return err
+	}
+
+	err = uc.importFile(ctx, job, content)
+	job.Finish(err)
+	if updateErr := uc.importJobRepo.Update(ctx, job); updateErr != nil {
+		logger.Error(updateErr.Error())
+		if err == nil {
+			return updateErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"processed": job.ProcessedRows,
+		"created":   job.CreatedCount,
+		"updated":   job.UpdatedCount,
+		"failed":    job.FailedCount,
+	}).Info("import completed")
+	return nil
+}
+
+func (uc *productUsecase) importFile(ctx context.Context, job *model.ProductImportJob, content []byte) error {
+	var source io.Reader = bytes.NewReader(content)
+	if job.ObjectID != "" {
+		file, err := uc.downloadImportObject(ctx, job)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = file.Close()
+			_ = os.Remove(file.Name())
+		}()
+		source = file
+	}
+
+	reader, err := model.NewImportReader(job.Format, source, job.ColumnMapping)
+	if err != nil {
+		return err
+	}
+
+	state := newImportState()
+	batchSize := config.ImportBatchSize()
+	batch := make([]*model.ImportRow, 0, batchSize)
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		batch = append(batch, row)
+		if len(batch) < batchSize {
+			continue
+		}
+		err = uc.importBatch(ctx, job, state, batch)
+		if err != nil {
+			return err
+		}
+		batch = batch[:0]
+	}
+
+	return uc.importBatch(ctx, job, state, batch)
+}
+
+// downloadImportObject copy the object to a temporary file so a slow import doesn't hold the download open,
+// the caller close and remove the file.
+func (uc *productUsecase) downloadImportObject(ctx context.Context, job *model.ProductImportJob) (*os.File, error) {
+	object, err := uc.storageClient.GetObjectByID(ctx, &storagePB.GetObjectByIDRequest{
+		UserId:   job.CreatedBy,
+		ObjectId: job.ObjectID,
+	})
+	if err != nil {
+		return nil, dependencyError(ctx, err, model.ErrStorageServiceUnavailable, model.ErrImportObjectNotFound)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, config.ImportDownloadTimeout())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, object.GetSignedUrl(), nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("download import object: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download import object: unexpected status %s", res.Status)
+	}
+
+	file, err := os.CreateTemp("", "product-import-*")
+	if err != nil {
+		return nil, err
+	}
+	_, err = io.Copy(file, res.Body)
+	if err == nil {
+		_, err = file.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+		return nil, fmt.Errorf("download import object: %w", err)
+	}
+	return file, nil
+}
+
+// importBatch write the valid rows of the batch in one transaction, rows matching the external sku
+// of a product of the owner update it and the others create a product. Invalid rows are recorded as errors.
+func (uc *productUsecase) importBatch(ctx context.Context, job *model.ProductImportJob, state *importState, rows []*model.ImportRow) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	failures := make(model.ProductImportErrors, 0)
+	fail := func(row *model.ImportRow, err error) {
+		failures = append(failures, model.NewProductImportErrors(job.ID, row, err)...)
+		job.FailedCount++
+	}
+
+	validRows := make([]*importedRow, 0, len(rows))
+	skus := make([]string, 0, len(rows))
+	for _, row := range rows {
+		payload, err := row.ToPayload()
+		if err == nil {
+			err = state.reserveSKU(row)
+		}
+		if err == nil {
+			err = uc.checkImportThumbnail(ctx, state, job.CreatedBy, payload.ThumbnailID)
+		}
+		if errors.Is(err, model.ErrStorageServiceUnavailable) {
+			return err
+		}
+		if err != nil {
+			fail(row, err)
+			continue
+		}
+
+		validRows = append(validRows, &importedRow{row: row, payload: payload})
+		if sku := row.ExternalSKU(); sku != "" {
+			skus = append(skus, sku)
+		}
+	}
+
+	existingProducts, err := uc.productRepo.FindByOwnerIDAndExternalSKUs(ctx, job.OwnerID, skus)
+	if err != nil {
+		return err
+	}
+	productsBySKU := make(map[string]*model.Product, len(existingProducts))
+	for _, product := range existingProducts {
+		productsBySKU[product.ExternalSKU] = product
+	}
+
+	writes := make([]*importWrite, 0, len(validRows))
+	for _, validRow := range validRows {
+		sku := validRow.row.ExternalSKU()
+		product, found := productsBySKU[sku]
+		switch {
+		case found && product.DeletedAt.Valid:
+			fail(validRow.row, model.NewImportFieldError(model.ImportFieldExternalSKU, "belongs to a deleted product, restore it first"))
+		case found:
+			before := *product
+			product = (&model.UpdateProductPayload{
+				ID:          product.ID,
+				Name:        validRow.payload.Name,
+				Description: validRow.payload.Description,
+				Price:       validRow.payload.Price,
+				ThumbnailID: validRow.payload.ThumbnailID,
+			}).UpdateProduct(product)
+			if len(model.NewProductAuditChanges(&before, product)) == 0 {
+				continue
+			}
+			writes = append(writes, &importWrite{row: validRow.row, before: &before, product: product})
+		default:
+			product = validRow.payload.ToProduct(job.OwnerID)
+			product.ExternalSKU = sku
+			writes = append(writes, &importWrite{row: validRow.row, product: product})
+		}
+	}
+
+	// the creates over the quota are only known under the owner lock, they are failed once the batch is committed
+	written := make([]*importWrite, 0, len(writes))
+	overQuota := make([]*importWrite, 0)
+	var limit int64
+	if len(writes) > 0 {
+		err = uc.withProductChanges(ctx, job.CreatedBy, job.Origin(), func(txCtx context.Context) (model.ProductChanges, error) {
+			var remaining int64
+			var err error
+			remaining, limit, err = uc.remainingProductQuota(txCtx, job.OwnerID)
+			if err != nil {
+				return nil, err
+			}
+
+			products := make(model.Products, 0, len(writes))
+			changes := make(model.ProductChanges, 0, len(writes))
+			for _, write := range writes {
+				switch {
+				case write.before != nil:
+					changes = append(changes, model.NewProductChange(model.ProductAuditActionUpdate, write.before, write.product))
+				case limit > 0 && remaining <= 0:
+					overQuota = append(overQuota, write)
+					continue
+				default:
+					remaining--
+					changes = append(changes, model.NewProductChange(model.ProductAuditActionCreate, nil, write.product))
+				}
+				products = append(products, write.product)
+				written = append(written, write)
+			}
+
+			err = uc.productRepo.UpsertInBatches(txCtx, products)
+			if err != nil {
+				return nil, err
+			}
+			return changes, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for _, write := range overQuota {
+		fail(write.row, &model.ProductQuotaError{OwnerID: job.OwnerID, Limit: limit})
+	}
+
+	for _, write := range written {
+		if write.before == nil {
+			job.CreatedCount++
+			continue
+		}
+		job.UpdatedCount++
+	}
+	job.ProcessedRows += int64(len(rows))
+
+	err = uc.storeImportErrors(ctx, state, failures)
+	if err != nil {
+		return err
+	}
+
+	return uc.importJobRepo.Update(ctx, job)
+}
+
+// checkImportThumbnail apply the thumbnail rules of Create once per thumbnail of the job,
+// ErrStorageServiceUnavailable is returned as is since it would fail every row.
+func (uc *productUsecase) checkImportThumbnail(ctx context.Context, state *importState, userID string, thumbnailID string) error {
+	if err, ok := state.thumbnails[thumbnailID]; ok {
+		return err
+	}
+
+	object, err := uc.storageClient.GetObjectByID(ctx, &storagePB.GetObjectByIDRequest{
+		UserId:   userID,
+		ObjectId: thumbnailID,
+	})
+
+	var rowErr error
+	switch {
+	case err != nil && ctx.Err() != nil:
+		return ctx.Err()
+	case err != nil && utils.IsUnavailableError(ctx, err):
+		return model.ErrStorageServiceUnavailable
+	case err != nil:
+		rowErr = model.NewImportFieldError(model.ImportFieldThumbnailID, model.ErrThumbnailNotFound.Error())
+	case object.GetType() != model.ThumbnailType:
+		rowErr = model.NewImportFieldError(model.ImportFieldThumbnailID, model.ErrThumbnailTypeNotAllowed.Error())
+	case !object.GetIsPublic():
+		rowErr = model.NewImportFieldError(model.ImportFieldThumbnailID, model.ErrThumbnailNotAllowed.Error())
+	}
+	state.thumbnails[thumbnailID] = rowErr
+	return rowErr
+}
+
+// storeImportErrors keep at most import.max_errors errors per job, the failed rows are still counted.
+func (uc *productUsecase) storeImportErrors(ctx context.Context, state *importState, failures model.ProductImportErrors) error {
+	room := config.ImportMaxErrors() - state.storedErrors
+	if room <= 0 {
+		return nil
+	}
+	if len(failures) > room {
+		failures = failures[:room]
+	}
+
+	err := uc.importJobRepo.CreateErrors(ctx, failures)
+	if err != nil {
+		return err
+	}
+	state.storedErrors += len(failures)
+	return nil
+}
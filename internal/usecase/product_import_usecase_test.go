@@ -0,0 +1,422 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	authMock "github.com/krobus00/auth-service/pb/auth/mock"
+	"github.com/krobus00/product-service/internal/constant"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/model/mock"
+	"github.com/krobus00/product-service/internal/utils"
+	storagePB "github.com/krobus00/storage-service/pb/storage"
+	storageMock "github.com/krobus00/storage-service/pb/storage/mock"
+	"github.com/spf13/viper"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func Test_productUsecase_RunImport(t *testing.T) {
+	userID := utils.GenerateUUID()
+	ownerID := utils.GenerateUUID()
+	productID := utils.GenerateUUID()
+	thumbnailID := utils.GenerateUUID()
+	content := []byte("SKU,name,price,thumbnail_id\n" +
+		"A-1,Coffee,10," + thumbnailID + "\n" +
+		"A-2,Green tea,5," + thumbnailID + "\n" +
+		"A-3,,3," + thumbnailID + "\n" +
+		"A-1,Coffee again,1," + thumbnailID + "\n")
+	existingProduct := func() *model.Product {
+		return &model.Product{
+			ID:          productID,
+			OwnerID:     ownerID,
+			ExternalSKU: "A-2",
+			Name:        "Tea",
+			Price:       5,
+			ThumbnailID: thumbnailID,
+		}
+	}
+
+	type mockFindBySKU struct {
+		products model.Products
+		err      error
+	}
+
+	tests := []struct {
+		name            string
+		payload         *model.ImportProductsPayload
+		mockStartErr    error
+		mockFindBySKU   *mockFindBySKU
+		quota           int64
+		mockCount       int64 // products of the owner counted under the lock when there is a quota
+		wantJob         *model.ProductImportJob
+		wantImportError []string // field of every stored row error
+		wantErr         bool
+	}{
+		{
+			name: "success",
+			payload: &model.ImportProductsPayload{
+				OwnerID:       ownerID,
+				FileName:      "products.csv",
+				Content:       content,
+				ColumnMapping: model.ImportColumnMapping{model.ImportFieldExternalSKU: "SKU"},
+			},
+			mockFindBySKU: &mockFindBySKU{
+				products: model.Products{existingProduct()},
+				err:      nil,
+			},
+			wantJob: &model.ProductImportJob{
+				OwnerID:       ownerID,
+				CreatedBy:     userID,
+				FileName:      "products.csv",
+				Format:        model.ImportFormatCSV,
+				ColumnMapping: model.ImportColumnMapping{model.ImportFieldExternalSKU: "SKU"},
+				Status:        model.ImportJobStatusCompleted,
+				ProcessedRows: 4,
+				CreatedCount:  1,
+				UpdatedCount:  1,
+				FailedCount:   2,
+			},
+			wantImportError: []string{model.ImportFieldName, model.ImportFieldExternalSKU},
+			wantErr:         false,
+		},
+		{
+			name: "create over the quota counted in the transaction",
+			payload: &model.ImportProductsPayload{
+				OwnerID:       ownerID,
+				FileName:      "products.csv",
+				Content:       content,
+				ColumnMapping: model.ImportColumnMapping{model.ImportFieldExternalSKU: "SKU"},
+			},
+			mockFindBySKU: &mockFindBySKU{
+				products: model.Products{existingProduct()},
+				err:      nil,
+			},
+			quota:     1,
+			mockCount: 1,
+			wantJob: &model.ProductImportJob{
+				OwnerID:       ownerID,
+				CreatedBy:     userID,
+				FileName:      "products.csv",
+				Format:        model.ImportFormatCSV,
+				ColumnMapping: model.ImportColumnMapping{model.ImportFieldExternalSKU: "SKU"},
+				Status:        model.ImportJobStatusCompleted,
+				ProcessedRows: 4,
+				CreatedCount:  0,
+				UpdatedCount:  1,
+				FailedCount:   3,
+			},
+			wantImportError: []string{model.ImportFieldName, model.ImportFieldExternalSKU, ""},
+			wantErr:         false,
+		},
+		{
+			name: "invalid payload",
+			payload: &model.ImportProductsPayload{
+				OwnerID: ownerID,
+			},
+			wantJob: nil,
+			wantErr: true,
+		},
+		{
+			name: "unknown format",
+			payload: &model.ImportProductsPayload{
+				OwnerID:  ownerID,
+				FileName: "products.xlsx",
+				Content:  content,
+			},
+			wantJob: nil,
+			wantErr: true,
+		},
+		{
+			name: "error when start",
+			payload: &model.ImportProductsPayload{
+				OwnerID:  ownerID,
+				FileName: "products.csv",
+				Content:  content,
+			},
+			mockStartErr: errors.New("db error"),
+			wantJob: &model.ProductImportJob{
+				OwnerID:   ownerID,
+				CreatedBy: userID,
+				FileName:  "products.csv",
+				Format:    model.ImportFormatCSV,
+				Status:    model.ImportJobStatusPending,
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when find existing products",
+			payload: &model.ImportProductsPayload{
+				OwnerID:       ownerID,
+				FileName:      "products.csv",
+				Content:       content,
+				ColumnMapping: model.ImportColumnMapping{model.ImportFieldExternalSKU: "SKU"},
+			},
+			mockFindBySKU: &mockFindBySKU{
+				products: nil,
+				err:      errors.New("db error"),
+			},
+			wantJob: &model.ProductImportJob{
+				OwnerID:       ownerID,
+				CreatedBy:     userID,
+				FileName:      "products.csv",
+				Format:        model.ImportFormatCSV,
+				ColumnMapping: model.ImportColumnMapping{model.ImportFieldExternalSKU: "SKU"},
+				Status:        model.ImportJobStatusFailed,
+				FailedCount:   2,
+				ErrorMessage:  "db error",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			viper.Set("quota.max_products_per_owner", tt.quota)
+			defer viper.Set("quota.max_products_per_owner", 0)
+
+			uc := NewProductUsecase()
+			db, dbMock := utils.NewDBMock()
+			err := uc.InjectDB(db)
+			utils.ContinueOrFatal(err)
+			mockStorageClient := storageMock.NewMockStorageServiceClient(ctrl)
+			err = uc.InjectStorageClient(mockStorageClient)
+			utils.ContinueOrFatal(err)
+			mockProductRepo := mock.NewMockProductRepository(ctrl)
+			err = uc.InjectProductRepo(mockProductRepo)
+			utils.ContinueOrFatal(err)
+			mockAuditLogRepo := mock.NewMockProductAuditLogRepository(ctrl)
+			err = uc.InjectProductAuditLogRepo(mockAuditLogRepo)
+			utils.ContinueOrFatal(err)
+			mockRevisionRepo := mock.NewMockProductRevisionRepository(ctrl)
+			err = uc.InjectProductRevisionRepo(mockRevisionRepo)
+			utils.ContinueOrFatal(err)
+			mockImportJobRepo := mock.NewMockProductImportJobRepository(ctrl)
+			err = uc.InjectProductImportJobRepo(mockImportJobRepo)
+			utils.ContinueOrFatal(err)
+
+			if tt.wantJob != nil {
+				mockImportJobRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				mockImportJobRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(tt.mockStartErr)
+			}
+
+			if tt.mockFindBySKU != nil {
+				mockStorageClient.EXPECT().GetObjectByID(gomock.Any(), &storagePB.GetObjectByIDRequest{
+					UserId:   userID,
+					ObjectId: thumbnailID,
+				}).Times(1).Return(&storagePB.Object{
+					Id:       thumbnailID,
+					Type:     model.ThumbnailType,
+					IsPublic: true,
+				}, nil)
+				mockProductRepo.EXPECT().FindByOwnerIDAndExternalSKUs(gomock.Any(), ownerID, []string{"A-1", "A-2"}).Times(1).
+					Return(tt.mockFindBySKU.products, tt.mockFindBySKU.err)
+				// the job is failed or completed
+				mockImportJobRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			}
+
+			if tt.mockFindBySKU != nil && tt.mockFindBySKU.err == nil {
+				dbMock.ExpectBegin()
+				if tt.quota > 0 {
+					mockProductRepo.EXPECT().LockOwner(gomock.Any(), ownerID).Times(1).Return(nil)
+					mockProductRepo.EXPECT().CountByOwnerID(gomock.Any(), ownerID).Times(1).Return(tt.mockCount, nil)
+				}
+				wantUpdated := existingProduct()
+				wantUpdated.Name = "Green tea"
+				mockProductRepo.EXPECT().UpsertInBatches(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, products model.Products) error {
+					if len(products) != int(tt.wantJob.CreatedCount+tt.wantJob.UpdatedCount) {
+						t.Fatalf("productUsecase.RunImport() upserted %d products", len(products))
+					}
+					if tt.wantJob.CreatedCount > 0 {
+						created := products[0]
+						if created.OwnerID != ownerID || created.ExternalSKU != "A-1" || created.Name != "Coffee" || created.Price != 10 {
+							t.Errorf("productUsecase.RunImport() created = %v", created)
+						}
+					}
+					if updated := products[len(products)-1]; !reflect.DeepEqual(updated, wantUpdated) {
+						t.Errorf("productUsecase.RunImport() updated = %v, want %v", updated, wantUpdated)
+					}
+					return nil
+				})
+				mockAuditLogRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				mockRevisionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				dbMock.ExpectCommit()
+
+				mockImportJobRepo.EXPECT().CreateErrors(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, importErrors model.ProductImportErrors) error {
+					fields := make([]string, 0, len(importErrors))
+					for _, importError := range importErrors {
+						fields = append(fields, importError.Field)
+					}
+					if !reflect.DeepEqual(fields, tt.wantImportError) {
+						t.Errorf("productUsecase.RunImport() import errors = %v, want %v", fields, tt.wantImportError)
+					}
+					return nil
+				})
+				// progress of the batch
+				mockImportJobRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			}
+
+			got, err := uc.RunImport(context.TODO(), tt.payload, userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productUsecase.RunImport() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != nil {
+				if got.ID == "" {
+					t.Errorf("productUsecase.RunImport() job without id")
+				}
+				if tt.wantJob.Status != model.ImportJobStatusPending && (got.StartedAt == nil || got.FinishedAt == nil) {
+					t.Errorf("productUsecase.RunImport() job not started or finished")
+				}
+				got.ID = ""
+				got.StartedAt = nil
+				got.FinishedAt = nil
+			}
+			if !reflect.DeepEqual(got, tt.wantJob) {
+				t.Errorf("productUsecase.RunImport() = %v, want %v", got, tt.wantJob)
+			}
+			if err := dbMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("productUsecase.RunImport() %v", err)
+			}
+		})
+	}
+}
+
+func Test_productUsecase_GetImportJob(t *testing.T) {
+	userID := utils.GenerateUUID()
+	otherUserID := utils.GenerateUUID()
+	jobID := utils.GenerateUUID()
+	job := &model.ProductImportJob{
+		ID:          jobID,
+		OwnerID:     userID,
+		CreatedBy:   userID,
+		Status:      model.ImportJobStatusCompleted,
+		FailedCount: 1,
+	}
+	importErrors := model.ProductImportErrors{
+		{ID: utils.GenerateUUID(), JobID: jobID, RowNumber: 2, ExternalSKU: "A-2", Field: "name", Message: "is required"},
+	}
+
+	type mockFind struct {
+		job *model.ProductImportJob
+		err error
+	}
+
+	tests := []struct {
+		name               string
+		userID             string
+		includeErrorReport bool
+		mockAuth           []bool // result of every permission check
+		mockFind           *mockFind
+		mockFindErrors     bool
+		want               *model.ProductImportJobResponse
+		wantErr            error
+	}{
+		{
+			name:           "success",
+			userID:         userID,
+			mockAuth:       []bool{true},
+			mockFind:       &mockFind{job: job, err: nil},
+			mockFindErrors: true,
+			want: &model.ProductImportJobResponse{
+				Job:    job,
+				Errors: importErrors,
+			},
+			wantErr: nil,
+		},
+		{
+			name:               "success with error report",
+			userID:             userID,
+			includeErrorReport: true,
+			mockAuth:           []bool{true},
+			mockFind:           &mockFind{job: job, err: nil},
+			mockFindErrors:     true,
+			want: &model.ProductImportJobResponse{
+				Job:         job,
+				Errors:      importErrors,
+				ErrorReport: []byte("row,external_sku,field,message\n2,A-2,name,is required\n"),
+			},
+			wantErr: nil,
+		},
+		{
+			name:           "success job of another user",
+			userID:         otherUserID,
+			mockAuth:       []bool{true, true},
+			mockFind:       &mockFind{job: job, err: nil},
+			mockFindErrors: true,
+			want: &model.ProductImportJobResponse{
+				Job:    job,
+				Errors: importErrors,
+			},
+			wantErr: nil,
+		},
+		{
+			name:     "job of another user without permission",
+			userID:   otherUserID,
+			mockAuth: []bool{true, false},
+			mockFind: &mockFind{job: job, err: nil},
+			want:     nil,
+			wantErr:  model.ErrUnauthorizedAccess,
+		},
+		{
+			name:     "job not found",
+			userID:   userID,
+			mockAuth: []bool{true},
+			mockFind: &mockFind{job: nil, err: nil},
+			want:     nil,
+			wantErr:  model.ErrImportJobNotFound,
+		},
+		{
+			name:     "without import permission",
+			userID:   userID,
+			mockAuth: []bool{false},
+			want:     nil,
+			wantErr:  model.ErrUnauthorizedAccess,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.TODO()
+			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, tt.userID)
+
+			uc := NewProductUsecase()
+			mockAuthClient := authMock.NewMockAuthServiceClient(ctrl)
+			err := uc.InjectAuthClient(mockAuthClient)
+			utils.ContinueOrFatal(err)
+			mockImportJobRepo := mock.NewMockProductImportJobRepository(ctrl)
+			err = uc.InjectProductImportJobRepo(mockImportJobRepo)
+			utils.ContinueOrFatal(err)
+
+			for _, hasAccess := range tt.mockAuth {
+				mockAuthClient.EXPECT().HasAccess(gomock.Any(), gomock.Any()).Times(1).Return(&wrapperspb.BoolValue{
+					Value: hasAccess,
+				}, nil)
+			}
+			if tt.mockFind != nil {
+				mockImportJobRepo.EXPECT().FindByID(gomock.Any(), jobID).Times(1).Return(tt.mockFind.job, tt.mockFind.err)
+			}
+			if tt.mockFindErrors {
+				mockImportJobRepo.EXPECT().FindErrorsByJobID(gomock.Any(), jobID, model.ImportJobErrorsLimit).Times(1).Return(importErrors, nil)
+				if tt.includeErrorReport {
+					mockImportJobRepo.EXPECT().FindErrorsByJobID(gomock.Any(), jobID, 0).Times(1).Return(importErrors, nil)
+				}
+			}
+
+			got, err := uc.GetImportJob(ctx, jobID, tt.includeErrorReport)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("productUsecase.GetImportJob() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("productUsecase.GetImportJob() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
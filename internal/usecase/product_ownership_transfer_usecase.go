@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/krobus00/product-service/internal/constant"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+	"github.com/sirupsen/logrus"
+)
+
+func (uc *productUsecase) TransferOwnership(ctx context.Context, payload *model.TransferOwnershipPayload) (model.Products, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	userID := getUserIDFromCtx(ctx)
+
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"userID":      userID,
+		"productID":   payload.ID,
+		"fromOwnerID": payload.FromOwnerID,
+		"toOwnerID":   payload.ToOwnerID,
+	})
+
+	err := uc.hasPermission(ctx, []string{
+		constant.PermissionProductTransferOwnership,
+	})
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	err = payload.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	var products model.Products
+	err = uc.withIdempotency(ctx, model.IdempotencyOperationTransferOwnership, payload, &products, func(ctx context.Context) error {
+		if !payload.IsBulk() {
+			product, err := uc.productRepo.FindByID(ctx, payload.ID)
+			if err != nil {
+				logger.Error(err.Error())
+				return err
+			}
+			if product == nil {
+				return model.ErrProductNotFound
+			}
+			if payload.FromOwnerID != "" && payload.FromOwnerID != product.OwnerID {
+				return model.ErrProductNotFound
+			}
+			if product.OwnerID == payload.ToOwnerID {
+				return model.ErrInvalidOwnershipTransfer
+			}
+			payload.FromOwnerID = product.OwnerID
+		}
+
+		return uc.withProductChanges(ctx, userID, getOriginFromCtx(ctx), func(txCtx context.Context) (model.ProductChanges, error) {
+			products, err = uc.productRepo.TransferOwnership(txCtx, payload)
+			if err != nil {
+				return nil, err
+			}
+			if !payload.IsBulk() && len(products) == 0 {
+				// the product was transferred by someone else in the meantime
+				return nil, model.ErrProductNotFound
+			}
+
+			// the transferred products are already counted, deleted ones don't count against the quota
+			err = uc.checkProductQuota(txCtx, payload.ToOwnerID, 0)
+			if err != nil {
+				return nil, err
+			}
+
+			err = uc.ownershipTransferRepo.Create(txCtx, payload.ToProductOwnershipTransfers(products, userID))
+			if err != nil {
+				return nil, err
+			}
+
+			changes := make(model.ProductChanges, 0)
+			for _, product := range products {
+				before := *product
+				before.OwnerID = payload.FromOwnerID
+				changes = append(changes, model.NewProductChange(model.ProductAuditActionTransferOwnership, &before, product))
+			}
+			return changes, nil
+		})
+	})
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"total":  len(products),
+		"reason": payload.Reason,
+	}).Info("product ownership transferred")
+
+	return products, nil
+}
@@ -0,0 +1,322 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	authMock "github.com/krobus00/auth-service/pb/auth/mock"
+	"github.com/krobus00/product-service/internal/constant"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/model/mock"
+	"github.com/krobus00/product-service/internal/utils"
+	"github.com/spf13/viper"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func Test_productUsecase_TransferOwnership(t *testing.T) {
+	userID := utils.GenerateUUID()
+	productID := utils.GenerateUUID()
+	fromOwnerID := utils.GenerateUUID()
+	toOwnerID := utils.GenerateUUID()
+	products := model.Products{
+		{
+			ID:      productID,
+			OwnerID: toOwnerID,
+		},
+	}
+
+	type mockSelect struct {
+		product *model.Product
+		err     error
+	}
+	type mockAuth struct {
+		hasAccess bool
+		err       error
+	}
+	type mockTransfer struct {
+		products model.Products
+		err      error
+	}
+	type mockTransferLog struct {
+		err error
+	}
+	type mockCountByOwnerID struct {
+		count int64
+		err   error
+	}
+
+	tests := []struct {
+		name            string
+		payload         *model.TransferOwnershipPayload
+		mockAuth        *mockAuth
+		mockSelect      *mockSelect
+		mockTransfer    *mockTransfer
+		mockTransferLog *mockTransferLog
+		quota           int64
+		mockCount       *mockCountByOwnerID
+		want            model.Products
+		wantErr         error
+	}{
+		{
+			name: "success single product",
+			payload: &model.TransferOwnershipPayload{
+				ID:        productID,
+				ToOwnerID: toOwnerID,
+				Reason:    "storefront merged",
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockSelect: &mockSelect{
+				product: &model.Product{
+					ID:      productID,
+					OwnerID: fromOwnerID,
+				},
+				err: nil,
+			},
+			mockTransfer: &mockTransfer{
+				products: products,
+				err:      nil,
+			},
+			mockTransferLog: &mockTransferLog{
+				err: nil,
+			},
+			want:    products,
+			wantErr: nil,
+		},
+		{
+			name: "success all products of owner",
+			payload: &model.TransferOwnershipPayload{
+				FromOwnerID: fromOwnerID,
+				ToOwnerID:   toOwnerID,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockTransfer: &mockTransfer{
+				products: products,
+				err:      nil,
+			},
+			mockTransferLog: &mockTransferLog{
+				err: nil,
+			},
+			want:    products,
+			wantErr: nil,
+		},
+		{
+			name: "invalid payload",
+			payload: &model.TransferOwnershipPayload{
+				ToOwnerID: toOwnerID,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			want:    nil,
+			wantErr: model.ErrInvalidOwnershipTransfer,
+		},
+		{
+			name: "permission denied",
+			payload: &model.TransferOwnershipPayload{
+				ID:        productID,
+				ToOwnerID: toOwnerID,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: false,
+				err:       nil,
+			},
+			want:    nil,
+			wantErr: model.ErrUnauthorizedAccess,
+		},
+		{
+			name: "product not found",
+			payload: &model.TransferOwnershipPayload{
+				ID:        productID,
+				ToOwnerID: toOwnerID,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockSelect: &mockSelect{
+				product: nil,
+				err:     nil,
+			},
+			want:    nil,
+			wantErr: model.ErrProductNotFound,
+		},
+		{
+			name: "product owned by another user",
+			payload: &model.TransferOwnershipPayload{
+				ID:          productID,
+				FromOwnerID: utils.GenerateUUID(),
+				ToOwnerID:   toOwnerID,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockSelect: &mockSelect{
+				product: &model.Product{
+					ID:      productID,
+					OwnerID: fromOwnerID,
+				},
+				err: nil,
+			},
+			want:    nil,
+			wantErr: model.ErrProductNotFound,
+		},
+		{
+			name: "success under the quota of the new owner",
+			payload: &model.TransferOwnershipPayload{
+				FromOwnerID: fromOwnerID,
+				ToOwnerID:   toOwnerID,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockTransfer: &mockTransfer{
+				products: products,
+				err:      nil,
+			},
+			quota: 2,
+			mockCount: &mockCountByOwnerID{
+				count: 2,
+				err:   nil,
+			},
+			mockTransferLog: &mockTransferLog{
+				err: nil,
+			},
+			want:    products,
+			wantErr: nil,
+		},
+		{
+			name: "rollback when the new owner exceed the quota",
+			payload: &model.TransferOwnershipPayload{
+				FromOwnerID: fromOwnerID,
+				ToOwnerID:   toOwnerID,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockTransfer: &mockTransfer{
+				products: products,
+				err:      nil,
+			},
+			quota: 2,
+			mockCount: &mockCountByOwnerID{
+				count: 3,
+				err:   nil,
+			},
+			want: nil,
+			wantErr: &model.ProductQuotaError{
+				OwnerID: toOwnerID,
+				Limit:   2,
+			},
+		},
+		{
+			name: "rollback when audit error",
+			payload: &model.TransferOwnershipPayload{
+				FromOwnerID: fromOwnerID,
+				ToOwnerID:   toOwnerID,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockTransfer: &mockTransfer{
+				products: products,
+				err:      nil,
+			},
+			mockTransferLog: &mockTransferLog{
+				err: errors.New("db error"),
+			},
+			want:    nil,
+			wantErr: errors.New("db error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.TODO()
+			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, userID)
+
+			uc := NewProductUsecase()
+			db, dbMock := utils.NewDBMock()
+			err := uc.InjectDB(db)
+			utils.ContinueOrFatal(err)
+			mockAuthClient := authMock.NewMockAuthServiceClient(ctrl)
+			err = uc.InjectAuthClient(mockAuthClient)
+			utils.ContinueOrFatal(err)
+			mockProductRepo := mock.NewMockProductRepository(ctrl)
+			err = uc.InjectProductRepo(mockProductRepo)
+			utils.ContinueOrFatal(err)
+			mockTransferRepo := mock.NewMockProductOwnershipTransferRepository(ctrl)
+			err = uc.InjectProductOwnershipTransferRepo(mockTransferRepo)
+			utils.ContinueOrFatal(err)
+			mockAuditLogRepo := mock.NewMockProductAuditLogRepository(ctrl)
+			err = uc.InjectProductAuditLogRepo(mockAuditLogRepo)
+			utils.ContinueOrFatal(err)
+			mockRevisionRepo := mock.NewMockProductRevisionRepository(ctrl)
+			err = uc.InjectProductRevisionRepo(mockRevisionRepo)
+			utils.ContinueOrFatal(err)
+
+			if tt.mockAuth != nil {
+				mockAuthClient.EXPECT().HasAccess(gomock.Any(), gomock.Any()).Times(1).Return(&wrapperspb.BoolValue{
+					Value: tt.mockAuth.hasAccess,
+				}, tt.mockAuth.err)
+			}
+
+			if tt.mockSelect != nil {
+				mockProductRepo.EXPECT().FindByID(gomock.Any(), productID).Times(1).Return(tt.mockSelect.product, tt.mockSelect.err)
+			}
+
+			if tt.mockTransfer != nil {
+				dbMock.ExpectBegin()
+				mockProductRepo.EXPECT().TransferOwnership(gomock.Any(), tt.payload).Times(1).Return(tt.mockTransfer.products, tt.mockTransfer.err)
+			}
+
+			viper.Set("quota.max_products_per_owner", tt.quota)
+			defer viper.Set("quota.max_products_per_owner", 0)
+			if tt.mockCount != nil {
+				mockProductRepo.EXPECT().LockOwner(gomock.Any(), tt.payload.ToOwnerID).Times(1).Return(nil)
+				mockProductRepo.EXPECT().CountByOwnerID(gomock.Any(), tt.payload.ToOwnerID).Times(1).Return(tt.mockCount.count, tt.mockCount.err)
+				if tt.mockTransferLog == nil {
+					dbMock.ExpectRollback()
+				}
+			}
+
+			if tt.mockTransferLog != nil {
+				mockTransferRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(tt.mockTransferLog.err)
+				if tt.mockTransferLog.err != nil {
+					dbMock.ExpectRollback()
+				} else {
+					mockAuditLogRepo.EXPECT().Create(gomock.Any(), gomock.Len(len(tt.mockTransfer.products))).Times(1).Return(nil)
+					mockRevisionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+					dbMock.ExpectCommit()
+				}
+			}
+
+			got, err := uc.TransferOwnership(ctx, tt.payload)
+			if (err != nil) != (tt.wantErr != nil) || (err != nil && err.Error() != tt.wantErr.Error()) {
+				t.Errorf("productUsecase.TransferOwnership() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("productUsecase.TransferOwnership() = %v, want %v", got, tt.want)
+			}
+			if err := dbMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("productUsecase.TransferOwnership() %v", err)
+			}
+		})
+	}
+}
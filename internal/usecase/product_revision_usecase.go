@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/krobus00/product-service/internal/constant"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+	"github.com/sirupsen/logrus"
+)
+
+func (uc *productUsecase) FindRevision(ctx context.Context, productID string, revision int64) (*model.ProductRevision, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	userID := getUserIDFromCtx(ctx)
+
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"userID":    userID,
+		"productID": productID,
+		"revision":  revision,
+	})
+
+	err := uc.hasRevisionAccess(ctx, productID)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	productRevision, err := uc.revisionRepo.FindByProductIDAndRevision(ctx, productID, revision)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+	if productRevision == nil {
+		return nil, model.ErrProductRevisionNotFound
+	}
+
+	return productRevision, nil
+}
+
+func (uc *productUsecase) FindRevisions(ctx context.Context, req *model.ProductRevisionsPayload) (*model.ProductRevisionsResponse, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	userID := getUserIDFromCtx(ctx)
+
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"userID":    userID,
+		"productID": req.ProductID,
+		"page":      req.Page,
+		"limit":     req.Limit,
+	})
+
+	err := uc.hasRevisionAccess(ctx, req.ProductID)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	req = req.Sanitize()
+	revisions, count, err := uc.revisionRepo.FindPaginatedByProductID(ctx, req)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return model.NewProductRevisionsResponse(req, revisions, count), nil
+}
+
+// Revert bring the product back to the revision through Update,
+// so the usual access check, thumbnail validation, indexing and caching apply and a new revision is recorded.
+func (uc *productUsecase) Revert(ctx context.Context, productID string, revision int64) (*model.Product, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	userID := getUserIDFromCtx(ctx)
+
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"userID":    userID,
+		"productID": productID,
+		"revision":  revision,
+	})
+
+	// check the access first so a revision of a product the caller can't update is never loaded
+	product, err := uc.productRepo.FindByID(ctx, productID)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+	if product == nil {
+		return nil, model.ErrProductNotFound
+	}
+
+	err = uc.hasAccess(ctx, []string{
+		constant.PermissionProductAll,
+		constant.PermissionProductUpdate,
+	}, product, model.CollaboratorRoleEditor)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	productRevision, err := uc.revisionRepo.FindByProductIDAndRevision(ctx, productID, revision)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+	if productRevision == nil {
+		return nil, model.ErrProductRevisionNotFound
+	}
+
+	payload := productRevision.ToUpdateProductPayload()
+	err = payload.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.updateProduct(ctx, product, payload)
+}
+
+// hasRevisionAccess allow the owner, the collaborators and users allowed to modify other users products to read the revisions.
+func (uc *productUsecase) hasRevisionAccess(ctx context.Context, productID string) error {
+	product, err := uc.productRepo.FindByID(ctx, productID)
+	if err != nil {
+		return err
+	}
+	if product == nil {
+		return model.ErrProductNotFound
+	}
+
+	return uc.hasAccess(ctx, []string{
+		constant.PermissionProductAll,
+		constant.PermissionProductRead,
+	}, product, model.CollaboratorRoleEditor, model.CollaboratorRoleViewer)
+}
@@ -0,0 +1,428 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	authMock "github.com/krobus00/auth-service/pb/auth/mock"
+	"github.com/krobus00/product-service/internal/constant"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/model/mock"
+	"github.com/krobus00/product-service/internal/utils"
+	storagePB "github.com/krobus00/storage-service/pb/storage"
+	storageMock "github.com/krobus00/storage-service/pb/storage/mock"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func Test_productUsecase_FindRevision(t *testing.T) {
+	userID := utils.GenerateUUID()
+	productID := utils.GenerateUUID()
+	revision := &model.ProductRevision{
+		ProductID: productID,
+		Revision:  2,
+		Name:      "sample product",
+		OwnerID:   userID,
+	}
+
+	type mockAuth struct {
+		hasAccess bool
+		err       error
+	}
+	type mockFind struct {
+		revision *model.ProductRevision
+		err      error
+	}
+
+	tests := []struct {
+		name     string
+		product  *model.Product
+		mockAuth *mockAuth
+		mockFind *mockFind
+		want     *model.ProductRevision
+		wantErr  error
+	}{
+		{
+			name: "success",
+			product: &model.Product{
+				ID:      productID,
+				OwnerID: userID,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockFind: &mockFind{
+				revision: revision,
+				err:      nil,
+			},
+			want:    revision,
+			wantErr: nil,
+		},
+		{
+			name: "revision not found",
+			product: &model.Product{
+				ID:      productID,
+				OwnerID: userID,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockFind: &mockFind{
+				revision: nil,
+				err:      nil,
+			},
+			want:    nil,
+			wantErr: model.ErrProductRevisionNotFound,
+		},
+		{
+			name:    "product not found",
+			product: nil,
+			want:    nil,
+			wantErr: model.ErrProductNotFound,
+		},
+		{
+			name: "permission denied",
+			product: &model.Product{
+				ID:      productID,
+				OwnerID: userID,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: false,
+				err:       nil,
+			},
+			want:    nil,
+			wantErr: model.ErrUnauthorizedAccess,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.TODO()
+			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, userID)
+
+			uc := NewProductUsecase()
+			mockAuthClient := authMock.NewMockAuthServiceClient(ctrl)
+			err := uc.InjectAuthClient(mockAuthClient)
+			utils.ContinueOrFatal(err)
+			mockProductRepo := mock.NewMockProductRepository(ctrl)
+			err = uc.InjectProductRepo(mockProductRepo)
+			utils.ContinueOrFatal(err)
+			mockRevisionRepo := mock.NewMockProductRevisionRepository(ctrl)
+			err = uc.InjectProductRevisionRepo(mockRevisionRepo)
+			utils.ContinueOrFatal(err)
+
+			mockProductRepo.EXPECT().FindByID(gomock.Any(), productID).Times(1).Return(tt.product, nil)
+
+			if tt.mockAuth != nil {
+				mockAuthClient.EXPECT().HasAccess(gomock.Any(), gomock.Any()).Times(1).Return(&wrapperspb.BoolValue{
+					Value: tt.mockAuth.hasAccess,
+				}, tt.mockAuth.err)
+			}
+
+			if tt.mockFind != nil {
+				mockRevisionRepo.EXPECT().FindByProductIDAndRevision(gomock.Any(), productID, int64(2)).Times(1).Return(tt.mockFind.revision, tt.mockFind.err)
+			}
+
+			got, err := uc.FindRevision(ctx, productID, 2)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("productUsecase.FindRevision() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("productUsecase.FindRevision() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_productUsecase_FindRevisions(t *testing.T) {
+	userID := utils.GenerateUUID()
+	productID := utils.GenerateUUID()
+	revisions := model.ProductRevisions{
+		{
+			ProductID: productID,
+			Revision:  1,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		mockErr error
+		want    *model.ProductRevisionsResponse
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			mockErr: nil,
+			want: &model.ProductRevisionsResponse{
+				Count:   1,
+				MaxPage: 1,
+				Items:   revisions,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "db error",
+			mockErr: errors.New("db error"),
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.TODO()
+			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, userID)
+
+			uc := NewProductUsecase()
+			mockAuthClient := authMock.NewMockAuthServiceClient(ctrl)
+			err := uc.InjectAuthClient(mockAuthClient)
+			utils.ContinueOrFatal(err)
+			mockProductRepo := mock.NewMockProductRepository(ctrl)
+			err = uc.InjectProductRepo(mockProductRepo)
+			utils.ContinueOrFatal(err)
+			mockRevisionRepo := mock.NewMockProductRevisionRepository(ctrl)
+			err = uc.InjectProductRevisionRepo(mockRevisionRepo)
+			utils.ContinueOrFatal(err)
+
+			mockProductRepo.EXPECT().FindByID(gomock.Any(), productID).Times(1).Return(&model.Product{
+				ID:      productID,
+				OwnerID: userID,
+			}, nil)
+			mockAuthClient.EXPECT().HasAccess(gomock.Any(), gomock.Any()).Times(1).Return(&wrapperspb.BoolValue{
+				Value: true,
+			}, nil)
+			mockRevisionRepo.EXPECT().FindPaginatedByProductID(gomock.Any(), &model.ProductRevisionsPayload{
+				ProductID: productID,
+				Limit:     10,
+				Page:      1,
+			}).Times(1).Return(revisions, int64(1), tt.mockErr)
+
+			got, err := uc.FindRevisions(ctx, &model.ProductRevisionsPayload{ProductID: productID})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productUsecase.FindRevisions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("productUsecase.FindRevisions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_productUsecase_Revert(t *testing.T) {
+	userID := utils.GenerateUUID()
+	productID := utils.GenerateUUID()
+	thumbnailID := utils.GenerateUUID()
+	revision := &model.ProductRevision{
+		ProductID:   productID,
+		Revision:    1,
+		Name:        "sample product",
+		Description: "red cotton shirt",
+		Price:       100,
+		ThumbnailID: thumbnailID,
+		OwnerID:     userID,
+	}
+
+	product := &model.Product{
+		ID:          productID,
+		Name:        "bad edit",
+		Description: "red cotton shirt",
+		Price:       1,
+		ThumbnailID: thumbnailID,
+		OwnerID:     userID,
+	}
+
+	type mockFindByID struct {
+		product *model.Product
+		err     error
+	}
+	type mockAuth struct {
+		hasAccess bool
+	}
+	type mockFind struct {
+		revision *model.ProductRevision
+		err      error
+	}
+
+	tests := []struct {
+		name         string
+		mockFindByID *mockFindByID
+		mockAuth     *mockAuth
+		mockFind     *mockFind
+		mockUpdate   bool
+		want         *model.Product
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockFindByID: &mockFindByID{
+				product: product,
+				err:     nil,
+			},
+			mockAuth: &mockAuth{hasAccess: true},
+			mockFind: &mockFind{
+				revision: revision,
+				err:      nil,
+			},
+			mockUpdate: true,
+			want: &model.Product{
+				ID:          productID,
+				Name:        "sample product",
+				Description: "red cotton shirt",
+				Price:       100,
+				ThumbnailID: thumbnailID,
+				OwnerID:     userID,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "success to a revision with zero values",
+			mockFindByID: &mockFindByID{
+				product: product,
+				err:     nil,
+			},
+			mockAuth: &mockAuth{hasAccess: true},
+			mockFind: &mockFind{
+				revision: &model.ProductRevision{
+					ProductID:   productID,
+					Revision:    1,
+					Name:        "sample product",
+					Description: "",
+					Price:       0,
+					ThumbnailID: thumbnailID,
+					OwnerID:     userID,
+				},
+				err: nil,
+			},
+			mockUpdate: true,
+			want: &model.Product{
+				ID:          productID,
+				Name:        "sample product",
+				Description: "",
+				Price:       0,
+				ThumbnailID: thumbnailID,
+				OwnerID:     userID,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "revision not found",
+			mockFindByID: &mockFindByID{
+				product: product,
+				err:     nil,
+			},
+			mockAuth: &mockAuth{hasAccess: true},
+			mockFind: &mockFind{
+				revision: nil,
+				err:      nil,
+			},
+			want:    nil,
+			wantErr: model.ErrProductRevisionNotFound,
+		},
+		{
+			name: "unauthorized caller never load the revision",
+			mockFindByID: &mockFindByID{
+				product: product,
+				err:     nil,
+			},
+			mockAuth: &mockAuth{hasAccess: false},
+			want:     nil,
+			wantErr:  model.ErrUnauthorizedAccess,
+		},
+		{
+			name: "product not found",
+			mockFindByID: &mockFindByID{
+				product: nil,
+				err:     nil,
+			},
+			want:    nil,
+			wantErr: model.ErrProductNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.TODO()
+			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, userID)
+
+			uc := NewProductUsecase()
+			db, dbMock := utils.NewDBMock()
+			err := uc.InjectDB(db)
+			utils.ContinueOrFatal(err)
+			mockAuthClient := authMock.NewMockAuthServiceClient(ctrl)
+			err = uc.InjectAuthClient(mockAuthClient)
+			utils.ContinueOrFatal(err)
+			mockStorageClient := storageMock.NewMockStorageServiceClient(ctrl)
+			err = uc.InjectStorageClient(mockStorageClient)
+			utils.ContinueOrFatal(err)
+			mockProductRepo := mock.NewMockProductRepository(ctrl)
+			err = uc.InjectProductRepo(mockProductRepo)
+			utils.ContinueOrFatal(err)
+			mockAuditLogRepo := mock.NewMockProductAuditLogRepository(ctrl)
+			err = uc.InjectProductAuditLogRepo(mockAuditLogRepo)
+			utils.ContinueOrFatal(err)
+			mockRevisionRepo := mock.NewMockProductRevisionRepository(ctrl)
+			err = uc.InjectProductRevisionRepo(mockRevisionRepo)
+			utils.ContinueOrFatal(err)
+
+			if tt.mockFindByID != nil {
+				// the product is copied, a revert update it in place
+				var found *model.Product
+				if tt.mockFindByID.product != nil {
+					copied := *tt.mockFindByID.product
+					found = &copied
+				}
+				mockProductRepo.EXPECT().FindByID(gomock.Any(), productID).Times(1).Return(found, tt.mockFindByID.err)
+			}
+
+			if tt.mockAuth != nil {
+				mockAuthClient.EXPECT().HasAccess(gomock.Any(), gomock.Any()).Times(1).Return(&wrapperspb.BoolValue{
+					Value: tt.mockAuth.hasAccess,
+				}, nil)
+			}
+
+			if tt.mockFind != nil {
+				mockRevisionRepo.EXPECT().FindByProductIDAndRevision(gomock.Any(), productID, int64(1)).Times(1).Return(tt.mockFind.revision, tt.mockFind.err)
+			}
+
+			if tt.mockUpdate {
+				mockStorageClient.EXPECT().GetObjectByID(gomock.Any(), &storagePB.GetObjectByIDRequest{
+					UserId:   userID,
+					ObjectId: thumbnailID,
+				}).Times(1).Return(&storagePB.Object{
+					Id:       thumbnailID,
+					Type:     model.ThumbnailType,
+					IsPublic: true,
+				}, nil)
+				dbMock.ExpectBegin()
+				mockProductRepo.EXPECT().Update(gomock.Any(), tt.want).Times(1).Return(nil)
+				mockAuditLogRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				mockRevisionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				dbMock.ExpectCommit()
+			}
+
+			got, err := uc.Revert(ctx, productID, 1)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("productUsecase.Revert() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("productUsecase.Revert() = %v, want %v", got, tt.want)
+			}
+			if err := dbMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("productUsecase.Revert() %v", err)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/hibiken/asynq"
 	authPB "github.com/krobus00/auth-service/pb/auth"
@@ -12,20 +13,33 @@ import (
 	storagePB "github.com/krobus00/storage-service/pb/storage"
 	"github.com/nats-io/nats.go"
 	"github.com/sirupsen/logrus"
+	"github.com/sony/gobreaker"
 	"gorm.io/gorm"
 )
 
 type productUsecase struct {
-	db            *gorm.DB
-	productRepo   model.ProductRepository
-	authClient    authPB.AuthServiceClient
-	storageClient storagePB.StorageServiceClient
-	jsClient      nats.JetStreamContext
-	asynqClient   *asynq.Client
+	db                    *gorm.DB
+	productRepo           model.ProductRepository
+	collaboratorRepo      model.ProductCollaboratorRepository
+	ownershipTransferRepo model.ProductOwnershipTransferRepository
+	auditLogRepo          model.ProductAuditLogRepository
+	revisionRepo          model.ProductRevisionRepository
+	importJobRepo         model.ProductImportJobRepository
+	outboxRepo            model.ProductOutboxRepository
+	authClient            authPB.AuthServiceClient
+	permissionCacheRepo   model.PermissionCacheRepository
+	idempotencyRepo       model.IdempotencyRepository
+	storageClient         storagePB.StorageServiceClient
+	jsClient              nats.JetStreamContext
+	asynqClient           *asynq.Client
+	objectUploader        model.ObjectUploader
+	searchBreaker         *gobreaker.CircuitBreaker
 }
 
 func NewProductUsecase() model.ProductUsecase {
-	return new(productUsecase)
+	return &productUsecase{
+		searchBreaker: newSearchBreaker(),
+	}
 }
 
 func (uc *productUsecase) Create(ctx context.Context, payload *model.CreateProductPayload) (*model.Product, error) {
@@ -35,13 +49,11 @@ func (uc *productUsecase) Create(ctx context.Context, payload *model.CreateProdu
 
 	userID := getUserIDFromCtx(ctx)
 
-	logger := logrus.WithFields(logrus.Fields{
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"userID": userID,
 	})
 
-	newProduct := payload.ToProduct(userID)
-
-	err := hasAccess(ctx, uc.authClient, []string{
+	err := uc.hasPermission(ctx, []string{
 		constant.PermissionProductAll,
 		constant.PermissionProductCreate,
 	})
@@ -50,24 +62,46 @@ func (uc *productUsecase) Create(ctx context.Context, payload *model.CreateProdu
 		return nil, err
 	}
 
-	object, err := uc.storageClient.GetObjectByID(ctx, &storagePB.GetObjectByIDRequest{
-		UserId:   userID,
-		ObjectId: payload.ThumbnailID,
-	})
+	err = payload.Validate()
 	if err != nil {
-		logger.Error(err.Error())
-		return nil, model.ErrThumbnailNotFound
+		return nil, err
 	}
 
-	if object.GetType() != model.ThumbnailType {
-		return nil, model.ErrThumbnailTypeNotAllowed
-	}
+	var newProduct *model.Product
+	err = uc.withIdempotency(ctx, model.IdempotencyOperationCreate, payload, &newProduct, func(ctx context.Context) error {
+		object, err := uc.storageClient.GetObjectByID(ctx, &storagePB.GetObjectByIDRequest{
+			UserId:   userID,
+			ObjectId: payload.ThumbnailID,
+		})
+		if err != nil {
+			logger.Error(err.Error())
+			return dependencyError(ctx, err, model.ErrStorageServiceUnavailable, model.ErrThumbnailNotFound)
+		}
 
-	if !object.GetIsPublic() {
-		return nil, model.ErrThumbnailNotAllowed
-	}
+		if object.GetType() != model.ThumbnailType {
+			return model.ErrThumbnailTypeNotAllowed
+		}
+
+		if !object.GetIsPublic() {
+			return model.ErrThumbnailNotAllowed
+		}
 
-	err = uc.productRepo.Create(ctx, newProduct)
+		newProduct = payload.ToProduct(userID)
+		return uc.withProductChanges(ctx, userID, getOriginFromCtx(ctx), func(txCtx context.Context) (model.ProductChanges, error) {
+			err := uc.checkProductQuota(txCtx, userID, 1)
+			if err != nil {
+				return nil, err
+			}
+
+			err = uc.productRepo.Create(txCtx, newProduct)
+			if err != nil {
+				return nil, err
+			}
+			return model.ProductChanges{
+				model.NewProductChange(model.ProductAuditActionCreate, nil, newProduct),
+			}, nil
+		})
+	})
 	if err != nil {
 		logger.Error(err.Error())
 		return nil, err
@@ -83,7 +117,7 @@ func (uc *productUsecase) Update(ctx context.Context, payload *model.UpdateProdu
 
 	userID := getUserIDFromCtx(ctx)
 
-	logger := logrus.WithFields(logrus.Fields{
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"userID": userID,
 	})
 
@@ -100,19 +134,36 @@ func (uc *productUsecase) Update(ctx context.Context, payload *model.UpdateProdu
 	err = uc.hasAccess(ctx, []string{
 		constant.PermissionProductAll,
 		constant.PermissionProductUpdate,
-	}, product)
+	}, product, model.CollaboratorRoleEditor)
 	if err != nil {
 		logger.Error(err.Error())
 		return nil, err
 	}
 
+	err = payload.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.updateProduct(ctx, product, payload)
+}
+
+// updateProduct apply the payload to a product the caller is allowed to update.
+func (uc *productUsecase) updateProduct(ctx context.Context, product *model.Product, payload *model.UpdateProductPayload) (*model.Product, error) {
+	userID := getUserIDFromCtx(ctx)
+
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"userID":    userID,
+		"productID": product.ID,
+	})
+
 	object, err := uc.storageClient.GetObjectByID(ctx, &storagePB.GetObjectByIDRequest{
 		UserId:   userID,
 		ObjectId: payload.ThumbnailID,
 	})
 	if err != nil {
 		logger.Error(err.Error())
-		return nil, model.ErrThumbnailNotFound
+		return nil, dependencyError(ctx, err, model.ErrStorageServiceUnavailable, model.ErrThumbnailNotFound)
 	}
 
 	if object.GetType() != model.ThumbnailType {
@@ -123,8 +174,17 @@ func (uc *productUsecase) Update(ctx context.Context, payload *model.UpdateProdu
 		return nil, model.ErrThumbnailNotAllowed
 	}
 
+	before := *product
 	product = payload.UpdateProduct(product)
-	err = uc.productRepo.Update(ctx, product)
+	err = uc.withProductChanges(ctx, userID, getOriginFromCtx(ctx), func(txCtx context.Context) (model.ProductChanges, error) {
+		err := uc.productRepo.Update(txCtx, product)
+		if err != nil {
+			return nil, err
+		}
+		return model.ProductChanges{
+			model.NewProductChange(model.ProductAuditActionUpdate, &before, product),
+		}, nil
+	})
 	if err != nil {
 		logger.Error(err.Error())
 		return nil, err
@@ -139,7 +199,7 @@ func (uc *productUsecase) Delete(ctx context.Context, id string) error {
 
 	userID := getUserIDFromCtx(ctx)
 
-	logger := logrus.WithFields(logrus.Fields{
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"userID":    userID,
 		"productID": id,
 	})
@@ -164,7 +224,17 @@ func (uc *productUsecase) Delete(ctx context.Context, id string) error {
 		return err
 	}
 
-	err = uc.productRepo.DeleteByID(ctx, product.ID)
+	err = uc.withProductChanges(ctx, userID, getOriginFromCtx(ctx), func(txCtx context.Context) (model.ProductChanges, error) {
+		err := uc.productRepo.DeleteByID(txCtx, product.ID)
+		if err != nil {
+			return nil, err
+		}
+		deleted := *product
+		deleted.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+		return model.ProductChanges{
+			model.NewProductChange(model.ProductAuditActionDelete, product, &deleted),
+		}, nil
+	})
 	if err != nil {
 		logger.Error(err.Error())
 		return err
@@ -173,6 +243,60 @@ func (uc *productUsecase) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+func (uc *productUsecase) Restore(ctx context.Context, id string) (*model.Product, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	userID := getUserIDFromCtx(ctx)
+
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"userID":    userID,
+		"productID": id,
+	})
+
+	product, err := uc.productRepo.FindByID(ctx, id)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	if product == nil {
+		return nil, model.ErrProductNotFound
+	}
+
+	err = uc.hasAccess(ctx, []string{
+		constant.PermissionProductAll,
+		constant.PermissionProductRestore,
+	}, product)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	if !product.DeletedAt.Valid {
+		return product, nil
+	}
+
+	before := *product
+	err = uc.withProductChanges(ctx, userID, getOriginFromCtx(ctx), func(txCtx context.Context) (model.ProductChanges, error) {
+		err := uc.productRepo.RestoreByID(txCtx, product.ID)
+		if err != nil {
+			return nil, err
+		}
+		product.DeletedAt = gorm.DeletedAt{}
+		return model.ProductChanges{
+			model.NewProductChange(model.ProductAuditActionRestore, &before, product),
+		}, nil
+	})
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return product, nil
+}
+
 func (uc *productUsecase) FindPaginatedIDs(ctx context.Context, req *model.PaginationPayload) (*model.PaginationResponse, error) {
 	_, _, fn := utils.Trace()
 	ctx, span := utils.NewSpan(ctx, fn)
@@ -180,12 +304,13 @@ func (uc *productUsecase) FindPaginatedIDs(ctx context.Context, req *model.Pagin
 
 	var (
 		ids        []string
+		highlights []*model.ProductHighlight
 		count      int64
+		nextCursor string
 		userID     = getUserIDFromCtx(ctx)
-		dataSource = getDataSource(ctx)
 	)
 
-	logger := logrus.WithFields(logrus.Fields{
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"userID": userID,
 		"search": req.Search,
 		"sort":   req.Sort,
@@ -193,22 +318,62 @@ func (uc *productUsecase) FindPaginatedIDs(ctx context.Context, req *model.Pagin
 		"limit":  req.Limit,
 	})
 
-	err := hasAccess(ctx, uc.authClient, []string{
-		constant.PermissionProductAll,
-		constant.PermissionProductRead,
-	})
+	_, err := uc.hasReadAccess(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	err = req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	dataSource, isChosen, err := getDataSource(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.OwnedOrShared {
+		req.UserID = userID
+	}
+
+	if req.IncludeDeleted {
+		err = uc.hasPermission(ctx, []string{
+			constant.PermissionProductAll,
+			constant.PermissionProductReadDeleted,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if isChosen {
+		err = uc.hasPermission(ctx, []string{
+			constant.PermissionProductDataSource,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	req = req.Sanitize()
-	switch dataSource {
-	case constant.SourceDB:
+	listingSort, isListing := model.NewProductListingSort(req)
+	switch {
+	case dataSource == constant.SourceRedis && isListing:
+		ids, count, nextCursor, err = uc.productRepo.FindListingPaginatedIDs(ctx, req, listingSort)
+	case dataSource == constant.SourceDB, dataSource == constant.SourceRedis:
+		// the listing only materialize the not deleted products by created_at and price
+		dataSource = constant.SourceDB
 		ids, count, err = uc.productRepo.FindPaginatedIDs(ctx, req)
-	case constant.SourceOS:
-		ids, count, err = uc.productRepo.FindOSPaginatedIDs(ctx, req)
+	case isChosen:
+		ids, highlights, count, err = uc.productRepo.FindSearchPaginatedIDs(ctx, req)
 	default:
-		ids, count, err = uc.productRepo.FindOSPaginatedIDs(ctx, req)
+		ids, highlights, count, err = uc.findSearchPaginatedIDs(ctx, req)
+		if err != nil {
+			logger.Warn("search unavailable, fallback to database: ", err.Error())
+			dataSource = constant.SourceDB
+			ids, count, err = uc.productRepo.FindPaginatedIDs(ctx, req)
+		}
 	}
 	if err != nil {
 		logger.Error(err.Error())
@@ -217,11 +382,50 @@ func (uc *productUsecase) FindPaginatedIDs(ctx context.Context, req *model.Pagin
 
 	res := model.NewPaginationResponse(req).
 		WithCount(count).
-		WithItems(ids)
+		WithItems(ids).
+		WithHighlights(highlights).
+		WithDataSource(constant.DataSourceNames[dataSource]).
+		WithNextCursor(nextCursor)
 
 	return res.BuildResponse(), nil
 }
 
+func (uc *productUsecase) FindRelated(ctx context.Context, req *model.RelatedPayload) ([]string, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	userID := getUserIDFromCtx(ctx)
+
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"userID":    userID,
+		"productID": req.ID,
+	})
+
+	_, err := uc.hasReadAccess(ctx)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	product, err := uc.productRepo.FindByID(ctx, req.ID)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+	if product == nil || product.DeletedAt.Valid {
+		return nil, model.ErrProductNotFound
+	}
+
+	ids, err := uc.productRepo.FindRelatedIDs(ctx, product, req.Sanitize())
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return ids, nil
+}
+
 func (uc *productUsecase) FindByID(ctx context.Context, id string) (*model.Product, error) {
 	_, _, fn := utils.Trace()
 	ctx, span := utils.NewSpan(ctx, fn)
@@ -229,16 +433,12 @@ func (uc *productUsecase) FindByID(ctx context.Context, id string) (*model.Produ
 
 	userID := getUserIDFromCtx(ctx)
 
-	logger := logrus.WithFields(logrus.Fields{
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"userID":    userID,
 		"productID": id,
 	})
 
-	err := hasAccess(ctx, uc.authClient, []string{
-		constant.PermissionProductAll,
-		constant.PermissionProductRead,
-	},
-	)
+	anonymous, err := uc.hasReadAccess(ctx)
 	if err != nil {
 		logger.Error(err.Error())
 		return nil, err
@@ -249,12 +449,12 @@ func (uc *productUsecase) FindByID(ctx context.Context, id string) (*model.Produ
 		logger.Error(err.Error())
 		return nil, err
 	}
-	if product == nil {
+	if product == nil || (anonymous && product.DeletedAt.Valid) {
 		return nil, model.ErrProductNotFound
 	}
 
 	if product.DeletedAt.Valid {
-		err := hasAccess(ctx, uc.authClient, []string{
+		err := uc.hasPermission(ctx, []string{
 			constant.PermissionProductAll,
 			constant.PermissionProductReadDeleted,
 		},
@@ -275,16 +475,13 @@ func (uc *productUsecase) FindByIDs(ctx context.Context, ids []string) (model.Pr
 
 	userID := getUserIDFromCtx(ctx)
 
-	logger := logrus.WithFields(logrus.Fields{
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"userID":     userID,
 		"productIDs": ids,
 	})
 	products := model.Products{}
 
-	err := hasAccess(ctx, uc.authClient, []string{
-		constant.PermissionProductAll,
-		constant.PermissionProductRead,
-	})
+	anonymous, err := uc.hasReadAccess(ctx)
 	if err != nil {
 		logger.Error(err.Error())
 		return products, err
@@ -307,6 +504,9 @@ func (uc *productUsecase) FindByIDs(ctx context.Context, ids []string) (model.Pr
 				logger.Error(err.Error())
 				return
 			}
+			if anonymous && (product == nil || product.DeletedAt.Valid) {
+				return
+			}
 			productMapMu.Lock()
 			productMap[id] = product
 			productMapMu.Unlock()
@@ -324,31 +524,35 @@ func (uc *productUsecase) FindByIDs(ctx context.Context, ids []string) (model.Pr
 	return products, nil
 }
 
-func (uc *productUsecase) hasAccess(ctx context.Context, permissions []string, object *model.Product) error {
+// hasAccess evaluate the product policy, the caller must hold one of the action permissions
+// and must also be allowed to modify other users products when it is neither the owner
+// nor a collaborator with one of the roles.
+func (uc *productUsecase) hasAccess(ctx context.Context, permissions []string, object *model.Product, roles ...string) error {
 	_, _, fn := utils.Trace()
 	ctx, span := utils.NewSpan(ctx, fn)
 	defer span.End()
 
-	userID := getUserIDFromCtx(ctx)
+	err := uc.hasPermission(ctx, permissions)
+	if err != nil {
+		return err
+	}
 
+	userID := getUserIDFromCtx(ctx)
 	if object.OwnerID == userID {
 		return nil
 	}
 
-	if object.OwnerID != userID {
-		err := hasAccess(ctx, uc.authClient, []string{
-			constant.PermissionProductModifyOther,
-		})
+	if len(roles) > 0 {
+		collaborator, err := uc.collaboratorRepo.FindByProductIDAndUserID(ctx, object.ID, userID)
 		if err != nil {
 			return err
 		}
-		return nil
-	}
-
-	err := hasAccess(ctx, uc.authClient, permissions)
-	if err != nil {
-		return err
+		if collaborator.HasRole(roles) {
+			return nil
+		}
 	}
 
-	return nil
+	return uc.hasPermission(ctx, []string{
+		constant.PermissionProductModifyOther,
+	})
 }
@@ -27,6 +27,46 @@ func (uc *productUsecase) InjectProductRepo(repo model.ProductRepository) error
 	return nil
 }
 
+func (uc *productUsecase) InjectProductCollaboratorRepo(repo model.ProductCollaboratorRepository) error {
+	if repo == nil {
+		return errors.New("invalid product collaborator repository")
+	}
+	uc.collaboratorRepo = repo
+	return nil
+}
+
+func (uc *productUsecase) InjectProductOwnershipTransferRepo(repo model.ProductOwnershipTransferRepository) error {
+	if repo == nil {
+		return errors.New("invalid product ownership transfer repository")
+	}
+	uc.ownershipTransferRepo = repo
+	return nil
+}
+
+func (uc *productUsecase) InjectProductAuditLogRepo(repo model.ProductAuditLogRepository) error {
+	if repo == nil {
+		return errors.New("invalid product audit log repository")
+	}
+	uc.auditLogRepo = repo
+	return nil
+}
+
+func (uc *productUsecase) InjectProductRevisionRepo(repo model.ProductRevisionRepository) error {
+	if repo == nil {
+		return errors.New("invalid product revision repository")
+	}
+	uc.revisionRepo = repo
+	return nil
+}
+
+func (uc *productUsecase) InjectProductImportJobRepo(repo model.ProductImportJobRepository) error {
+	if repo == nil {
+		return errors.New("invalid product import job repository")
+	}
+	uc.importJobRepo = repo
+	return nil
+}
+
 func (uc *productUsecase) InjectAuthClient(client authPB.AuthServiceClient) error {
 	if client == nil {
 		return errors.New("invalid auth client")
@@ -35,6 +75,14 @@ func (uc *productUsecase) InjectAuthClient(client authPB.AuthServiceClient) erro
 	return nil
 }
 
+func (uc *productUsecase) InjectPermissionCacheRepo(repo model.PermissionCacheRepository) error {
+	if repo == nil {
+		return errors.New("invalid permission cache repository")
+	}
+	uc.permissionCacheRepo = repo
+	return nil
+}
+
 func (uc *productUsecase) InjectStorageClient(client storagePB.StorageServiceClient) error {
 	if client == nil {
 		return errors.New("invalid storage client")
@@ -58,3 +106,27 @@ func (uc *productUsecase) InjectAsynqClient(client *asynq.Client) error {
 	uc.asynqClient = client
 	return nil
 }
+
+func (uc *productUsecase) InjectIdempotencyRepo(repo model.IdempotencyRepository) error {
+	if repo == nil {
+		return errors.New("invalid idempotency repository")
+	}
+	uc.idempotencyRepo = repo
+	return nil
+}
+
+func (uc *productUsecase) InjectProductOutboxRepo(repo model.ProductOutboxRepository) error {
+	if repo == nil {
+		return errors.New("invalid product outbox repository")
+	}
+	uc.outboxRepo = repo
+	return nil
+}
+
+func (uc *productUsecase) InjectObjectUploader(uploader model.ObjectUploader) error {
+	if uploader == nil {
+		return errors.New("invalid object uploader")
+	}
+	uc.objectUploader = uploader
+	return nil
+}
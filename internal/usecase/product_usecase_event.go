@@ -1,27 +1,42 @@
 package usecase
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/hibiken/asynq"
 	"github.com/krobus00/product-service/internal/config"
 	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
 	"github.com/nats-io/nats.go"
 	"github.com/sirupsen/logrus"
 )
 
 func (uc *productUsecase) CreateStream() error {
-	stream, _ := uc.jsClient.StreamInfo(model.ProductStreamName)
-	// stream not found, create it
-	if stream == nil {
-		logrus.Printf("Creating stream: %s\n", model.ProductStreamName)
-		_, err := uc.jsClient.AddStream(&nats.StreamConfig{
+	streams := []*nats.StreamConfig{
+		{
 			Name:     model.ProductStreamName,
 			Subjects: []string{model.ProductStreamSubjects},
 			MaxAge:   config.JetstreamMaxAge(),
 			Storage:  nats.FileStorage,
-		})
+		},
+		{
+			Name:     model.ProductChangeStreamName,
+			Subjects: []string{model.ProductChangeStreamSubjects},
+			MaxAge:   config.JetstreamMaxAge(),
+			Storage:  nats.FileStorage,
+		},
+	}
+	for _, streamConfig := range streams {
+		stream, _ := uc.jsClient.StreamInfo(streamConfig.Name)
+		// stream not found, create it
+		if stream != nil {
+			continue
+		}
+		logrus.Printf("Creating stream: %s\n", streamConfig.Name)
+		_, err := uc.jsClient.AddStream(streamConfig)
 		if err != nil {
 			return err
 		}
@@ -38,6 +53,108 @@ func (uc *productUsecase) ConsumeEvent() error {
 		return err
 	}
 
+	logrus.Info(fmt.Sprintf("starting consume %s", model.AuthPermissionChangedSubject))
+	_, err = uc.jsClient.QueueSubscribe(model.AuthPermissionChangedSubject, config.QueueGroup(), uc.consumeAuthStream, natsSubOpt...)
+	if errors.Is(err, nats.ErrNoMatchingStream) {
+		// auth-service doesn't publish permission changes yet, cached decisions will expire by ttl
+		logrus.Warn(fmt.Sprintf("unable to consume %s: %v", model.AuthPermissionChangedSubject, err))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RelayProductEvents publish the outbox events in the order they were written until none is pending. Every batch is
+// published and deleted in one transaction holding the relay lock, a relay finding it taken by another worker stop
+// right away so the change stream keep the outbox order. An event failing to publish stop the relay and is retried
+// first on the next run.
+func (uc *productUsecase) RelayProductEvents(ctx context.Context) error {
+	for {
+		var pending int
+		var publishErr error
+		err := utils.RunInTx(ctx, uc.db, func(txCtx context.Context) error {
+			pending = 0
+			locked, err := uc.outboxRepo.TryLockRelay(txCtx)
+			if err != nil || !locked {
+				return err
+			}
+
+			events, err := uc.outboxRepo.FindPending(txCtx, config.OutboxBatchSize())
+			if err != nil {
+				return err
+			}
+			pending = len(events)
+
+			var published model.ProductOutboxEvents
+			published, publishErr = uc.publishOutboxEvents(ctx, events)
+			return uc.outboxRepo.DeleteByIDs(txCtx, published.IDs())
+		})
+		if err != nil {
+			return err
+		}
+		if publishErr != nil {
+			return publishErr
+		}
+		if pending < config.OutboxBatchSize() {
+			return nil
+		}
+	}
+}
+
+// publishOutboxEvents publish the events in order and return the ones acknowledged by the change stream.
+func (uc *productUsecase) publishOutboxEvents(ctx context.Context, events model.ProductOutboxEvents) (model.ProductOutboxEvents, error) {
+	for i, event := range events {
+		_, err := uc.jsClient.Publish(event.Subject, event.Payload, nats.MsgId(event.MsgID()), nats.Context(ctx))
+		if err != nil {
+			logrus.WithContext(ctx).WithFields(logrus.Fields{
+				"eventID": event.ID,
+				"subject": event.Subject,
+			}).Error(err.Error())
+			return events[:i], err
+		}
+	}
+	return events, nil
+}
+
+func (uc *productUsecase) consumeAuthStream(msg *nats.Msg) {
+	err := msg.Ack()
+	if err != nil {
+		logrus.Error(fmt.Sprintf("unable to Ack: %v ", err))
+		return
+	}
+
+	switch msg.Subject {
+	case model.AuthPermissionChangedSubject:
+		err = uc.handlePermissionChangedEvent(msg)
+	default:
+		logrus.Warn("unknown subject")
+	}
+	if err != nil {
+		logrus.Error(err.Error())
+		return
+	}
+}
+
+func (uc *productUsecase) handlePermissionChangedEvent(msg *nats.Msg) error {
+	msgPayload := new(model.JSPermissionChangedPayload)
+	err := json.Unmarshal(msg.Data, &msgPayload)
+	if err != nil {
+		logrus.Error(err.Error())
+		return err
+	}
+
+	if msgPayload.UserID == "" {
+		err = uc.permissionCacheRepo.DeleteAll(context.Background())
+	} else {
+		err = uc.permissionCacheRepo.DeleteByUserID(context.Background(), msgPayload.UserID)
+	}
+	if err != nil {
+		logrus.WithField("userID", msgPayload.UserID).Error(err.Error())
+		return err
+	}
 	return nil
 }
 
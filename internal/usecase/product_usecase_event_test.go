@@ -0,0 +1,261 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/model/mock"
+	"github.com/krobus00/product-service/internal/utils"
+	"github.com/nats-io/nats.go"
+	"github.com/spf13/viper"
+)
+
+func Test_productUsecase_RelayProductEvents(t *testing.T) {
+	newEvents := func(ids ...int64) model.ProductOutboxEvents {
+		events := make(model.ProductOutboxEvents, 0, len(ids))
+		for _, id := range ids {
+			events = append(events, &model.ProductOutboxEvent{
+				ID:      id,
+				Subject: model.ProductUpdatedSubject,
+				Payload: []byte(`{"type":"PRODUCT_UPDATED","product":{"id":"1"}}`),
+			})
+		}
+		return events
+	}
+
+	type mockBatch struct {
+		// locked tell whether the relay lock is free, the other fields are unused when it is not
+		locked  bool
+		events  model.ProductOutboxEvents
+		findErr error
+		// deletedIDs is nil when the batch is not deleted
+		deletedIDs []int64
+	}
+
+	tests := []struct {
+		name         string
+		createStream bool
+		batches      []*mockBatch
+		wantMsgs     uint64
+		wantErr      bool
+	}{
+		{
+			name:         "publish and delete every batch until none is full",
+			createStream: true,
+			batches: []*mockBatch{
+				{locked: true, events: newEvents(1, 2), deletedIDs: []int64{1, 2}},
+				{locked: true, events: newEvents(3), deletedIDs: []int64{3}},
+			},
+			wantMsgs: 3,
+			wantErr:  false,
+		},
+		{
+			name:         "duplicate publish of an event is dropped by the stream",
+			createStream: true,
+			batches: []*mockBatch{
+				{locked: true, events: newEvents(1, 1), deletedIDs: []int64{1, 1}},
+				{locked: true, events: newEvents(), deletedIDs: []int64{}},
+			},
+			wantMsgs: 1,
+			wantErr:  false,
+		},
+		{
+			name:         "events are kept when they can't be published",
+			createStream: false,
+			batches: []*mockBatch{
+				{locked: true, events: newEvents(1, 2), deletedIDs: []int64{}},
+			},
+			wantErr: true,
+		},
+		{
+			name:         "nothing is published while another relay hold the lock",
+			createStream: true,
+			batches: []*mockBatch{
+				{locked: false},
+			},
+			wantMsgs: 0,
+			wantErr:  false,
+		},
+		{
+			name:         "db error",
+			createStream: true,
+			batches: []*mockBatch{
+				{locked: true, findErr: errors.New("db error")},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			viper.Set("outbox.batch_size", 2)
+			defer viper.Set("outbox.batch_size", 0)
+
+			ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
+			defer cancel()
+
+			uc := NewProductUsecase()
+			db, dbMock := utils.NewDBMock()
+			err := uc.InjectDB(db)
+			utils.ContinueOrFatal(err)
+			mockOutboxRepo := mock.NewMockProductOutboxRepository(ctrl)
+			err = uc.InjectProductOutboxRepo(mockOutboxRepo)
+			utils.ContinueOrFatal(err)
+			js := newJetstreamMock(t)
+			err = uc.InjectJetstreamClient(js)
+			utils.ContinueOrFatal(err)
+			if tt.createStream {
+				err = uc.CreateStream()
+				utils.ContinueOrFatal(err)
+			}
+
+			calls := make([]*gomock.Call, 0)
+			for _, batch := range tt.batches {
+				dbMock.ExpectBegin()
+				calls = append(calls, mockOutboxRepo.EXPECT().TryLockRelay(gomock.Any()).Times(1).Return(batch.locked, nil))
+				if !batch.locked {
+					dbMock.ExpectCommit()
+					continue
+				}
+				calls = append(calls, mockOutboxRepo.EXPECT().FindPending(gomock.Any(), 2).Times(1).Return(batch.events, batch.findErr))
+				if batch.findErr != nil {
+					dbMock.ExpectRollback()
+					continue
+				}
+				calls = append(calls, mockOutboxRepo.EXPECT().DeleteByIDs(gomock.Any(), batch.deletedIDs).Times(1).Return(nil))
+				dbMock.ExpectCommit()
+			}
+			gomock.InOrder(calls...)
+
+			err = uc.RelayProductEvents(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productUsecase.RelayProductEvents() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err := dbMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("productUsecase.RelayProductEvents() %v", err)
+			}
+			if !tt.createStream {
+				return
+			}
+
+			info, err := js.StreamInfo(model.ProductChangeStreamName)
+			utils.ContinueOrFatal(err)
+			if info.State.Msgs != tt.wantMsgs {
+				t.Errorf("productUsecase.RelayProductEvents() published %d events, want %d", info.State.Msgs, tt.wantMsgs)
+			}
+		})
+	}
+}
+
+func Test_productUsecase_RelayProductEvents_Concurrent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	viper.Set("outbox.batch_size", 2)
+	defer viper.Set("outbox.batch_size", 0)
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
+	defer cancel()
+
+	uc := NewProductUsecase()
+	db, dbMock := utils.NewDBMock()
+	err := uc.InjectDB(db)
+	utils.ContinueOrFatal(err)
+	mockOutboxRepo := mock.NewMockProductOutboxRepository(ctrl)
+	err = uc.InjectProductOutboxRepo(mockOutboxRepo)
+	utils.ContinueOrFatal(err)
+	js := newJetstreamMock(t)
+	err = uc.InjectJetstreamClient(js)
+	utils.ContinueOrFatal(err)
+	err = uc.CreateStream()
+	utils.ContinueOrFatal(err)
+
+	var mu sync.Mutex
+	outbox := make(model.ProductOutboxEvents, 0)
+	for id := int64(1); id <= 5; id++ {
+		outbox = append(outbox, &model.ProductOutboxEvent{
+			ID:      id,
+			Subject: model.ProductUpdatedSubject,
+			Payload: []byte(`{"type":"PRODUCT_UPDATED","product":{"id":"1"}}`),
+		})
+	}
+
+	// the relay lock behave like the advisory lock, it is held until the transaction taking it is committed
+	var relayLock sync.Mutex
+	contended := make(chan struct{})
+	var contendedOnce sync.Once
+	mockOutboxRepo.EXPECT().TryLockRelay(gomock.Any()).AnyTimes().DoAndReturn(func(txCtx context.Context) (bool, error) {
+		if !relayLock.TryLock() {
+			contendedOnce.Do(func() { close(contended) })
+			return false, nil
+		}
+		utils.AfterCommit(txCtx, func(_ context.Context) {
+			relayLock.Unlock()
+		})
+		return true, nil
+	})
+	mockOutboxRepo.EXPECT().FindPending(gomock.Any(), 2).AnyTimes().DoAndReturn(func(_ context.Context, limit int) (model.ProductOutboxEvents, error) {
+		// the relay holding the lock wait for the other one to run into it
+		select {
+		case <-contended:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if limit > len(outbox) {
+			limit = len(outbox)
+		}
+		return append(model.ProductOutboxEvents{}, outbox[:limit]...), nil
+	})
+	mockOutboxRepo.EXPECT().DeleteByIDs(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(_ context.Context, ids []int64) error {
+		mu.Lock()
+		defer mu.Unlock()
+		outbox = outbox[len(ids):]
+		return nil
+	})
+
+	// the relay holding the lock publish 3 batches, the other one stop after finding the lock taken
+	dbMock.MatchExpectationsInOrder(false)
+	for i := 0; i < 4; i++ {
+		dbMock.ExpectBegin()
+		dbMock.ExpectCommit()
+	}
+
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			errs <- uc.RelayProductEvents(ctx)
+		}()
+	}
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != nil {
+			t.Fatalf("productUsecase.RelayProductEvents() error = %v", err)
+		}
+	}
+	if err := dbMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("productUsecase.RelayProductEvents() %v", err)
+	}
+
+	info, err := js.StreamInfo(model.ProductChangeStreamName)
+	utils.ContinueOrFatal(err)
+	if info.State.Msgs != 5 {
+		t.Fatalf("productUsecase.RelayProductEvents() published %d events, want 5", info.State.Msgs)
+	}
+	for seq := uint64(1); seq <= 5; seq++ {
+		msg, err := js.GetMsg(model.ProductChangeStreamName, seq)
+		utils.ContinueOrFatal(err)
+		want := (&model.ProductOutboxEvent{ID: int64(seq)}).MsgID()
+		if got := msg.Header.Get(nats.MsgIdHdr); got != want {
+			t.Errorf("productUsecase.RelayProductEvents() published %s at sequence %d, want %s", got, seq, want)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/hibiken/asynq"
+	"github.com/krobus00/product-service/internal/constant"
 	"github.com/krobus00/product-service/internal/model"
 	"github.com/krobus00/product-service/internal/utils"
 	"github.com/sirupsen/logrus"
@@ -21,7 +22,20 @@ func (uc *productUsecase) HandleUpdateThumbnailTask(ctx context.Context, t *asyn
 		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
 	}
 
-	err := uc.productRepo.UpdateAllThumbnail(ctx, payload.OldObjectID, payload.NewObjectID)
+	err := uc.withProductChanges(ctx, constant.SystemID, t.Type(), func(txCtx context.Context) (model.ProductChanges, error) {
+		products, err := uc.productRepo.UpdateAllThumbnail(txCtx, payload.OldObjectID, payload.NewObjectID)
+		if err != nil {
+			return nil, err
+		}
+
+		changes := make(model.ProductChanges, 0)
+		for _, product := range products {
+			before := *product
+			before.ThumbnailID = payload.OldObjectID
+			changes = append(changes, model.NewProductChange(model.ProductAuditActionUpdateThumbnail, &before, product))
+		}
+		return changes, nil
+	})
 	if err != nil {
 		logrus.Error(err.Error())
 		return err
@@ -29,3 +43,74 @@ func (uc *productUsecase) HandleUpdateThumbnailTask(ctx context.Context, t *asyn
 
 	return nil
 }
+
+// HandleImportProductsTask run a pending import job. A job found running was interrupted by a stopped worker,
+// it is failed rather than resumed since the rows without external sku would be created twice.
+func (uc *productUsecase) HandleImportProductsTask(ctx context.Context, t *asynq.Task) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	payload := new(model.TaskImportProductsPayload)
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"jobID": payload.JobID,
+	})
+
+	job, err := uc.importJobRepo.FindByID(ctx, payload.JobID)
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+	if job == nil {
+		return fmt.Errorf("%v: %w", model.ErrImportJobNotFound, asynq.SkipRetry)
+	}
+
+	switch job.Status {
+	case model.ImportJobStatusPending:
+	case model.ImportJobStatusRunning:
+		logger.Warn(model.ErrImportJobInterrupted.Error())
+		job.Finish(model.ErrImportJobInterrupted)
+		return uc.importJobRepo.Update(ctx, job)
+	default:
+		return nil
+	}
+
+	err = uc.runImportJob(ctx, job, payload.Content)
+	if err != nil {
+		logger.Error(err.Error())
+		if job.Status == model.ImportJobStatusPending {
+			return err
+		}
+		// the failure is recorded on the job
+		return fmt.Errorf("%v: %w", err, asynq.SkipRetry)
+	}
+
+	return nil
+}
+
+// HandleExportCatalogTask write the scheduled snapshot of the catalog, a failed export is retried from scratch.
+func (uc *productUsecase) HandleExportCatalogTask(ctx context.Context, t *asynq.Task) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	payload := new(model.CatalogExportPayload)
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+	if err := payload.Validate(); err != nil {
+		return fmt.Errorf("%v: %w", err, asynq.SkipRetry)
+	}
+
+	_, err := uc.ExportCatalog(ctx, payload)
+	if err != nil {
+		logrus.WithContext(ctx).Error(err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/golang/mock/gomock"
+	"github.com/hibiken/asynq"
+	"github.com/krobus00/product-service/internal/constant"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/model/mock"
+	"github.com/krobus00/product-service/internal/utils"
+)
+
+func Test_productUsecase_HandleUpdateThumbnailTask(t *testing.T) {
+	oldObjectID := utils.GenerateUUID()
+	newObjectID := utils.GenerateUUID()
+	product := &model.Product{
+		ID:          utils.GenerateUUID(),
+		ThumbnailID: newObjectID,
+	}
+
+	type mockUpdate struct {
+		products model.Products
+		err      error
+	}
+
+	tests := []struct {
+		name       string
+		mockUpdate *mockUpdate
+		wantLogs   model.ProductAuditLogs
+		wantErr    bool
+	}{
+		{
+			name: "success",
+			mockUpdate: &mockUpdate{
+				products: model.Products{product},
+				err:      nil,
+			},
+			wantLogs: model.ProductAuditLogs{
+				{
+					ProductID: product.ID,
+					ActorID:   constant.SystemID,
+					Action:    model.ProductAuditActionUpdateThumbnail,
+					Origin:    model.TaskProductUpdateThumbnail,
+					Changes: model.ProductAuditChanges{
+						{Field: "thumbnail_id", Before: oldObjectID, After: newObjectID},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "db error",
+			mockUpdate: &mockUpdate{
+				products: nil,
+				err:      errors.New("db error"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := NewProductUsecase()
+			db, dbMock := utils.NewDBMock()
+			err := uc.InjectDB(db)
+			utils.ContinueOrFatal(err)
+			mockProductRepo := mock.NewMockProductRepository(ctrl)
+			err = uc.InjectProductRepo(mockProductRepo)
+			utils.ContinueOrFatal(err)
+			mockAuditLogRepo := mock.NewMockProductAuditLogRepository(ctrl)
+			err = uc.InjectProductAuditLogRepo(mockAuditLogRepo)
+			utils.ContinueOrFatal(err)
+			mockRevisionRepo := mock.NewMockProductRevisionRepository(ctrl)
+			err = uc.InjectProductRevisionRepo(mockRevisionRepo)
+			utils.ContinueOrFatal(err)
+
+			dbMock.ExpectBegin()
+			mockProductRepo.EXPECT().UpdateAllThumbnail(gomock.Any(), oldObjectID, newObjectID).Times(1).Return(tt.mockUpdate.products, tt.mockUpdate.err)
+			if tt.mockUpdate.err != nil {
+				dbMock.ExpectRollback()
+			} else {
+				mockAuditLogRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, logs model.ProductAuditLogs) error {
+					for i, log := range logs {
+						log.ID = ""
+						if !reflect.DeepEqual(log, tt.wantLogs[i]) {
+							t.Errorf("productUsecase.HandleUpdateThumbnailTask() log = %v, want %v", log, tt.wantLogs[i])
+						}
+					}
+					return nil
+				})
+				mockRevisionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				dbMock.ExpectCommit()
+			}
+
+			payload, err := json.Marshal(model.TaskUpdateThumbnailPayload{
+				OldObjectID: oldObjectID,
+				NewObjectID: newObjectID,
+			})
+			utils.ContinueOrFatal(err)
+
+			err = uc.HandleUpdateThumbnailTask(context.TODO(), asynq.NewTask(model.TaskProductUpdateThumbnail, payload))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productUsecase.HandleUpdateThumbnailTask() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := dbMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("productUsecase.HandleUpdateThumbnailTask() %v", err)
+			}
+		})
+	}
+}
+
+func Test_productUsecase_HandleImportProductsTask(t *testing.T) {
+	jobID := utils.GenerateUUID()
+
+	type mockFind struct {
+		job *model.ProductImportJob
+		err error
+	}
+
+	tests := []struct {
+		name          string
+		mockFind      *mockFind
+		wantStatus    string // status of the updated job, empty when the job is not updated
+		wantSkipRetry bool
+		wantErr       bool
+	}{
+		{
+			name: "interrupted job",
+			mockFind: &mockFind{
+				job: &model.ProductImportJob{ID: jobID, Status: model.ImportJobStatusRunning},
+				err: nil,
+			},
+			wantStatus: model.ImportJobStatusFailed,
+			wantErr:    false,
+		},
+		{
+			name: "finished job",
+			mockFind: &mockFind{
+				job: &model.ProductImportJob{ID: jobID, Status: model.ImportJobStatusCompleted},
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "job not found",
+			mockFind: &mockFind{
+				job: nil,
+				err: nil,
+			},
+			wantSkipRetry: true,
+			wantErr:       true,
+		},
+		{
+			name: "db error",
+			mockFind: &mockFind{
+				job: nil,
+				err: errors.New("db error"),
+			},
+			wantSkipRetry: false,
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := NewProductUsecase()
+			mockImportJobRepo := mock.NewMockProductImportJobRepository(ctrl)
+			err := uc.InjectProductImportJobRepo(mockImportJobRepo)
+			utils.ContinueOrFatal(err)
+
+			mockImportJobRepo.EXPECT().FindByID(gomock.Any(), jobID).Times(1).Return(tt.mockFind.job, tt.mockFind.err)
+			if tt.wantStatus != "" {
+				mockImportJobRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, job *model.ProductImportJob) error {
+					if job.Status != tt.wantStatus || job.ErrorMessage != model.ErrImportJobInterrupted.Error() {
+						t.Errorf("productUsecase.HandleImportProductsTask() job = %v, want status %v", job, tt.wantStatus)
+					}
+					return nil
+				})
+			}
+
+			payload, err := json.Marshal(model.TaskImportProductsPayload{
+				JobID: jobID,
+			})
+			utils.ContinueOrFatal(err)
+
+			err = uc.HandleImportProductsTask(context.TODO(), asynq.NewTask(model.TaskProductImport, payload))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productUsecase.HandleImportProductsTask() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if errors.Is(err, asynq.SkipRetry) != tt.wantSkipRetry {
+				t.Errorf("productUsecase.HandleImportProductsTask() error = %v, wantSkipRetry %v", err, tt.wantSkipRetry)
+			}
+		})
+	}
+}
+
+func Test_productUsecase_HandleExportCatalogTask(t *testing.T) {
+	tests := []struct {
+		name          string
+		payload       []byte
+		wantSkipRetry bool
+		wantErr       bool
+	}{
+		{
+			name:          "invalid json",
+			payload:       []byte("{"),
+			wantSkipRetry: true,
+			wantErr:       true,
+		},
+		{
+			name:          "invalid payload",
+			payload:       []byte(`{"format":"xlsx","destination":"local"}`),
+			wantSkipRetry: true,
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewProductUsecase()
+
+			err := uc.HandleExportCatalogTask(context.TODO(), asynq.NewTask(model.TaskProductCatalogExport, tt.payload))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productUsecase.HandleExportCatalogTask() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if errors.Is(err, asynq.SkipRetry) != tt.wantSkipRetry {
+				t.Errorf("productUsecase.HandleExportCatalogTask() error = %v, wantSkipRetry %v", err, tt.wantSkipRetry)
+			}
+		})
+	}
+}
@@ -1,21 +1,36 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/goccy/go-json"
 	"github.com/golang/mock/gomock"
+	authPB "github.com/krobus00/auth-service/pb/auth"
 	authMock "github.com/krobus00/auth-service/pb/auth/mock"
 	"github.com/krobus00/product-service/internal/constant"
+	"github.com/krobus00/product-service/internal/infrastructure"
 	"github.com/krobus00/product-service/internal/model"
 	"github.com/krobus00/product-service/internal/model/mock"
+	"github.com/krobus00/product-service/internal/repository"
 	"github.com/krobus00/product-service/internal/utils"
 	storagePB "github.com/krobus00/storage-service/pb/storage"
 	storageMock "github.com/krobus00/storage-service/pb/storage/mock"
+	"github.com/nats-io/nats.go"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/wrapperspb"
+	"gorm.io/gorm"
 )
 
 func Test_productUsecase_Create(t *testing.T) {
@@ -32,20 +47,50 @@ func Test_productUsecase_Create(t *testing.T) {
 	type mockCreate struct {
 		err error
 	}
+	type mockCountByOwnerID struct {
+		count int64
+		err   error
+	}
 	type mockAuth struct {
 		hasAccess bool
 		err       error
 	}
+	type mockFindByKey struct {
+		record *model.IdempotencyRecord
+		err    error
+	}
+	type mockStore struct {
+		created bool
+		err     error
+	}
+
+	payload := &model.CreateProductPayload{
+		ID:          productID,
+		Name:        "new product",
+		Description: "product description",
+		Price:       17.17,
+		ThumbnailID: thumbnailID,
+	}
+	requestHash, err := model.NewIdempotencyRequestHash(payload)
+	utils.ContinueOrFatal(err)
+	storedProduct := payload.ToProduct(userID)
+	storedResponse, err := json.Marshal(storedProduct)
+	utils.ContinueOrFatal(err)
 
 	tests := []struct {
-		name              string
-		args              args
-		userID            string
-		mockGetObjectByID *mockGetObjectByID
-		mockCreate        *mockCreate
-		mockAuth          *mockAuth
-		want              *model.Product
-		wantErr           bool
+		name               string
+		args               args
+		userID             string
+		mockGetObjectByID  *mockGetObjectByID
+		mockCreate         *mockCreate
+		mockAuth           *mockAuth
+		quota              int64
+		mockCountByOwnerID *mockCountByOwnerID
+		idempotencyKey     string
+		mockFindByKey      []*mockFindByKey
+		mockStore          *mockStore
+		want               *model.Product
+		wantErr            bool
 	}{
 		{
 			name: "success",
@@ -139,132 +184,26 @@ func Test_productUsecase_Create(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-
-			ctx := context.TODO()
-			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, tt.userID)
-
-			uc := NewProductUsecase()
-			db, _ := utils.NewDBMock()
-			err := uc.InjectDB(db)
-			utils.ContinueOrFatal(err)
-			mockAuthClient := authMock.NewMockAuthServiceClient(ctrl)
-			err = uc.InjectAuthClient(mockAuthClient)
-			utils.ContinueOrFatal(err)
-			mockStorageClient := storageMock.NewMockStorageServiceClient(ctrl)
-			err = uc.InjectStorageClient(mockStorageClient)
-			utils.ContinueOrFatal(err)
-			mockProductRepo := mock.NewMockProductRepository(ctrl)
-			err = uc.InjectProductRepo(mockProductRepo)
-			utils.ContinueOrFatal(err)
-
-			if tt.mockAuth != nil {
-				mockAuthClient.EXPECT().HasAccess(gomock.Any(), gomock.Any()).Times(1).Return(&wrapperspb.BoolValue{
-					Value: tt.mockAuth.hasAccess,
-				}, tt.mockAuth.err)
-			}
-
-			if tt.mockCreate != nil {
-				product := tt.args.payload.ToProduct(userID)
-				mockProductRepo.EXPECT().Create(gomock.Any(), product).Times(1).Return(tt.mockCreate.err)
-			}
-
-			if tt.mockGetObjectByID != nil {
-				mockStorageClient.EXPECT().GetObjectByID(gomock.Any(), &storagePB.GetObjectByIDRequest{
-					UserId:   tt.userID,
-					ObjectId: tt.args.payload.ThumbnailID,
-				}).Times(1).Return(tt.mockGetObjectByID.res, tt.mockGetObjectByID.err)
-			}
-
-			got, err := uc.Create(ctx, tt.args.payload)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("productUsecase.Create() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("productUsecase.Create() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
-func Test_productUsecase_Update(t *testing.T) {
-	userID := utils.GenerateUUID()
-	productID := utils.GenerateUUID()
-	thumbnailID := utils.GenerateUUID()
-
-	type args struct {
-		payload *model.UpdateProductPayload
-	}
-	type mockSelect struct {
-		product *model.Product
-		err     error
-	}
-	type mockGetObjectByID struct {
-		res *storagePB.Object
-		err error
-	}
-	type mockUpdate struct {
-		product *model.Product
-		err     error
-	}
-	type mockAuth struct {
-		hasAccess bool
-		err       error
-	}
-
-	tests := []struct {
-		name              string
-		args              args
-		userID            string
-		mockSelect        *mockSelect
-		mockGetObjectByID *mockGetObjectByID
-		mockUpdate        *mockUpdate
-		mockAuth          *mockAuth
-		want              *model.Product
-		wantErr           bool
-	}{
 		{
-			name: "success",
+			name: "success under quota",
 			args: args{
-				payload: &model.UpdateProductPayload{
-					ID:          productID,
-					Name:        "updated product",
-					Description: "updated product",
-					Price:       10.10,
-					ThumbnailID: thumbnailID,
-				},
-			},
-			userID: userID,
-			mockSelect: &mockSelect{
-				product: &model.Product{
+				payload: &model.CreateProductPayload{
 					ID:          productID,
-					Name:        "product-1",
-					Description: "product1",
+					Name:        "new product",
+					Description: "product description",
 					Price:       17.17,
 					ThumbnailID: thumbnailID,
 				},
-				err: nil,
 			},
+			userID: userID,
 			mockGetObjectByID: &mockGetObjectByID{
 				res: &storagePB.Object{
-					Id:       thumbnailID,
-					FileName: "test.png",
-					Type:     model.ThumbnailType,
-					IsPublic: true,
-				},
-			},
-			mockUpdate: &mockUpdate{
-				product: &model.Product{
-					ID:          productID,
-					Name:        "updated product",
-					Description: "updated product",
-					Price:       10.10,
-					ThumbnailID: thumbnailID,
+					Id:         thumbnailID,
+					FileName:   "test.png",
+					Type:       model.ThumbnailType,
+					SignedUrl:  "url",
+					IsPublic:   true,
+					UploadedBy: userID,
 				},
 				err: nil,
 			},
@@ -272,54 +211,44 @@ func Test_productUsecase_Update(t *testing.T) {
 				hasAccess: true,
 				err:       nil,
 			},
+			quota: 2,
+			mockCountByOwnerID: &mockCountByOwnerID{
+				count: 1,
+				err:   nil,
+			},
+			mockCreate: &mockCreate{
+				err: nil,
+			},
 			want: &model.Product{
 				ID:          productID,
-				Name:        "updated product",
-				Description: "updated product",
-				Price:       10.10,
+				Name:        "new product",
+				Description: "product description",
+				Price:       17.17,
 				ThumbnailID: thumbnailID,
+				OwnerID:     userID,
 			},
 			wantErr: false,
 		},
 		{
-			name: "success update other user product with full access permission",
+			name: "quota exceeded",
 			args: args{
-				payload: &model.UpdateProductPayload{
-					ID:          productID,
-					Name:        "updated product",
-					Description: "updated product",
-					Price:       10.10,
-					ThumbnailID: thumbnailID,
-				},
-			},
-			userID: utils.GenerateUUID(),
-			mockSelect: &mockSelect{
-				product: &model.Product{
+				payload: &model.CreateProductPayload{
 					ID:          productID,
-					Name:        "product-1",
-					Description: "product1",
+					Name:        "new product",
+					Description: "product description",
 					Price:       17.17,
 					ThumbnailID: thumbnailID,
-					OwnerID:     userID,
 				},
-				err: nil,
 			},
+			userID: userID,
 			mockGetObjectByID: &mockGetObjectByID{
 				res: &storagePB.Object{
-					Id:       thumbnailID,
-					FileName: "test.png",
-					Type:     model.ThumbnailType,
-					IsPublic: true,
-				},
-			},
-			mockUpdate: &mockUpdate{
-				product: &model.Product{
-					ID:          productID,
-					Name:        "updated product",
-					Description: "updated product",
-					Price:       10.10,
-					ThumbnailID: thumbnailID,
-					OwnerID:     userID,
+					Id:         thumbnailID,
+					FileName:   "test.png",
+					Type:       model.ThumbnailType,
+					SignedUrl:  "url",
+					IsPublic:   true,
+					UploadedBy: userID,
 				},
 				err: nil,
 			},
@@ -327,85 +256,48 @@ func Test_productUsecase_Update(t *testing.T) {
 				hasAccess: true,
 				err:       nil,
 			},
-			want: &model.Product{
-				ID:          productID,
-				Name:        "updated product",
-				Description: "updated product",
-				Price:       10.10,
-				ThumbnailID: thumbnailID,
-				OwnerID:     userID,
+			quota: 2,
+			mockCountByOwnerID: &mockCountByOwnerID{
+				count: 2,
+				err:   nil,
 			},
-			wantErr: false,
+			want:    nil,
+			wantErr: true,
 		},
 		{
-			name: "error update other user product",
+			name: "invalid payload",
 			args: args{
-				payload: &model.UpdateProductPayload{
-					ID:          productID,
-					Name:        "updated product",
-					Description: "updated product",
-					Price:       10.10,
-					ThumbnailID: thumbnailID,
-				},
-			},
-			userID: utils.GenerateUUID(),
-			mockSelect: &mockSelect{
-				product: &model.Product{
+				payload: &model.CreateProductPayload{
 					ID:          productID,
-					Name:        "product-1",
-					Description: "product1",
-					Price:       17.17,
+					Name:        "",
+					Description: "product description",
+					Price:       -1,
 					ThumbnailID: thumbnailID,
-					OwnerID:     utils.GenerateUUID(),
 				},
-				err: nil,
 			},
+			userID: userID,
 			mockAuth: &mockAuth{
-				hasAccess: false,
+				hasAccess: true,
 				err:       nil,
 			},
 			want:    nil,
 			wantErr: true,
 		},
 		{
-			name: "db error when update",
+			name: "storage service unavailable",
 			args: args{
-				payload: &model.UpdateProductPayload{
-					ID:          productID,
-					Name:        "updated product",
-					Description: "updated product",
-					Price:       10.10,
-					ThumbnailID: thumbnailID,
-				},
-			},
-			userID: userID,
-			mockSelect: &mockSelect{
-				product: &model.Product{
+				payload: &model.CreateProductPayload{
 					ID:          productID,
-					Name:        "product-1",
-					Description: "product1",
+					Name:        "new product",
+					Description: "product description",
 					Price:       17.17,
 					ThumbnailID: thumbnailID,
 				},
-				err: nil,
 			},
+			userID: userID,
 			mockGetObjectByID: &mockGetObjectByID{
-				res: &storagePB.Object{
-					Id:       thumbnailID,
-					FileName: "test.png",
-					Type:     model.ThumbnailType,
-					IsPublic: true,
-				},
-			},
-			mockUpdate: &mockUpdate{
-				product: &model.Product{
-					ID:          productID,
-					Name:        "updated product",
-					Description: "updated product",
-					Price:       10.10,
-					ThumbnailID: thumbnailID,
-				},
-				err: errors.New("db error"),
+				res: nil,
+				err: status.Error(codes.Unavailable, "connection refused"),
 			},
 			mockAuth: &mockAuth{
 				hasAccess: true,
@@ -415,66 +307,2007 @@ func Test_productUsecase_Update(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "product not found",
+			name: "idempotent first request",
 			args: args{
-				payload: &model.UpdateProductPayload{
-					ID:          productID,
-					Name:        "updated product",
-					Description: "updated product",
-					Price:       10.10,
-					ThumbnailID: thumbnailID,
-				},
+				payload: payload,
 			},
-			userID: userID,
-			mockSelect: &mockSelect{
-				product: nil,
+			userID:         userID,
+			idempotencyKey: "create-1",
+			mockFindByKey: []*mockFindByKey{
+				{record: nil, err: nil},
+			},
+			mockGetObjectByID: &mockGetObjectByID{
+				res: &storagePB.Object{
+					Id:         thumbnailID,
+					FileName:   "test.png",
+					Type:       model.ThumbnailType,
+					SignedUrl:  "url",
+					IsPublic:   true,
+					UploadedBy: userID,
+				},
+				err: nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockCreate: &mockCreate{
+				err: nil,
+			},
+			mockStore: &mockStore{
+				created: true,
+				err:     nil,
+			},
+			want:    storedProduct,
+			wantErr: false,
+		},
+		{
+			name: "idempotent write failed",
+			args: args{
+				payload: payload,
+			},
+			userID:         userID,
+			idempotencyKey: "create-1",
+			mockFindByKey: []*mockFindByKey{
+				{record: nil, err: nil},
+			},
+			mockGetObjectByID: &mockGetObjectByID{
+				res: &storagePB.Object{
+					Id:         thumbnailID,
+					FileName:   "test.png",
+					Type:       model.ThumbnailType,
+					SignedUrl:  "url",
+					IsPublic:   true,
+					UploadedBy: userID,
+				},
+				err: nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockCreate: &mockCreate{
+				err: errors.New("db error"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "rollback when the record can't be stored",
+			args: args{
+				payload: payload,
+			},
+			userID:         userID,
+			idempotencyKey: "create-1",
+			mockFindByKey: []*mockFindByKey{
+				{record: nil, err: nil},
+			},
+			mockGetObjectByID: &mockGetObjectByID{
+				res: &storagePB.Object{
+					Id:         thumbnailID,
+					FileName:   "test.png",
+					Type:       model.ThumbnailType,
+					SignedUrl:  "url",
+					IsPublic:   true,
+					UploadedBy: userID,
+				},
+				err: nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockCreate: &mockCreate{
+				err: nil,
+			},
+			mockStore: &mockStore{
+				created: false,
+				err:     errors.New("db error"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "replay the response of a concurrent request that committed first",
+			args: args{
+				payload: payload,
+			},
+			userID:         userID,
+			idempotencyKey: "create-1",
+			mockFindByKey: []*mockFindByKey{
+				{record: nil, err: nil},
+				{
+					record: &model.IdempotencyRecord{
+						RequestHash: requestHash,
+						Response:    storedResponse,
+					},
+					err: nil,
+				},
+			},
+			mockGetObjectByID: &mockGetObjectByID{
+				res: &storagePB.Object{
+					Id:         thumbnailID,
+					FileName:   "test.png",
+					Type:       model.ThumbnailType,
+					SignedUrl:  "url",
+					IsPublic:   true,
+					UploadedBy: userID,
+				},
+				err: nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockCreate: &mockCreate{
+				err: nil,
+			},
+			mockStore: &mockStore{
+				created: false,
 				err:     nil,
 			},
+			want:    storedProduct,
+			wantErr: false,
+		},
+		{
+			name: "idempotent replay",
+			args: args{
+				payload: payload,
+			},
+			userID:         userID,
+			idempotencyKey: "create-1",
+			mockFindByKey: []*mockFindByKey{
+				{
+					record: &model.IdempotencyRecord{
+						RequestHash: requestHash,
+						Response:    storedResponse,
+					},
+					err: nil,
+				},
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			want:    storedProduct,
+			wantErr: false,
+		},
+		{
+			name: "idempotency key reused",
+			args: args{
+				payload: payload,
+			},
+			userID:         userID,
+			idempotencyKey: "create-1",
+			mockFindByKey: []*mockFindByKey{
+				{
+					record: &model.IdempotencyRecord{
+						RequestHash: "other request",
+						Response:    storedResponse,
+					},
+					err: nil,
+				},
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.TODO()
+			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, tt.userID)
+			ctx = context.WithValue(ctx, constant.KeyIdempotencyCtx, tt.idempotencyKey)
+			viper.Set("quota.max_products_per_owner", tt.quota)
+			defer viper.Set("quota.max_products_per_owner", 0)
+
+			uc := NewProductUsecase()
+			db, dbMock := utils.NewDBMock()
+			err := uc.InjectDB(db)
+			utils.ContinueOrFatal(err)
+			mockAuthClient := authMock.NewMockAuthServiceClient(ctrl)
+			err = uc.InjectAuthClient(mockAuthClient)
+			utils.ContinueOrFatal(err)
+			mockStorageClient := storageMock.NewMockStorageServiceClient(ctrl)
+			err = uc.InjectStorageClient(mockStorageClient)
+			utils.ContinueOrFatal(err)
+			mockProductRepo := mock.NewMockProductRepository(ctrl)
+			err = uc.InjectProductRepo(mockProductRepo)
+			utils.ContinueOrFatal(err)
+			mockAuditLogRepo := mock.NewMockProductAuditLogRepository(ctrl)
+			err = uc.InjectProductAuditLogRepo(mockAuditLogRepo)
+			utils.ContinueOrFatal(err)
+			mockRevisionRepo := mock.NewMockProductRevisionRepository(ctrl)
+			err = uc.InjectProductRevisionRepo(mockRevisionRepo)
+			utils.ContinueOrFatal(err)
+			mockIdempotencyRepo := mock.NewMockIdempotencyRepository(ctrl)
+			err = uc.InjectIdempotencyRepo(mockIdempotencyRepo)
+			utils.ContinueOrFatal(err)
+
+			key := model.NewIdempotencyKey(tt.userID, model.IdempotencyOperationCreate, tt.idempotencyKey)
+			findCalls := make([]*gomock.Call, 0, len(tt.mockFindByKey))
+			for _, find := range tt.mockFindByKey {
+				findCalls = append(findCalls, mockIdempotencyRepo.EXPECT().FindByKey(gomock.Any(), key).Times(1).Return(find.record, find.err))
+			}
+			gomock.InOrder(findCalls...)
+
+			if tt.mockAuth != nil {
+				mockAuthClient.EXPECT().HasAccess(gomock.Any(), gomock.Any()).Times(1).Return(&wrapperspb.BoolValue{
+					Value: tt.mockAuth.hasAccess,
+				}, tt.mockAuth.err)
+			}
+
+			if tt.mockCountByOwnerID != nil || tt.mockCreate != nil {
+				dbMock.ExpectBegin()
+			}
+
+			if tt.mockCountByOwnerID != nil {
+				mockProductRepo.EXPECT().LockOwner(gomock.Any(), tt.userID).Times(1).Return(nil)
+				mockProductRepo.EXPECT().CountByOwnerID(gomock.Any(), tt.userID).Times(1).Return(tt.mockCountByOwnerID.count, tt.mockCountByOwnerID.err)
+				if tt.mockCreate == nil {
+					dbMock.ExpectRollback()
+				}
+			}
+
+			if tt.mockCreate != nil {
+				product := tt.args.payload.ToProduct(userID)
+				mockProductRepo.EXPECT().Create(gomock.Any(), product).Times(1).Return(tt.mockCreate.err)
+				if tt.mockCreate.err != nil {
+					dbMock.ExpectRollback()
+				} else {
+					mockAuditLogRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+					mockRevisionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+					if tt.mockStore != nil {
+						mockIdempotencyRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, record *model.IdempotencyRecord) (bool, error) {
+							if record.Key != key || record.RequestHash != requestHash || !bytes.Equal(record.Response, storedResponse) {
+								t.Errorf("productUsecase.Create() stored idempotency record %v", record)
+							}
+							return tt.mockStore.created, tt.mockStore.err
+						})
+					}
+					if tt.mockStore != nil && (!tt.mockStore.created || tt.mockStore.err != nil) {
+						dbMock.ExpectRollback()
+					} else {
+						dbMock.ExpectCommit()
+					}
+				}
+			}
+
+			if tt.mockGetObjectByID != nil {
+				mockStorageClient.EXPECT().GetObjectByID(gomock.Any(), &storagePB.GetObjectByIDRequest{
+					UserId:   tt.userID,
+					ObjectId: tt.args.payload.ThumbnailID,
+				}).Times(1).Return(tt.mockGetObjectByID.res, tt.mockGetObjectByID.err)
+			}
+
+			got, err := uc.Create(ctx, tt.args.payload)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productUsecase.Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("productUsecase.Create() = %v, want %v", got, tt.want)
+			}
+			if err := dbMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("productUsecase.Create() %v", err)
+			}
+		})
+	}
+}
+
+// Test_productUsecase_Create_RolledBack check a write rolled back by a failed audit log never reach
+// the search backend, the listing or the cache.
+func Test_productUsecase_Create_RolledBack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := utils.GenerateUUID()
+	thumbnailID := utils.GenerateUUID()
+	payload := &model.CreateProductPayload{
+		ID:          utils.GenerateUUID(),
+		Name:        "new product",
+		Description: "product description",
+		Price:       17.17,
+		ThumbnailID: thumbnailID,
+	}
+
+	miniRedis := miniredis.RunT(t)
+	viper.Set("redis.cache_host", fmt.Sprintf("redis://%s", miniRedis.Addr()))
+	defer viper.Set("redis.cache_host", "")
+	redisClient, err := infrastructure.NewRedisClient()
+	utils.ContinueOrFatal(err)
+	cacheKey := model.NewProductCacheKey(payload.ID)
+	err = miniRedis.Set(cacheKey, "null")
+	utils.ContinueOrFatal(err)
+
+	db, dbMock := utils.NewDBMock()
+	// the searcher has no expectation, any Index call fail the test
+	mockSearcher := mock.NewMockProductSearcher(ctrl)
+	productRepo := repository.NewProductRepository()
+	utils.ContinueOrFatal(productRepo.InjectDB(db))
+	utils.ContinueOrFatal(productRepo.InjectRedisClient(redisClient))
+	utils.ContinueOrFatal(productRepo.InjectProductSearcher(mockSearcher))
+
+	uc := NewProductUsecase()
+	utils.ContinueOrFatal(uc.InjectDB(db))
+	utils.ContinueOrFatal(uc.InjectProductRepo(productRepo))
+	mockAuthClient := authMock.NewMockAuthServiceClient(ctrl)
+	utils.ContinueOrFatal(uc.InjectAuthClient(mockAuthClient))
+	mockStorageClient := storageMock.NewMockStorageServiceClient(ctrl)
+	utils.ContinueOrFatal(uc.InjectStorageClient(mockStorageClient))
+	mockAuditLogRepo := mock.NewMockProductAuditLogRepository(ctrl)
+	utils.ContinueOrFatal(uc.InjectProductAuditLogRepo(mockAuditLogRepo))
+	mockRevisionRepo := mock.NewMockProductRevisionRepository(ctrl)
+	utils.ContinueOrFatal(uc.InjectProductRevisionRepo(mockRevisionRepo))
+
+	mockAuthClient.EXPECT().HasAccess(gomock.Any(), gomock.Any()).Times(1).Return(&wrapperspb.BoolValue{Value: true}, nil)
+	mockStorageClient.EXPECT().GetObjectByID(gomock.Any(), gomock.Any()).Times(1).Return(&storagePB.Object{
+		Id:       thumbnailID,
+		Type:     model.ThumbnailType,
+		IsPublic: true,
+	}, nil)
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec("INSERT INTO \"products\"").WillReturnResult(sqlmock.NewResult(1, 1))
+	mockAuditLogRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("db error"))
+	dbMock.ExpectRollback()
+
+	commands := miniRedis.CommandCount()
+	ctx := context.WithValue(context.TODO(), constant.KeyUserIDCtx, userID)
+	_, err = uc.Create(ctx, payload)
+	if err == nil {
+		t.Fatal("productUsecase.Create() error = nil, want the audit log error")
+	}
+	if err := dbMock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+	if got := miniRedis.CommandCount() - commands; got != 0 {
+		t.Errorf("productUsecase.Create() sent %d redis commands after a rollback, want 0", got)
+	}
+	if !miniRedis.Exists(cacheKey) {
+		t.Errorf("productUsecase.Create() deleted %s after a rollback", cacheKey)
+	}
+}
+
+// Test_productUsecase_Create_IdempotentRetry check a write whose idempotency record can't be stored is rolled back,
+// so the retry with the same key write once and the next retry replay it.
+func Test_productUsecase_Create_IdempotentRetry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := utils.GenerateUUID()
+	thumbnailID := utils.GenerateUUID()
+	payload := &model.CreateProductPayload{
+		ID:          utils.GenerateUUID(),
+		Name:        "new product",
+		Description: "product description",
+		Price:       17.17,
+		ThumbnailID: thumbnailID,
+	}
+	ctx := context.WithValue(context.TODO(), constant.KeyUserIDCtx, userID)
+	ctx = context.WithValue(ctx, constant.KeyIdempotencyCtx, "create-1")
+	key := model.NewIdempotencyKey(userID, model.IdempotencyOperationCreate, "create-1")
+
+	uc := NewProductUsecase()
+	db, dbMock := utils.NewDBMock()
+	err := uc.InjectDB(db)
+	utils.ContinueOrFatal(err)
+	mockAuthClient := authMock.NewMockAuthServiceClient(ctrl)
+	err = uc.InjectAuthClient(mockAuthClient)
+	utils.ContinueOrFatal(err)
+	mockStorageClient := storageMock.NewMockStorageServiceClient(ctrl)
+	err = uc.InjectStorageClient(mockStorageClient)
+	utils.ContinueOrFatal(err)
+	mockProductRepo := mock.NewMockProductRepository(ctrl)
+	err = uc.InjectProductRepo(mockProductRepo)
+	utils.ContinueOrFatal(err)
+	mockAuditLogRepo := mock.NewMockProductAuditLogRepository(ctrl)
+	err = uc.InjectProductAuditLogRepo(mockAuditLogRepo)
+	utils.ContinueOrFatal(err)
+	mockRevisionRepo := mock.NewMockProductRevisionRepository(ctrl)
+	err = uc.InjectProductRevisionRepo(mockRevisionRepo)
+	utils.ContinueOrFatal(err)
+	mockIdempotencyRepo := mock.NewMockIdempotencyRepository(ctrl)
+	err = uc.InjectIdempotencyRepo(mockIdempotencyRepo)
+	utils.ContinueOrFatal(err)
+
+	mockAuthClient.EXPECT().HasAccess(gomock.Any(), gomock.Any()).Times(3).Return(&wrapperspb.BoolValue{Value: true}, nil)
+	mockStorageClient.EXPECT().GetObjectByID(gomock.Any(), gomock.Any()).Times(2).Return(&storagePB.Object{
+		Id:       thumbnailID,
+		Type:     model.ThumbnailType,
+		IsPublic: true,
+	}, nil)
+	mockProductRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(2).Return(nil)
+	mockAuditLogRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(2).Return(nil)
+	mockRevisionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(2).Return(nil)
+
+	var stored *model.IdempotencyRecord
+	gomock.InOrder(
+		mockIdempotencyRepo.EXPECT().FindByKey(gomock.Any(), key).Times(1).Return(nil, nil),
+		mockIdempotencyRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(false, errors.New("db error")),
+		mockIdempotencyRepo.EXPECT().FindByKey(gomock.Any(), key).Times(1).Return(nil, nil),
+		mockIdempotencyRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, record *model.IdempotencyRecord) (bool, error) {
+			stored = record
+			return true, nil
+		}),
+		mockIdempotencyRepo.EXPECT().FindByKey(gomock.Any(), key).Times(1).DoAndReturn(func(_ context.Context, _ string) (*model.IdempotencyRecord, error) {
+			return stored, nil
+		}),
+	)
+	dbMock.ExpectBegin()
+	dbMock.ExpectRollback()
+	dbMock.ExpectBegin()
+	dbMock.ExpectCommit()
+
+	_, err = uc.Create(ctx, payload)
+	if err == nil {
+		t.Fatalf("productUsecase.Create() first attempt should fail when the record can't be stored")
+	}
+
+	created, err := uc.Create(ctx, payload)
+	if err != nil {
+		t.Fatalf("productUsecase.Create() retry error = %v", err)
+	}
+
+	replayed, err := uc.Create(ctx, payload)
+	if err != nil {
+		t.Fatalf("productUsecase.Create() replay error = %v", err)
+	}
+	if !reflect.DeepEqual(replayed, created) {
+		t.Errorf("productUsecase.Create() replay = %v, want %v", replayed, created)
+	}
+	if err := dbMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("productUsecase.Create() %v", err)
+	}
+}
+
+func Test_productUsecase_Update(t *testing.T) {
+	userID := utils.GenerateUUID()
+	productID := utils.GenerateUUID()
+	thumbnailID := utils.GenerateUUID()
+	ownerID := utils.GenerateUUID()
+
+	type args struct {
+		payload *model.UpdateProductPayload
+	}
+	type mockSelect struct {
+		product *model.Product
+		err     error
+	}
+	type mockGetObjectByID struct {
+		res *storagePB.Object
+		err error
+	}
+	type mockUpdate struct {
+		product *model.Product
+		err     error
+	}
+	type mockAuth struct {
+		hasAccess bool
+		err       error
+	}
+	type mockFindCollaborator struct {
+		collaborator *model.ProductCollaborator
+		err          error
+	}
+
+	tests := []struct {
+		name                 string
+		args                 args
+		userID               string
+		mockSelect           *mockSelect
+		mockGetObjectByID    *mockGetObjectByID
+		mockUpdate           *mockUpdate
+		mockAuth             *mockAuth
+		mockFindCollaborator *mockFindCollaborator
+		mockModifyOther      *mockAuth
+		want                 *model.Product
+		wantErr              bool
+	}{
+		{
+			name: "success",
+			args: args{
+				payload: &model.UpdateProductPayload{
+					ID:          productID,
+					Name:        "updated product",
+					Description: "updated product",
+					Price:       10.10,
+					ThumbnailID: thumbnailID,
+				},
+			},
+			userID: userID,
+			mockSelect: &mockSelect{
+				product: &model.Product{
+					ID:          productID,
+					Name:        "product-1",
+					Description: "product1",
+					Price:       17.17,
+					ThumbnailID: thumbnailID,
+					OwnerID:     userID,
+				},
+				err: nil,
+			},
+			mockGetObjectByID: &mockGetObjectByID{
+				res: &storagePB.Object{
+					Id:       thumbnailID,
+					FileName: "test.png",
+					Type:     model.ThumbnailType,
+					IsPublic: true,
+				},
+			},
+			mockUpdate: &mockUpdate{
+				product: &model.Product{
+					ID:          productID,
+					Name:        "updated product",
+					Description: "updated product",
+					Price:       10.10,
+					ThumbnailID: thumbnailID,
+					OwnerID:     userID,
+				},
+				err: nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			want: &model.Product{
+				ID:          productID,
+				Name:        "updated product",
+				Description: "updated product",
+				Price:       10.10,
+				ThumbnailID: thumbnailID,
+				OwnerID:     userID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success update other user product with full access permission",
+			args: args{
+				payload: &model.UpdateProductPayload{
+					ID:          productID,
+					Name:        "updated product",
+					Description: "updated product",
+					Price:       10.10,
+					ThumbnailID: thumbnailID,
+				},
+			},
+			userID: utils.GenerateUUID(),
+			mockSelect: &mockSelect{
+				product: &model.Product{
+					ID:          productID,
+					Name:        "product-1",
+					Description: "product1",
+					Price:       17.17,
+					ThumbnailID: thumbnailID,
+					OwnerID:     userID,
+				},
+				err: nil,
+			},
+			mockGetObjectByID: &mockGetObjectByID{
+				res: &storagePB.Object{
+					Id:       thumbnailID,
+					FileName: "test.png",
+					Type:     model.ThumbnailType,
+					IsPublic: true,
+				},
+			},
+			mockUpdate: &mockUpdate{
+				product: &model.Product{
+					ID:          productID,
+					Name:        "updated product",
+					Description: "updated product",
+					Price:       10.10,
+					ThumbnailID: thumbnailID,
+					OwnerID:     userID,
+				},
+				err: nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockFindCollaborator: &mockFindCollaborator{
+				collaborator: nil,
+				err:          nil,
+			},
+			mockModifyOther: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			want: &model.Product{
+				ID:          productID,
+				Name:        "updated product",
+				Description: "updated product",
+				Price:       10.10,
+				ThumbnailID: thumbnailID,
+				OwnerID:     userID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "error update other user product",
+			args: args{
+				payload: &model.UpdateProductPayload{
+					ID:          productID,
+					Name:        "updated product",
+					Description: "updated product",
+					Price:       10.10,
+					ThumbnailID: thumbnailID,
+				},
+			},
+			userID: utils.GenerateUUID(),
+			mockSelect: &mockSelect{
+				product: &model.Product{
+					ID:          productID,
+					Name:        "product-1",
+					Description: "product1",
+					Price:       17.17,
+					ThumbnailID: thumbnailID,
+					OwnerID:     utils.GenerateUUID(),
+				},
+				err: nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: false,
+				err:       nil,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "db error when update",
+			args: args{
+				payload: &model.UpdateProductPayload{
+					ID:          productID,
+					Name:        "updated product",
+					Description: "updated product",
+					Price:       10.10,
+					ThumbnailID: thumbnailID,
+				},
+			},
+			userID: userID,
+			mockSelect: &mockSelect{
+				product: &model.Product{
+					ID:          productID,
+					Name:        "product-1",
+					Description: "product1",
+					Price:       17.17,
+					ThumbnailID: thumbnailID,
+					OwnerID:     userID,
+				},
+				err: nil,
+			},
+			mockGetObjectByID: &mockGetObjectByID{
+				res: &storagePB.Object{
+					Id:       thumbnailID,
+					FileName: "test.png",
+					Type:     model.ThumbnailType,
+					IsPublic: true,
+				},
+			},
+			mockUpdate: &mockUpdate{
+				product: &model.Product{
+					ID:          productID,
+					Name:        "updated product",
+					Description: "updated product",
+					Price:       10.10,
+					ThumbnailID: thumbnailID,
+					OwnerID:     userID,
+				},
+				err: errors.New("db error"),
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "product not found",
+			args: args{
+				payload: &model.UpdateProductPayload{
+					ID:          productID,
+					Name:        "updated product",
+					Description: "updated product",
+					Price:       10.10,
+					ThumbnailID: thumbnailID,
+				},
+			},
+			userID: userID,
+			mockSelect: &mockSelect{
+				product: nil,
+				err:     nil,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error when find product",
+			args: args{
+				payload: &model.UpdateProductPayload{
+					ID:          productID,
+					Name:        "updated product",
+					Description: "updated product",
+					Price:       10.10,
+					ThumbnailID: thumbnailID,
+				},
+			},
+			userID: userID,
+			mockSelect: &mockSelect{
+				product: nil,
+				err:     errors.New("db error"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "permission denied",
+			args: args{
+				payload: &model.UpdateProductPayload{
+					ID:          productID,
+					Name:        "updated product",
+					Description: "updated product",
+					Price:       10.10,
+					ThumbnailID: thumbnailID,
+				},
+			},
+			userID: userID,
+			mockSelect: &mockSelect{
+				product: &model.Product{
+					ID:          productID,
+					Name:        "product-1",
+					Description: "product1",
+					Price:       17.17,
+					ThumbnailID: thumbnailID,
+					OwnerID:     utils.GenerateUUID(),
+				},
+				err: nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: false,
+				err:       nil,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error update own product without update permission",
+			args: args{
+				payload: &model.UpdateProductPayload{
+					ID:          productID,
+					Name:        "updated product",
+					Description: "updated product",
+					Price:       10.10,
+					ThumbnailID: thumbnailID,
+				},
+			},
+			userID: userID,
+			mockSelect: &mockSelect{
+				product: &model.Product{
+					ID:          productID,
+					Name:        "product-1",
+					Description: "product1",
+					Price:       17.17,
+					ThumbnailID: thumbnailID,
+					OwnerID:     userID,
+				},
+				err: nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: false,
+				err:       nil,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error update other user product without modify other permission",
+			args: args{
+				payload: &model.UpdateProductPayload{
+					ID:          productID,
+					Name:        "updated product",
+					Description: "updated product",
+					Price:       10.10,
+					ThumbnailID: thumbnailID,
+				},
+			},
+			userID: userID,
+			mockSelect: &mockSelect{
+				product: &model.Product{
+					ID:          productID,
+					Name:        "product-1",
+					Description: "product1",
+					Price:       17.17,
+					ThumbnailID: thumbnailID,
+					OwnerID:     utils.GenerateUUID(),
+				},
+				err: nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockFindCollaborator: &mockFindCollaborator{
+				collaborator: nil,
+				err:          nil,
+			},
+			mockModifyOther: &mockAuth{
+				hasAccess: false,
+				err:       nil,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "success update shared product as editor",
+			args: args{
+				payload: &model.UpdateProductPayload{
+					ID:          productID,
+					Name:        "updated product",
+					Description: "updated product",
+					Price:       10.10,
+					ThumbnailID: thumbnailID,
+				},
+			},
+			userID: userID,
+			mockSelect: &mockSelect{
+				product: &model.Product{
+					ID:          productID,
+					Name:        "product-1",
+					Description: "product1",
+					Price:       17.17,
+					ThumbnailID: thumbnailID,
+					OwnerID:     ownerID,
+				},
+				err: nil,
+			},
+			mockGetObjectByID: &mockGetObjectByID{
+				res: &storagePB.Object{
+					Id:       thumbnailID,
+					FileName: "test.png",
+					Type:     model.ThumbnailType,
+					IsPublic: true,
+				},
+			},
+			mockUpdate: &mockUpdate{
+				product: &model.Product{
+					ID:          productID,
+					Name:        "updated product",
+					Description: "updated product",
+					Price:       10.10,
+					ThumbnailID: thumbnailID,
+					OwnerID:     ownerID,
+				},
+				err: nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockFindCollaborator: &mockFindCollaborator{
+				collaborator: &model.ProductCollaborator{
+					ProductID: productID,
+					UserID:    userID,
+					Role:      model.CollaboratorRoleEditor,
+				},
+				err: nil,
+			},
+			want: &model.Product{
+				ID:          productID,
+				Name:        "updated product",
+				Description: "updated product",
+				Price:       10.10,
+				ThumbnailID: thumbnailID,
+				OwnerID:     ownerID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "error update shared product as viewer",
+			args: args{
+				payload: &model.UpdateProductPayload{
+					ID:          productID,
+					Name:        "updated product",
+					Description: "updated product",
+					Price:       10.10,
+					ThumbnailID: thumbnailID,
+				},
+			},
+			userID: userID,
+			mockSelect: &mockSelect{
+				product: &model.Product{
+					ID:          productID,
+					Name:        "product-1",
+					Description: "product1",
+					Price:       17.17,
+					ThumbnailID: thumbnailID,
+					OwnerID:     ownerID,
+				},
+				err: nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockFindCollaborator: &mockFindCollaborator{
+				collaborator: &model.ProductCollaborator{
+					ProductID: productID,
+					UserID:    userID,
+					Role:      model.CollaboratorRoleViewer,
+				},
+				err: nil,
+			},
+			mockModifyOther: &mockAuth{
+				hasAccess: false,
+				err:       nil,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "invalid payload",
+			args: args{
+				payload: &model.UpdateProductPayload{
+					ID:          productID,
+					Name:        "",
+					Description: "product description",
+					Price:       17.17,
+					ThumbnailID: "invalid-thumbnail-id",
+				},
+			},
+			userID: userID,
+			mockSelect: &mockSelect{
+				product: &model.Product{
+					ID:          productID,
+					Name:        "product-1",
+					Description: "product1",
+					Price:       17.17,
+					ThumbnailID: thumbnailID,
+					OwnerID:     userID,
+				},
+				err: nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.TODO()
+			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, tt.userID)
+
+			uc := NewProductUsecase()
+			db, dbMock := utils.NewDBMock()
+			err := uc.InjectDB(db)
+			utils.ContinueOrFatal(err)
+			mockAuthClient := authMock.NewMockAuthServiceClient(ctrl)
+			err = uc.InjectAuthClient(mockAuthClient)
+			utils.ContinueOrFatal(err)
+			mockStorageClient := storageMock.NewMockStorageServiceClient(ctrl)
+			err = uc.InjectStorageClient(mockStorageClient)
+			utils.ContinueOrFatal(err)
+			mockProductRepo := mock.NewMockProductRepository(ctrl)
+			err = uc.InjectProductRepo(mockProductRepo)
+			utils.ContinueOrFatal(err)
+			mockAuditLogRepo := mock.NewMockProductAuditLogRepository(ctrl)
+			err = uc.InjectProductAuditLogRepo(mockAuditLogRepo)
+			utils.ContinueOrFatal(err)
+			mockRevisionRepo := mock.NewMockProductRevisionRepository(ctrl)
+			err = uc.InjectProductRevisionRepo(mockRevisionRepo)
+			utils.ContinueOrFatal(err)
+			mockCollaboratorRepo := mock.NewMockProductCollaboratorRepository(ctrl)
+			err = uc.InjectProductCollaboratorRepo(mockCollaboratorRepo)
+			utils.ContinueOrFatal(err)
+
+			if tt.mockAuth != nil {
+				mockAuthClient.EXPECT().
+					HasAccess(gomock.Any(), gomock.Any()).
+					Times(1).Return(&wrapperspb.BoolValue{
+					Value: tt.mockAuth.hasAccess,
+				}, tt.mockAuth.err)
+			}
+
+			if tt.mockFindCollaborator != nil {
+				mockCollaboratorRepo.EXPECT().FindByProductIDAndUserID(gomock.Any(), tt.args.payload.ID, tt.userID).Times(1).Return(tt.mockFindCollaborator.collaborator, tt.mockFindCollaborator.err)
+			}
+
+			if tt.mockModifyOther != nil {
+				mockAuthClient.EXPECT().
+					HasAccess(gomock.Any(), gomock.Any()).
+					Times(1).Return(&wrapperspb.BoolValue{
+					Value: tt.mockModifyOther.hasAccess,
+				}, tt.mockModifyOther.err)
+			}
+
+			if tt.mockSelect != nil {
+				mockProductRepo.EXPECT().FindByID(gomock.Any(), tt.args.payload.ID).Times(1).Return(tt.mockSelect.product, tt.mockSelect.err)
+			}
+
+			if tt.mockGetObjectByID != nil {
+				mockStorageClient.EXPECT().GetObjectByID(gomock.Any(), &storagePB.GetObjectByIDRequest{
+					UserId:   tt.userID,
+					ObjectId: tt.args.payload.ThumbnailID,
+				}).Times(1).Return(tt.mockGetObjectByID.res, tt.mockGetObjectByID.err)
+			}
+
+			if tt.mockUpdate != nil {
+				dbMock.ExpectBegin()
+				mockProductRepo.EXPECT().Update(gomock.Any(), tt.mockUpdate.product).Times(1).Return(tt.mockUpdate.err)
+				if tt.mockUpdate.err != nil {
+					dbMock.ExpectRollback()
+				} else {
+					mockAuditLogRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+					mockRevisionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+					dbMock.ExpectCommit()
+				}
+			}
+
+			got, err := uc.Update(ctx, tt.args.payload)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productUsecase.Update() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("productUsecase.Update() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_productUsecase_Delete(t *testing.T) {
+	userID := utils.GenerateUUID()
+	productID := utils.GenerateUUID()
+	thumbnailID := utils.GenerateUUID()
+
+	type args struct {
+		id string
+	}
+	type mockSelect struct {
+		product *model.Product
+		err     error
+	}
+	type mockDelete struct {
+		err error
+	}
+	type mockAuth struct {
+		hasAccess bool
+		err       error
+	}
+
+	tests := []struct {
+		name            string
+		args            args
+		userID          string
+		mockSelect      *mockSelect
+		mockDelete      *mockDelete
+		mockAuth        *mockAuth
+		mockModifyOther *mockAuth
+		wantErr         bool
+	}{
+		{
+			name: "success",
+			args: args{
+				id: productID,
+			},
+			userID: userID,
+			mockSelect: &mockSelect{
+				product: &model.Product{
+					ID:          productID,
+					Name:        "product-1",
+					Description: "product1",
+					Price:       17.17,
+					ThumbnailID: thumbnailID,
+					OwnerID:     userID,
+				},
+				err: nil,
+			},
+			mockDelete: &mockDelete{
+				err: nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "error when delete product",
+			args: args{
+				id: productID,
+			},
+			userID: userID,
+			mockSelect: &mockSelect{
+				product: &model.Product{
+					ID:          productID,
+					Name:        "product-1",
+					Description: "product1",
+					Price:       17.17,
+					ThumbnailID: thumbnailID,
+					OwnerID:     userID,
+				},
+				err: nil,
+			},
+			mockDelete: &mockDelete{
+				err: errors.New("db error"),
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			wantErr: true,
+		},
+		{
+			name: "success delete other user product",
+			args: args{
+				id: productID,
+			},
+			userID: userID,
+			mockSelect: &mockSelect{
+				product: &model.Product{
+					ID:          productID,
+					Name:        "product-1",
+					Description: "product1",
+					Price:       17.17,
+					ThumbnailID: thumbnailID,
+					OwnerID:     utils.GenerateUUID(),
+				},
+				err: nil,
+			},
+			mockDelete: &mockDelete{
+				err: nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockModifyOther: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "permission denied for owner",
+			args: args{
+				id: productID,
+			},
+			userID: userID,
+			mockSelect: &mockSelect{
+				product: &model.Product{
+					ID:          productID,
+					Name:        "product-1",
+					Description: "product1",
+					Price:       17.17,
+					ThumbnailID: thumbnailID,
+					OwnerID:     userID,
+				},
+				err: nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: false,
+				err:       nil,
+			},
+			wantErr: true,
+		},
+		{
+			name: "permission denied",
+			args: args{
+				id: productID,
+			},
+			userID: userID,
+			mockSelect: &mockSelect{
+				product: &model.Product{
+					ID:          productID,
+					Name:        "product-1",
+					Description: "product1",
+					Price:       17.17,
+					ThumbnailID: thumbnailID,
+					OwnerID:     utils.GenerateUUID(),
+				},
+				err: nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: false,
+				err:       nil,
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when find product",
+			args: args{
+				id: productID,
+			},
+			userID: userID,
+			mockSelect: &mockSelect{
+				product: nil,
+				err:     errors.New("db error"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when product not found",
+			args: args{
+				id: productID,
+			},
+			userID: userID,
+			mockSelect: &mockSelect{
+				product: nil,
+				err:     nil,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.TODO()
+			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, tt.userID)
+
+			uc := NewProductUsecase()
+			db, dbMock := utils.NewDBMock()
+			err := uc.InjectDB(db)
+			utils.ContinueOrFatal(err)
+			mockAuthClient := authMock.NewMockAuthServiceClient(ctrl)
+			err = uc.InjectAuthClient(mockAuthClient)
+			utils.ContinueOrFatal(err)
+			mockProductRepo := mock.NewMockProductRepository(ctrl)
+			err = uc.InjectProductRepo(mockProductRepo)
+			utils.ContinueOrFatal(err)
+			mockAuditLogRepo := mock.NewMockProductAuditLogRepository(ctrl)
+			err = uc.InjectProductAuditLogRepo(mockAuditLogRepo)
+			utils.ContinueOrFatal(err)
+			mockRevisionRepo := mock.NewMockProductRevisionRepository(ctrl)
+			err = uc.InjectProductRevisionRepo(mockRevisionRepo)
+			utils.ContinueOrFatal(err)
+
+			if tt.mockSelect != nil {
+				mockProductRepo.EXPECT().FindByID(gomock.Any(), tt.args.id).Times(1).Return(tt.mockSelect.product, tt.mockSelect.err)
+			}
+
+			if tt.mockAuth != nil {
+				mockAuthClient.EXPECT().HasAccess(gomock.Any(), gomock.Any()).Times(1).Return(&wrapperspb.BoolValue{
+					Value: tt.mockAuth.hasAccess,
+				}, tt.mockAuth.err)
+			}
+
+			if tt.mockModifyOther != nil {
+				mockAuthClient.EXPECT().HasAccess(gomock.Any(), gomock.Any()).Times(1).Return(&wrapperspb.BoolValue{
+					Value: tt.mockModifyOther.hasAccess,
+				}, tt.mockModifyOther.err)
+			}
+
+			if tt.mockDelete != nil {
+				dbMock.ExpectBegin()
+				mockProductRepo.EXPECT().DeleteByID(gomock.Any(), tt.args.id).Times(1).Return(tt.mockDelete.err)
+				if tt.mockDelete.err != nil {
+					dbMock.ExpectRollback()
+				} else {
+					mockAuditLogRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+					mockRevisionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+					dbMock.ExpectCommit()
+				}
+			}
+
+			if err := uc.Delete(ctx, tt.args.id); (err != nil) != tt.wantErr {
+				t.Errorf("productUsecase.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_productUsecase_Restore(t *testing.T) {
+	userID := utils.GenerateUUID()
+	productID := utils.GenerateUUID()
+	deletedAt := gorm.DeletedAt{
+		Time:  time.Now(),
+		Valid: true,
+	}
+
+	type args struct {
+		id string
+	}
+	type mockSelect struct {
+		product *model.Product
+		err     error
+	}
+	type mockRestore struct {
+		err error
+	}
+	type mockAuth struct {
+		hasAccess bool
+		err       error
+	}
+
+	tests := []struct {
+		name        string
+		args        args
+		userID      string
+		mockSelect  *mockSelect
+		mockRestore *mockRestore
+		mockAuth    *mockAuth
+		want        *model.Product
+		wantErr     bool
+	}{
+		{
+			name: "success",
+			args: args{
+				id: productID,
+			},
+			userID: userID,
+			mockSelect: &mockSelect{
+				product: &model.Product{
+					ID:        productID,
+					Name:      "product-1",
+					OwnerID:   userID,
+					DeletedAt: deletedAt,
+				},
+				err: nil,
+			},
+			mockRestore: &mockRestore{
+				err: nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			want: &model.Product{
+				ID:      productID,
+				Name:    "product-1",
+				OwnerID: userID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success when product is not deleted",
+			args: args{
+				id: productID,
+			},
+			userID: userID,
+			mockSelect: &mockSelect{
+				product: &model.Product{
+					ID:      productID,
+					Name:    "product-1",
+					OwnerID: userID,
+				},
+				err: nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			want: &model.Product{
+				ID:      productID,
+				Name:    "product-1",
+				OwnerID: userID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "error when restore product",
+			args: args{
+				id: productID,
+			},
+			userID: userID,
+			mockSelect: &mockSelect{
+				product: &model.Product{
+					ID:        productID,
+					Name:      "product-1",
+					OwnerID:   userID,
+					DeletedAt: deletedAt,
+				},
+				err: nil,
+			},
+			mockRestore: &mockRestore{
+				err: errors.New("db error"),
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "permission denied",
+			args: args{
+				id: productID,
+			},
+			userID: userID,
+			mockSelect: &mockSelect{
+				product: &model.Product{
+					ID:        productID,
+					Name:      "product-1",
+					OwnerID:   utils.GenerateUUID(),
+					DeletedAt: deletedAt,
+				},
+				err: nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: false,
+				err:       nil,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error when product not found",
+			args: args{
+				id: productID,
+			},
+			userID: userID,
+			mockSelect: &mockSelect{
+				product: nil,
+				err:     nil,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.TODO()
+			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, tt.userID)
+
+			uc := NewProductUsecase()
+			db, dbMock := utils.NewDBMock()
+			err := uc.InjectDB(db)
+			utils.ContinueOrFatal(err)
+			mockAuthClient := authMock.NewMockAuthServiceClient(ctrl)
+			err = uc.InjectAuthClient(mockAuthClient)
+			utils.ContinueOrFatal(err)
+			mockProductRepo := mock.NewMockProductRepository(ctrl)
+			err = uc.InjectProductRepo(mockProductRepo)
+			utils.ContinueOrFatal(err)
+			mockAuditLogRepo := mock.NewMockProductAuditLogRepository(ctrl)
+			err = uc.InjectProductAuditLogRepo(mockAuditLogRepo)
+			utils.ContinueOrFatal(err)
+			mockRevisionRepo := mock.NewMockProductRevisionRepository(ctrl)
+			err = uc.InjectProductRevisionRepo(mockRevisionRepo)
+			utils.ContinueOrFatal(err)
+
+			if tt.mockSelect != nil {
+				mockProductRepo.EXPECT().FindByID(gomock.Any(), tt.args.id).Times(1).Return(tt.mockSelect.product, tt.mockSelect.err)
+			}
+
+			if tt.mockAuth != nil {
+				mockAuthClient.EXPECT().HasAccess(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, req *authPB.HasAccessRequest, _ ...grpc.CallOption) (*wrapperspb.BoolValue, error) {
+					if req.Permissions[1] != constant.PermissionProductRestore {
+						t.Errorf("productUsecase.Restore() permissions = %v, want %v", req.Permissions, constant.PermissionProductRestore)
+					}
+					return &wrapperspb.BoolValue{
+						Value: tt.mockAuth.hasAccess,
+					}, tt.mockAuth.err
+				})
+			}
+
+			if tt.mockRestore != nil {
+				dbMock.ExpectBegin()
+				mockProductRepo.EXPECT().RestoreByID(gomock.Any(), tt.args.id).Times(1).Return(tt.mockRestore.err)
+				if tt.mockRestore.err != nil {
+					dbMock.ExpectRollback()
+				} else {
+					mockAuditLogRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+					mockRevisionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+					dbMock.ExpectCommit()
+				}
+			}
+
+			got, err := uc.Restore(ctx, tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productUsecase.Restore() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("productUsecase.Restore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_productUsecase_FindPaginatedIDs(t *testing.T) {
+	userID := utils.GenerateUUID()
+	productID := utils.GenerateUUID()
+
+	type args struct {
+		datasource string
+		req        *model.PaginationPayload
+	}
+	type mockFindPaginatedIDs struct {
+		ids   []string
+		count int64
+		err   error
+	}
+	type mockFindSearchPaginatedIDs struct {
+		ids        []string
+		highlights []*model.ProductHighlight
+		count      int64
+		err        error
+	}
+	type mockFindListingPaginatedIDs struct {
+		ids        []string
+		count      int64
+		nextCursor string
+		err        error
+	}
+	type mockAuth struct {
+		hasAccess bool
+		err       error
+	}
+	tests := []struct {
+		name                        string
+		args                        args
+		userID                      string
+		anonymousRead               bool
+		mockFindPaginatedIDs        *mockFindPaginatedIDs
+		mockFindSearchPaginatedIDs  *mockFindSearchPaginatedIDs
+		mockFindListingPaginatedIDs *mockFindListingPaginatedIDs
+		mockAuth                    *mockAuth
+		mockReadDeletedAuth         *mockAuth
+		mockDataSourceAuth          *mockAuth
+		want                        *model.PaginationResponse
+		wantErr                     bool
+	}{
+		{
+			name: "success",
+			args: args{
+				datasource: constant.DataSourceDB,
+				req: &model.PaginationPayload{
+					Search: "",
+					Sort:   []string{},
+					Limit:  10,
+					Page:   1,
+				},
+			},
+			userID: userID,
+			mockFindPaginatedIDs: &mockFindPaginatedIDs{
+				ids:   []string{productID},
+				count: 1,
+				err:   nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockDataSourceAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			want: &model.PaginationResponse{
+				Meta: &model.PaginationPayload{
+					Search: "",
+					Sort:   []string{},
+					Limit:  10,
+					Page:   1,
+				},
+				Count:      1,
+				MaxPage:    1,
+				Items:      []string{productID},
+				DataSource: constant.DataSourceDB,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success from search",
+			args: args{
+				req: &model.PaginationPayload{
+					Search: "product",
+					Sort:   []string{},
+					Limit:  10,
+					Page:   1,
+				},
+			},
+			userID: userID,
+			mockFindSearchPaginatedIDs: &mockFindSearchPaginatedIDs{
+				ids:   []string{productID},
+				count: 1,
+				err:   nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			want: &model.PaginationResponse{
+				Meta: &model.PaginationPayload{
+					Search: "product",
+					Sort:   []string{},
+					Limit:  10,
+					Page:   1,
+				},
+				Count:      1,
+				MaxPage:    1,
+				Items:      []string{productID},
+				DataSource: constant.DataSourceOpensearch,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success fallback to database when search error",
+			args: args{
+				req: &model.PaginationPayload{
+					Search: "product",
+					Sort:   []string{},
+					Limit:  10,
+					Page:   1,
+				},
+			},
+			userID: userID,
+			mockFindSearchPaginatedIDs: &mockFindSearchPaginatedIDs{
+				err: errors.New("opensearch error"),
+			},
+			mockFindPaginatedIDs: &mockFindPaginatedIDs{
+				ids:   []string{productID},
+				count: 1,
+				err:   nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			want: &model.PaginationResponse{
+				Meta: &model.PaginationPayload{
+					Search: "product",
+					Sort:   []string{},
+					Limit:  10,
+					Page:   1,
+				},
+				Count:      1,
+				MaxPage:    1,
+				Items:      []string{productID},
+				DataSource: constant.DataSourceDB,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success from redis listing",
+			args: args{
+				datasource: constant.DataSourceRedis,
+				req: &model.PaginationPayload{
+					Sort:  []string{"+price"},
+					Limit: 1,
+					Page:  1,
+				},
+			},
+			userID: userID,
+			mockFindListingPaginatedIDs: &mockFindListingPaginatedIDs{
+				ids:        []string{productID},
+				count:      2,
+				nextCursor: "next-cursor",
+				err:        nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockDataSourceAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			want: &model.PaginationResponse{
+				Meta: &model.PaginationPayload{
+					Sort:  []string{"+price"},
+					Limit: 1,
+					Page:  1,
+				},
+				Count:      2,
+				MaxPage:    2,
+				Items:      []string{productID},
+				DataSource: constant.DataSourceRedis,
+				NextCursor: "next-cursor",
+			},
+			wantErr: false,
+		},
+		{
+			name: "success redis fallback to database when search",
+			args: args{
+				datasource: constant.DataSourceRedis,
+				req: &model.PaginationPayload{
+					Search: "product",
+					Sort:   []string{},
+					Limit:  10,
+					Page:   1,
+				},
+			},
+			userID: userID,
+			mockFindPaginatedIDs: &mockFindPaginatedIDs{
+				ids:   []string{productID},
+				count: 1,
+				err:   nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockDataSourceAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			want: &model.PaginationResponse{
+				Meta: &model.PaginationPayload{
+					Search: "product",
+					Sort:   []string{},
+					Limit:  10,
+					Page:   1,
+				},
+				Count:      1,
+				MaxPage:    1,
+				Items:      []string{productID},
+				DataSource: constant.DataSourceDB,
+			},
+			wantErr: false,
+		},
+		{
+			name: "error when chosen search error",
+			args: args{
+				datasource: constant.DataSourceOpensearch,
+				req: &model.PaginationPayload{
+					Search: "product",
+					Sort:   []string{},
+					Limit:  10,
+					Page:   1,
+				},
+			},
+			userID: userID,
+			mockFindSearchPaginatedIDs: &mockFindSearchPaginatedIDs{
+				err: errors.New("opensearch error"),
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockDataSourceAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error when get data",
+			args: args{
+				datasource: constant.DataSourceDB,
+				req: &model.PaginationPayload{
+					Search: "",
+					Sort:   []string{},
+					Limit:  10,
+					Page:   1,
+				},
+			},
+			userID: userID,
+			mockFindPaginatedIDs: &mockFindPaginatedIDs{
+				ids:   []string{},
+				count: 0,
+				err:   errors.New("db error"),
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockDataSourceAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "invalid data source",
+			args: args{
+				datasource: "memcached",
+				req: &model.PaginationPayload{
+					Search: "",
+					Sort:   []string{},
+					Limit:  10,
+					Page:   1,
+				},
+			},
+			userID: userID,
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "permission denied",
+			args: args{
+				datasource: constant.DataSourceDB,
+				req: &model.PaginationPayload{
+					Search: "",
+					Sort:   []string{},
+					Limit:  10,
+					Page:   1,
+				},
+			},
+			userID: userID,
+			mockAuth: &mockAuth{
+				hasAccess: false,
+				err:       nil,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "data source permission denied",
+			args: args{
+				datasource: constant.DataSourceDB,
+				req: &model.PaginationPayload{
+					Search: "",
+					Sort:   []string{},
+					Limit:  10,
+					Page:   1,
+				},
+			},
+			userID: userID,
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockDataSourceAuth: &mockAuth{
+				hasAccess: false,
+				err:       nil,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "success owned or shared",
+			args: args{
+				req: &model.PaginationPayload{
+					Sort:          []string{},
+					Limit:         10,
+					Page:          1,
+					OwnedOrShared: true,
+				},
+			},
+			userID: userID,
+			mockFindSearchPaginatedIDs: &mockFindSearchPaginatedIDs{
+				ids:   []string{productID},
+				count: 1,
+				err:   nil,
+			},
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			want: &model.PaginationResponse{
+				Meta: &model.PaginationPayload{
+					Sort:          []string{},
+					Limit:         10,
+					Page:          1,
+					OwnedOrShared: true,
+					UserID:        userID,
+				},
+				Count:      1,
+				MaxPage:    1,
+				Items:      []string{productID},
+				DataSource: constant.DataSourceOpensearch,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success anonymous read",
+			args: args{
+				req: &model.PaginationPayload{
+					Search: "product",
+					Sort:   []string{},
+					Limit:  10,
+					Page:   1,
+				},
+			},
+			userID:        constant.GuestID,
+			anonymousRead: true,
+			mockFindSearchPaginatedIDs: &mockFindSearchPaginatedIDs{
+				ids:   []string{productID},
+				count: 1,
+				err:   nil,
+			},
+			want: &model.PaginationResponse{
+				Meta: &model.PaginationPayload{
+					Search: "product",
+					Sort:   []string{},
+					Limit:  10,
+					Page:   1,
+				},
+				Count:      1,
+				MaxPage:    1,
+				Items:      []string{productID},
+				DataSource: constant.DataSourceOpensearch,
+			},
+			wantErr: false,
+		},
+		{
+			name: "include deleted permission denied",
+			args: args{
+				req: &model.PaginationPayload{
+					Search:         "",
+					Sort:           []string{},
+					Limit:          10,
+					Page:           1,
+					IncludeDeleted: true,
+				},
+			},
+			userID: userID,
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
+			mockReadDeletedAuth: &mockAuth{
+				hasAccess: false,
+				err:       nil,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "anonymous include deleted permission denied",
+			args: args{
+				req: &model.PaginationPayload{
+					Search:         "",
+					Sort:           []string{},
+					Limit:          10,
+					Page:           1,
+					IncludeDeleted: true,
+				},
+			},
+			userID:        constant.GuestID,
+			anonymousRead: true,
+			mockReadDeletedAuth: &mockAuth{
+				hasAccess: false,
+				err:       nil,
+			},
 			want:    nil,
 			wantErr: true,
 		},
 		{
-			name: "error when find product",
+			name: "invalid payload",
 			args: args{
-				payload: &model.UpdateProductPayload{
-					ID:          productID,
-					Name:        "updated product",
-					Description: "updated product",
-					Price:       10.10,
-					ThumbnailID: thumbnailID,
+				datasource: constant.DataSourceDB,
+				req: &model.PaginationPayload{
+					Search: "",
+					Sort:   []string{},
+					Limit:  10,
+					Page:   -1,
 				},
 			},
 			userID: userID,
-			mockSelect: &mockSelect{
-				product: nil,
-				err:     errors.New("db error"),
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
 			},
 			want:    nil,
 			wantErr: true,
 		},
 		{
-			name: "permission denied",
+			name: "invalid payload of an unauthorized caller",
 			args: args{
-				payload: &model.UpdateProductPayload{
-					ID:          productID,
-					Name:        "updated product",
-					Description: "updated product",
-					Price:       10.10,
-					ThumbnailID: thumbnailID,
+				datasource: constant.DataSourceDB,
+				req: &model.PaginationPayload{
+					Search: "",
+					Sort:   []string{},
+					Limit:  10,
+					Page:   -1,
 				},
 			},
 			userID: userID,
-			mockSelect: &mockSelect{
-				product: &model.Product{
-					ID:          productID,
-					Name:        "product-1",
-					Description: "product1",
-					Price:       17.17,
-					ThumbnailID: thumbnailID,
-					OwnerID:     utils.GenerateUUID(),
-				},
-				err: nil,
-			},
 			mockAuth: &mockAuth{
 				hasAccess: false,
 				err:       nil,
@@ -488,8 +2321,14 @@ func Test_productUsecase_Update(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
+			viper.Set("auth.anonymous_read", tt.anonymousRead)
+			defer viper.Set("auth.anonymous_read", false)
+
 			ctx := context.TODO()
 			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, tt.userID)
+			if tt.args.datasource != "" {
+				ctx = context.WithValue(ctx, constant.KeyDataSource, tt.args.datasource)
+			}
 
 			uc := NewProductUsecase()
 			db, _ := utils.NewDBMock()
@@ -498,76 +2337,76 @@ func Test_productUsecase_Update(t *testing.T) {
 			mockAuthClient := authMock.NewMockAuthServiceClient(ctrl)
 			err = uc.InjectAuthClient(mockAuthClient)
 			utils.ContinueOrFatal(err)
-			mockStorageClient := storageMock.NewMockStorageServiceClient(ctrl)
-			err = uc.InjectStorageClient(mockStorageClient)
-			utils.ContinueOrFatal(err)
 			mockProductRepo := mock.NewMockProductRepository(ctrl)
 			err = uc.InjectProductRepo(mockProductRepo)
 			utils.ContinueOrFatal(err)
 
 			if tt.mockAuth != nil {
-				mockAuthClient.EXPECT().
-					HasAccess(gomock.Any(), gomock.Any()).
-					Times(1).Return(&wrapperspb.BoolValue{
+				mockAuthClient.EXPECT().HasAccess(gomock.Any(), gomock.Any()).Times(1).Return(&wrapperspb.BoolValue{
 					Value: tt.mockAuth.hasAccess,
 				}, tt.mockAuth.err)
 			}
 
-			if tt.mockSelect != nil {
-				mockProductRepo.EXPECT().FindByID(gomock.Any(), tt.args.payload.ID).Times(1).Return(tt.mockSelect.product, tt.mockSelect.err)
+			if tt.mockReadDeletedAuth != nil {
+				mockAuthClient.EXPECT().HasAccess(gomock.Any(), gomock.Any()).Times(1).Return(&wrapperspb.BoolValue{
+					Value: tt.mockReadDeletedAuth.hasAccess,
+				}, tt.mockReadDeletedAuth.err)
 			}
 
-			if tt.mockGetObjectByID != nil {
-				mockStorageClient.EXPECT().GetObjectByID(gomock.Any(), &storagePB.GetObjectByIDRequest{
-					UserId:   tt.userID,
-					ObjectId: tt.args.payload.ThumbnailID,
-				}).Times(1).Return(tt.mockGetObjectByID.res, tt.mockGetObjectByID.err)
+			if tt.mockDataSourceAuth != nil {
+				mockAuthClient.EXPECT().HasAccess(gomock.Any(), gomock.Any()).Times(1).Return(&wrapperspb.BoolValue{
+					Value: tt.mockDataSourceAuth.hasAccess,
+				}, tt.mockDataSourceAuth.err)
 			}
 
-			if tt.mockUpdate != nil {
-				mockProductRepo.EXPECT().Update(gomock.Any(), tt.mockUpdate.product).Times(1).Return(tt.mockUpdate.err)
+			if tt.mockFindSearchPaginatedIDs != nil {
+				mockProductRepo.EXPECT().FindSearchPaginatedIDs(gomock.Any(), tt.args.req).Times(1).Return(tt.mockFindSearchPaginatedIDs.ids, tt.mockFindSearchPaginatedIDs.highlights, tt.mockFindSearchPaginatedIDs.count, tt.mockFindSearchPaginatedIDs.err)
 			}
 
-			got, err := uc.Update(ctx, tt.args.payload)
+			if tt.mockFindPaginatedIDs != nil {
+				mockProductRepo.EXPECT().FindPaginatedIDs(gomock.Any(), tt.args.req).Times(1).Return(tt.mockFindPaginatedIDs.ids, tt.mockFindPaginatedIDs.count, tt.mockFindPaginatedIDs.err)
+			}
+
+			if tt.mockFindListingPaginatedIDs != nil {
+				mockProductRepo.EXPECT().FindListingPaginatedIDs(gomock.Any(), tt.args.req, gomock.Any()).Times(1).Return(tt.mockFindListingPaginatedIDs.ids, tt.mockFindListingPaginatedIDs.count, tt.mockFindListingPaginatedIDs.nextCursor, tt.mockFindListingPaginatedIDs.err)
+			}
+
+			got, err := uc.FindPaginatedIDs(ctx, tt.args.req)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("productUsecase.Update() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("productUsecase.FindPaginatedIDs() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("productUsecase.Update() = %v, want %v", got, tt.want)
+				t.Errorf("productUsecase.FindPaginatedIDs() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
-func Test_productUsecase_Delete(t *testing.T) {
+func Test_productUsecase_FindByID(t *testing.T) {
 	userID := utils.GenerateUUID()
 	productID := utils.GenerateUUID()
 	thumbnailID := utils.GenerateUUID()
-
 	type args struct {
 		id string
 	}
-	type mockSelect struct {
+	type mockFindByID struct {
 		product *model.Product
 		err     error
 	}
-	type mockDelete struct {
-		err error
-	}
 	type mockAuth struct {
 		hasAccess bool
 		err       error
 	}
-
 	tests := []struct {
-		name       string
-		args       args
-		userID     string
-		mockSelect *mockSelect
-		mockDelete *mockDelete
-		mockAuth   *mockAuth
-		wantErr    bool
+		name          string
+		args          args
+		userID        string
+		anonymousRead bool
+		mockFindByID  *mockFindByID
+		mockAuth      *mockAuth
+		want          *model.Product
+		wantErr       bool
 	}{
 		{
 			name: "success",
@@ -575,10 +2414,10 @@ func Test_productUsecase_Delete(t *testing.T) {
 				id: productID,
 			},
 			userID: userID,
-			mockSelect: &mockSelect{
+			mockFindByID: &mockFindByID{
 				product: &model.Product{
 					ID:          productID,
-					Name:        "product-1",
+					Name:        "product1",
 					Description: "product1",
 					Price:       17.17,
 					ThumbnailID: thumbnailID,
@@ -586,39 +2425,35 @@ func Test_productUsecase_Delete(t *testing.T) {
 				},
 				err: nil,
 			},
-			mockDelete: &mockDelete{
-				err: nil,
-			},
 			mockAuth: &mockAuth{
 				hasAccess: true,
 				err:       nil,
 			},
+			want: &model.Product{
+				ID:          productID,
+				Name:        "product1",
+				Description: "product1",
+				Price:       17.17,
+				ThumbnailID: thumbnailID,
+				OwnerID:     userID,
+			},
 			wantErr: false,
 		},
 		{
-			name: "error when delete product",
+			name: "error when get data",
 			args: args{
 				id: productID,
 			},
 			userID: userID,
-			mockSelect: &mockSelect{
-				product: &model.Product{
-					ID:          productID,
-					Name:        "product-1",
-					Description: "product1",
-					Price:       17.17,
-					ThumbnailID: thumbnailID,
-					OwnerID:     userID,
-				},
-				err: nil,
-			},
-			mockDelete: &mockDelete{
-				err: errors.New("db error"),
+			mockFindByID: &mockFindByID{
+				product: nil,
+				err:     errors.New("db error"),
 			},
 			mockAuth: &mockAuth{
 				hasAccess: true,
 				err:       nil,
 			},
+			want:    nil,
 			wantErr: true,
 		},
 		{
@@ -627,45 +2462,68 @@ func Test_productUsecase_Delete(t *testing.T) {
 				id: productID,
 			},
 			userID: userID,
-			mockSelect: &mockSelect{
-				product: &model.Product{
-					ID:          productID,
-					Name:        "product-1",
-					Description: "product1",
-					Price:       17.17,
-					ThumbnailID: thumbnailID,
-					OwnerID:     utils.GenerateUUID(),
-				},
-				err: nil,
-			},
 			mockAuth: &mockAuth{
 				hasAccess: false,
 				err:       nil,
 			},
+			want:    nil,
 			wantErr: true,
 		},
 		{
-			name: "error when find product",
+			name: "success anonymous read",
 			args: args{
 				id: productID,
 			},
-			userID: userID,
-			mockSelect: &mockSelect{
-				product: nil,
-				err:     errors.New("db error"),
+			userID:        constant.GuestID,
+			anonymousRead: true,
+			mockFindByID: &mockFindByID{
+				product: &model.Product{
+					ID:      productID,
+					Name:    "product1",
+					OwnerID: userID,
+				},
+				err: nil,
+			},
+			want: &model.Product{
+				ID:      productID,
+				Name:    "product1",
+				OwnerID: userID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "anonymous read deleted product",
+			args: args{
+				id: productID,
+			},
+			userID:        constant.GuestID,
+			anonymousRead: true,
+			mockFindByID: &mockFindByID{
+				product: &model.Product{
+					ID:      productID,
+					Name:    "product1",
+					OwnerID: userID,
+					DeletedAt: gorm.DeletedAt{
+						Time:  time.Now(),
+						Valid: true,
+					},
+				},
+				err: nil,
 			},
+			want:    nil,
 			wantErr: true,
 		},
 		{
-			name: "error when product not found",
+			name: "guest permission denied without anonymous read",
 			args: args{
 				id: productID,
 			},
-			userID: userID,
-			mockSelect: &mockSelect{
-				product: nil,
-				err:     nil,
+			userID: constant.GuestID,
+			mockAuth: &mockAuth{
+				hasAccess: false,
+				err:       nil,
 			},
+			want:    nil,
 			wantErr: true,
 		},
 	}
@@ -674,6 +2532,9 @@ func Test_productUsecase_Delete(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
+			viper.Set("auth.anonymous_read", tt.anonymousRead)
+			defer viper.Set("auth.anonymous_read", false)
+
 			ctx := context.TODO()
 			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, tt.userID)
 
@@ -688,127 +2549,113 @@ func Test_productUsecase_Delete(t *testing.T) {
 			err = uc.InjectProductRepo(mockProductRepo)
 			utils.ContinueOrFatal(err)
 
-			if tt.mockSelect != nil {
-				mockProductRepo.EXPECT().FindByID(gomock.Any(), tt.args.id).Times(1).Return(tt.mockSelect.product, tt.mockSelect.err)
-				if tt.mockAuth != nil && tt.mockSelect.product.OwnerID != tt.userID {
-					mockAuthClient.EXPECT().HasAccess(gomock.Any(), gomock.Any()).Times(1).Return(&wrapperspb.BoolValue{
-						Value: tt.mockAuth.hasAccess,
-					}, tt.mockAuth.err)
-				}
+			if tt.mockAuth != nil {
+				mockAuthClient.EXPECT().HasAccess(gomock.Any(), gomock.Any()).Times(1).Return(&wrapperspb.BoolValue{
+					Value: tt.mockAuth.hasAccess,
+				}, tt.mockAuth.err)
 			}
 
-			if tt.mockDelete != nil {
-				mockProductRepo.EXPECT().DeleteByID(gomock.Any(), tt.args.id).Times(1).Return(tt.mockDelete.err)
+			if tt.mockFindByID != nil {
+				mockProductRepo.EXPECT().FindByID(gomock.Any(), tt.args.id).Times(1).Return(tt.mockFindByID.product, tt.mockFindByID.err)
 			}
 
-			if err := uc.Delete(ctx, tt.args.id); (err != nil) != tt.wantErr {
-				t.Errorf("productUsecase.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			got, err := uc.FindByID(ctx, tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("productUsecase.FindByID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("productUsecase.FindByID() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
-func Test_productUsecase_FindPaginatedIDs(t *testing.T) {
+func Test_productUsecase_FindByIDs(t *testing.T) {
 	userID := utils.GenerateUUID()
 	productID := utils.GenerateUUID()
-
+	thumbnailID := utils.GenerateUUID()
 	type args struct {
-		datasource int
-		req        *model.PaginationPayload
+		ids []string
 	}
-	type mockFindPaginatedIDs struct {
-		ids   []string
-		count int64
-		err   error
+	type mockFindByID struct {
+		product *model.Product
+		err     error
 	}
 	type mockAuth struct {
 		hasAccess bool
 		err       error
 	}
 	tests := []struct {
-		name                 string
-		args                 args
-		userID               string
-		mockFindPaginatedIDs *mockFindPaginatedIDs
-		mockAuth             *mockAuth
-		want                 *model.PaginationResponse
-		wantErr              bool
+		name         string
+		args         args
+		userID       string
+		mockFindByID *mockFindByID
+		mockAuth     *mockAuth
+		want         model.Products
+		wantErr      bool
 	}{
 		{
 			name: "success",
 			args: args{
-				datasource: constant.SourceDB,
-				req: &model.PaginationPayload{
-					Search: "",
-					Sort:   []string{},
-					Limit:  10,
-					Page:   1,
-				},
+				ids: []string{productID},
 			},
 			userID: userID,
-			mockFindPaginatedIDs: &mockFindPaginatedIDs{
-				ids:   []string{productID},
-				count: 1,
-				err:   nil,
+			mockFindByID: &mockFindByID{
+				product: &model.Product{
+					ID:          productID,
+					Name:        "product1",
+					Description: "product1",
+					Price:       17.17,
+					ThumbnailID: thumbnailID,
+					OwnerID:     userID,
+				},
+				err: nil,
 			},
 			mockAuth: &mockAuth{
 				hasAccess: true,
 				err:       nil,
 			},
-			want: &model.PaginationResponse{
-				Meta: &model.PaginationPayload{
-					Search: "",
-					Sort:   []string{},
-					Limit:  10,
-					Page:   1,
+			want: model.Products{
+				{
+					ID:          productID,
+					Name:        "product1",
+					Description: "product1",
+					Price:       17.17,
+					ThumbnailID: thumbnailID,
+					OwnerID:     userID,
 				},
-				Count:   1,
-				MaxPage: 1,
-				Items:   []string{productID},
 			},
 			wantErr: false,
 		},
 		{
-			name: "error when get data",
+			name: "ignore error if data not found",
 			args: args{
-				datasource: constant.SourceDB,
-				req: &model.PaginationPayload{
-					Search: "",
-					Sort:   []string{},
-					Limit:  10,
-					Page:   1,
-				},
+				ids: []string{productID},
 			},
-			userID: userID,
-			mockFindPaginatedIDs: &mockFindPaginatedIDs{
-				ids:   []string{},
-				count: 0,
-				err:   errors.New("db error"),
+			userID: userID,
+			mockFindByID: &mockFindByID{
+				product: nil,
+				err:     errors.New("db error"),
 			},
 			mockAuth: &mockAuth{
 				hasAccess: true,
 				err:       nil,
 			},
-			want:    nil,
-			wantErr: true,
+			want:    model.Products{},
+			wantErr: false,
 		},
 		{
 			name: "permission denied",
 			args: args{
-				datasource: constant.SourceDB,
-				req: &model.PaginationPayload{
-					Search: "",
-					Sort:   []string{},
-					Limit:  10,
-					Page:   1,
-				},
+				ids: []string{productID},
 			},
 			userID: userID,
 			mockAuth: &mockAuth{
 				hasAccess: false,
 				err:       nil,
 			},
-			want:    nil,
+			want:    model.Products{},
 			wantErr: true,
 		},
 	}
@@ -819,7 +2666,6 @@ func Test_productUsecase_FindPaginatedIDs(t *testing.T) {
 
 			ctx := context.TODO()
 			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, tt.userID)
-			ctx = context.WithValue(ctx, constant.KeyDataSource, tt.args.datasource)
 
 			uc := NewProductUsecase()
 			db, _ := utils.NewDBMock()
@@ -832,104 +2678,162 @@ func Test_productUsecase_FindPaginatedIDs(t *testing.T) {
 			err = uc.InjectProductRepo(mockProductRepo)
 			utils.ContinueOrFatal(err)
 
+			wg := sync.WaitGroup{}
 			if tt.mockAuth != nil {
 				mockAuthClient.EXPECT().HasAccess(gomock.Any(), gomock.Any()).Times(1).Return(&wrapperspb.BoolValue{
 					Value: tt.mockAuth.hasAccess,
 				}, tt.mockAuth.err)
 			}
 
-			if tt.mockFindPaginatedIDs != nil {
-				mockProductRepo.EXPECT().FindPaginatedIDs(gomock.Any(), tt.args.req).Times(1).Return(tt.mockFindPaginatedIDs.ids, tt.mockFindPaginatedIDs.count, tt.mockFindPaginatedIDs.err)
+			if tt.mockFindByID != nil {
+				wg.Add(1)
+				mockProductRepo.EXPECT().FindByID(gomock.Any(), tt.args.ids[0]).Times(1).DoAndReturn(func(_ context.Context, id string) (*model.Product, error) {
+					defer wg.Done()
+					return tt.mockFindByID.product, tt.mockFindByID.err
+				})
 			}
 
-			got, err := uc.FindPaginatedIDs(ctx, tt.args.req)
+			got, err := uc.FindByIDs(ctx, tt.args.ids)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("productUsecase.FindPaginatedIDs() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("productUsecase.FindByIDs() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("productUsecase.FindPaginatedIDs() = %v, want %v", got, tt.want)
+				t.Errorf("productUsecase.FindByIDs() = %v, want %v", got, tt.want)
 			}
+			wg.Wait()
 		})
 	}
 }
 
-func Test_productUsecase_FindByID(t *testing.T) {
+func Test_productUsecase_FindRelated(t *testing.T) {
 	userID := utils.GenerateUUID()
 	productID := utils.GenerateUUID()
-	thumbnailID := utils.GenerateUUID()
+	relatedID := utils.GenerateUUID()
 	type args struct {
-		id string
+		req *model.RelatedPayload
 	}
 	type mockFindByID struct {
 		product *model.Product
 		err     error
 	}
+	type mockFindRelatedIDs struct {
+		ids []string
+		err error
+	}
 	type mockAuth struct {
 		hasAccess bool
 		err       error
 	}
 	tests := []struct {
-		name         string
-		args         args
-		userID       string
-		mockFindByID *mockFindByID
-		mockAuth     *mockAuth
-		want         *model.Product
-		wantErr      bool
+		name               string
+		args               args
+		userID             string
+		mockAuth           *mockAuth
+		mockFindByID       *mockFindByID
+		mockFindRelatedIDs *mockFindRelatedIDs
+		want               []string
+		wantErr            bool
 	}{
 		{
 			name: "success",
 			args: args{
-				id: productID,
+				req: &model.RelatedPayload{
+					ID:        productID,
+					SameOwner: true,
+				},
 			},
 			userID: userID,
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
 			mockFindByID: &mockFindByID{
 				product: &model.Product{
-					ID:          productID,
-					Name:        "product1",
-					Description: "product1",
-					Price:       17.17,
-					ThumbnailID: thumbnailID,
-					OwnerID:     userID,
+					ID:      productID,
+					OwnerID: userID,
 				},
 				err: nil,
 			},
+			mockFindRelatedIDs: &mockFindRelatedIDs{
+				ids: []string{relatedID},
+				err: nil,
+			},
+			want:    []string{relatedID},
+			wantErr: false,
+		},
+		{
+			name: "product not found",
+			args: args{
+				req: &model.RelatedPayload{
+					ID: productID,
+				},
+			},
+			userID: userID,
 			mockAuth: &mockAuth{
 				hasAccess: true,
 				err:       nil,
 			},
-			want: &model.Product{
-				ID:          productID,
-				Name:        "product1",
-				Description: "product1",
-				Price:       17.17,
-				ThumbnailID: thumbnailID,
-				OwnerID:     userID,
+			mockFindByID: &mockFindByID{
+				product: nil,
+				err:     nil,
 			},
-			wantErr: false,
+			want:    nil,
+			wantErr: true,
 		},
 		{
-			name: "error when get data",
+			name: "deleted product",
 			args: args{
-				id: productID,
+				req: &model.RelatedPayload{
+					ID: productID,
+				},
 			},
 			userID: userID,
+			mockAuth: &mockAuth{
+				hasAccess: true,
+				err:       nil,
+			},
 			mockFindByID: &mockFindByID{
-				product: nil,
-				err:     errors.New("db error"),
+				product: &model.Product{
+					ID:        productID,
+					DeletedAt: gorm.DeletedAt{Valid: true},
+				},
+				err: nil,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error when get related data",
+			args: args{
+				req: &model.RelatedPayload{
+					ID: productID,
+				},
 			},
+			userID: userID,
 			mockAuth: &mockAuth{
 				hasAccess: true,
 				err:       nil,
 			},
+			mockFindByID: &mockFindByID{
+				product: &model.Product{
+					ID: productID,
+				},
+				err: nil,
+			},
+			mockFindRelatedIDs: &mockFindRelatedIDs{
+				ids: nil,
+				err: errors.New("opensearch error"),
+			},
 			want:    nil,
 			wantErr: true,
 		},
 		{
 			name: "permission denied",
 			args: args{
-				id: productID,
+				req: &model.RelatedPayload{
+					ID: productID,
+				},
 			},
 			userID: userID,
 			mockAuth: &mockAuth{
@@ -949,11 +2853,8 @@ func Test_productUsecase_FindByID(t *testing.T) {
 			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, tt.userID)
 
 			uc := NewProductUsecase()
-			db, _ := utils.NewDBMock()
-			err := uc.InjectDB(db)
-			utils.ContinueOrFatal(err)
 			mockAuthClient := authMock.NewMockAuthServiceClient(ctrl)
-			err = uc.InjectAuthClient(mockAuthClient)
+			err := uc.InjectAuthClient(mockAuthClient)
 			utils.ContinueOrFatal(err)
 			mockProductRepo := mock.NewMockProductRepository(ctrl)
 			err = uc.InjectProductRepo(mockProductRepo)
@@ -966,107 +2867,121 @@ func Test_productUsecase_FindByID(t *testing.T) {
 			}
 
 			if tt.mockFindByID != nil {
-				mockProductRepo.EXPECT().FindByID(gomock.Any(), tt.args.id).Times(1).Return(tt.mockFindByID.product, tt.mockFindByID.err)
+				mockProductRepo.EXPECT().FindByID(gomock.Any(), tt.args.req.ID).Times(1).Return(tt.mockFindByID.product, tt.mockFindByID.err)
 			}
 
-			got, err := uc.FindByID(ctx, tt.args.id)
+			if tt.mockFindRelatedIDs != nil {
+				mockProductRepo.EXPECT().FindRelatedIDs(gomock.Any(), tt.mockFindByID.product, tt.args.req).Times(1).Return(tt.mockFindRelatedIDs.ids, tt.mockFindRelatedIDs.err)
+			}
+
+			got, err := uc.FindRelated(ctx, tt.args.req)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("productUsecase.FindByID() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("productUsecase.FindRelated() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("productUsecase.FindByID() = %v, want %v", got, tt.want)
+				t.Errorf("productUsecase.FindRelated() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
-func Test_productUsecase_FindByIDs(t *testing.T) {
+func Test_productUsecase_hasPermission(t *testing.T) {
 	userID := utils.GenerateUUID()
-	productID := utils.GenerateUUID()
-	thumbnailID := utils.GenerateUUID()
-	type args struct {
-		ids []string
+	permissions := []string{
+		constant.PermissionProductAll,
+		constant.PermissionProductRead,
 	}
-	type mockFindByID struct {
-		product *model.Product
+
+	type mockCacheGet struct {
+		allowed bool
+		found   bool
 		err     error
 	}
+	type mockCacheSet struct {
+		allowed bool
+	}
 	type mockAuth struct {
 		hasAccess bool
 		err       error
 	}
+
 	tests := []struct {
 		name         string
-		args         args
-		userID       string
-		mockFindByID *mockFindByID
+		mockCacheGet *mockCacheGet
 		mockAuth     *mockAuth
-		want         model.Products
+		mockCacheSet *mockCacheSet
 		wantErr      bool
+		wantErrIs    error
 	}{
 		{
-			name: "success",
-			args: args{
-				ids: []string{productID},
+			name: "allowed from cache",
+			mockCacheGet: &mockCacheGet{
+				allowed: true,
+				found:   true,
 			},
-			userID: userID,
-			mockFindByID: &mockFindByID{
-				product: &model.Product{
-					ID:          productID,
-					Name:        "product1",
-					Description: "product1",
-					Price:       17.17,
-					ThumbnailID: thumbnailID,
-					OwnerID:     userID,
-				},
-				err: nil,
+			wantErr: false,
+		},
+		{
+			name: "denied from cache",
+			mockCacheGet: &mockCacheGet{
+				allowed: false,
+				found:   true,
 			},
+			wantErr: true,
+		},
+		{
+			name:         "allowed from auth service",
+			mockCacheGet: &mockCacheGet{},
 			mockAuth: &mockAuth{
 				hasAccess: true,
-				err:       nil,
 			},
-			want: model.Products{
-				{
-					ID:          productID,
-					Name:        "product1",
-					Description: "product1",
-					Price:       17.17,
-					ThumbnailID: thumbnailID,
-					OwnerID:     userID,
-				},
+			mockCacheSet: &mockCacheSet{
+				allowed: true,
 			},
 			wantErr: false,
 		},
 		{
-			name: "ignore error if data not found",
-			args: args{
-				ids: []string{productID},
+			name:         "denied from auth service",
+			mockCacheGet: &mockCacheGet{},
+			mockAuth: &mockAuth{
+				hasAccess: false,
 			},
-			userID: userID,
-			mockFindByID: &mockFindByID{
-				product: nil,
-				err:     errors.New("db error"),
+			mockCacheSet: &mockCacheSet{
+				allowed: false,
+			},
+			wantErr: true,
+		},
+		{
+			name: "cache error fallback to auth service",
+			mockCacheGet: &mockCacheGet{
+				err: errors.New("redis error"),
 			},
 			mockAuth: &mockAuth{
 				hasAccess: true,
-				err:       nil,
 			},
-			want:    model.Products{},
+			mockCacheSet: &mockCacheSet{
+				allowed: true,
+			},
 			wantErr: false,
 		},
 		{
-			name: "permission denied",
-			args: args{
-				ids: []string{productID},
+			name:         "auth service error is not cached",
+			mockCacheGet: &mockCacheGet{},
+			mockAuth: &mockAuth{
+				err: errors.New("auth error"),
 			},
-			userID: userID,
+			wantErr:   true,
+			wantErrIs: model.ErrUnauthorizedAccess,
+		},
+		{
+			name:         "auth service unavailable",
+			mockCacheGet: &mockCacheGet{},
 			mockAuth: &mockAuth{
-				hasAccess: false,
-				err:       nil,
+				err: status.Error(codes.Unavailable, "connection refused"),
 			},
-			want:    model.Products{},
-			wantErr: true,
+			wantErr:   true,
+			wantErrIs: model.ErrAuthServiceUnavailable,
 		},
 	}
 	for _, tt := range tests {
@@ -1075,43 +2990,112 @@ func Test_productUsecase_FindByIDs(t *testing.T) {
 			defer ctrl.Finish()
 
 			ctx := context.TODO()
-			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, tt.userID)
+			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, userID)
 
-			uc := NewProductUsecase()
-			db, _ := utils.NewDBMock()
-			err := uc.InjectDB(db)
-			utils.ContinueOrFatal(err)
+			uc := &productUsecase{}
 			mockAuthClient := authMock.NewMockAuthServiceClient(ctrl)
-			err = uc.InjectAuthClient(mockAuthClient)
+			err := uc.InjectAuthClient(mockAuthClient)
 			utils.ContinueOrFatal(err)
-			mockProductRepo := mock.NewMockProductRepository(ctrl)
-			err = uc.InjectProductRepo(mockProductRepo)
+			mockPermissionCacheRepo := mock.NewMockPermissionCacheRepository(ctrl)
+			err = uc.InjectPermissionCacheRepo(mockPermissionCacheRepo)
 			utils.ContinueOrFatal(err)
 
-			wg := sync.WaitGroup{}
+			wantPermissions := append(append([]string{}, permissions...), constant.PermissionFullAccess)
+
+			if tt.mockCacheGet != nil {
+				mockPermissionCacheRepo.EXPECT().Get(gomock.Any(), userID, wantPermissions).Times(1).Return(tt.mockCacheGet.allowed, tt.mockCacheGet.found, tt.mockCacheGet.err)
+			}
+
 			if tt.mockAuth != nil {
 				mockAuthClient.EXPECT().HasAccess(gomock.Any(), gomock.Any()).Times(1).Return(&wrapperspb.BoolValue{
 					Value: tt.mockAuth.hasAccess,
 				}, tt.mockAuth.err)
 			}
 
-			if tt.mockFindByID != nil {
-				wg.Add(1)
-				mockProductRepo.EXPECT().FindByID(gomock.Any(), tt.args.ids[0]).Times(1).DoAndReturn(func(_ context.Context, id string) (*model.Product, error) {
-					defer wg.Done()
-					return tt.mockFindByID.product, tt.mockFindByID.err
-				})
+			if tt.mockCacheSet != nil {
+				mockPermissionCacheRepo.EXPECT().Set(gomock.Any(), userID, wantPermissions, tt.mockCacheSet.allowed).Times(1).Return(nil)
 			}
 
-			got, err := uc.FindByIDs(ctx, tt.args.ids)
+			err = uc.hasPermission(ctx, append([]string{}, permissions...))
 			if (err != nil) != tt.wantErr {
-				t.Errorf("productUsecase.FindByIDs() error = %v, wantErr %v", err, tt.wantErr)
-				return
+				t.Errorf("productUsecase.hasPermission() error = %v, wantErr %v", err, tt.wantErr)
 			}
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("productUsecase.FindByIDs() = %v, want %v", got, tt.want)
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("productUsecase.hasPermission() error = %v, wantErrIs %v", err, tt.wantErrIs)
+			}
+		})
+	}
+}
+
+func Test_productUsecase_handlePermissionChangedEvent(t *testing.T) {
+	userID := utils.GenerateUUID()
+
+	type mockDelete struct {
+		err error
+	}
+
+	tests := []struct {
+		name             string
+		data             []byte
+		mockDeleteByUser *mockDelete
+		mockDeleteAll    *mockDelete
+		wantErr          bool
+	}{
+		{
+			name: "invalidate user permissions",
+			data: []byte(`{"userID":"` + userID + `"}`),
+			mockDeleteByUser: &mockDelete{
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalidate all permissions",
+			data: []byte(`{}`),
+			mockDeleteAll: &mockDelete{
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "error when invalidate permissions",
+			data: []byte(`{"userID":"` + userID + `"}`),
+			mockDeleteByUser: &mockDelete{
+				err: errors.New("redis error"),
+			},
+			wantErr: true,
+		},
+		{
+			name:    "invalid payload",
+			data:    []byte(`invalid`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := &productUsecase{}
+			mockPermissionCacheRepo := mock.NewMockPermissionCacheRepository(ctrl)
+			err := uc.InjectPermissionCacheRepo(mockPermissionCacheRepo)
+			utils.ContinueOrFatal(err)
+
+			if tt.mockDeleteByUser != nil {
+				mockPermissionCacheRepo.EXPECT().DeleteByUserID(gomock.Any(), userID).Times(1).Return(tt.mockDeleteByUser.err)
+			}
+
+			if tt.mockDeleteAll != nil {
+				mockPermissionCacheRepo.EXPECT().DeleteAll(gomock.Any()).Times(1).Return(tt.mockDeleteAll.err)
+			}
+
+			msg := &nats.Msg{
+				Subject: model.AuthPermissionChangedSubject,
+				Data:    tt.data,
+			}
+			if err := uc.handlePermissionChangedEvent(msg); (err != nil) != tt.wantErr {
+				t.Errorf("productUsecase.handlePermissionChangedEvent() error = %v, wantErr %v", err, tt.wantErr)
 			}
-			wg.Wait()
 		})
 	}
 }
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/krobus00/product-service/internal/config"
+	"github.com/krobus00/product-service/internal/constant"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+	"github.com/nats-io/nats.go"
+	"github.com/sirupsen/logrus"
+)
+
+// WatchProducts follow the change stream with an ordered ephemeral consumer until the caller leave or send fail.
+// The ordered consumer recreate itself from the last delivered sequence when a message is missed,
+// so events are sent once and in stream order.
+func (uc *productUsecase) WatchProducts(ctx context.Context, req *model.WatchProductsPayload, send func(*model.ProductEvent) error) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"userID":        getUserIDFromCtx(ctx),
+		"ids":           req.IDs,
+		"ownerID":       req.OwnerID,
+		"afterSequence": req.AfterSequence,
+	})
+
+	err := uc.hasPermission(ctx, []string{
+		constant.PermissionProductWatch,
+	})
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	err = req.Validate()
+	if err != nil {
+		return err
+	}
+
+	subOpts := []nats.SubOpt{nats.OrderedConsumer(), nats.DeliverNew()}
+	if req.AfterSequence > 0 {
+		stream, err := uc.jsClient.StreamInfo(model.ProductChangeStreamName, nats.Context(ctx))
+		if err != nil {
+			logger.Error(err.Error())
+			return err
+		}
+		// the events right after the sequence expired, resuming would silently skip them
+		if req.AfterSequence+1 < stream.State.FirstSeq {
+			return model.ErrWatchSequenceExpired
+		}
+		subOpts = []nats.SubOpt{nats.OrderedConsumer(), nats.StartSequence(req.AfterSequence + 1)}
+	}
+
+	msgs := make(chan *nats.Msg, config.WatchBufferSize())
+	sub, err := uc.jsClient.ChanSubscribe(model.ProductChangeStreamSubjects, msgs, subOpts...)
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+	defer func() {
+		if err := sub.Unsubscribe(); err != nil {
+			logger.Warn(err.Error())
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg := <-msgs:
+			event, err := model.NewProductEventFromMsg(msg)
+			if err != nil {
+				logger.WithField("subject", msg.Subject).Warn(err.Error())
+				continue
+			}
+			if !req.Match(event) {
+				continue
+			}
+			err = send(event)
+			if err != nil {
+				logger.WithField("sequence", event.Sequence).Warn(err.Error())
+				return err
+			}
+		}
+	}
+}
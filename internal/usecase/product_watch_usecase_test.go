@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	authMock "github.com/krobus00/auth-service/pb/auth/mock"
+	"github.com/krobus00/product-service/internal/constant"
+	"github.com/krobus00/product-service/internal/model"
+	"github.com/krobus00/product-service/internal/utils"
+	natsserver "github.com/nats-io/nats-server/v2/test"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func newJetstreamMock(t *testing.T) nats.JetStreamContext {
+	opts := natsserver.DefaultTestOptions
+	opts.Port = -1
+	opts.JetStream = true
+	opts.StoreDir = t.TempDir()
+	server := natsserver.RunServer(&opts)
+	t.Cleanup(server.Shutdown)
+
+	nc, err := nats.Connect(server.ClientURL())
+	utils.ContinueOrFatal(err)
+	t.Cleanup(nc.Close)
+	js, err := nc.JetStream()
+	utils.ContinueOrFatal(err)
+
+	return js
+}
+
+func Test_productUsecase_WatchProducts(t *testing.T) {
+	userID := utils.GenerateUUID()
+	ownerA := utils.GenerateUUID()
+	ownerB := utils.GenerateUUID()
+	productA := &model.Product{ID: utils.GenerateUUID(), Name: "product a", OwnerID: ownerA}
+	productB := &model.Product{ID: utils.GenerateUUID(), Name: "product b", OwnerID: ownerB}
+	updatedA := &model.Product{ID: productA.ID, Name: "updated product a", OwnerID: ownerA}
+	transferredA := &model.Product{ID: productA.ID, Name: "updated product a", OwnerID: ownerB}
+	deletedB := &model.Product{ID: productB.ID, Name: "product b", OwnerID: ownerB}
+
+	// published with the sequences 1 to 5
+	changes := model.ProductChanges{
+		model.NewProductChange(model.ProductAuditActionCreate, nil, productA),
+		model.NewProductChange(model.ProductAuditActionCreate, nil, productB),
+		model.NewProductChange(model.ProductAuditActionUpdate, productA, updatedA),
+		model.NewProductChange(model.ProductAuditActionTransferOwnership, updatedA, transferredA),
+		model.NewProductChange(model.ProductAuditActionDelete, productB, deletedB),
+	}
+
+	type mockAuth struct {
+		hasAccess bool
+		err       error
+	}
+
+	tests := []struct {
+		name          string
+		payload       *model.WatchProductsPayload
+		mockAuth      *mockAuth
+		purgeBefore   uint64
+		wantSequences []uint64
+		wantTypes     []string
+		wantErr       bool
+	}{
+		{
+			name: "resume after sequence",
+			payload: &model.WatchProductsPayload{
+				AfterSequence: 1,
+			},
+			mockAuth:      &mockAuth{hasAccess: true, err: nil},
+			wantSequences: []uint64{2, 3, 4, 5},
+			wantTypes:     []string{model.ProductEventCreated, model.ProductEventUpdated, model.ProductEventUpdated, model.ProductEventDeleted},
+			wantErr:       false,
+		},
+		{
+			name: "filter by ids",
+			payload: &model.WatchProductsPayload{
+				IDs:           []string{productB.ID},
+				AfterSequence: 1,
+			},
+			mockAuth:      &mockAuth{hasAccess: true, err: nil},
+			wantSequences: []uint64{2, 5},
+			wantTypes:     []string{model.ProductEventCreated, model.ProductEventDeleted},
+			wantErr:       false,
+		},
+		{
+			name: "filter by owner include transferred away",
+			payload: &model.WatchProductsPayload{
+				OwnerID:       ownerA,
+				AfterSequence: 1,
+			},
+			mockAuth:      &mockAuth{hasAccess: true, err: nil},
+			wantSequences: []uint64{3, 4},
+			wantTypes:     []string{model.ProductEventUpdated, model.ProductEventUpdated},
+			wantErr:       false,
+		},
+		{
+			name: "resume from the first retained sequence",
+			payload: &model.WatchProductsPayload{
+				AfterSequence: 3,
+			},
+			mockAuth:      &mockAuth{hasAccess: true, err: nil},
+			purgeBefore:   4,
+			wantSequences: []uint64{4, 5},
+			wantTypes:     []string{model.ProductEventUpdated, model.ProductEventDeleted},
+			wantErr:       false,
+		},
+		{
+			name: "sequence expired",
+			payload: &model.WatchProductsPayload{
+				AfterSequence: 1,
+			},
+			mockAuth:    &mockAuth{hasAccess: true, err: nil},
+			purgeBefore: 4,
+			wantErr:     true,
+		},
+		{
+			name: "permission denied",
+			payload: &model.WatchProductsPayload{
+				AfterSequence: 1,
+			},
+			mockAuth: &mockAuth{hasAccess: false, err: nil},
+			wantErr:  true,
+		},
+		{
+			name: "invalid payload",
+			payload: &model.WatchProductsPayload{
+				IDs: []string{"not-a-uuid"},
+			},
+			mockAuth: &mockAuth{hasAccess: true, err: nil},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
+			defer cancel()
+			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, userID)
+
+			uc := NewProductUsecase()
+			mockAuthClient := authMock.NewMockAuthServiceClient(ctrl)
+			err := uc.InjectAuthClient(mockAuthClient)
+			utils.ContinueOrFatal(err)
+			js := newJetstreamMock(t)
+			err = uc.InjectJetstreamClient(js)
+			utils.ContinueOrFatal(err)
+			err = uc.CreateStream()
+			utils.ContinueOrFatal(err)
+
+			events, err := changes.ToOutboxEvents()
+			utils.ContinueOrFatal(err)
+			for i, event := range events {
+				event.ID = int64(i + 1)
+			}
+			_, err = uc.(*productUsecase).publishOutboxEvents(ctx, events)
+			utils.ContinueOrFatal(err)
+			if tt.purgeBefore > 0 {
+				err = js.PurgeStream(model.ProductChangeStreamName, &nats.StreamPurgeRequest{Sequence: tt.purgeBefore})
+				utils.ContinueOrFatal(err)
+			}
+
+			if tt.mockAuth != nil {
+				mockAuthClient.EXPECT().HasAccess(gomock.Any(), gomock.Any()).Times(1).Return(&wrapperspb.BoolValue{
+					Value: tt.mockAuth.hasAccess,
+				}, tt.mockAuth.err)
+			}
+
+			var gotSequences []uint64
+			var gotTypes []string
+			err = uc.WatchProducts(ctx, tt.payload, func(event *model.ProductEvent) error {
+				gotSequences = append(gotSequences, event.Sequence)
+				gotTypes = append(gotTypes, event.Type)
+				if len(gotSequences) == len(tt.wantSequences) {
+					cancel()
+				}
+				return nil
+			})
+			if (err != nil && !errors.Is(err, context.Canceled)) != tt.wantErr {
+				t.Errorf("productUsecase.WatchProducts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotSequences, tt.wantSequences) {
+				t.Errorf("productUsecase.WatchProducts() sequences = %v, want %v", gotSequences, tt.wantSequences)
+			}
+			if !reflect.DeepEqual(gotTypes, tt.wantTypes) {
+				t.Errorf("productUsecase.WatchProducts() types = %v, want %v", gotTypes, tt.wantTypes)
+			}
+		})
+	}
+}
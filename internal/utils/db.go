@@ -2,11 +2,35 @@ package utils
 
 import (
 	"context"
+	"database/sql"
+	"sync"
 
 	"github.com/krobus00/product-service/internal/constant"
 	"gorm.io/gorm"
 )
 
+type afterCommitHooks struct {
+	mu    sync.Mutex
+	hooks []func(ctx context.Context)
+}
+
+func (h *afterCommitHooks) add(hook func(ctx context.Context)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.hooks = append(h.hooks, hook)
+}
+
+func (h *afterCommitHooks) run(ctx context.Context) {
+	h.mu.Lock()
+	hooks := h.hooks
+	h.hooks = nil
+	h.mu.Unlock()
+
+	for _, hook := range hooks {
+		hook(ctx)
+	}
+}
+
 func NewTxContext(ctx context.Context, tx *gorm.DB) context.Context {
 	return context.WithValue(ctx, constant.KeyDBCtx, tx)
 }
@@ -19,3 +43,35 @@ func GetTxFromContext(ctx context.Context, defaultTx *gorm.DB) *gorm.DB {
 	}
 	return tx
 }
+
+// RunInTx run fn in a transaction, hooks registered with AfterCommit run with ctx once it is committed
+// and are dropped when it is rolled back. A transaction nested in another one hand its hooks to the outer one.
+func RunInTx(ctx context.Context, db *gorm.DB, fn func(txCtx context.Context) error, opts ...*sql.TxOptions) error {
+	hooks := new(afterCommitHooks)
+	err := GetTxFromContext(ctx, db).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txCtx := context.WithValue(NewTxContext(ctx, tx), constant.KeyAfterCommitCtx, hooks)
+		return fn(txCtx)
+	}, opts...)
+	if err != nil {
+		return err
+	}
+
+	AfterCommit(ctx, hooks.run)
+	return nil
+}
+
+// AfterCommit run hook once the transaction of ctx is committed, right away when ctx has no transaction.
+func AfterCommit(ctx context.Context, hook func(ctx context.Context)) {
+	hooks, ok := ctx.Value(constant.KeyAfterCommitCtx).(*afterCommitHooks)
+	if !ok {
+		hook(ctx)
+		return
+	}
+	hooks.add(hook)
+}
+
+// InTx tell whether ctx carry a transaction started by RunInTx.
+func InTx(ctx context.Context) bool {
+	_, ok := ctx.Value(constant.KeyAfterCommitCtx).(*afterCommitHooks)
+	return ok
+}
@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// IsUnavailableError report whether a grpc call made with ctx failed because the called service is down or overloaded,
+// the call may succeed later unlike a business error answered by the service. A deadline exceeded only count while
+// ctx is alive, the caller running out of time says nothing about the service.
+func IsUnavailableError(ctx context.Context, err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted:
+		return true
+	case codes.DeadlineExceeded:
+		return ctx.Err() == nil
+	default:
+		return false
+	}
+}
@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/krobus00/product-service/internal/constant"
+	"github.com/sirupsen/logrus"
+)
+
+func NewRequestIDContext(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, constant.KeyRequestIDCtx, requestID)
+}
+
+func GetRequestIDFromCtx(ctx context.Context) string {
+	requestID, ok := ctx.Value(constant.KeyRequestIDCtx).(string)
+	if !ok {
+		return ""
+	}
+	return requestID
+}
+
+// RequestIDHook add the request id of the entry context, loggers have to be created with logrus.WithContext.
+type RequestIDHook struct{}
+
+func NewRequestIDHook() *RequestIDHook {
+	return &RequestIDHook{}
+}
+
+func (h *RequestIDHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *RequestIDHook) Fire(entry *logrus.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+	if requestID := GetRequestIDFromCtx(entry.Context); requestID != "" {
+		entry.Data["requestID"] = requestID
+	}
+	return nil
+}
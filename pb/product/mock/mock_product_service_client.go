@@ -36,6 +36,26 @@ func (m *MockProductServiceClient) EXPECT() *MockProductServiceClientMockRecorde
 	return m.recorder
 }
 
+// AddCollaborator mocks base method.
+func (m *MockProductServiceClient) AddCollaborator(arg0 context.Context, arg1 *product.AddCollaboratorRequest, arg2 ...grpc.CallOption) (*product.ProductCollaborator, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddCollaborator", varargs...)
+	ret0, _ := ret[0].(*product.ProductCollaborator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCollaborator indicates an expected call of AddCollaborator.
+func (mr *MockProductServiceClientMockRecorder) AddCollaborator(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCollaborator", reflect.TypeOf((*MockProductServiceClient)(nil).AddCollaborator), varargs...)
+}
+
 // Create mocks base method.
 func (m *MockProductServiceClient) Create(arg0 context.Context, arg1 *product.CreateProductRequest, arg2 ...grpc.CallOption) (*product.Product, error) {
 	m.ctrl.T.Helper()
@@ -76,6 +96,26 @@ func (mr *MockProductServiceClientMockRecorder) Delete(arg0, arg1 interface{}, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProductServiceClient)(nil).Delete), varargs...)
 }
 
+// ExportProducts mocks base method.
+func (m *MockProductServiceClient) ExportProducts(arg0 context.Context, arg1 *product.ExportProductsRequest, arg2 ...grpc.CallOption) (product.ProductService_ExportProductsClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ExportProducts", varargs...)
+	ret0, _ := ret[0].(product.ProductService_ExportProductsClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportProducts indicates an expected call of ExportProducts.
+func (mr *MockProductServiceClientMockRecorder) ExportProducts(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportProducts", reflect.TypeOf((*MockProductServiceClient)(nil).ExportProducts), varargs...)
+}
+
 // FindByID mocks base method.
 func (m *MockProductServiceClient) FindByID(arg0 context.Context, arg1 *product.FindByIDRequest, arg2 ...grpc.CallOption) (*product.Product, error) {
 	m.ctrl.T.Helper()
@@ -136,6 +176,226 @@ func (mr *MockProductServiceClientMockRecorder) FindPaginatedIDs(arg0, arg1 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPaginatedIDs", reflect.TypeOf((*MockProductServiceClient)(nil).FindPaginatedIDs), varargs...)
 }
 
+// FindRelated mocks base method.
+func (m *MockProductServiceClient) FindRelated(arg0 context.Context, arg1 *product.FindRelatedRequest, arg2 ...grpc.CallOption) (*product.FindRelatedResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FindRelated", varargs...)
+	ret0, _ := ret[0].(*product.FindRelatedResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRelated indicates an expected call of FindRelated.
+func (mr *MockProductServiceClientMockRecorder) FindRelated(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRelated", reflect.TypeOf((*MockProductServiceClient)(nil).FindRelated), varargs...)
+}
+
+// GetImportJob mocks base method.
+func (m *MockProductServiceClient) GetImportJob(arg0 context.Context, arg1 *product.GetImportJobRequest, arg2 ...grpc.CallOption) (*product.ImportJob, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetImportJob", varargs...)
+	ret0, _ := ret[0].(*product.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImportJob indicates an expected call of GetImportJob.
+func (mr *MockProductServiceClientMockRecorder) GetImportJob(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportJob", reflect.TypeOf((*MockProductServiceClient)(nil).GetImportJob), varargs...)
+}
+
+// GetProductRevision mocks base method.
+func (m *MockProductServiceClient) GetProductRevision(arg0 context.Context, arg1 *product.GetProductRevisionRequest, arg2 ...grpc.CallOption) (*product.ProductRevision, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetProductRevision", varargs...)
+	ret0, _ := ret[0].(*product.ProductRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductRevision indicates an expected call of GetProductRevision.
+func (mr *MockProductServiceClientMockRecorder) GetProductRevision(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductRevision", reflect.TypeOf((*MockProductServiceClient)(nil).GetProductRevision), varargs...)
+}
+
+// ImportProducts mocks base method.
+func (m *MockProductServiceClient) ImportProducts(arg0 context.Context, arg1 *product.ImportProductsRequest, arg2 ...grpc.CallOption) (*product.ImportJob, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ImportProducts", varargs...)
+	ret0, _ := ret[0].(*product.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportProducts indicates an expected call of ImportProducts.
+func (mr *MockProductServiceClientMockRecorder) ImportProducts(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportProducts", reflect.TypeOf((*MockProductServiceClient)(nil).ImportProducts), varargs...)
+}
+
+// ListCollaborators mocks base method.
+func (m *MockProductServiceClient) ListCollaborators(arg0 context.Context, arg1 *product.ListCollaboratorsRequest, arg2 ...grpc.CallOption) (*product.ListCollaboratorsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListCollaborators", varargs...)
+	ret0, _ := ret[0].(*product.ListCollaboratorsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollaborators indicates an expected call of ListCollaborators.
+func (mr *MockProductServiceClientMockRecorder) ListCollaborators(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollaborators", reflect.TypeOf((*MockProductServiceClient)(nil).ListCollaborators), varargs...)
+}
+
+// ListProductHistory mocks base method.
+func (m *MockProductServiceClient) ListProductHistory(arg0 context.Context, arg1 *product.ListProductHistoryRequest, arg2 ...grpc.CallOption) (*product.ListProductHistoryResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListProductHistory", varargs...)
+	ret0, _ := ret[0].(*product.ListProductHistoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductHistory indicates an expected call of ListProductHistory.
+func (mr *MockProductServiceClientMockRecorder) ListProductHistory(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductHistory", reflect.TypeOf((*MockProductServiceClient)(nil).ListProductHistory), varargs...)
+}
+
+// ListProductRevisions mocks base method.
+func (m *MockProductServiceClient) ListProductRevisions(arg0 context.Context, arg1 *product.ListProductRevisionsRequest, arg2 ...grpc.CallOption) (*product.ListProductRevisionsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListProductRevisions", varargs...)
+	ret0, _ := ret[0].(*product.ListProductRevisionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductRevisions indicates an expected call of ListProductRevisions.
+func (mr *MockProductServiceClientMockRecorder) ListProductRevisions(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductRevisions", reflect.TypeOf((*MockProductServiceClient)(nil).ListProductRevisions), varargs...)
+}
+
+// RemoveCollaborator mocks base method.
+func (m *MockProductServiceClient) RemoveCollaborator(arg0 context.Context, arg1 *product.RemoveCollaboratorRequest, arg2 ...grpc.CallOption) (*product.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveCollaborator", varargs...)
+	ret0, _ := ret[0].(*product.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveCollaborator indicates an expected call of RemoveCollaborator.
+func (mr *MockProductServiceClientMockRecorder) RemoveCollaborator(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCollaborator", reflect.TypeOf((*MockProductServiceClient)(nil).RemoveCollaborator), varargs...)
+}
+
+// Restore mocks base method.
+func (m *MockProductServiceClient) Restore(arg0 context.Context, arg1 *product.RestoreProductRequest, arg2 ...grpc.CallOption) (*product.Product, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Restore", varargs...)
+	ret0, _ := ret[0].(*product.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockProductServiceClientMockRecorder) Restore(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockProductServiceClient)(nil).Restore), varargs...)
+}
+
+// RevertProduct mocks base method.
+func (m *MockProductServiceClient) RevertProduct(arg0 context.Context, arg1 *product.RevertProductRequest, arg2 ...grpc.CallOption) (*product.Product, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RevertProduct", varargs...)
+	ret0, _ := ret[0].(*product.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevertProduct indicates an expected call of RevertProduct.
+func (mr *MockProductServiceClientMockRecorder) RevertProduct(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertProduct", reflect.TypeOf((*MockProductServiceClient)(nil).RevertProduct), varargs...)
+}
+
+// TransferOwnership mocks base method.
+func (m *MockProductServiceClient) TransferOwnership(arg0 context.Context, arg1 *product.TransferOwnershipRequest, arg2 ...grpc.CallOption) (*product.TransferOwnershipResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TransferOwnership", varargs...)
+	ret0, _ := ret[0].(*product.TransferOwnershipResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferOwnership indicates an expected call of TransferOwnership.
+func (mr *MockProductServiceClientMockRecorder) TransferOwnership(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferOwnership", reflect.TypeOf((*MockProductServiceClient)(nil).TransferOwnership), varargs...)
+}
+
 // Update mocks base method.
 func (m *MockProductServiceClient) Update(arg0 context.Context, arg1 *product.UpdateProductRequest, arg2 ...grpc.CallOption) (*product.Product, error) {
 	m.ctrl.T.Helper()
@@ -155,3 +415,23 @@ func (mr *MockProductServiceClientMockRecorder) Update(arg0, arg1 interface{}, a
 	varargs := append([]interface{}{arg0, arg1}, arg2...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProductServiceClient)(nil).Update), varargs...)
 }
+
+// WatchProducts mocks base method.
+func (m *MockProductServiceClient) WatchProducts(arg0 context.Context, arg1 *product.WatchProductsRequest, arg2 ...grpc.CallOption) (product.ProductService_WatchProductsClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WatchProducts", varargs...)
+	ret0, _ := ret[0].(product.ProductService_WatchProductsClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchProducts indicates an expected call of WatchProducts.
+func (mr *MockProductServiceClientMockRecorder) WatchProducts(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchProducts", reflect.TypeOf((*MockProductServiceClient)(nil).WatchProducts), varargs...)
+}
@@ -0,0 +1,10 @@
+package product
+
+import (
+	_ "embed"
+)
+
+// OpenAPIDocument is the generated description of the REST gateway, served on /openapi.json.
+//
+//go:embed product_service.swagger.json
+var OpenAPIDocument []byte
@@ -28,7 +28,7 @@ var file_pb_product_product_service_proto_rawDesc = []byte{
 	0x70, 0x62, 0x2f, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x64, 0x75,
 	0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x17, 0x70, 0x62, 0x2f, 0x70, 0x72, 0x6f,
 	0x64, 0x75, 0x63, 0x74, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x32, 0xb8, 0x03, 0x0a, 0x0e, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x53, 0x65, 0x72,
+	0x6f, 0x32, 0x83, 0x0d, 0x0a, 0x0e, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x53, 0x65, 0x72,
 	0x76, 0x69, 0x63, 0x65, 0x12, 0x41, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x20,
 	0x2e, 0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e, 0x43, 0x72, 0x65, 0x61,
 	0x74, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
@@ -41,55 +41,183 @@ var file_pb_product_product_service_proto_rawDesc = []byte{
 	0x6c, 0x65, 0x74, 0x65, 0x12, 0x20, 0x2e, 0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63,
 	0x74, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x64,
-	0x75, 0x63, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x08, 0x46,
-	0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x12, 0x1b, 0x2e, 0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f,
-	0x64, 0x75, 0x63, 0x74, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63,
-	0x74, 0x2e, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x09, 0x46,
-	0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x73, 0x12, 0x1c, 0x2e, 0x70, 0x62, 0x2e, 0x70, 0x72,
-	0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x64,
-	0x75, 0x63, 0x74, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x53, 0x0a, 0x10, 0x46, 0x69, 0x6e, 0x64, 0x50,
-	0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x49, 0x44, 0x73, 0x12, 0x1d, 0x2e, 0x70, 0x62,
-	0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x62, 0x2e,
-	0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x0c, 0x5a, 0x0a,
-	0x70, 0x62, 0x2f, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x75, 0x63, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x07, 0x52,
+	0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x21, 0x2e, 0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75,
+	0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x70,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x22, 0x00,
+	0x12, 0x3e, 0x0a, 0x08, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x12, 0x1b, 0x2e, 0x70,
+	0x62, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79,
+	0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x70,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x22, 0x00,
+	0x12, 0x4a, 0x0a, 0x09, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x44, 0x73, 0x12, 0x1c, 0x2e,
+	0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x42,
+	0x79, 0x49, 0x44, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x62,
+	0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x49,
+	0x44, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x53, 0x0a, 0x10,
+	0x46, 0x69, 0x6e, 0x64, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x49, 0x44, 0x73,
+	0x12, 0x1d, 0x2e, 0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e, 0x50, 0x61,
+	0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1e, 0x2e, 0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e, 0x50, 0x61, 0x67,
+	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x50, 0x0a, 0x0b, 0x46, 0x69, 0x6e, 0x64, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64,
+	0x12, 0x1e, 0x2e, 0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e, 0x46, 0x69,
+	0x6e, 0x64, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1f, 0x2e, 0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e, 0x46, 0x69,
+	0x6e, 0x64, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x58, 0x0a, 0x0f, 0x41, 0x64, 0x64, 0x43, 0x6f, 0x6c, 0x6c, 0x61, 0x62,
+	0x6f, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x22, 0x2e, 0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x74, 0x2e, 0x41, 0x64, 0x64, 0x43, 0x6f, 0x6c, 0x6c, 0x61, 0x62, 0x6f, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x62, 0x2e,
+	0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x43,
+	0x6f, 0x6c, 0x6c, 0x61, 0x62, 0x6f, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x22, 0x00, 0x12, 0x50, 0x0a,
+	0x12, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x61, 0x62, 0x6f, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x12, 0x25, 0x2e, 0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x61, 0x62, 0x6f, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x70, 0x62, 0x2e,
+	0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12,
+	0x62, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x61, 0x62, 0x6f, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x73, 0x12, 0x24, 0x2e, 0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x61, 0x62, 0x6f, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x70, 0x62, 0x2e,
+	0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6c, 0x6c,
+	0x61, 0x62, 0x6f, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x62, 0x0a, 0x11, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4f,
+	0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x12, 0x24, 0x2e, 0x70, 0x62, 0x2e, 0x70, 0x72,
+	0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4f, 0x77,
+	0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25,
+	0x2e, 0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x65, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x50,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x25, 0x2e,
+	0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x48, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5a,
+	0x0a, 0x12, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x52, 0x65, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x2e, 0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x74, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x52, 0x65, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x62,
+	0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x00, 0x12, 0x6b, 0x0a, 0x14, 0x4c, 0x69,
+	0x73, 0x74, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x27, 0x2e, 0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x52, 0x65, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x70, 0x62,
+	0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x6f,
+	0x64, 0x75, 0x63, 0x74, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0d, 0x52, 0x65, 0x76, 0x65, 0x72,
+	0x74, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x12, 0x20, 0x2e, 0x70, 0x62, 0x2e, 0x70, 0x72,
+	0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e, 0x52, 0x65, 0x76, 0x65, 0x72, 0x74, 0x50, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x70, 0x62, 0x2e,
+	0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x22,
+	0x00, 0x12, 0x5b, 0x0a, 0x0e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x72, 0x6f, 0x64, 0x75,
+	0x63, 0x74, 0x73, 0x12, 0x21, 0x2e, 0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x74, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x4f,
+	0x0a, 0x0d, 0x57, 0x61, 0x74, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x12,
+	0x20, 0x2e, 0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e, 0x50,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x00, 0x30, 0x01, 0x12,
+	0x4c, 0x0a, 0x0e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x73, 0x12, 0x21, 0x2e, 0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e, 0x49,
+	0x6d, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x74, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x4a, 0x6f, 0x62, 0x22, 0x00, 0x12, 0x48, 0x0a,
+	0x0c, 0x47, 0x65, 0x74, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x4a, 0x6f, 0x62, 0x12, 0x1f, 0x2e,
+	0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6d,
+	0x70, 0x6f, 0x72, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15,
+	0x2e, 0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e, 0x49, 0x6d, 0x70, 0x6f,
+	0x72, 0x74, 0x4a, 0x6f, 0x62, 0x22, 0x00, 0x42, 0x0c, 0x5a, 0x0a, 0x70, 0x62, 0x2f, 0x70, 0x72,
+	0x6f, 0x64, 0x75, 0x63, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var file_pb_product_product_service_proto_goTypes = []interface{}{
-	(*CreateProductRequest)(nil), // 0: pb.product.CreateProductRequest
-	(*UpdateProductRequest)(nil), // 1: pb.product.UpdateProductRequest
-	(*DeleteProductRequest)(nil), // 2: pb.product.DeleteProductRequest
-	(*FindByIDRequest)(nil),      // 3: pb.product.FindByIDRequest
-	(*FindByIDsRequest)(nil),     // 4: pb.product.FindByIDsRequest
-	(*PaginationRequest)(nil),    // 5: pb.product.PaginationRequest
-	(*Product)(nil),              // 6: pb.product.Product
-	(*Empty)(nil),                // 7: pb.product.Empty
-	(*FindByIDsResponse)(nil),    // 8: pb.product.FindByIDsResponse
-	(*PaginationResponse)(nil),   // 9: pb.product.PaginationResponse
+	(*CreateProductRequest)(nil),         // 0: pb.product.CreateProductRequest
+	(*UpdateProductRequest)(nil),         // 1: pb.product.UpdateProductRequest
+	(*DeleteProductRequest)(nil),         // 2: pb.product.DeleteProductRequest
+	(*RestoreProductRequest)(nil),        // 3: pb.product.RestoreProductRequest
+	(*FindByIDRequest)(nil),              // 4: pb.product.FindByIDRequest
+	(*FindByIDsRequest)(nil),             // 5: pb.product.FindByIDsRequest
+	(*PaginationRequest)(nil),            // 6: pb.product.PaginationRequest
+	(*FindRelatedRequest)(nil),           // 7: pb.product.FindRelatedRequest
+	(*AddCollaboratorRequest)(nil),       // 8: pb.product.AddCollaboratorRequest
+	(*RemoveCollaboratorRequest)(nil),    // 9: pb.product.RemoveCollaboratorRequest
+	(*ListCollaboratorsRequest)(nil),     // 10: pb.product.ListCollaboratorsRequest
+	(*TransferOwnershipRequest)(nil),     // 11: pb.product.TransferOwnershipRequest
+	(*ListProductHistoryRequest)(nil),    // 12: pb.product.ListProductHistoryRequest
+	(*GetProductRevisionRequest)(nil),    // 13: pb.product.GetProductRevisionRequest
+	(*ListProductRevisionsRequest)(nil),  // 14: pb.product.ListProductRevisionsRequest
+	(*RevertProductRequest)(nil),         // 15: pb.product.RevertProductRequest
+	(*ExportProductsRequest)(nil),        // 16: pb.product.ExportProductsRequest
+	(*WatchProductsRequest)(nil),         // 17: pb.product.WatchProductsRequest
+	(*ImportProductsRequest)(nil),        // 18: pb.product.ImportProductsRequest
+	(*GetImportJobRequest)(nil),          // 19: pb.product.GetImportJobRequest
+	(*Product)(nil),                      // 20: pb.product.Product
+	(*Empty)(nil),                        // 21: pb.product.Empty
+	(*FindByIDsResponse)(nil),            // 22: pb.product.FindByIDsResponse
+	(*PaginationResponse)(nil),           // 23: pb.product.PaginationResponse
+	(*FindRelatedResponse)(nil),          // 24: pb.product.FindRelatedResponse
+	(*ProductCollaborator)(nil),          // 25: pb.product.ProductCollaborator
+	(*ListCollaboratorsResponse)(nil),    // 26: pb.product.ListCollaboratorsResponse
+	(*TransferOwnershipResponse)(nil),    // 27: pb.product.TransferOwnershipResponse
+	(*ListProductHistoryResponse)(nil),   // 28: pb.product.ListProductHistoryResponse
+	(*ProductRevision)(nil),              // 29: pb.product.ProductRevision
+	(*ListProductRevisionsResponse)(nil), // 30: pb.product.ListProductRevisionsResponse
+	(*ExportProductsResponse)(nil),       // 31: pb.product.ExportProductsResponse
+	(*ProductEvent)(nil),                 // 32: pb.product.ProductEvent
+	(*ImportJob)(nil),                    // 33: pb.product.ImportJob
 }
 var file_pb_product_product_service_proto_depIdxs = []int32{
-	0, // 0: pb.product.ProductService.Create:input_type -> pb.product.CreateProductRequest
-	1, // 1: pb.product.ProductService.Update:input_type -> pb.product.UpdateProductRequest
-	2, // 2: pb.product.ProductService.Delete:input_type -> pb.product.DeleteProductRequest
-	3, // 3: pb.product.ProductService.FindByID:input_type -> pb.product.FindByIDRequest
-	4, // 4: pb.product.ProductService.FindByIDs:input_type -> pb.product.FindByIDsRequest
-	5, // 5: pb.product.ProductService.FindPaginatedIDs:input_type -> pb.product.PaginationRequest
-	6, // 6: pb.product.ProductService.Create:output_type -> pb.product.Product
-	6, // 7: pb.product.ProductService.Update:output_type -> pb.product.Product
-	7, // 8: pb.product.ProductService.Delete:output_type -> pb.product.Empty
-	6, // 9: pb.product.ProductService.FindByID:output_type -> pb.product.Product
-	8, // 10: pb.product.ProductService.FindByIDs:output_type -> pb.product.FindByIDsResponse
-	9, // 11: pb.product.ProductService.FindPaginatedIDs:output_type -> pb.product.PaginationResponse
-	6, // [6:12] is the sub-list for method output_type
-	0, // [0:6] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: pb.product.ProductService.Create:input_type -> pb.product.CreateProductRequest
+	1,  // 1: pb.product.ProductService.Update:input_type -> pb.product.UpdateProductRequest
+	2,  // 2: pb.product.ProductService.Delete:input_type -> pb.product.DeleteProductRequest
+	3,  // 3: pb.product.ProductService.Restore:input_type -> pb.product.RestoreProductRequest
+	4,  // 4: pb.product.ProductService.FindByID:input_type -> pb.product.FindByIDRequest
+	5,  // 5: pb.product.ProductService.FindByIDs:input_type -> pb.product.FindByIDsRequest
+	6,  // 6: pb.product.ProductService.FindPaginatedIDs:input_type -> pb.product.PaginationRequest
+	7,  // 7: pb.product.ProductService.FindRelated:input_type -> pb.product.FindRelatedRequest
+	8,  // 8: pb.product.ProductService.AddCollaborator:input_type -> pb.product.AddCollaboratorRequest
+	9,  // 9: pb.product.ProductService.RemoveCollaborator:input_type -> pb.product.RemoveCollaboratorRequest
+	10, // 10: pb.product.ProductService.ListCollaborators:input_type -> pb.product.ListCollaboratorsRequest
+	11, // 11: pb.product.ProductService.TransferOwnership:input_type -> pb.product.TransferOwnershipRequest
+	12, // 12: pb.product.ProductService.ListProductHistory:input_type -> pb.product.ListProductHistoryRequest
+	13, // 13: pb.product.ProductService.GetProductRevision:input_type -> pb.product.GetProductRevisionRequest
+	14, // 14: pb.product.ProductService.ListProductRevisions:input_type -> pb.product.ListProductRevisionsRequest
+	15, // 15: pb.product.ProductService.RevertProduct:input_type -> pb.product.RevertProductRequest
+	16, // 16: pb.product.ProductService.ExportProducts:input_type -> pb.product.ExportProductsRequest
+	17, // 17: pb.product.ProductService.WatchProducts:input_type -> pb.product.WatchProductsRequest
+	18, // 18: pb.product.ProductService.ImportProducts:input_type -> pb.product.ImportProductsRequest
+	19, // 19: pb.product.ProductService.GetImportJob:input_type -> pb.product.GetImportJobRequest
+	20, // 20: pb.product.ProductService.Create:output_type -> pb.product.Product
+	20, // 21: pb.product.ProductService.Update:output_type -> pb.product.Product
+	21, // 22: pb.product.ProductService.Delete:output_type -> pb.product.Empty
+	20, // 23: pb.product.ProductService.Restore:output_type -> pb.product.Product
+	20, // 24: pb.product.ProductService.FindByID:output_type -> pb.product.Product
+	22, // 25: pb.product.ProductService.FindByIDs:output_type -> pb.product.FindByIDsResponse
+	23, // 26: pb.product.ProductService.FindPaginatedIDs:output_type -> pb.product.PaginationResponse
+	24, // 27: pb.product.ProductService.FindRelated:output_type -> pb.product.FindRelatedResponse
+	25, // 28: pb.product.ProductService.AddCollaborator:output_type -> pb.product.ProductCollaborator
+	21, // 29: pb.product.ProductService.RemoveCollaborator:output_type -> pb.product.Empty
+	26, // 30: pb.product.ProductService.ListCollaborators:output_type -> pb.product.ListCollaboratorsResponse
+	27, // 31: pb.product.ProductService.TransferOwnership:output_type -> pb.product.TransferOwnershipResponse
+	28, // 32: pb.product.ProductService.ListProductHistory:output_type -> pb.product.ListProductHistoryResponse
+	29, // 33: pb.product.ProductService.GetProductRevision:output_type -> pb.product.ProductRevision
+	30, // 34: pb.product.ProductService.ListProductRevisions:output_type -> pb.product.ListProductRevisionsResponse
+	20, // 35: pb.product.ProductService.RevertProduct:output_type -> pb.product.Product
+	31, // 36: pb.product.ProductService.ExportProducts:output_type -> pb.product.ExportProductsResponse
+	32, // 37: pb.product.ProductService.WatchProducts:output_type -> pb.product.ProductEvent
+	33, // 38: pb.product.ProductService.ImportProducts:output_type -> pb.product.ImportJob
+	33, // 39: pb.product.ProductService.GetImportJob:output_type -> pb.product.ImportJob
+	20, // [20:40] is the sub-list for method output_type
+	0,  // [0:20] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_pb_product_product_service_proto_init() }
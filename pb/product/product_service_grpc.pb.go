@@ -19,12 +19,26 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	ProductService_Create_FullMethodName           = "/pb.product.ProductService/Create"
-	ProductService_Update_FullMethodName           = "/pb.product.ProductService/Update"
-	ProductService_Delete_FullMethodName           = "/pb.product.ProductService/Delete"
-	ProductService_FindByID_FullMethodName         = "/pb.product.ProductService/FindByID"
-	ProductService_FindByIDs_FullMethodName        = "/pb.product.ProductService/FindByIDs"
-	ProductService_FindPaginatedIDs_FullMethodName = "/pb.product.ProductService/FindPaginatedIDs"
+	ProductService_Create_FullMethodName               = "/pb.product.ProductService/Create"
+	ProductService_Update_FullMethodName               = "/pb.product.ProductService/Update"
+	ProductService_Delete_FullMethodName               = "/pb.product.ProductService/Delete"
+	ProductService_Restore_FullMethodName              = "/pb.product.ProductService/Restore"
+	ProductService_FindByID_FullMethodName             = "/pb.product.ProductService/FindByID"
+	ProductService_FindByIDs_FullMethodName            = "/pb.product.ProductService/FindByIDs"
+	ProductService_FindPaginatedIDs_FullMethodName     = "/pb.product.ProductService/FindPaginatedIDs"
+	ProductService_FindRelated_FullMethodName          = "/pb.product.ProductService/FindRelated"
+	ProductService_AddCollaborator_FullMethodName      = "/pb.product.ProductService/AddCollaborator"
+	ProductService_RemoveCollaborator_FullMethodName   = "/pb.product.ProductService/RemoveCollaborator"
+	ProductService_ListCollaborators_FullMethodName    = "/pb.product.ProductService/ListCollaborators"
+	ProductService_TransferOwnership_FullMethodName    = "/pb.product.ProductService/TransferOwnership"
+	ProductService_ListProductHistory_FullMethodName   = "/pb.product.ProductService/ListProductHistory"
+	ProductService_GetProductRevision_FullMethodName   = "/pb.product.ProductService/GetProductRevision"
+	ProductService_ListProductRevisions_FullMethodName = "/pb.product.ProductService/ListProductRevisions"
+	ProductService_RevertProduct_FullMethodName        = "/pb.product.ProductService/RevertProduct"
+	ProductService_ExportProducts_FullMethodName       = "/pb.product.ProductService/ExportProducts"
+	ProductService_WatchProducts_FullMethodName        = "/pb.product.ProductService/WatchProducts"
+	ProductService_ImportProducts_FullMethodName       = "/pb.product.ProductService/ImportProducts"
+	ProductService_GetImportJob_FullMethodName         = "/pb.product.ProductService/GetImportJob"
 )
 
 // ProductServiceClient is the client API for ProductService service.
@@ -34,9 +48,23 @@ type ProductServiceClient interface {
 	Create(ctx context.Context, in *CreateProductRequest, opts ...grpc.CallOption) (*Product, error)
 	Update(ctx context.Context, in *UpdateProductRequest, opts ...grpc.CallOption) (*Product, error)
 	Delete(ctx context.Context, in *DeleteProductRequest, opts ...grpc.CallOption) (*Empty, error)
+	Restore(ctx context.Context, in *RestoreProductRequest, opts ...grpc.CallOption) (*Product, error)
 	FindByID(ctx context.Context, in *FindByIDRequest, opts ...grpc.CallOption) (*Product, error)
 	FindByIDs(ctx context.Context, in *FindByIDsRequest, opts ...grpc.CallOption) (*FindByIDsResponse, error)
 	FindPaginatedIDs(ctx context.Context, in *PaginationRequest, opts ...grpc.CallOption) (*PaginationResponse, error)
+	FindRelated(ctx context.Context, in *FindRelatedRequest, opts ...grpc.CallOption) (*FindRelatedResponse, error)
+	AddCollaborator(ctx context.Context, in *AddCollaboratorRequest, opts ...grpc.CallOption) (*ProductCollaborator, error)
+	RemoveCollaborator(ctx context.Context, in *RemoveCollaboratorRequest, opts ...grpc.CallOption) (*Empty, error)
+	ListCollaborators(ctx context.Context, in *ListCollaboratorsRequest, opts ...grpc.CallOption) (*ListCollaboratorsResponse, error)
+	TransferOwnership(ctx context.Context, in *TransferOwnershipRequest, opts ...grpc.CallOption) (*TransferOwnershipResponse, error)
+	ListProductHistory(ctx context.Context, in *ListProductHistoryRequest, opts ...grpc.CallOption) (*ListProductHistoryResponse, error)
+	GetProductRevision(ctx context.Context, in *GetProductRevisionRequest, opts ...grpc.CallOption) (*ProductRevision, error)
+	ListProductRevisions(ctx context.Context, in *ListProductRevisionsRequest, opts ...grpc.CallOption) (*ListProductRevisionsResponse, error)
+	RevertProduct(ctx context.Context, in *RevertProductRequest, opts ...grpc.CallOption) (*Product, error)
+	ExportProducts(ctx context.Context, in *ExportProductsRequest, opts ...grpc.CallOption) (ProductService_ExportProductsClient, error)
+	WatchProducts(ctx context.Context, in *WatchProductsRequest, opts ...grpc.CallOption) (ProductService_WatchProductsClient, error)
+	ImportProducts(ctx context.Context, in *ImportProductsRequest, opts ...grpc.CallOption) (*ImportJob, error)
+	GetImportJob(ctx context.Context, in *GetImportJobRequest, opts ...grpc.CallOption) (*ImportJob, error)
 }
 
 type productServiceClient struct {
@@ -74,6 +102,15 @@ func (c *productServiceClient) Delete(ctx context.Context, in *DeleteProductRequ
 	return out, nil
 }
 
+func (c *productServiceClient) Restore(ctx context.Context, in *RestoreProductRequest, opts ...grpc.CallOption) (*Product, error) {
+	out := new(Product)
+	err := c.cc.Invoke(ctx, ProductService_Restore_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *productServiceClient) FindByID(ctx context.Context, in *FindByIDRequest, opts ...grpc.CallOption) (*Product, error) {
 	out := new(Product)
 	err := c.cc.Invoke(ctx, ProductService_FindByID_FullMethodName, in, out, opts...)
@@ -101,6 +138,169 @@ func (c *productServiceClient) FindPaginatedIDs(ctx context.Context, in *Paginat
 	return out, nil
 }
 
+func (c *productServiceClient) FindRelated(ctx context.Context, in *FindRelatedRequest, opts ...grpc.CallOption) (*FindRelatedResponse, error) {
+	out := new(FindRelatedResponse)
+	err := c.cc.Invoke(ctx, ProductService_FindRelated_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) AddCollaborator(ctx context.Context, in *AddCollaboratorRequest, opts ...grpc.CallOption) (*ProductCollaborator, error) {
+	out := new(ProductCollaborator)
+	err := c.cc.Invoke(ctx, ProductService_AddCollaborator_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) RemoveCollaborator(ctx context.Context, in *RemoveCollaboratorRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ProductService_RemoveCollaborator_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) ListCollaborators(ctx context.Context, in *ListCollaboratorsRequest, opts ...grpc.CallOption) (*ListCollaboratorsResponse, error) {
+	out := new(ListCollaboratorsResponse)
+	err := c.cc.Invoke(ctx, ProductService_ListCollaborators_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) TransferOwnership(ctx context.Context, in *TransferOwnershipRequest, opts ...grpc.CallOption) (*TransferOwnershipResponse, error) {
+	out := new(TransferOwnershipResponse)
+	err := c.cc.Invoke(ctx, ProductService_TransferOwnership_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) ListProductHistory(ctx context.Context, in *ListProductHistoryRequest, opts ...grpc.CallOption) (*ListProductHistoryResponse, error) {
+	out := new(ListProductHistoryResponse)
+	err := c.cc.Invoke(ctx, ProductService_ListProductHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) GetProductRevision(ctx context.Context, in *GetProductRevisionRequest, opts ...grpc.CallOption) (*ProductRevision, error) {
+	out := new(ProductRevision)
+	err := c.cc.Invoke(ctx, ProductService_GetProductRevision_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) ListProductRevisions(ctx context.Context, in *ListProductRevisionsRequest, opts ...grpc.CallOption) (*ListProductRevisionsResponse, error) {
+	out := new(ListProductRevisionsResponse)
+	err := c.cc.Invoke(ctx, ProductService_ListProductRevisions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) RevertProduct(ctx context.Context, in *RevertProductRequest, opts ...grpc.CallOption) (*Product, error) {
+	out := new(Product)
+	err := c.cc.Invoke(ctx, ProductService_RevertProduct_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) ExportProducts(ctx context.Context, in *ExportProductsRequest, opts ...grpc.CallOption) (ProductService_ExportProductsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ProductService_ServiceDesc.Streams[0], ProductService_ExportProducts_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &productServiceExportProductsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ProductService_ExportProductsClient interface {
+	Recv() (*ExportProductsResponse, error)
+	grpc.ClientStream
+}
+
+type productServiceExportProductsClient struct {
+	grpc.ClientStream
+}
+
+func (x *productServiceExportProductsClient) Recv() (*ExportProductsResponse, error) {
+	m := new(ExportProductsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *productServiceClient) WatchProducts(ctx context.Context, in *WatchProductsRequest, opts ...grpc.CallOption) (ProductService_WatchProductsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ProductService_ServiceDesc.Streams[1], ProductService_WatchProducts_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &productServiceWatchProductsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ProductService_WatchProductsClient interface {
+	Recv() (*ProductEvent, error)
+	grpc.ClientStream
+}
+
+type productServiceWatchProductsClient struct {
+	grpc.ClientStream
+}
+
+func (x *productServiceWatchProductsClient) Recv() (*ProductEvent, error) {
+	m := new(ProductEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *productServiceClient) ImportProducts(ctx context.Context, in *ImportProductsRequest, opts ...grpc.CallOption) (*ImportJob, error) {
+	out := new(ImportJob)
+	err := c.cc.Invoke(ctx, ProductService_ImportProducts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) GetImportJob(ctx context.Context, in *GetImportJobRequest, opts ...grpc.CallOption) (*ImportJob, error) {
+	out := new(ImportJob)
+	err := c.cc.Invoke(ctx, ProductService_GetImportJob_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProductServiceServer is the server API for ProductService service.
 // All implementations must embed UnimplementedProductServiceServer
 // for forward compatibility
@@ -108,9 +308,23 @@ type ProductServiceServer interface {
 	Create(context.Context, *CreateProductRequest) (*Product, error)
 	Update(context.Context, *UpdateProductRequest) (*Product, error)
 	Delete(context.Context, *DeleteProductRequest) (*Empty, error)
+	Restore(context.Context, *RestoreProductRequest) (*Product, error)
 	FindByID(context.Context, *FindByIDRequest) (*Product, error)
 	FindByIDs(context.Context, *FindByIDsRequest) (*FindByIDsResponse, error)
 	FindPaginatedIDs(context.Context, *PaginationRequest) (*PaginationResponse, error)
+	FindRelated(context.Context, *FindRelatedRequest) (*FindRelatedResponse, error)
+	AddCollaborator(context.Context, *AddCollaboratorRequest) (*ProductCollaborator, error)
+	RemoveCollaborator(context.Context, *RemoveCollaboratorRequest) (*Empty, error)
+	ListCollaborators(context.Context, *ListCollaboratorsRequest) (*ListCollaboratorsResponse, error)
+	TransferOwnership(context.Context, *TransferOwnershipRequest) (*TransferOwnershipResponse, error)
+	ListProductHistory(context.Context, *ListProductHistoryRequest) (*ListProductHistoryResponse, error)
+	GetProductRevision(context.Context, *GetProductRevisionRequest) (*ProductRevision, error)
+	ListProductRevisions(context.Context, *ListProductRevisionsRequest) (*ListProductRevisionsResponse, error)
+	RevertProduct(context.Context, *RevertProductRequest) (*Product, error)
+	ExportProducts(*ExportProductsRequest, ProductService_ExportProductsServer) error
+	WatchProducts(*WatchProductsRequest, ProductService_WatchProductsServer) error
+	ImportProducts(context.Context, *ImportProductsRequest) (*ImportJob, error)
+	GetImportJob(context.Context, *GetImportJobRequest) (*ImportJob, error)
 	mustEmbedUnimplementedProductServiceServer()
 }
 
@@ -127,6 +341,9 @@ func (UnimplementedProductServiceServer) Update(context.Context, *UpdateProductR
 func (UnimplementedProductServiceServer) Delete(context.Context, *DeleteProductRequest) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedProductServiceServer) Restore(context.Context, *RestoreProductRequest) (*Product, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
 func (UnimplementedProductServiceServer) FindByID(context.Context, *FindByIDRequest) (*Product, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FindByID not implemented")
 }
@@ -136,6 +353,45 @@ func (UnimplementedProductServiceServer) FindByIDs(context.Context, *FindByIDsRe
 func (UnimplementedProductServiceServer) FindPaginatedIDs(context.Context, *PaginationRequest) (*PaginationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FindPaginatedIDs not implemented")
 }
+func (UnimplementedProductServiceServer) FindRelated(context.Context, *FindRelatedRequest) (*FindRelatedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindRelated not implemented")
+}
+func (UnimplementedProductServiceServer) AddCollaborator(context.Context, *AddCollaboratorRequest) (*ProductCollaborator, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCollaborator not implemented")
+}
+func (UnimplementedProductServiceServer) RemoveCollaborator(context.Context, *RemoveCollaboratorRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveCollaborator not implemented")
+}
+func (UnimplementedProductServiceServer) ListCollaborators(context.Context, *ListCollaboratorsRequest) (*ListCollaboratorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollaborators not implemented")
+}
+func (UnimplementedProductServiceServer) TransferOwnership(context.Context, *TransferOwnershipRequest) (*TransferOwnershipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferOwnership not implemented")
+}
+func (UnimplementedProductServiceServer) ListProductHistory(context.Context, *ListProductHistoryRequest) (*ListProductHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProductHistory not implemented")
+}
+func (UnimplementedProductServiceServer) GetProductRevision(context.Context, *GetProductRevisionRequest) (*ProductRevision, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductRevision not implemented")
+}
+func (UnimplementedProductServiceServer) ListProductRevisions(context.Context, *ListProductRevisionsRequest) (*ListProductRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProductRevisions not implemented")
+}
+func (UnimplementedProductServiceServer) RevertProduct(context.Context, *RevertProductRequest) (*Product, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevertProduct not implemented")
+}
+func (UnimplementedProductServiceServer) ExportProducts(*ExportProductsRequest, ProductService_ExportProductsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportProducts not implemented")
+}
+func (UnimplementedProductServiceServer) WatchProducts(*WatchProductsRequest, ProductService_WatchProductsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchProducts not implemented")
+}
+func (UnimplementedProductServiceServer) ImportProducts(context.Context, *ImportProductsRequest) (*ImportJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportProducts not implemented")
+}
+func (UnimplementedProductServiceServer) GetImportJob(context.Context, *GetImportJobRequest) (*ImportJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetImportJob not implemented")
+}
 func (UnimplementedProductServiceServer) mustEmbedUnimplementedProductServiceServer() {}
 
 // UnsafeProductServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -203,6 +459,24 @@ func _ProductService_Delete_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProductService_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_Restore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).Restore(ctx, req.(*RestoreProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProductService_FindByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FindByIDRequest)
 	if err := dec(in); err != nil {
@@ -257,6 +531,246 @@ func _ProductService_FindPaginatedIDs_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProductService_FindRelated_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindRelatedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).FindRelated(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_FindRelated_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).FindRelated(ctx, req.(*FindRelatedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_AddCollaborator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCollaboratorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).AddCollaborator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_AddCollaborator_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).AddCollaborator(ctx, req.(*AddCollaboratorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_RemoveCollaborator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveCollaboratorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).RemoveCollaborator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_RemoveCollaborator_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).RemoveCollaborator(ctx, req.(*RemoveCollaboratorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_ListCollaborators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollaboratorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).ListCollaborators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_ListCollaborators_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).ListCollaborators(ctx, req.(*ListCollaboratorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_TransferOwnership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferOwnershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).TransferOwnership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_TransferOwnership_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).TransferOwnership(ctx, req.(*TransferOwnershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_ListProductHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProductHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).ListProductHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_ListProductHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).ListProductHistory(ctx, req.(*ListProductHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_GetProductRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).GetProductRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_GetProductRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).GetProductRevision(ctx, req.(*GetProductRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_ListProductRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProductRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).ListProductRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_ListProductRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).ListProductRevisions(ctx, req.(*ListProductRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_RevertProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevertProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).RevertProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_RevertProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).RevertProduct(ctx, req.(*RevertProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_ExportProducts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportProductsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProductServiceServer).ExportProducts(m, &productServiceExportProductsServer{stream})
+}
+
+type ProductService_ExportProductsServer interface {
+	Send(*ExportProductsResponse) error
+	grpc.ServerStream
+}
+
+type productServiceExportProductsServer struct {
+	grpc.ServerStream
+}
+
+func (x *productServiceExportProductsServer) Send(m *ExportProductsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ProductService_WatchProducts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchProductsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProductServiceServer).WatchProducts(m, &productServiceWatchProductsServer{stream})
+}
+
+type ProductService_WatchProductsServer interface {
+	Send(*ProductEvent) error
+	grpc.ServerStream
+}
+
+type productServiceWatchProductsServer struct {
+	grpc.ServerStream
+}
+
+func (x *productServiceWatchProductsServer) Send(m *ProductEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ProductService_ImportProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).ImportProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_ImportProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).ImportProducts(ctx, req.(*ImportProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_GetImportJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetImportJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).GetImportJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_GetImportJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).GetImportJob(ctx, req.(*GetImportJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProductService_ServiceDesc is the grpc.ServiceDesc for ProductService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -276,6 +790,10 @@ var ProductService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _ProductService_Delete_Handler,
 		},
+		{
+			MethodName: "Restore",
+			Handler:    _ProductService_Restore_Handler,
+		},
 		{
 			MethodName: "FindByID",
 			Handler:    _ProductService_FindByID_Handler,
@@ -288,7 +806,62 @@ var ProductService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FindPaginatedIDs",
 			Handler:    _ProductService_FindPaginatedIDs_Handler,
 		},
+		{
+			MethodName: "FindRelated",
+			Handler:    _ProductService_FindRelated_Handler,
+		},
+		{
+			MethodName: "AddCollaborator",
+			Handler:    _ProductService_AddCollaborator_Handler,
+		},
+		{
+			MethodName: "RemoveCollaborator",
+			Handler:    _ProductService_RemoveCollaborator_Handler,
+		},
+		{
+			MethodName: "ListCollaborators",
+			Handler:    _ProductService_ListCollaborators_Handler,
+		},
+		{
+			MethodName: "TransferOwnership",
+			Handler:    _ProductService_TransferOwnership_Handler,
+		},
+		{
+			MethodName: "ListProductHistory",
+			Handler:    _ProductService_ListProductHistory_Handler,
+		},
+		{
+			MethodName: "GetProductRevision",
+			Handler:    _ProductService_GetProductRevision_Handler,
+		},
+		{
+			MethodName: "ListProductRevisions",
+			Handler:    _ProductService_ListProductRevisions_Handler,
+		},
+		{
+			MethodName: "RevertProduct",
+			Handler:    _ProductService_RevertProduct_Handler,
+		},
+		{
+			MethodName: "ImportProducts",
+			Handler:    _ProductService_ImportProducts_Handler,
+		},
+		{
+			MethodName: "GetImportJob",
+			Handler:    _ProductService_GetImportJob_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportProducts",
+			Handler:       _ProductService_ExportProducts_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchProducts",
+			Handler:       _ProductService_WatchProducts_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "pb/product/product_service.proto",
 }